@@ -63,30 +63,42 @@ func initWeb() (*app.Web, error) {
 	logger := bootstrap.NewLog(config)
 	cacheRepo := data.NewCacheRepo(db)
 	queue := bootstrap.NewQueue()
-	taskRepo := data.NewTaskRepo(locale, db, logger, queue)
+	notifyRepo := data.NewNotifyRepo(locale, db)
+	taskRepo := data.NewTaskRepo(locale, db, logger, queue, notifyRepo)
 	appRepo := data.NewAppRepo(locale, config, db, logger, cacheRepo, taskRepo)
 	userTokenRepo := data.NewUserTokenRepo(locale, config, db)
-	middlewares := middleware.NewMiddlewares(config, manager, appRepo, userTokenRepo)
+	roleRepo := data.NewRoleRepo(locale, db)
 	userRepo := data.NewUserRepo(locale, db)
-	userService := service.NewUserService(locale, config, manager, userRepo)
+	settingRepo := data.NewSettingRepo(locale, db, config, taskRepo)
+	auditRepo := data.NewAuditRepo(db, settingRepo)
+	userSessionRepo := data.NewUserSessionRepo(locale, config, db)
+	http := bootstrap.NewMetrics()
+	middlewares := middleware.NewMiddlewares(locale, config, manager, appRepo, userTokenRepo, roleRepo, userRepo, auditRepo, userSessionRepo, http)
+	userPasskeyRepo := data.NewUserPasskeyRepo(locale, db)
+	oidcRepo := data.NewOIDCRepo(locale, db, settingRepo, userRepo)
+	loginLockRepo := data.NewLoginLockRepo(locale, db, settingRepo)
+	userService := service.NewUserService(locale, config, manager, userRepo, roleRepo, userPasskeyRepo, oidcRepo, userSessionRepo, loginLockRepo)
 	userTokenService := service.NewUserTokenService(locale, userTokenRepo)
+	roleService := service.NewRoleService(roleRepo)
+	auditService := service.NewAuditService(auditRepo)
 	databaseServerRepo := data.NewDatabaseServerRepo(locale, db, logger)
 	databaseUserRepo := data.NewDatabaseUserRepo(locale, db, databaseServerRepo)
 	databaseRepo := data.NewDatabaseRepo(locale, db, databaseServerRepo, databaseUserRepo)
-	certRepo := data.NewCertRepo(locale, db, logger)
+	certRepo := data.NewCertRepo(locale, db, logger, settingRepo)
 	certAccountRepo := data.NewCertAccountRepo(locale, db, userRepo, logger)
-	settingRepo := data.NewSettingRepo(locale, db, config, taskRepo)
 	websiteRepo := data.NewWebsiteRepo(locale, db, cacheRepo, databaseRepo, databaseServerRepo, databaseUserRepo, certRepo, certAccountRepo, settingRepo)
 	environmentRepo := data.NewEnvironmentRepo(locale, config, cacheRepo, taskRepo)
 	cronRepo := data.NewCronRepo(locale, db)
-	backupRepo := data.NewBackupRepo(locale, db, settingRepo, websiteRepo)
+	backupStorageRepo := data.NewBackupStorageRepo(locale, db)
+	backupRepo := data.NewBackupRepo(locale, db, settingRepo, websiteRepo, backupStorageRepo, databaseServerRepo)
 	homeService := service.NewHomeService(locale, config, taskRepo, websiteRepo, appRepo, environmentRepo, settingRepo, cronRepo, backupRepo)
 	taskService := service.NewTaskService(taskRepo)
-	websiteService := service.NewWebsiteService(websiteRepo, settingRepo)
-	databaseService := service.NewDatabaseService(databaseRepo)
+	websiteService := service.NewWebsiteService(locale, websiteRepo, settingRepo, userRepo)
+	databaseService := service.NewDatabaseService(locale, databaseRepo)
 	databaseServerService := service.NewDatabaseServerService(databaseServerRepo)
 	databaseUserService := service.NewDatabaseUserService(databaseUserRepo)
-	backupService := service.NewBackupService(locale, backupRepo)
+	backupService := service.NewBackupService(locale, backupRepo, websiteRepo, databaseRepo, databaseServerRepo, userRepo)
+	backupStorageService := service.NewBackupStorageService(backupStorageRepo)
 	certService := service.NewCertService(locale, certRepo)
 	certDNSRepo := data.NewCertDNSRepo(db)
 	certDNSService := service.NewCertDNSService(certDNSRepo)
@@ -94,7 +106,7 @@ func initWeb() (*app.Web, error) {
 	appService := service.NewAppService(locale, appRepo, cacheRepo, settingRepo)
 	environmentService := service.NewEnvironmentService(locale, environmentRepo, taskRepo)
 	environmentPHPService := service.NewEnvironmentPHPService(locale, environmentRepo, taskRepo)
-	cronService := service.NewCronService(cronRepo)
+	cronService := service.NewCronService(locale, cronRepo, websiteRepo, userRepo)
 	processService := service.NewProcessService()
 	safeRepo := data.NewSafeRepo()
 	safeService := service.NewSafeService(safeRepo)
@@ -111,10 +123,12 @@ func initWeb() (*app.Web, error) {
 	containerImageService := service.NewContainerImageService(containerImageRepo)
 	containerVolumeRepo := data.NewContainerVolumeRepo()
 	containerVolumeService := service.NewContainerVolumeService(containerVolumeRepo)
-	fileService := service.NewFileService(locale, taskRepo)
+	fileService := service.NewFileService(locale, taskRepo, websiteRepo)
+	metricsService := service.NewMetricsService(locale, logger, settingRepo, taskRepo, websiteRepo, queue, http)
 	monitorRepo := data.NewMonitorRepo(db, settingRepo)
-	monitorService := service.NewMonitorService(settingRepo, monitorRepo)
-	settingService := service.NewSettingService(locale, db, settingRepo, certRepo, certAccountRepo)
+	alertRepo := data.NewAlertRepo(locale, db, logger, notifyRepo)
+	monitorService := service.NewMonitorService(settingRepo, monitorRepo, alertRepo)
+	settingService := service.NewSettingService(locale, db, settingRepo, certRepo, certAccountRepo, oidcRepo)
 	systemctlService := service.NewSystemctlService(locale)
 	toolboxSystemService := service.NewToolboxSystemService(locale)
 	toolboxBenchmarkService := service.NewToolboxBenchmarkService(locale)
@@ -122,6 +136,7 @@ func initWeb() (*app.Web, error) {
 	toolboxDiskService := service.NewToolboxDiskService(locale)
 	webHookRepo := data.NewWebHookRepo(locale, db)
 	webHookService := service.NewWebHookService(webHookRepo)
+	notifyService := service.NewNotifyService(notifyRepo)
 	codeserverApp := codeserver.NewApp()
 	dockerApp := docker.NewApp()
 	fail2banApp := fail2ban.NewApp(locale, websiteRepo)
@@ -143,10 +158,10 @@ func initWeb() (*app.Web, error) {
 	s3fsApp := s3fs.NewApp(locale)
 	supervisorApp := supervisor.NewApp(locale)
 	loader := bootstrap.NewLoader(codeserverApp, dockerApp, fail2banApp, frpApp, giteaApp, mariadbApp, memcachedApp, minioApp, mysqlApp, nginxApp, openrestyApp, perconaApp, phpmyadminApp, podmanApp, postgresqlApp, pureftpdApp, redisApp, rsyncApp, s3fsApp, supervisorApp)
-	http := route.NewHttp(config, userService, userTokenService, homeService, taskService, websiteService, databaseService, databaseServerService, databaseUserService, backupService, certService, certDNSService, certAccountService, appService, environmentService, environmentPHPService, cronService, processService, safeService, firewallService, sshService, containerService, containerComposeService, containerNetworkService, containerImageService, containerVolumeService, fileService, monitorService, settingService, systemctlService, toolboxSystemService, toolboxBenchmarkService, toolboxSSHService, toolboxDiskService, webHookService, loader)
+	routeHttp := route.NewHttp(config, middlewares, userService, userTokenService, roleService, auditService, homeService, taskService, websiteService, databaseService, databaseServerService, databaseUserService, backupService, backupStorageService, certService, certDNSService, certAccountService, appService, environmentService, environmentPHPService, cronService, processService, safeService, firewallService, sshService, containerService, containerComposeService, containerNetworkService, containerImageService, containerVolumeService, fileService, metricsService, monitorService, settingService, systemctlService, toolboxSystemService, toolboxBenchmarkService, toolboxSSHService, toolboxDiskService, webHookService, notifyService, loader)
 	wsService := service.NewWsService(locale, config, logger, sshRepo)
-	ws := route.NewWs(wsService)
-	mux, err := bootstrap.NewRouter(locale, middlewares, http, ws)
+	ws := route.NewWs(middlewares, wsService)
+	mux, err := bootstrap.NewRouter(locale, middlewares, routeHttp, ws)
 	if err != nil {
 		return nil, err
 	}
@@ -155,7 +170,7 @@ func initWeb() (*app.Web, error) {
 		return nil, err
 	}
 	gormigrate := bootstrap.NewMigrate(db)
-	jobs := job.NewJobs(config, db, logger, settingRepo, certRepo, certAccountRepo, backupRepo, cacheRepo, taskRepo)
+	jobs := job.NewJobs(locale, config, db, logger, settingRepo, certRepo, certAccountRepo, backupRepo, cacheRepo, taskRepo, auditRepo, notifyRepo, alertRepo, monitorRepo, appRepo, environmentRepo, containerRepo, websiteRepo)
 	cron, err := bootstrap.NewCron(config, logger, jobs)
 	if err != nil {
 		return nil, err
@@ -57,18 +57,22 @@ func initCli() (*app.Cli, error) {
 	logger := bootstrap.NewLog(config)
 	cacheRepo := data.NewCacheRepo(db)
 	queue := bootstrap.NewQueue()
-	taskRepo := data.NewTaskRepo(locale, db, logger, queue)
+	notifyRepo := data.NewNotifyRepo(locale, db)
+	taskRepo := data.NewTaskRepo(locale, db, logger, queue, notifyRepo)
 	appRepo := data.NewAppRepo(locale, config, db, logger, cacheRepo, taskRepo)
 	userRepo := data.NewUserRepo(locale, db)
 	settingRepo := data.NewSettingRepo(locale, db, config, taskRepo)
 	databaseServerRepo := data.NewDatabaseServerRepo(locale, db, logger)
 	databaseUserRepo := data.NewDatabaseUserRepo(locale, db, databaseServerRepo)
 	databaseRepo := data.NewDatabaseRepo(locale, db, databaseServerRepo, databaseUserRepo)
-	certRepo := data.NewCertRepo(locale, db, logger)
+	certRepo := data.NewCertRepo(locale, db, logger, settingRepo)
 	certAccountRepo := data.NewCertAccountRepo(locale, db, userRepo, logger)
 	websiteRepo := data.NewWebsiteRepo(locale, db, cacheRepo, databaseRepo, databaseServerRepo, databaseUserRepo, certRepo, certAccountRepo, settingRepo)
-	backupRepo := data.NewBackupRepo(locale, db, settingRepo, websiteRepo)
-	cliService := service.NewCliService(locale, config, db, appRepo, cacheRepo, userRepo, settingRepo, backupRepo, websiteRepo, databaseServerRepo, certRepo, certAccountRepo)
+	backupStorageRepo := data.NewBackupStorageRepo(locale, db)
+	backupRepo := data.NewBackupRepo(locale, db, settingRepo, websiteRepo, backupStorageRepo, databaseServerRepo)
+	auditRepo := data.NewAuditRepo(db, settingRepo)
+	userSessionRepo := data.NewUserSessionRepo(locale, config, db)
+	cliService := service.NewCliService(locale, config, db, appRepo, cacheRepo, userRepo, settingRepo, backupRepo, websiteRepo, databaseServerRepo, certRepo, certAccountRepo, auditRepo, userSessionRepo, notifyRepo)
 	cli := route.NewCli(locale, cliService)
 	command := bootstrap.NewCli(locale, cli)
 	gormigrate := bootstrap.NewMigrate(db)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/leonelquinteros/gotext"
+	"github.com/libtnb/chix"
 	"github.com/spf13/cast"
 
 	"github.com/acepanel/panel/internal/app"
@@ -42,6 +43,8 @@ func (s *App) Route(r chi.Router) {
 	r.Post("/clear_slow_log", s.ClearSlowLog)
 	r.Get("/root_password", s.GetRootPassword)
 	r.Post("/root_password", s.SetRootPassword)
+	r.Get("/binlog", s.GetBinlog)
+	r.Post("/binlog", s.UpdateBinlog)
 }
 
 // GetConfig 获取配置
@@ -261,6 +264,67 @@ func (s *App) SetRootPassword(w http.ResponseWriter, r *http.Request) {
 	service.Success(w, nil)
 }
 
+// GetBinlog 获取 binlog 归档设置
+func (s *App) GetBinlog(w http.ResponseWriter, r *http.Request) {
+	archive, err := s.settingRepo.GetBool(biz.SettingKeyMySQLBinlogArchive)
+	if err != nil {
+		service.Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	days, err := s.settingRepo.GetInt(biz.SettingKeyMySQLBinlogDays, 7)
+	if err != nil {
+		service.Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	service.Success(w, chix.M{
+		"log_bin": s.logBin(),
+		"archive": archive,
+		"days":    days,
+	})
+}
+
+// UpdateBinlog 设置 binlog 归档
+func (s *App) UpdateBinlog(w http.ResponseWriter, r *http.Request) {
+	req, err := service.Bind[UpdateBinlog](r)
+	if err != nil {
+		service.Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if req.Archive && !s.logBin() {
+		service.Error(w, http.StatusUnprocessableEntity, s.t.Get("binary logging is not enabled, please enable log-bin in my.cnf first"))
+		return
+	}
+	if err = s.settingRepo.Set(biz.SettingKeyMySQLBinlogArchive, cast.ToString(req.Archive)); err != nil {
+		service.Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	if err = s.settingRepo.Set(biz.SettingKeyMySQLBinlogDays, cast.ToString(req.Days)); err != nil {
+		service.Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	service.Success(w, nil)
+}
+
+// logBin 是否开启了 binlog
+func (s *App) logBin() bool {
+	rootPassword, _ := s.settingRepo.Get(biz.SettingKeyMySQLRootPassword)
+	mysql, err := db.NewMySQL("root", rootPassword, s.getSock(), "unix")
+	if err != nil {
+		return false
+	}
+	defer mysql.Close()
+
+	var logBin bool
+	if err = mysql.QueryRow("SELECT @@log_bin").Scan(&logBin); err != nil {
+		return false
+	}
+
+	return logBin
+}
+
 func (s *App) getSock() string {
 	if io.Exists("/tmp/mysql.sock") {
 		return "/tmp/mysql.sock"
@@ -7,3 +7,8 @@ type UpdateConfig struct {
 type SetRootPassword struct {
 	Password string `form:"password" json:"password" validate:"required|password"`
 }
+
+type UpdateBinlog struct {
+	Archive bool `form:"archive" json:"archive"`
+	Days    int  `form:"days" json:"days" validate:"required|min:1"`
+}
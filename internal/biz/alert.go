@@ -0,0 +1,76 @@
+package biz
+
+import (
+	"time"
+
+	"github.com/acepanel/panel/internal/http/request"
+	"github.com/acepanel/panel/pkg/types"
+)
+
+type AlertMetric string
+
+const (
+	AlertMetricCPU     AlertMetric = "cpu"     // CPU 使用率
+	AlertMetricMemory  AlertMetric = "memory"  // 内存使用率
+	AlertMetricSwap    AlertMetric = "swap"    // Swap 使用率
+	AlertMetricLoad1   AlertMetric = "load1"   // 1 分钟负载
+	AlertMetricLoad5   AlertMetric = "load5"   // 5 分钟负载
+	AlertMetricLoad15  AlertMetric = "load15"  // 15 分钟负载
+	AlertMetricDisk    AlertMetric = "disk"    // 磁盘使用率，Target 为挂载点
+	AlertMetricService AlertMetric = "service" // 服务未运行，Target 为服务名
+)
+
+type AlertOperator string
+
+const (
+	AlertOperatorGT AlertOperator = "gt"
+	AlertOperatorLT AlertOperator = "lt"
+)
+
+type AlertStatus string
+
+const (
+	AlertStatusFiring   AlertStatus = "firing"
+	AlertStatusResolved AlertStatus = "resolved"
+)
+
+type AlertRule struct {
+	ID         uint          `gorm:"primaryKey" json:"id"`
+	Name       string        `gorm:"not null;default:'';unique" json:"name"`
+	Metric     AlertMetric   `gorm:"not null;default:''" json:"metric"`
+	Target     string        `gorm:"not null;default:''" json:"target"`
+	Operator   AlertOperator `gorm:"not null;default:'gt'" json:"operator"`
+	Threshold  float64       `gorm:"not null;default:0" json:"threshold"`
+	Hysteresis float64       `gorm:"not null;default:0" json:"hysteresis"` // 恢复时需越过阈值的幅度，避免在阈值附近反复告警
+	Duration   uint          `gorm:"not null;default:0" json:"duration"`   // 持续多少分钟后触发或恢复
+	Enabled    bool          `gorm:"not null;default:true" json:"enabled"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+type Alert struct {
+	ID         uint        `gorm:"primaryKey" json:"id"`
+	RuleID     uint        `gorm:"not null;default:0;index" json:"rule_id"`
+	Name       string      `gorm:"not null;default:''" json:"name"` // 触发时的规则名称
+	Status     AlertStatus `gorm:"not null;default:'firing';index" json:"status"`
+	Value      float64     `gorm:"not null;default:0" json:"value"` // 触发时的值
+	Message    string      `gorm:"not null;default:''" json:"message"`
+	ResolvedAt *time.Time  `json:"resolved_at"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+type AlertRepo interface {
+	RuleList(page, limit uint) ([]*AlertRule, int64, error)
+	RuleGet(id uint) (*AlertRule, error)
+	RuleCreate(req *request.AlertRuleCreate) error
+	RuleUpdate(req *request.AlertRuleUpdate) error
+	RuleDelete(id uint) error
+	// List 告警记录，status 为空时返回全部
+	List(status AlertStatus, page, limit uint) ([]*Alert, int64, error)
+	Delete(id uint) error
+	// Clear 清空已恢复的告警记录
+	Clear() error
+	// Evaluate 使用监控数据评估告警规则，由监控任务每分钟调用
+	Evaluate(info types.CurrentInfo) error
+}
@@ -0,0 +1,38 @@
+package biz
+
+import (
+	"time"
+
+	"github.com/acepanel/panel/internal/http/request"
+)
+
+type AuditSource string
+
+const (
+	AuditSourceAPI AuditSource = "api"
+	AuditSourceCLI AuditSource = "cli"
+)
+
+type Audit struct {
+	ID        uint        `gorm:"primaryKey" json:"id"`
+	Source    AuditSource `gorm:"not null;default:'api';index" json:"source"`
+	UserID    uint        `gorm:"not null;default:0;index" json:"user_id"`
+	TokenID   uint        `gorm:"not null;default:0" json:"token_id"`  // API 令牌，为 0 表示会话登录
+	Operator  string      `gorm:"not null;default:''" json:"operator"` // 操作者名称，命令行为系统用户
+	IP        string      `gorm:"not null;default:''" json:"ip"`
+	Method    string      `gorm:"not null;default:''" json:"method"`
+	Route     string      `gorm:"not null;default:'';index" json:"route"` // 路由模板或命令名称
+	Path      string      `gorm:"not null;default:''" json:"path"`
+	Target    string      `gorm:"not null;default:''" json:"target"`  // 操作对象，如 id=1
+	Summary   string      `gorm:"not null;default:''" json:"summary"` // 脱敏后的请求参数
+	Status    int         `gorm:"not null;default:0" json:"status"`   // HTTP 状态码，命令行成功为 0 失败为 1
+	Success   bool        `gorm:"not null;default:false;index" json:"success"`
+	Message   string      `gorm:"not null;default:''" json:"message"` // 失败原因
+	CreatedAt time.Time   `gorm:"index" json:"created_at"`
+}
+
+type AuditRepo interface {
+	List(req *request.AuditList) ([]*Audit, int64, error)
+	Create(audit *Audit) error
+	ClearExpired() error
+}
@@ -1,6 +1,11 @@
 package biz
 
-import "github.com/acepanel/panel/pkg/types"
+import (
+	"time"
+
+	"github.com/acepanel/panel/pkg/snapshot"
+	"github.com/acepanel/panel/pkg/types"
+)
 
 type BackupType string
 
@@ -11,14 +16,51 @@ const (
 	BackupTypePostgres BackupType = "postgres"
 	BackupTypeRedis    BackupType = "redis"
 	BackupTypePanel    BackupType = "panel"
+
+	BackupTypeWebsiteSnapshot BackupType = "website_snapshot"
+	BackupTypeMySQLBinlog     BackupType = "mysql_binlog"
 )
 
+type BackupCheckStatus string
+
+const (
+	BackupCheckStatusPassed BackupCheckStatus = "passed"
+	BackupCheckStatusFailed BackupCheckStatus = "failed"
+)
+
+// BackupCheck 备份文件的校验和与校验结果
+type BackupCheck struct {
+	ID        uint              `gorm:"primaryKey" json:"id"`
+	Type      BackupType        `gorm:"not null;default:'';uniqueIndex:idx_backup_check" json:"type"`
+	Storage   uint              `gorm:"not null;default:0;uniqueIndex:idx_backup_check" json:"storage"`
+	Name      string            `gorm:"not null;default:'';uniqueIndex:idx_backup_check" json:"name"`
+	Checksum  string            `gorm:"not null;default:''" json:"checksum"` // 创建时记录的 sha256
+	Status    BackupCheckStatus `gorm:"not null;default:''" json:"status"`   // 为空表示未校验
+	Message   string            `gorm:"not null;default:''" json:"message"`
+	CheckedAt *time.Time        `json:"checked_at"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// BackupPoint 时间点恢复的目标，Time 和 GTID 二选一
+type BackupPoint struct {
+	Time time.Time // 恢复到该时间点（不含）
+	GTID string    // 恢复到该 GTID（含）
+}
+
 type BackupRepo interface {
-	List(typ BackupType) ([]*types.BackupFile, error)
-	Create(typ BackupType, target string, path ...string) error
-	Delete(typ BackupType, name string) error
-	Restore(typ BackupType, backup, target string) error
-	ClearExpired(path, prefix string, save int) error
+	List(typ BackupType, storage uint) ([]*types.BackupFile, error)
+	Create(typ BackupType, target string, storage uint, path ...string) error
+	Delete(typ BackupType, storage uint, name string) error
+	Restore(typ BackupType, storage uint, backup, target string, point ...BackupPoint) error
+	ClearExpired(typ BackupType, storage uint, path, prefix string, save int) error
+	Verify(typ BackupType, storage uint, name string) (*BackupCheck, error)
+	SnapshotFiles(id string) ([]snapshot.Node, error)
+	RestoreSnapshotFile(id, file, target string) error
+	ClearSnapshots(path, target string, policy snapshot.Policy) error
+	ArchiveMySQLBinlog() error
+	// SwapMySQLPoint 用时间点恢复到的临时数据库替换目标数据库
+	SwapMySQLPoint(target string) error
 	CutoffLog(path, target string) error
 	GetPath(typ BackupType) (string, error)
 	FixPanel() error
@@ -0,0 +1,61 @@
+package biz
+
+import (
+	"time"
+
+	"github.com/libtnb/utils/crypt"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/internal/app"
+	"github.com/acepanel/panel/internal/http/request"
+	"github.com/acepanel/panel/pkg/storage"
+)
+
+type BackupStorage struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	Name      string         `gorm:"not null;default:'';unique" json:"name"`
+	Type      storage.Type   `gorm:"not null;default:''" json:"type"`
+	Info      storage.Config `gorm:"not null;default:'{}';serializer:json" json:"info"`
+	Remark    string         `gorm:"not null;default:''" json:"remark"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+func (r *BackupStorage) BeforeSave(tx *gorm.DB) error {
+	crypter, err := crypt.NewXChacha20Poly1305([]byte(app.Key))
+	if err != nil {
+		return err
+	}
+
+	for _, field := range []*string{&r.Info.S3.SecretKey, &r.Info.SFTP.Password, &r.Info.SFTP.Key, &r.Info.SFTP.Passphrase, &r.Info.WebDav.Password} {
+		if *field, err = crypter.Encrypt([]byte(*field)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *BackupStorage) AfterFind(tx *gorm.DB) error {
+	crypter, err := crypt.NewXChacha20Poly1305([]byte(app.Key))
+	if err != nil {
+		return err
+	}
+
+	for _, field := range []*string{&r.Info.S3.SecretKey, &r.Info.SFTP.Password, &r.Info.SFTP.Key, &r.Info.SFTP.Passphrase, &r.Info.WebDav.Password} {
+		if decrypted, err := crypter.Decrypt(*field); err == nil {
+			*field = string(decrypted)
+		}
+	}
+
+	return nil
+}
+
+type BackupStorageRepo interface {
+	List(page, limit uint) ([]*BackupStorage, int64, error)
+	Get(id uint) (*BackupStorage, error)
+	Create(req *request.BackupStorageCreate) error
+	Update(req *request.BackupStorageUpdate) error
+	Delete(id uint) error
+	Open(id uint) (storage.Storage, error)
+}
@@ -19,4 +19,5 @@ type ContainerRepo interface {
 	Rename(id string, newName string) error
 	Logs(id string) (string, error)
 	Prune() error
+	Usage() ([]types.ContainerUsage, error)
 }
@@ -14,13 +14,14 @@ type Cron struct {
 	Time      string    `gorm:"not null;default:''" json:"time"`
 	Shell     string    `gorm:"not null;default:''" json:"shell"`
 	Log       string    `gorm:"not null;default:''" json:"log"`
+	UserID    uint      `gorm:"not null;default:0;index" json:"user_id"` // 所属用户，为 0 表示仅管理员可见
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type CronRepo interface {
 	Count() (int64, error)
-	List(page, limit uint) ([]*Cron, int64, error)
+	List(owner, page, limit uint) ([]*Cron, int64, error)
 	Get(id uint) (*Cron, error)
 	Create(req *request.CronCreate) error
 	Update(req *request.CronUpdate) error
@@ -24,8 +24,9 @@ type Database struct {
 }
 
 type DatabaseRepo interface {
-	List(page, limit uint) ([]*Database, int64, error)
+	List(owner, page, limit uint) ([]*Database, int64, error)
 	Create(req *request.DatabaseCreate) error
 	Delete(serverID uint, name string) error
 	Comment(req *request.DatabaseComment) error
+	Owns(owner, serverID uint, name string) (bool, error)
 }
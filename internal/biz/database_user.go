@@ -26,6 +26,7 @@ type DatabaseUser struct {
 	Status     DatabaseUserStatus `gorm:"-:all" json:"status"`             // 仅显示
 	Privileges []string           `gorm:"-:all" json:"privileges"`         // 仅显示
 	Remark     string             `gorm:"not null;default:''" json:"remark"`
+	UserID     uint               `gorm:"not null;default:0;index" json:"user_id"` // 所属用户，为 0 表示仅管理员可见
 	CreatedAt  time.Time          `json:"created_at"`
 	UpdatedAt  time.Time          `json:"updated_at"`
 
@@ -63,7 +64,7 @@ func (r *DatabaseUser) AfterFind(tx *gorm.DB) error {
 
 type DatabaseUserRepo interface {
 	Count() (int64, error)
-	List(page, limit uint) ([]*DatabaseUser, int64, error)
+	List(owner, page, limit uint) ([]*DatabaseUser, int64, error)
 	Get(id uint) (*DatabaseUser, error)
 	Create(req *request.DatabaseUserCreate) error
 	Update(req *request.DatabaseUserUpdate) error
@@ -0,0 +1,40 @@
+package biz
+
+import "time"
+
+type LoginLockType string
+
+const (
+	LoginLockTypeUsername LoginLockType = "username"
+	LoginLockTypeIP       LoginLockType = "ip"
+)
+
+// LoginLock 登录失败计数，按用户名和 IP 分别统计
+type LoginLock struct {
+	ID           uint          `gorm:"primaryKey" json:"id"`
+	Type         LoginLockType `gorm:"not null;default:'';uniqueIndex:idx_login_lock" json:"type"`
+	Key          string        `gorm:"not null;default:'';uniqueIndex:idx_login_lock" json:"key"`
+	Failures     uint          `gorm:"not null;default:0" json:"failures"` // 距上次锁定后的连续失败次数
+	Lockouts     uint          `gorm:"not null;default:0" json:"lockouts"` // 累计锁定次数，用于识别反复尝试的 IP
+	RetryAt      time.Time     `json:"retry_at"`                           // 逐次递增的等待时间
+	LockedUntil  time.Time     `json:"locked_until"`
+	Banned       bool          `gorm:"not null;default:false" json:"banned"` // 是否已通过防火墙封禁
+	LastFailedAt time.Time     `json:"last_failed_at"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+
+	Locked bool `gorm:"-" json:"locked"`
+}
+
+type LoginLockRepo interface {
+	List(page, limit uint) ([]*LoginLock, int64, error)
+	// Check 检查用户名和 IP 是否处于等待或锁定状态
+	Check(username, ip string) error
+	// Fail 记录一次登录失败，达到阈值后锁定，反复被锁定的 IP 可自动封禁
+	Fail(username, ip string) error
+	// Succeed 登录成功后清空失败计数
+	Succeed(username, ip string) error
+	// Delete 解除锁定，已封禁的 IP 同时移除防火墙规则
+	Delete(id uint) error
+	Clear() error
+}
@@ -7,6 +7,13 @@ import (
 	"github.com/acepanel/panel/pkg/types"
 )
 
+// 监控数据精度，单位：秒
+const (
+	MonitorResolutionRaw        uint = 60
+	MonitorResolutionFiveMinute uint = 300
+	MonitorResolutionHour       uint = 3600
+)
+
 type Monitor struct {
 	ID        uint              `gorm:"primaryKey" json:"id"`
 	Info      types.CurrentInfo `gorm:"not null;default:'{}';serializer:json" json:"info"`
@@ -14,9 +21,81 @@ type Monitor struct {
 	UpdatedAt time.Time         `json:"updated_at"`
 }
 
+type MonitorStat struct {
+	Min float64 `json:"min"`
+	Avg float64 `json:"avg"`
+	Max float64 `json:"max"`
+}
+
+type MonitorStats struct {
+	CPU          MonitorStat `json:"cpu"`
+	Load1        MonitorStat `json:"load1"`
+	Load5        MonitorStat `json:"load5"`
+	Load15       MonitorStat `json:"load15"`
+	MemUsed      MonitorStat `json:"mem_used"` // 字节
+	MemAvailable MonitorStat `json:"mem_available"`
+	SwapUsed     MonitorStat `json:"swap_used"`
+	SwapFree     MonitorStat `json:"swap_free"`
+	NetTx        MonitorStat `json:"net_tx"` // 字节/秒
+	NetRx        MonitorStat `json:"net_rx"`
+	MemTotal     uint64      `json:"mem_total"` // 以下为区间内最后一次采样的值
+	SwapTotal    uint64      `json:"swap_total"`
+	NetSent      uint64      `json:"net_sent"` // 累计流量
+	NetRecv      uint64      `json:"net_recv"`
+
+	DiskIO    map[string]*MonitorDiskIO `json:"disk_io,omitempty"`    // 按设备
+	DiskUsage map[string]*MonitorStat   `json:"disk_usage,omitempty"` // 按挂载点，使用率百分比
+}
+
+// MonitorDiskIO 磁盘设备的读写速率
+type MonitorDiskIO struct {
+	Read      MonitorStat `json:"read"` // 字节/秒
+	Write     MonitorStat `json:"write"`
+	ReadIOPS  MonitorStat `json:"read_iops"`
+	WriteIOPS MonitorStat `json:"write_iops"`
+}
+
+// MonitorAggregate 按精度汇总的监控数据，原始数据按相同结构返回
+type MonitorAggregate struct {
+	ID         uint         `gorm:"primaryKey" json:"id"`
+	Resolution uint         `gorm:"not null;default:0;uniqueIndex:idx_monitor_aggregate" json:"resolution"`
+	Time       time.Time    `gorm:"not null;uniqueIndex:idx_monitor_aggregate" json:"time"` // 区间开始时间
+	Samples    uint         `gorm:"not null;default:0" json:"samples"`                      // 区间内的原始数据条数
+	Stats      MonitorStats `gorm:"not null;default:'{}';serializer:json" json:"stats"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+type MonitorProcessType string
+
+const (
+	MonitorProcessTypeProcess   MonitorProcessType = "process"
+	MonitorProcessTypeService   MonitorProcessType = "service"
+	MonitorProcessTypeContainer MonitorProcessType = "container"
+)
+
+// MonitorProcess 进程、服务和容器的资源占用记录，同一次采样的记录时间相同
+type MonitorProcess struct {
+	ID        uint               `gorm:"primaryKey" json:"id"`
+	Time      time.Time          `gorm:"not null;index" json:"time"`
+	Type      MonitorProcessType `gorm:"not null;default:'process'" json:"type"`
+	Name      string             `gorm:"not null;default:''" json:"name"`
+	PID       int32              `gorm:"not null;default:0" json:"pid"`       // 仅进程
+	Username  string             `gorm:"not null;default:''" json:"username"` // 仅进程
+	Command   string             `gorm:"not null;default:''" json:"command"`  // 进程命令行或容器 ID
+	CPU       float64            `gorm:"not null;default:0" json:"cpu"`       // 占用单核的百分比
+	Memory    uint64             `gorm:"not null;default:0" json:"memory"`    // 字节，进程为 RSS
+	CreatedAt time.Time          `json:"created_at"`
+}
+
 type MonitorRepo interface {
 	GetSetting() (*request.MonitorSetting, error)
 	UpdateSetting(setting *request.MonitorSetting) error
 	Clear() error
-	List(start, end time.Time) ([]*Monitor, error)
+	// List 按时间范围自动选择精度
+	List(start, end time.Time) ([]*MonitorAggregate, error)
+	// Rollup 将已结束区间的数据汇总为 5 分钟和 1 小时精度，并清理各精度的过期数据
+	Rollup(now time.Time) error
+	// Processes 距离指定时间最近一次采样的资源占用记录，按 CPU 或内存从高到低排序
+	Processes(at time.Time, typ MonitorProcessType, sort string) ([]*MonitorProcess, error)
 }
@@ -0,0 +1,86 @@
+package biz
+
+import (
+	"time"
+
+	"github.com/libtnb/utils/crypt"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/internal/app"
+	"github.com/acepanel/panel/internal/http/request"
+	"github.com/acepanel/panel/pkg/notify"
+)
+
+type NotifyEvent string
+
+const (
+	NotifyEventTest         NotifyEvent = "test"          // 测试发送
+	NotifyEventCertRenew    NotifyEvent = "cert_renew"    // 证书续签失败
+	NotifyEventTaskFailed   NotifyEvent = "task_failed"   // 后台任务失败
+	NotifyEventBackupFailed NotifyEvent = "backup_failed" // 备份或备份校验失败
+	NotifyEventDiskFull     NotifyEvent = "disk_full"     // 磁盘空间不足
+	NotifyEventAlert        NotifyEvent = "alert"         // 资源告警触发或恢复
+)
+
+// NotifyEvents 可订阅的事件
+var NotifyEvents = []NotifyEvent{NotifyEventCertRenew, NotifyEventTaskFailed, NotifyEventBackupFailed, NotifyEventDiskFull, NotifyEventAlert}
+
+// notifySecrets 渠道配置中需要加密保存的字段
+var notifySecrets = []string{"password", "token", "secret"}
+
+type NotifyChannel struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	Name      string         `gorm:"not null;default:'';unique" json:"name"`
+	Type      notify.Type    `gorm:"not null;default:''" json:"type"`
+	Config    map[string]any `gorm:"not null;default:'{}';serializer:json" json:"config"` // 字段见 notify 包中各渠道的 Config
+	Events    []NotifyEvent  `gorm:"not null;default:'[]';serializer:json" json:"events"` // 订阅的事件
+	Enabled   bool           `gorm:"not null;default:true" json:"enabled"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+func (r *NotifyChannel) BeforeSave(tx *gorm.DB) error {
+	crypter, err := crypt.NewXChacha20Poly1305([]byte(app.Key))
+	if err != nil {
+		return err
+	}
+
+	for _, key := range notifySecrets {
+		if value, ok := r.Config[key].(string); ok && value != "" {
+			if r.Config[key], err = crypter.Encrypt([]byte(value)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *NotifyChannel) AfterFind(tx *gorm.DB) error {
+	crypter, err := crypt.NewXChacha20Poly1305([]byte(app.Key))
+	if err != nil {
+		return err
+	}
+
+	for _, key := range notifySecrets {
+		if value, ok := r.Config[key].(string); ok && value != "" {
+			if decrypted, err := crypter.Decrypt(value); err == nil {
+				r.Config[key] = string(decrypted)
+			}
+		}
+	}
+
+	return nil
+}
+
+type NotifyRepo interface {
+	List(page, limit uint) ([]*NotifyChannel, int64, error)
+	Get(id uint) (*NotifyChannel, error)
+	Create(req *request.NotifyChannelCreate) error
+	Update(req *request.NotifyChannelUpdate) error
+	Delete(id uint) error
+	// Test 向渠道发送测试通知
+	Test(id uint) error
+	// Notify 向订阅了事件的渠道发送通知，返回各渠道发送失败的错误
+	Notify(event NotifyEvent, title, content string) error
+}
@@ -0,0 +1,20 @@
+package biz
+
+import (
+	"context"
+
+	"github.com/acepanel/panel/internal/http/request"
+	"github.com/acepanel/panel/pkg/oidc"
+)
+
+// OIDCSecretMask 返回给前端的客户端密钥掩码，提交掩码时保留原密钥
+const OIDCSecretMask = "******"
+
+type OIDCRepo interface {
+	GetConfig() (*request.SettingOIDC, error)
+	UpdateConfig(req *request.SettingOIDC) error
+	// AuthCodeURL 生成身份提供方的授权地址，redirectURL 为未配置回调地址时的默认值
+	AuthCodeURL(ctx context.Context, redirectURL string) (*oidc.Auth, error)
+	// Login 使用授权码换取身份并映射为面板用户，按需自动创建
+	Login(ctx context.Context, redirectURL, code, verifier, nonce string) (*User, error)
+}
@@ -0,0 +1,58 @@
+package biz
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/acepanel/panel/internal/http/request"
+)
+
+// PermissionAll 全部路由组
+const PermissionAll = "*"
+
+// PermissionAdmin 仅管理员可访问的路由组，如用户、令牌和角色管理
+const PermissionAdmin = "admin"
+
+// PermissionReadSuffix 只读权限后缀，仅允许 GET/HEAD 请求
+const PermissionReadSuffix = ":read"
+
+// PermissionGroups 可分配的路由组，插件路由组为 apps/<slug>
+var PermissionGroups = []string{
+	"home", "task", "website", "database", "database_server", "database_user", "backup_storage", "backup",
+	"cert", "app", "environment", "cron", "process", "safe", "firewall", "ssh", "container", "file", "monitor",
+	"setting", "systemctl", "toolbox_system", "toolbox_benchmark", "toolbox_ssh", "toolbox_disk", "webhook", "audit", "notify", "apps",
+}
+
+type Role struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"not null;default:'';unique" json:"name"`
+	Description string    `gorm:"not null;default:''" json:"description"`
+	Permissions []string  `gorm:"not null;default:'[]';serializer:json" json:"permissions"` // 如 website、*:read、apps/mysql
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Allow 判断角色是否拥有路由组权限，apps 包含全部 apps/<slug>
+func (r *Role) Allow(group string, write bool) bool {
+	if group == PermissionAdmin {
+		return false
+	}
+	return slices.ContainsFunc(r.Permissions, func(permission string) bool {
+		name, readonly := strings.CutSuffix(permission, PermissionReadSuffix)
+		if readonly && write {
+			return false
+		}
+		return name == PermissionAll || name == group || strings.HasPrefix(group, name+"/")
+	})
+}
+
+type RoleRepo interface {
+	List(page, limit uint) ([]*Role, int64, error)
+	Get(id uint) (*Role, error)
+	Create(req *request.RoleCreate) (*Role, error)
+	Update(req *request.RoleUpdate) error
+	Delete(id uint) error
+	// Allowed 判断用户（及令牌）是否拥有路由组权限，角色为空的用户为管理员
+	Allowed(userID, tokenID uint, group string, write bool) (bool, error)
+}
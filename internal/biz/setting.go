@@ -14,17 +14,33 @@ const (
 	SettingKeyChannel             SettingKey = "channel"
 	SettingKeyMonitor             SettingKey = "monitor"
 	SettingKeyMonitorDays         SettingKey = "monitor_days"
+	SettingKeyMonitorFiveMinDays  SettingKey = "monitor_five_minute_days"
+	SettingKeyMonitorHourlyDays   SettingKey = "monitor_hourly_days"
+	SettingKeyMonitorDisks        SettingKey = "monitor_disks"
 	SettingKeyBackupPath          SettingKey = "backup_path"
+	SettingKeyBackupEncrypt       SettingKey = "backup_encrypt"
+	SettingKeyBackupPassphrase    SettingKey = "backup_passphrase"
+	SettingKeyBackupRecipient     SettingKey = "backup_recipient"
+	SettingKeyBackupIdentity      SettingKey = "backup_identity"
+	SettingKeySnapshotRepos       SettingKey = "snapshot_repositories"
 	SettingKeyWebsitePath         SettingKey = "website_path"
 	SettingKeyWebsiteTLSVersions  SettingKey = "website_tls_versions"
 	SettingKeyWebsiteCipherSuites SettingKey = "website_tls_cipher_suites"
 	SettingKeyMySQLRootPassword   SettingKey = "mysql_root_password"
+	SettingKeyMySQLBinlogArchive  SettingKey = "mysql_binlog_archive"
+	SettingKeyMySQLBinlogDays     SettingKey = "mysql_binlog_days"
 	SettingKeyOfflineMode         SettingKey = "offline_mode"
 	SettingKeyAutoUpdate          SettingKey = "auto_update"
 	SettingKeyWebserver           SettingKey = "webserver"
 	SettingKeyPublicIPs           SettingKey = "public_ips"
 	SettingHiddenMenu             SettingKey = "hidden_menu"
 	SettingKeyCustomLogo          SettingKey = "custom_logo"
+	SettingKeyAuditDays           SettingKey = "audit_days"
+	SettingKeyOIDC                SettingKey = "oidc"
+	SettingKeyLoginMaxFailures    SettingKey = "login_max_failures"
+	SettingKeyLoginLockMinutes    SettingKey = "login_lock_minutes"
+	SettingKeyLoginAutoBan        SettingKey = "login_auto_ban"
+	SettingKeyMetricsToken        SettingKey = "metrics_token"
 )
 
 type Setting struct {
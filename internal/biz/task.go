@@ -23,6 +23,8 @@ type Task struct {
 
 type TaskRepo interface {
 	HasRunningTask() bool
+	// StatusCount 各状态的任务数
+	StatusCount() (map[TaskStatus]int64, error)
 	List(page, limit uint) ([]*Task, int64, error)
 	Get(id uint) (*Task, error)
 	Delete(id uint) error
@@ -12,7 +12,9 @@ type User struct {
 	Username  string         `gorm:"not null;default:'';unique" json:"username"`
 	Password  string         `gorm:"not null;default:''" json:"password"`
 	Email     string         `gorm:"not null;default:''" json:"email"`
-	TwoFA     string         `gorm:"not null;default:''" json:"two_fa"` // 2FA secret，为空表示未开启
+	TwoFA     string         `gorm:"not null;default:''" json:"two_fa"`              // 2FA secret，为空表示未开启
+	RoleID    uint           `gorm:"not null;default:0;index" json:"role_id"`        // 角色，为 0 表示管理员
+	OIDC      string         `gorm:"column:oidc;not null;default:'';index" json:"-"` // 关联的 OIDC 身份，格式为 issuer#sub
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
@@ -20,6 +22,14 @@ type User struct {
 	Tokens []*UserToken `gorm:"foreignKey:UserID" json:"-"`
 }
 
+// OwnedResources 可分配给用户的资源类型及对应的表
+var OwnedResources = map[string]string{
+	"website":       "websites",
+	"database_user": "database_users",
+	"cron":          "crons",
+	"webhook":       "web_hooks",
+}
+
 type UserRepo interface {
 	List(page, limit uint) ([]*User, int64, error)
 	Get(id uint) (*User, error)
@@ -27,6 +37,9 @@ type UserRepo interface {
 	UpdateUsername(id uint, username string) error
 	UpdatePassword(id uint, password string) error
 	UpdateEmail(id uint, email string) error
+	UpdateRole(id uint, roleID uint) error
+	UpdateResources(id uint, typ string, ids []uint) error
+	Owns(id uint, table string, resource uint) (bool, error)
 	Delete(id uint) error
 	CheckPassword(username, password string) (*User, error)
 	IsTwoFA(username string) (bool, error)
@@ -0,0 +1,33 @@
+package biz
+
+import (
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+// UserPasskey WebAuthn 通行密钥，可作为第二验证因素或免密码登录
+type UserPasskey struct {
+	ID           uint                `gorm:"primaryKey" json:"id"`
+	UserID       uint                `gorm:"not null;default:0;index" json:"user_id"`
+	Name         string              `gorm:"not null;default:''" json:"name"`
+	CredentialID string              `gorm:"not null;default:'';unique" json:"-"` // base64url 编码的凭据 ID
+	Credential   webauthn.Credential `gorm:"not null;default:'{}';serializer:json" json:"-"`
+	LastUsedAt   *time.Time          `json:"last_used_at"`
+	CreatedAt    time.Time           `json:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+}
+
+type UserPasskeyRepo interface {
+	List(userID uint) ([]*UserPasskey, error)
+	Count(userID uint) (int64, error)
+	BeginRegistration(rp *webauthn.WebAuthn, userID uint) (*protocol.CredentialCreation, *webauthn.SessionData, error)
+	FinishRegistration(rp *webauthn.WebAuthn, userID uint, name string, session webauthn.SessionData, response *protocol.ParsedCredentialCreationData) (*UserPasskey, error)
+	// BeginLogin 生成登录挑战，username 为空时为免密码登录，由浏览器选择凭据
+	BeginLogin(rp *webauthn.WebAuthn, username string) (*protocol.CredentialAssertion, *webauthn.SessionData, error)
+	// FinishLogin 校验登录断言并返回对应用户，userID 为 0 时为免密码登录
+	FinishLogin(rp *webauthn.WebAuthn, userID uint, session webauthn.SessionData, response *protocol.ParsedCredentialAssertionData) (*User, error)
+	Delete(userID, id uint) error
+	Clear(userID uint) error
+}
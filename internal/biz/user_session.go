@@ -0,0 +1,28 @@
+package biz
+
+import "time"
+
+// UserSession 登录会话索引，会话数据本身加密保存在 sessions 表中
+type UserSession struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"not null;default:0;index" json:"user_id"`
+	SessionID  string    `gorm:"not null;default:'';unique" json:"-"`
+	IP         string    `gorm:"not null;default:''" json:"ip"` // 最近一次访问的 IP
+	UserAgent  string    `gorm:"not null;default:''" json:"user_agent"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	Current bool `gorm:"-" json:"current"` // 是否为当前请求的会话
+}
+
+type UserSessionRepo interface {
+	List(userID uint) ([]*UserSession, error)
+	Create(userID uint, sessionID, ip, userAgent string) error
+	// Validate 校验会话是否仍有效，并更新最近访问时间和 IP
+	Validate(userID uint, sessionID, ip string) (bool, error)
+	Delete(userID, id uint) error
+	// DeleteBySessionID 删除当前会话的索引，用于退出登录
+	DeleteBySessionID(sessionID string) error
+	// Revoke 吊销用户除 except 外的全部会话，userID 为 0 时吊销所有用户的会话
+	Revoke(userID uint, except string) error
+}
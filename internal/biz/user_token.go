@@ -2,6 +2,8 @@ package biz
 
 import (
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/libtnb/utils/crypt"
@@ -11,13 +13,42 @@ import (
 )
 
 type UserToken struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	UserID    uint      `gorm:"index" json:"user_id"`
-	Token     string    `gorm:"not null;default:'';unique" json:"-"`
-	IPs       []string  `gorm:"not null;default:'[]';serializer:json" json:"ips"`
-	ExpiredAt time.Time `json:"expired_at"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	UserID     uint       `gorm:"index" json:"user_id"`
+	Token      string     `gorm:"not null;default:'';unique" json:"-"`
+	IPs        []string   `gorm:"not null;default:'[]';serializer:json" json:"ips"`
+	RoleID     uint       `gorm:"not null;default:0;index" json:"role_id"`                  // 角色，为 0 表示与用户相同
+	Scopes     []string   `gorm:"not null;default:'[]';serializer:json" json:"scopes"`      // 权限范围，格式同角色权限，为空不限制
+	WebsiteIDs []uint     `gorm:"not null;default:'[]';serializer:json" json:"website_ids"` // 可操作的网站，为空不限制
+	LastUsedAt *time.Time `json:"last_used_at"`
+	LastUsedIP string     `gorm:"not null;default:''" json:"last_used_ip"`
+	ExpiredAt  time.Time  `json:"expired_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// Allow 判断令牌的权限范围是否允许访问路由组，websiteID 为请求路径中的网站 ID
+func (r *UserToken) Allow(group string, write bool, websiteID uint) bool {
+	if len(r.Scopes) > 0 && !(&Role{Permissions: r.Scopes}).Allow(group, write) {
+		return false
+	}
+	// 限制网站时只能操作指定的网站，不能列出或创建网站，也不能访问文件、备份等其他路由组
+	if len(r.WebsiteIDs) > 0 && (group != "website" || !slices.Contains(r.WebsiteIDs, websiteID)) {
+		return false
+	}
+
+	return true
+}
+
+// WebsiteScoped 限制网站的令牌只能拥有网站权限，其他路由组无法按网站限制
+func (r *UserToken) WebsiteScoped() bool {
+	if len(r.WebsiteIDs) == 0 {
+		return true
+	}
+
+	return !slices.ContainsFunc(r.Scopes, func(scope string) bool {
+		return strings.TrimSuffix(scope, PermissionReadSuffix) != "website"
+	})
 }
 
 func (r *UserToken) BeforeSave(tx *gorm.DB) error {
@@ -50,9 +81,10 @@ func (r *UserToken) AfterFind(tx *gorm.DB) error {
 
 type UserTokenRepo interface {
 	List(userID, page, limit uint) ([]*UserToken, int64, error)
-	Create(userID uint, ips []string, expired time.Time) (*UserToken, error)
+	Create(userID uint, ips []string, roleID uint, scopes []string, websiteIDs []uint, expired time.Time) (*UserToken, error)
 	Get(id uint) (*UserToken, error)
 	Delete(id uint) error
-	Update(id uint, ips []string, expired time.Time) (*UserToken, error)
-	ValidateReq(req *http.Request) (uint, error)
+	Update(id uint, ips []string, roleID uint, scopes []string, websiteIDs []uint, expired time.Time) (*UserToken, error)
+	// ValidateReq 校验请求签名、来源 IP 及权限范围，并记录令牌的最后使用时间和 IP
+	ValidateReq(req *http.Request) (*UserToken, error)
 }
@@ -0,0 +1,44 @@
+package biz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type UserTokenTestSuite struct {
+	suite.Suite
+}
+
+func TestUserTokenTestSuite(t *testing.T) {
+	suite.Run(t, &UserTokenTestSuite{})
+}
+
+func (s *UserTokenTestSuite) TestAllow() {
+	token := &UserToken{Scopes: []string{"website", "file:read"}}
+	s.True(token.Allow("website", true, 1))
+	s.True(token.Allow("file", false, 0))
+	s.False(token.Allow("file", true, 0))
+	s.False(token.Allow("cron", false, 0))
+	s.False(token.Allow(PermissionAdmin, false, 0))
+}
+
+func (s *UserTokenTestSuite) TestAllowWebsiteIDs() {
+	token := &UserToken{WebsiteIDs: []uint{1, 2}}
+	s.True(token.Allow("website", true, 1))
+	s.False(token.Allow("website", true, 3))
+	s.False(token.Allow("website", false, 0))
+	// 其他路由组无法按网站限制，一律拒绝
+	s.False(token.Allow("file", false, 0))
+	s.False(token.Allow("backup", false, 0))
+	s.False(token.Allow("cron", true, 0))
+	s.False(token.Allow("database", false, 0))
+}
+
+func (s *UserTokenTestSuite) TestWebsiteScoped() {
+	s.True((&UserToken{Scopes: []string{"file"}}).WebsiteScoped())
+	s.True((&UserToken{WebsiteIDs: []uint{1}}).WebsiteScoped())
+	s.True((&UserToken{WebsiteIDs: []uint{1}, Scopes: []string{"website", "website:read"}}).WebsiteScoped())
+	s.False((&UserToken{WebsiteIDs: []uint{1}, Scopes: []string{"website", "file"}}).WebsiteScoped())
+	s.False((&UserToken{WebsiteIDs: []uint{1}, Scopes: []string{"*"}}).WebsiteScoped())
+}
@@ -24,6 +24,7 @@ type Website struct {
 	Path      string      `gorm:"not null;default:''" json:"path"`
 	SSL       bool        `gorm:"not null;default:false" json:"ssl"`
 	Remark    string      `gorm:"not null;default:''" json:"remark"`
+	UserID    uint        `gorm:"not null;default:0;index" json:"user_id"` // 所属用户，为 0 表示仅管理员可见
 	CreatedAt time.Time   `json:"created_at"`
 	UpdatedAt time.Time   `json:"updated_at"`
 
@@ -39,14 +40,27 @@ type WebsiteRepo interface {
 	Count() (int64, error)
 	Get(id uint) (*types.WebsiteSetting, error)
 	GetByName(name string) (*types.WebsiteSetting, error)
-	List(typ string, page, limit uint) ([]*Website, int64, error)
-	Create(req *request.WebsiteCreate) (*Website, error)
-	Update(req *request.WebsiteUpdate) error
+	List(owner uint, typ string, page, limit uint) ([]*Website, int64, error)
+	Paths(owner uint) ([]string, error)
+	// AccessLogs 网站名与默认访问日志路径
+	AccessLogs() (map[string]string, error)
+	Create(ctx context.Context, req *request.WebsiteCreate) (*Website, error)
+	Update(ctx context.Context, req *request.WebsiteUpdate) error
 	Delete(req *request.WebsiteDelete) error
 	ClearLog(id uint) error
 	UpdateRemark(id uint, remark string) error
-	ResetConfig(id uint) error
-	UpdateStatus(id uint, status bool) error
+	ResetConfig(ctx context.Context, id uint) error
+	UpdateStatus(ctx context.Context, id uint, status bool) error
 	UpdateCert(req *request.WebsiteUpdateCert) error
 	ObtainCert(ctx context.Context, id uint) error
+	// Revisions 网站配置版本列表，按版本号倒序
+	Revisions(id uint, page, limit uint) ([]*WebsiteRevision, int64, error)
+	// DiffRevisions 比较网站的两个配置版本
+	DiffRevisions(id, from, to uint) ([]*WebsiteRevisionDiff, error)
+	// RestoreRevision 恢复网站配置到指定版本，校验通过后重载 Web 服务器
+	RestoreRevision(ctx context.Context, id, revision uint) error
+	// UpstreamStatuses 网站上游服务器的健康状态
+	UpstreamStatuses(id uint) ([]*WebsiteUpstreamStatus, error)
+	// CheckUpstreams 探测所有反向代理网站中配置了健康检查的上游服务器
+	CheckUpstreams() error
 }
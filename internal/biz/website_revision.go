@@ -0,0 +1,23 @@
+package biz
+
+import "time"
+
+// WebsiteRevision 网站配置版本，保存配置目录下所有 .conf 文件的完整内容
+type WebsiteRevision struct {
+	ID        uint              `gorm:"primaryKey" json:"id"`
+	WebsiteID uint              `gorm:"not null;default:0;index" json:"website_id"`
+	Version   uint              `gorm:"not null;default:0" json:"version"` // 网站内递增的版本号
+	Action    string            `gorm:"not null;default:''" json:"action"` // 产生该版本的操作，如 create、update、restore
+	UserID    uint              `gorm:"not null;default:0" json:"user_id"` // 操作者，为 0 表示系统或命令行
+	Author    string            `gorm:"not null;default:''" json:"author"`
+	Files     map[string]string `gorm:"not null;default:'{}';serializer:json" json:"-"` // 相对配置目录的路径与内容
+	CreatedAt time.Time         `json:"created_at"`
+
+	FileNames []string `gorm:"-:all" json:"files"` // 仅显示
+}
+
+// WebsiteRevisionDiff 两个配置版本之间单个文件的差异
+type WebsiteRevisionDiff struct {
+	File string `json:"file"`
+	Diff string `json:"diff"` // unified diff 格式
+}
@@ -0,0 +1,18 @@
+package biz
+
+import "time"
+
+// WebsiteUpstreamStatus 反向代理网站上游服务器的健康状态，由健康检查任务定时更新
+type WebsiteUpstreamStatus struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	WebsiteID  uint      `gorm:"not null;default:0;index" json:"website_id"`
+	Upstream   string    `gorm:"not null;default:''" json:"upstream"`
+	Server     string    `gorm:"not null;default:''" json:"server"`
+	Healthy    bool      `gorm:"not null;default:false" json:"healthy"`
+	Fails      int       `gorm:"not null;default:0" json:"fails"`           // 连续失败次数
+	Passes     int       `gorm:"not null;default:0" json:"passes"`          // 连续成功次数
+	MarkedDown bool      `gorm:"not null;default:false" json:"marked_down"` // 是否由健康检查在配置中标记为 down
+	Latency    int64     `gorm:"not null;default:0" json:"latency"`         // 探测耗时（毫秒）
+	Error      string    `gorm:"not null;default:''" json:"error"`          // 最近一次探测失败的原因
+	CheckedAt  time.Time `json:"checked_at"`
+}
@@ -0,0 +1,9 @@
+package bootstrap
+
+import (
+	"github.com/acepanel/panel/pkg/metrics"
+)
+
+func NewMetrics() *metrics.HTTP {
+	return metrics.NewHTTP()
+}
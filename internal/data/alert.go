@@ -0,0 +1,321 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/internal/http/request"
+	"github.com/acepanel/panel/pkg/systemctl"
+	"github.com/acepanel/panel/pkg/types"
+)
+
+type alertRepo struct {
+	t      *gotext.Locale
+	db     *gorm.DB
+	log    *slog.Logger
+	notify biz.NotifyRepo
+
+	mu      sync.Mutex
+	pending map[uint]uint // 规则连续满足触发或恢复条件的次数
+}
+
+func NewAlertRepo(t *gotext.Locale, db *gorm.DB, log *slog.Logger, notify biz.NotifyRepo) biz.AlertRepo {
+	return &alertRepo{
+		t:       t,
+		db:      db,
+		log:     log,
+		notify:  notify,
+		pending: make(map[uint]uint),
+	}
+}
+
+func (r *alertRepo) RuleList(page, limit uint) ([]*biz.AlertRule, int64, error) {
+	rules := make([]*biz.AlertRule, 0)
+	var total int64
+	err := r.db.Model(&biz.AlertRule{}).Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&rules).Error
+	return rules, total, err
+}
+
+func (r *alertRepo) RuleGet(id uint) (*biz.AlertRule, error) {
+	rule := new(biz.AlertRule)
+	if err := r.db.First(rule, id).Error; err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (r *alertRepo) RuleCreate(req *request.AlertRuleCreate) error {
+	rule := &biz.AlertRule{
+		Name:       req.Name,
+		Metric:     biz.AlertMetric(req.Metric),
+		Target:     req.Target,
+		Operator:   biz.AlertOperator(req.Operator),
+		Threshold:  req.Threshold,
+		Hysteresis: req.Hysteresis,
+		Duration:   req.Duration,
+		Enabled:    req.Enabled,
+	}
+
+	return r.db.Create(rule).Error
+}
+
+func (r *alertRepo) RuleUpdate(req *request.AlertRuleUpdate) error {
+	rule, err := r.RuleGet(req.ID)
+	if err != nil {
+		return err
+	}
+
+	rule.Name = req.Name
+	rule.Metric = biz.AlertMetric(req.Metric)
+	rule.Target = req.Target
+	rule.Operator = biz.AlertOperator(req.Operator)
+	rule.Threshold = req.Threshold
+	rule.Hysteresis = req.Hysteresis
+	rule.Duration = req.Duration
+	rule.Enabled = req.Enabled
+
+	// 条件变化后重新评估，未恢复的告警直接关闭
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.pending, rule.ID)
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err = tx.Save(rule).Error; err != nil {
+			return err
+		}
+		return tx.Model(&biz.Alert{}).Where("rule_id = ? AND status = ?", rule.ID, biz.AlertStatusFiring).Updates(map[string]any{
+			"status":      biz.AlertStatusResolved,
+			"resolved_at": time.Now(),
+		}).Error
+	})
+}
+
+func (r *alertRepo) RuleDelete(id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.pending, id)
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("rule_id = ?", id).Delete(&biz.Alert{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&biz.AlertRule{}, id).Error
+	})
+}
+
+func (r *alertRepo) List(status biz.AlertStatus, page, limit uint) ([]*biz.Alert, int64, error) {
+	alerts := make([]*biz.Alert, 0)
+	var total int64
+	query := r.db.Model(&biz.Alert{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&alerts).Error
+	return alerts, total, err
+}
+
+func (r *alertRepo) Delete(id uint) error {
+	return r.db.Delete(&biz.Alert{}, id).Error
+}
+
+func (r *alertRepo) Clear() error {
+	return r.db.Where("status = ?", biz.AlertStatusResolved).Delete(&biz.Alert{}).Error
+}
+
+func (r *alertRepo) Evaluate(info types.CurrentInfo) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rules := make([]*biz.AlertRule, 0)
+	if err := r.db.Where("enabled = ?", true).Find(&rules).Error; err != nil {
+		return err
+	}
+	alerts := make([]*biz.Alert, 0)
+	if err := r.db.Where("status = ?", biz.AlertStatusFiring).Find(&alerts).Error; err != nil {
+		return err
+	}
+	firing := make(map[uint]*biz.Alert, len(alerts))
+	for _, alert := range alerts {
+		firing[alert.RuleID] = alert
+	}
+
+	var errs []error
+	for _, rule := range rules {
+		value, ok := r.value(rule, info)
+		if !ok {
+			delete(r.pending, rule.ID)
+			continue
+		}
+
+		// 未告警时检查触发条件，告警中检查恢复条件，连续满足 Duration 分钟后才改变状态
+		alert, isFiring := firing[rule.ID]
+		var matched bool
+		if isFiring {
+			matched = r.recovered(rule, value)
+		} else {
+			matched = r.breached(rule, value)
+		}
+		if !matched {
+			delete(r.pending, rule.ID)
+			continue
+		}
+		if !r.sustained(rule) {
+			continue
+		}
+
+		var err error
+		if isFiring {
+			err = r.resolve(rule, alert, value)
+		} else {
+			err = r.fire(rule, value)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", rule.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// sustained 记录规则连续满足条件的次数，每分钟评估一次，第 Duration 次（至少 1 次）时返回 true 并重新计数
+func (r *alertRepo) sustained(rule *biz.AlertRule) bool {
+	r.pending[rule.ID]++
+	if r.pending[rule.ID] < max(rule.Duration, 1) {
+		return false
+	}
+	delete(r.pending, rule.ID)
+
+	return true
+}
+
+// fire 记录告警并发送通知
+func (r *alertRepo) fire(rule *biz.AlertRule, value float64) error {
+	alert := &biz.Alert{
+		RuleID:  rule.ID,
+		Name:    rule.Name,
+		Status:  biz.AlertStatusFiring,
+		Value:   value,
+		Message: r.describe(rule, value),
+	}
+	if err := r.db.Create(alert).Error; err != nil {
+		return err
+	}
+
+	r.send(r.t.Get("Alert firing: %s", rule.Name), alert.Message)
+	return nil
+}
+
+// resolve 标记告警已恢复并发送通知
+func (r *alertRepo) resolve(rule *biz.AlertRule, alert *biz.Alert, value float64) error {
+	now := time.Now()
+	alert.Status = biz.AlertStatusResolved
+	alert.ResolvedAt = &now
+	if err := r.db.Save(alert).Error; err != nil {
+		return err
+	}
+
+	r.send(r.t.Get("Alert resolved: %s", rule.Name), r.describe(rule, value))
+	return nil
+}
+
+// send 发送告警通知，通知失败不影响告警记录
+func (r *alertRepo) send(title, content string) {
+	if err := r.notify.Notify(biz.NotifyEventAlert, title, content); err != nil {
+		r.log.Warn("[Alert] failed to send notification", slog.Any("err", err))
+	}
+}
+
+// value 从监控数据中取出规则对应的值，服务运行时为 1，未运行为 0
+func (r *alertRepo) value(rule *biz.AlertRule, info types.CurrentInfo) (float64, bool) {
+	switch rule.Metric {
+	case biz.AlertMetricCPU:
+		return info.Percent, true
+	case biz.AlertMetricMemory:
+		if info.Mem == nil {
+			return 0, false
+		}
+		return info.Mem.UsedPercent, true
+	case biz.AlertMetricSwap:
+		if info.Swap == nil {
+			return 0, false
+		}
+		return info.Swap.UsedPercent, true
+	case biz.AlertMetricLoad1, biz.AlertMetricLoad5, biz.AlertMetricLoad15:
+		if info.Load == nil {
+			return 0, false
+		}
+		switch rule.Metric {
+		case biz.AlertMetricLoad1:
+			return info.Load.Load1, true
+		case biz.AlertMetricLoad5:
+			return info.Load.Load5, true
+		default:
+			return info.Load.Load15, true
+		}
+	case biz.AlertMetricDisk:
+		for _, usage := range info.DiskUsage {
+			if usage.Path == rule.Target {
+				return usage.UsedPercent, true
+			}
+		}
+	case biz.AlertMetricService:
+		active, err := systemctl.Status(rule.Target)
+		if err != nil || !active {
+			return 0, true
+		}
+		return 1, true
+	}
+
+	return 0, false
+}
+
+// breached 是否满足触发条件
+func (r *alertRepo) breached(rule *biz.AlertRule, value float64) bool {
+	if rule.Metric == biz.AlertMetricService {
+		return value == 0
+	}
+	if rule.Operator == biz.AlertOperatorLT {
+		return value < rule.Threshold
+	}
+	return value > rule.Threshold
+}
+
+// recovered 是否满足恢复条件，需越过阈值 Hysteresis 的幅度
+func (r *alertRepo) recovered(rule *biz.AlertRule, value float64) bool {
+	if rule.Metric == biz.AlertMetricService {
+		return value == 1
+	}
+	if rule.Operator == biz.AlertOperatorLT {
+		return value >= rule.Threshold+rule.Hysteresis
+	}
+	return value <= rule.Threshold-rule.Hysteresis
+}
+
+// describe 生成告警内容
+func (r *alertRepo) describe(rule *biz.AlertRule, value float64) string {
+	if rule.Metric == biz.AlertMetricService {
+		if value == 0 {
+			return r.t.Get("Service %s is not running", rule.Target)
+		}
+		return r.t.Get("Service %s is running", rule.Target)
+	}
+
+	metric := string(rule.Metric)
+	if rule.Target != "" {
+		metric += " " + rule.Target
+	}
+	operator := ">"
+	if rule.Operator == biz.AlertOperatorLT {
+		operator = "<"
+	}
+
+	return r.t.Get("%s is %.2f, threshold %s %.2f for %d minutes", metric, value, operator, rule.Threshold, rule.Duration)
+}
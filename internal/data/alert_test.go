@@ -0,0 +1,32 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/acepanel/panel/internal/biz"
+)
+
+type AlertTestSuite struct {
+	suite.Suite
+}
+
+func TestAlertTestSuite(t *testing.T) {
+	suite.Run(t, &AlertTestSuite{})
+}
+
+func (s *AlertTestSuite) TestSustained() {
+	repo := &alertRepo{pending: make(map[uint]uint)}
+
+	// 持续 3 分钟在第 3 次评估时触发，之后重新计数
+	rule := &biz.AlertRule{ID: 1, Duration: 3}
+	s.False(repo.sustained(rule))
+	s.False(repo.sustained(rule))
+	s.True(repo.sustained(rule))
+	s.False(repo.sustained(rule))
+
+	// 持续 0 或 1 分钟在第 1 次评估时触发
+	s.True(repo.sustained(&biz.AlertRule{ID: 2, Duration: 0}))
+	s.True(repo.sustained(&biz.AlertRule{ID: 3, Duration: 1}))
+}
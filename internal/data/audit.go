@@ -0,0 +1,84 @@
+package data
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/internal/http/request"
+)
+
+type auditRepo struct {
+	db      *gorm.DB
+	setting biz.SettingRepo
+}
+
+func NewAuditRepo(db *gorm.DB, setting biz.SettingRepo) biz.AuditRepo {
+	return &auditRepo{
+		db:      db,
+		setting: setting,
+	}
+}
+
+func (r *auditRepo) List(req *request.AuditList) ([]*biz.Audit, int64, error) {
+	audits := make([]*biz.Audit, 0)
+	var total int64
+
+	query := r.db.Model(&biz.Audit{})
+	if req.Source != "" {
+		query = query.Where("source = ?", req.Source)
+	}
+	if req.UserID != 0 {
+		query = query.Where("user_id = ?", req.UserID)
+	}
+	if req.IP != "" {
+		query = query.Where("ip = ?", req.IP)
+	}
+	if req.Route != "" {
+		query = query.Where("route LIKE ?", "%"+req.Route+"%")
+	}
+	if req.Keyword != "" {
+		query = query.Where("target LIKE ? OR summary LIKE ? OR message LIKE ?", "%"+req.Keyword+"%", "%"+req.Keyword+"%", "%"+req.Keyword+"%")
+	}
+	if req.Success != "" {
+		query = query.Where("success = ?", req.Success == "true")
+	}
+	if req.Start != "" {
+		if start, err := time.ParseInLocation(time.DateOnly, req.Start, time.Local); err == nil {
+			query = query.Where("created_at >= ?", start.Format(time.DateTime))
+		}
+	}
+	if req.End != "" {
+		if end, err := time.ParseInLocation(time.DateOnly, req.End, time.Local); err == nil {
+			query = query.Where("created_at < ?", end.AddDate(0, 0, 1).Format(time.DateTime))
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if req.Limit > 0 {
+		query = query.Offset(int((req.Page - 1) * req.Limit)).Limit(int(req.Limit))
+	}
+	err := query.Order("id desc").Find(&audits).Error
+
+	return audits, total, err
+}
+
+func (r *auditRepo) Create(audit *biz.Audit) error {
+	return r.db.Create(audit).Error
+}
+
+func (r *auditRepo) ClearExpired() error {
+	days, err := r.setting.GetInt(biz.SettingKeyAuditDays, 180)
+	if err != nil {
+		return err
+	}
+	// 0 表示永久保留
+	if days <= 0 {
+		return nil
+	}
+
+	return r.db.Where("created_at < ?", time.Now().AddDate(0, 0, -days).Format(time.DateTime)).Delete(&biz.Audit{}).Error
+}
@@ -1,11 +1,17 @@
 package data
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
+	stdio "io"
+	"maps"
 	"os"
+	gopath "path"
 	"path/filepath"
+	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,53 +21,87 @@ import (
 
 	"github.com/acepanel/panel/internal/app"
 	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/pkg/backupcrypto"
 	"github.com/acepanel/panel/pkg/db"
 	"github.com/acepanel/panel/pkg/io"
 	"github.com/acepanel/panel/pkg/shell"
+	"github.com/acepanel/panel/pkg/snapshot"
+	pkgstorage "github.com/acepanel/panel/pkg/storage"
+	"github.com/acepanel/panel/pkg/systemctl"
 	"github.com/acepanel/panel/pkg/tools"
 	"github.com/acepanel/panel/pkg/types"
 )
 
 type backupRepo struct {
-	t       *gotext.Locale
-	db      *gorm.DB
-	setting biz.SettingRepo
-	website biz.WebsiteRepo
+	t              *gotext.Locale
+	db             *gorm.DB
+	setting        biz.SettingRepo
+	website        biz.WebsiteRepo
+	storage        biz.BackupStorageRepo
+	databaseServer biz.DatabaseServerRepo
 }
 
-func NewBackupRepo(t *gotext.Locale, db *gorm.DB, setting biz.SettingRepo, website biz.WebsiteRepo) biz.BackupRepo {
+func NewBackupRepo(t *gotext.Locale, db *gorm.DB, setting biz.SettingRepo, website biz.WebsiteRepo, storage biz.BackupStorageRepo, databaseServer biz.DatabaseServerRepo) biz.BackupRepo {
 	return &backupRepo{
-		t:       t,
-		db:      db,
-		setting: setting,
-		website: website,
+		t:              t,
+		db:             db,
+		setting:        setting,
+		website:        website,
+		storage:        storage,
+		databaseServer: databaseServer,
 	}
 }
 
 // List 备份列表
-func (r *backupRepo) List(typ biz.BackupType) ([]*types.BackupFile, error) {
-	path, err := r.GetPath(typ)
+// storage 备份存储 ID，0 为本地
+func (r *backupRepo) List(typ biz.BackupType, storage uint) ([]*types.BackupFile, error) {
+	if typ == biz.BackupTypeWebsiteSnapshot {
+		return r.listSnapshots(storage)
+	}
+
+	s, dir, err := r.open(typ, storage, "")
 	if err != nil {
 		return nil, err
 	}
+	defer func(s pkgstorage.Storage) { _ = pkgstorage.Close(s) }(s)
 
-	files, err := os.ReadDir(path)
+	files, err := s.List(dir)
 	if err != nil {
 		return nil, err
 	}
 
+	// 本地存储返回绝对路径，远程存储返回存储中的路径
+	if storage == 0 {
+		if dir, err = r.GetPath(typ); err != nil {
+			return nil, err
+		}
+	}
+
+	var checks []*biz.BackupCheck
+	if err = r.db.Where("type = ? AND storage = ?", typ, storage).Find(&checks).Error; err != nil {
+		return nil, err
+	}
+	checked := make(map[string]*biz.BackupCheck)
+	for _, check := range checks {
+		checked[check.Name] = check
+	}
+
 	list := make([]*types.BackupFile, 0)
 	for _, file := range files {
-		info, err := file.Info()
-		if err != nil {
-			continue
+		item := &types.BackupFile{
+			Name:      file.Name,
+			Path:      gopath.Join(dir, file.Name),
+			Size:      tools.FormatBytes(float64(file.Size)),
+			Time:      file.Time,
+			Encrypted: backupcrypto.IsEncrypted(file.Name),
+		}
+		if check, ok := checked[file.Name]; ok {
+			item.Checksum = check.Checksum
+			item.Verify = string(check.Status)
+			item.VerifyMessage = check.Message
+			item.VerifiedAt = check.CheckedAt
 		}
-		list = append(list, &types.BackupFile{
-			Name: file.Name(),
-			Path: filepath.Join(path, file.Name()),
-			Size: tools.FormatBytes(float64(info.Size())),
-			Time: info.ModTime(),
-		})
+		list = append(list, item)
 	}
 
 	return list, nil
@@ -70,156 +110,1045 @@ func (r *backupRepo) List(typ biz.BackupType) ([]*types.BackupFile, error) {
 // Create 创建备份
 // typ 备份类型
 // target 目标名称
-// path 可选备份保存路径
-func (r *backupRepo) Create(typ biz.BackupType, target string, path ...string) error {
-	defPath, err := r.GetPath(typ)
+// storage 备份存储 ID，0 为本地
+// path 可选备份保存路径，远程存储时为存储中的目录
+func (r *backupRepo) Create(typ biz.BackupType, target string, storage uint, path ...string) error {
+	dir := ""
+	if len(path) > 0 {
+		dir = path[0]
+	}
+
+	if typ == biz.BackupTypeWebsiteSnapshot {
+		return r.createWebsiteSnapshot(storage, dir, target)
+	}
+
+	crypto, err := r.encryption()
 	if err != nil {
 		return err
 	}
-	if len(path) > 0 && path[0] != "" {
-		defPath = path[0]
+
+	// 备份先写入临时目录，加密后再移动到备份目录或上传到远程存储
+	// 本地存储的临时目录位于备份目录下，保证空间预检准确且可以直接移动
+	var s pkgstorage.Storage
+	local := ""
+	if storage == 0 {
+		if local, err = r.GetPath(typ); err != nil {
+			return err
+		}
+		if dir != "" {
+			local = dir
+		}
+		if err = os.MkdirAll(local, 0700); err != nil {
+			return err
+		}
+	} else {
+		if s, dir, err = r.open(typ, storage, dir); err != nil {
+			return err
+		}
+		defer func(s pkgstorage.Storage) { _ = pkgstorage.Close(s) }(s)
 	}
 
-	switch typ {
-	case biz.BackupTypeWebsite:
-		return r.createWebsite(defPath, target)
-	case biz.BackupTypeMySQL:
-		return r.createMySQL(defPath, target)
-	case biz.BackupTypePostgres:
-		return r.createPostgres(defPath, target)
-	case biz.BackupTypePanel:
-		return r.createPanel(defPath)
+	temp, err := os.MkdirTemp(local, ".panel-backup-")
+	if err != nil {
+		return err
+	}
+	defer func(temp string) { _ = io.Remove(temp) }(temp)
 
+	if err = r.create(typ, target, temp); err != nil {
+		return err
 	}
 
-	return errors.New(r.t.Get("unknown backup type"))
+	files, err := os.ReadDir(temp)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		path := filepath.Join(temp, file.Name())
+		if crypto.Enabled() {
+			if path, err = backupcrypto.EncryptFile(path, crypto); err != nil {
+				return errors.New(r.t.Get("Encrypt backup failed: %v", err))
+			}
+			if app.IsCli {
+				fmt.Println(r.t.Get("|-Encrypted backup file: %s", filepath.Base(path)))
+			}
+		}
+
+		name := filepath.Base(path)
+		if err = r.recordChecksum(typ, storage, path); err != nil {
+			return err
+		}
+		if storage == 0 {
+			if err = os.Rename(path, filepath.Join(local, name)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err = r.upload(s, path, gopath.Join(dir, name)); err != nil {
+			return errors.New(r.t.Get("Upload backup failed: %v", err))
+		}
+		if app.IsCli {
+			fmt.Println(r.t.Get("|-Uploaded to storage: %s", gopath.Join(dir, name)))
+		}
+	}
+
+	return nil
 }
 
 // Delete 删除备份
-func (r *backupRepo) Delete(typ biz.BackupType, name string) error {
-	path, err := r.GetPath(typ)
+func (r *backupRepo) Delete(typ biz.BackupType, storage uint, name string) error {
+	if typ == biz.BackupTypeWebsiteSnapshot {
+		return r.deleteSnapshot(storage, name)
+	}
+
+	s, dir, err := r.open(typ, storage, "")
 	if err != nil {
 		return err
 	}
+	defer func(s pkgstorage.Storage) { _ = pkgstorage.Close(s) }(s)
 
-	file := filepath.Join(path, name)
-	return io.Remove(file)
+	if err = s.Delete(gopath.Join(dir, name)); err != nil {
+		return err
+	}
+
+	return r.db.Where("type = ? AND storage = ? AND name = ?", typ, storage, name).Delete(&biz.BackupCheck{}).Error
 }
 
 // Restore 恢复备份
 // typ 备份类型
-// backup 备份压缩包，可以是绝对路径或者相对路径
+// storage 备份存储 ID，0 为本地
+// backup 备份压缩包，本地存储时可以是绝对路径或者相对路径
 // target 目标名称
-func (r *backupRepo) Restore(typ biz.BackupType, backup, target string) error {
-	if !io.Exists(backup) {
+// point 可选时间点，MySQL 备份恢复后继续重放 binlog 到该时间点
+func (r *backupRepo) Restore(typ biz.BackupType, storage uint, backup, target string, point ...biz.BackupPoint) error {
+	if typ == biz.BackupTypeWebsiteSnapshot {
+		return r.restoreWebsiteSnapshot(storage, backup, target)
+	}
+	if len(point) > 0 && typ != biz.BackupTypeMySQL {
+		return errors.New(r.t.Get("point-in-time recovery only supports MySQL backups"))
+	}
+
+	if storage == 0 {
+		if !io.Exists(backup) {
+			path, err := r.GetPath(typ)
+			if err != nil {
+				return err
+			}
+			backup = filepath.Join(path, backup)
+		}
+		return r.restore(typ, backup, target, point...)
+	}
+
+	// 远程存储先下载到临时目录
+	s, dir, err := r.open(typ, storage, "")
+	if err != nil {
+		return err
+	}
+	defer func(s pkgstorage.Storage) { _ = pkgstorage.Close(s) }(s)
+
+	temp, err := os.MkdirTemp("", "panel-backup-download")
+	if err != nil {
+		return err
+	}
+	defer func(temp string) { _ = io.Remove(temp) }(temp)
+
+	local := filepath.Join(temp, filepath.Base(backup))
+	if err = r.download(s, gopath.Join(dir, backup), local); err != nil {
+		return errors.New(r.t.Get("Download backup failed: %v", err))
+	}
+
+	return r.restore(typ, local, target, point...)
+}
+
+// SnapshotFiles 快照中的文件列表
+func (r *backupRepo) SnapshotFiles(id string) ([]snapshot.Node, error) {
+	repo, err := r.findSnapshot(id)
+	if err != nil {
+		return nil, err
+	}
+	defer repo.Close()
+
+	return repo.Nodes(id)
+}
+
+// RestoreSnapshotFile 从快照中恢复单个文件或目录到网站
+// id 快照 ID
+// file 相对网站目录的文件路径
+// target 网站名称
+func (r *backupRepo) RestoreSnapshotFile(id, file, target string) error {
+	website, err := r.website.GetByName(target)
+	if err != nil {
+		return err
+	}
+	repo, err := r.findSnapshot(id, website.Name)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	if err = repo.RestoreFile(id, file, website.Path); err != nil {
+		return err
+	}
+
+	return io.Chown(filepath.Join(website.Path, filepath.Clean("/"+file)), "www", "www")
+}
+
+// ClearSnapshots 按 GFS 策略清理网站快照
+// path 快照仓库目录，为空时清理所有已知的快照仓库
+// target 网站名称
+// policy 保留策略
+func (r *backupRepo) ClearSnapshots(path, target string, policy snapshot.Policy) error {
+	paths := []string{path}
+	if path == "" {
+		var err error
+		if paths, err = r.snapshotRepositories(); err != nil {
+			return err
+		}
+	}
+
+	for _, p := range paths {
+		if err := r.clearSnapshots(p, target, policy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// clearSnapshots 按 GFS 策略清理单个快照仓库中的网站快照
+func (r *backupRepo) clearSnapshots(path, target string, policy snapshot.Policy) error {
+	repo, err := r.openSnapshot(path)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	list, err := repo.List(target)
+	if err != nil {
+		return err
+	}
+
+	_, remove := policy.Apply(list)
+	for _, snap := range remove {
+		if app.IsCli {
+			fmt.Println(r.t.Get("|-Cleaning expired snapshot: %s (%s)", snap.ID, snap.Time.Format(time.DateTime)))
+		}
+		if err = repo.Forget(snap.ID); err != nil {
+			return errors.New(r.t.Get("Cleanup failed: %v", err))
+		}
+	}
+
+	count, size, err := repo.Prune()
+	if err != nil {
+		return errors.New(r.t.Get("Cleanup failed: %v", err))
+	}
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Removed %d unused chunks, freed %s", count, tools.FormatBytes(float64(size))))
+	}
+
+	return nil
+}
+
+// Verify 校验备份文件并记录结果
+// 依次检查校验和、压缩包内容，数据库备份会试恢复到临时数据库
+// 返回的错误表示无法完成校验，校验不通过记录在结果中
+func (r *backupRepo) Verify(typ biz.BackupType, storage uint, name string) (*biz.BackupCheck, error) {
+	if typ == biz.BackupTypeWebsiteSnapshot || typ == biz.BackupTypeMySQLBinlog {
+		return nil, errors.New(r.t.Get("this backup type does not support verification"))
+	}
+	if name != filepath.Base(name) {
+		return nil, errors.New(r.t.Get("invalid backup file name: %s", name))
+	}
+
+	temp, err := os.MkdirTemp("", "panel-backup-verify")
+	if err != nil {
+		return nil, err
+	}
+	defer func(temp string) { _ = io.Remove(temp) }(temp)
+
+	backup := ""
+	if storage == 0 {
 		path, err := r.GetPath(typ)
 		if err != nil {
+			return nil, err
+		}
+		backup = filepath.Join(path, name)
+		if !io.Exists(backup) {
+			return nil, errors.New(r.t.Get("backup file %s not exists", backup))
+		}
+	} else {
+		s, dir, err := r.open(typ, storage, "")
+		if err != nil {
+			return nil, err
+		}
+		backup = filepath.Join(temp, name)
+		err = r.download(s, gopath.Join(dir, name), backup)
+		_ = pkgstorage.Close(s)
+		if err != nil {
+			return nil, errors.New(r.t.Get("Download backup failed: %v", err))
+		}
+	}
+
+	check := &biz.BackupCheck{Type: typ, Storage: storage, Name: name}
+	if err = r.db.Where("type = ? AND storage = ? AND name = ?", typ, storage, name).FirstOrInit(check).Error; err != nil {
+		return nil, err
+	}
+
+	check.Status = biz.BackupCheckStatusPassed
+	check.Message = ""
+	if err = r.verify(typ, backup, temp, check); err != nil {
+		check.Status = biz.BackupCheckStatusFailed
+		check.Message = err.Error()
+	}
+	now := time.Now()
+	check.CheckedAt = &now
+	if err = r.db.Save(check).Error; err != nil {
+		return nil, err
+	}
+
+	if app.IsCli {
+		if check.Status == biz.BackupCheckStatusPassed {
+			fmt.Println(r.t.Get("|-Verification passed: %s", name))
+		} else {
+			fmt.Println(r.t.Get("|-Verification failed: %s, %s", name, check.Message))
+		}
+	}
+
+	return check, nil
+}
+
+// verify 校验本地备份文件，temp 为可用的临时目录
+func (r *backupRepo) verify(typ biz.BackupType, backup, temp string, check *biz.BackupCheck) error {
+	sum, err := io.Sha256(backup)
+	if err != nil {
+		return err
+	}
+	// 上传或旧版本创建的备份没有校验和，首次校验时记录
+	if check.Checksum == "" {
+		check.Checksum = sum
+	}
+	if check.Checksum != sum {
+		return errors.New(r.t.Get("checksum mismatch, expected %s, got %s", check.Checksum, sum))
+	}
+
+	if backupcrypto.IsEncrypted(backup) {
+		if backup, err = r.decrypt(backup, temp); err != nil {
+			return err
+		}
+	}
+
+	// 未压缩的 SQL 备份跳过压缩包检查
+	ext := map[biz.BackupType]string{biz.BackupTypeMySQL: ".sql", biz.BackupTypePostgres: ".sql", biz.BackupTypeRedis: ".rdb"}[typ]
+	if ext == "" || !strings.HasSuffix(backup, ext) {
+		files, err := io.ListCompress(backup)
+		if err != nil {
+			return errors.New(r.t.Get("failed to list archive contents: %v", err))
+		}
+		if len(slices.DeleteFunc(files, func(file string) bool { return strings.TrimSpace(file) == "" })) == 0 {
+			return errors.New(r.t.Get("archive is empty"))
+		}
+		if ext == "" {
+			return nil
+		}
+		if backup, err = r.autoUnCompress(backup, ext); err != nil {
 			return err
 		}
-		backup = filepath.Join(path, backup)
+		defer func(dir string) { _ = io.Remove(dir) }(filepath.Dir(backup))
 	}
 
 	switch typ {
-	case biz.BackupTypeWebsite:
-		return r.restoreWebsite(backup, target)
 	case biz.BackupTypeMySQL:
-		return r.restoreMySQL(backup, target)
+		return r.trialRestoreMySQL(backup)
 	case biz.BackupTypePostgres:
-		return r.restorePostgres(backup, target)
+		return r.trialRestorePostgres(backup)
+	case biz.BackupTypeRedis:
+		if _, err = shell.Execf("command -v redis-check-rdb"); err != nil {
+			return nil
+		}
+		if _, err = shell.Execf("redis-check-rdb '%s'", backup); err != nil {
+			return errors.New(r.t.Get("RDB check failed: %v", err))
+		}
 	}
 
-	return errors.New(r.t.Get("unknown backup type"))
+	return nil
 }
 
-// CutoffLog 切割日志
-// path 保存目录绝对路径
-// target 待切割日志文件绝对路径
-func (r *backupRepo) CutoffLog(path, target string) error {
-	if !io.Exists(target) {
-		return errors.New(r.t.Get("log file %s not exists", target))
+// trialRestoreMySQL 试恢复 SQL 备份到临时数据库，完成后删除
+func (r *backupRepo) trialRestoreMySQL(backup string) error {
+	rootPassword, err := r.setting.Get(biz.SettingKeyMySQLRootPassword)
+	if err != nil {
+		return err
 	}
+	mysql, err := db.NewMySQL("root", rootPassword, "/tmp/mysql.sock", "unix")
+	if err != nil {
+		return err
+	}
+	defer mysql.Close()
 
-	to := filepath.Join(path, fmt.Sprintf("%s_%s.zip", time.Now().Format("20060102150405"), filepath.Base(target)))
-	if err := io.Compress(filepath.Dir(target), []string{filepath.Base(target)}, to); err != nil {
+	name := fmt.Sprintf("panel_verify_%d", time.Now().UnixNano())
+	if err = mysql.DatabaseCreate(name); err != nil {
 		return err
 	}
+	defer func(mysql db.Operator, name string) { _ = mysql.DatabaseDrop(name) }(mysql, name)
 
-	// 原文件不能直接删除，直接删的话仍会占用空间直到重启相关的应用
-	if _, err := shell.Execf("cat /dev/null > '%s'", target); err != nil {
+	if err = os.Setenv("MYSQL_PWD", rootPassword); err != nil {
 		return err
 	}
+	defer func() { _ = os.Unsetenv("MYSQL_PWD") }()
+	// 试恢复不写入 binlog，避免被归档和重放
+	if _, err = shell.Execf(`mysql -u root --init-command='SET sql_log_bin=0' '%s' < '%s'`, name, backup); err != nil {
+		return errors.New(r.t.Get("trial restore failed: %v", err))
+	}
 
 	return nil
 }
 
-// ClearExpired 清理过期备份
-// path 备份目录绝对路径
-// prefix 目标文件前缀
-// save 保存份数
-func (r *backupRepo) ClearExpired(path, prefix string, save int) error {
-	files, err := os.ReadDir(path)
+// trialRestorePostgres 试恢复 SQL 备份到临时数据库，完成后删除
+func (r *backupRepo) trialRestorePostgres(backup string) error {
+	postgres, err := db.NewPostgres("postgres", "", "127.0.0.1", 5432)
 	if err != nil {
 		return err
 	}
+	defer postgres.Close()
+
+	name := fmt.Sprintf("panel_verify_%d", time.Now().UnixNano())
+	if err = postgres.DatabaseCreate(name); err != nil {
+		return err
+	}
+	defer func(postgres db.Operator, name string) { _ = postgres.DatabaseDrop(name) }(postgres, name)
 
-	var filtered []os.FileInfo
-	for _, file := range files {
-		if strings.HasPrefix(file.Name(), prefix) && strings.HasSuffix(file.Name(), ".zip") {
-			info, err := os.Stat(filepath.Join(path, file.Name()))
-			if err != nil {
-				continue
-			}
-			filtered = append(filtered, info)
-		}
+	if _, err = shell.Execf(`su - postgres -c "psql -v ON_ERROR_STOP=1 -q '%s'" < '%s'`, name, backup); err != nil {
+		return errors.New(r.t.Get("trial restore failed: %v", err))
 	}
 
-	// 排序所有备份文件，从新到旧
-	slices.SortFunc(filtered, func(a, b os.FileInfo) int {
-		if a.ModTime().After(b.ModTime()) {
-			return -1
+	return nil
+}
+
+// recordChecksum 记录新建备份的校验和
+func (r *backupRepo) recordChecksum(typ biz.BackupType, storage uint, path string) error {
+	sum, err := io.Sha256(path)
+	if err != nil {
+		return err
+	}
+
+	check := &biz.BackupCheck{Type: typ, Storage: storage, Name: filepath.Base(path)}
+	if err = r.db.Where("type = ? AND storage = ? AND name = ?", typ, storage, check.Name).FirstOrInit(check).Error; err != nil {
+		return err
+	}
+	check.Checksum = sum
+	check.Status = ""
+	check.Message = ""
+	check.CheckedAt = nil
+
+	return r.db.Save(check).Error
+}
+
+// ArchiveMySQLBinlog 归档 MySQL binlog 并清理过期归档
+// 当前日志在上次归档后有写入时先轮转，保证归档的都是完整文件
+func (r *backupRepo) ArchiveMySQLBinlog() error {
+	rootPassword, err := r.setting.Get(biz.SettingKeyMySQLRootPassword)
+	if err != nil {
+		return err
+	}
+	mysql, err := db.NewMySQL("root", rootPassword, "/tmp/mysql.sock", "unix")
+	if err != nil {
+		return err
+	}
+	defer mysql.Close()
+
+	basename, err := r.mysqlBinlogBasename(mysql)
+	if err != nil {
+		return err
+	}
+	archive, err := r.GetPath(biz.BackupTypeMySQLBinlog)
+	if err != nil {
+		return err
+	}
+	logs, err := r.mysqlBinlogs(mysql)
+	if err != nil || len(logs) == 0 {
+		return err
+	}
+
+	// 归档文件保留源文件的修改时间，最新的归档时间即上次归档时的写入时间
+	dir := filepath.Dir(basename)
+	var latest time.Time
+	entries, err := os.ReadDir(archive)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if info, err := entry.Info(); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
 		}
-		if a.ModTime().Before(b.ModTime()) {
-			return 1
+	}
+	if info, err := os.Stat(filepath.Join(dir, logs[len(logs)-1])); err == nil && info.ModTime().After(latest) {
+		if _, err = mysql.Exec("FLUSH BINARY LOGS"); err != nil {
+			return err
 		}
-		return 0
-	})
-	if len(filtered) <= save {
-		return nil
+		if logs, err = r.mysqlBinlogs(mysql); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range logs[:len(logs)-1] {
+		if io.Exists(filepath.Join(archive, name)) {
+			continue
+		}
+		if err = r.archiveFile(filepath.Join(dir, name), filepath.Join(archive, name)); err != nil {
+			return err
+		}
+	}
+
+	days, err := r.setting.GetInt(biz.SettingKeyMySQLBinlogDays, 7)
+	if err != nil {
+		return err
+	}
+	if entries, err = os.ReadDir(archive); err != nil {
+		return err
+	}
+	expire := time.Now().AddDate(0, 0, -days)
+	for _, entry := range entries {
+		if info, err := entry.Info(); err == nil && !entry.IsDir() && info.ModTime().Before(expire) {
+			if err = os.Remove(filepath.Join(archive, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// CutoffLog 切割日志
+// path 保存目录绝对路径
+// target 待切割日志文件绝对路径
+func (r *backupRepo) CutoffLog(path, target string) error {
+	if !io.Exists(target) {
+		return errors.New(r.t.Get("log file %s not exists", target))
+	}
+
+	to := filepath.Join(path, fmt.Sprintf("%s_%s.zip", time.Now().Format("20060102150405"), filepath.Base(target)))
+	if err := io.Compress(filepath.Dir(target), []string{filepath.Base(target)}, to); err != nil {
+		return err
+	}
+
+	// 原文件不能直接删除，直接删的话仍会占用空间直到重启相关的应用
+	if _, err := shell.Execf("cat /dev/null > '%s'", target); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ClearExpired 清理过期备份
+// typ 备份类型，为空时不是备份文件（如切割的日志），不清理校验记录
+// storage 备份存储 ID，0 为本地
+// path 备份目录，本地存储时为绝对路径，远程存储时为存储中的目录
+// prefix 目标文件前缀
+// save 保存份数
+func (r *backupRepo) ClearExpired(typ biz.BackupType, storage uint, path, prefix string, save int) error {
+	var s pkgstorage.Storage
+	var err error
+	dir := path
+	if storage == 0 {
+		s, err = pkgstorage.NewLocal(path)
+		dir = ""
+	} else {
+		s, err = r.storage.Open(storage)
+	}
+	if err != nil {
+		return err
+	}
+	defer func(s pkgstorage.Storage) { _ = pkgstorage.Close(s) }(s)
+
+	files, err := s.List(dir)
+	if err != nil {
+		return err
+	}
+
+	var filtered []pkgstorage.File
+	for _, file := range files {
+		if strings.HasPrefix(file.Name, prefix) && strings.HasSuffix(strings.TrimSuffix(file.Name, backupcrypto.Ext), ".zip") {
+			filtered = append(filtered, file)
+		}
+	}
+
+	// 排序所有备份文件，从新到旧
+	slices.SortFunc(filtered, func(a, b pkgstorage.File) int {
+		return b.Time.Compare(a.Time)
+	})
+	if len(filtered) <= save {
+		return nil
+	}
+
+	// 切片保留 save 份，删除剩余
+	toDelete := filtered[save:]
+	for _, file := range toDelete {
+		name := gopath.Join(dir, file.Name)
+		if app.IsCli {
+			fmt.Println(r.t.Get("|-Cleaning expired file: %s", name))
+		}
+		if err = s.Delete(name); err != nil {
+			return errors.New(r.t.Get("Cleanup failed: %v", err))
+		}
+		if typ == "" {
+			continue
+		}
+		if err = r.db.Where("type = ? AND storage = ? AND name = ?", typ, storage, file.Name).Delete(&biz.BackupCheck{}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetPath 获取备份路径
+func (r *backupRepo) GetPath(typ biz.BackupType) (string, error) {
+	backupPath, err := r.setting.Get(biz.SettingKeyBackupPath)
+	if err != nil {
+		return "", err
+	}
+	if !slices.Contains([]biz.BackupType{biz.BackupTypePath, biz.BackupTypeWebsite, biz.BackupTypeMySQL, biz.BackupTypePostgres, biz.BackupTypeRedis, biz.BackupTypePanel, biz.BackupTypeWebsiteSnapshot, biz.BackupTypeMySQLBinlog}, typ) {
+		return "", errors.New(r.t.Get("unknown backup type"))
+	}
+
+	backupPath = filepath.Join(backupPath, string(typ))
+	if !io.Exists(backupPath) {
+		if err = os.MkdirAll(backupPath, 0644); err != nil {
+			return "", err
+		}
+	}
+
+	return backupPath, nil
+}
+
+// open 打开备份存储，返回存储及备份所在目录
+// storage 为 0 时使用本地备份目录，dir 为空时使用备份类型作为远程目录
+func (r *backupRepo) open(typ biz.BackupType, storage uint, dir string) (pkgstorage.Storage, string, error) {
+	if storage == 0 {
+		path, err := r.GetPath(typ)
+		if err != nil {
+			return nil, "", err
+		}
+		s, err := pkgstorage.NewLocal(path)
+		return s, "", err
+	}
+
+	if dir == "" {
+		dir = string(typ)
+	}
+	s, err := r.storage.Open(storage)
+	return s, dir, err
+}
+
+// create 创建备份到本地目录
+func (r *backupRepo) create(typ biz.BackupType, target, to string) error {
+	switch typ {
+	case biz.BackupTypeWebsite:
+		return r.createWebsite(to, target)
+	case biz.BackupTypeMySQL:
+		return r.createMySQL(to, target)
+	case biz.BackupTypePostgres:
+		return r.createPostgres(to, target)
+	case biz.BackupTypeRedis:
+		return r.createRedis(to, target)
+	case biz.BackupTypePanel:
+		return r.createPanel(to)
+	}
+
+	return errors.New(r.t.Get("unknown backup type"))
+}
+
+// restore 从本地备份文件恢复，加密的备份先解密到临时目录
+func (r *backupRepo) restore(typ biz.BackupType, backup, target string, point ...biz.BackupPoint) error {
+	if backupcrypto.IsEncrypted(backup) {
+		temp, err := os.MkdirTemp("", "panel-backup-decrypt")
+		if err != nil {
+			return err
+		}
+		defer func(temp string) { _ = io.Remove(temp) }(temp)
+
+		if backup, err = r.decrypt(backup, temp); err != nil {
+			return err
+		}
+	}
+
+	switch typ {
+	case biz.BackupTypeWebsite:
+		return r.restoreWebsite(backup, target)
+	case biz.BackupTypeMySQL:
+		return r.restoreMySQL(backup, target, point...)
+	case biz.BackupTypePostgres:
+		return r.restorePostgres(backup, target)
+	case biz.BackupTypeRedis:
+		return r.restoreRedis(backup, target)
+	}
+
+	return errors.New(r.t.Get("unknown backup type"))
+}
+
+// encryption 获取备份加密配置
+func (r *backupRepo) encryption() (backupcrypto.Config, error) {
+	conf := backupcrypto.Config{}
+	mode, err := r.setting.Get(biz.SettingKeyBackupEncrypt)
+	if err != nil {
+		return conf, err
+	}
+	conf.Mode = backupcrypto.Mode(mode)
+	if conf.Passphrase, err = r.setting.Get(biz.SettingKeyBackupPassphrase); err != nil {
+		return conf, err
+	}
+	if conf.Recipient, err = r.setting.Get(biz.SettingKeyBackupRecipient); err != nil {
+		return conf, err
+	}
+	if conf.Identity, err = r.setting.Get(biz.SettingKeyBackupIdentity); err != nil {
+		return conf, err
+	}
+
+	return conf, nil
+}
+
+// decrypt 解密备份文件到 dir 目录，返回解密后的文件路径
+func (r *backupRepo) decrypt(backup, dir string) (string, error) {
+	if !io.Exists(backup) {
+		return "", errors.New(r.t.Get("backup file %s not exists", backup))
+	}
+	crypto, err := r.encryption()
+	if err != nil {
+		return "", err
+	}
+
+	decrypted, err := backupcrypto.DecryptFile(backup, dir, crypto)
+	if err != nil {
+		return "", errors.New(r.t.Get("Decrypt backup failed: %v", err))
+	}
+
+	return decrypted, nil
+}
+
+// upload 上传本地文件到存储
+func (r *backupRepo) upload(s pkgstorage.Storage, local, name string) error {
+	file, err := os.Open(local)
+	if err != nil {
+		return err
+	}
+	defer func(file *os.File) { _ = file.Close() }(file)
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	return s.Put(name, file, info.Size())
+}
+
+// download 从存储下载文件到本地
+func (r *backupRepo) download(s pkgstorage.Storage, name, local string) error {
+	reader, err := s.Get(name)
+	if err != nil {
+		return err
+	}
+	defer func(reader stdio.ReadCloser) { _ = reader.Close() }(reader)
+
+	file, err := os.OpenFile(local, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer func(file *os.File) { _ = file.Close() }(file)
+
+	_, err = stdio.Copy(file, reader)
+	return err
+}
+
+// mysqlBinlogBasename 获取 binlog 文件路径前缀，未开启 binlog 时返回错误
+func (r *backupRepo) mysqlBinlogBasename(mysql db.Operator) (string, error) {
+	var logBin bool
+	var basename sql.NullString
+	if err := mysql.QueryRow("SELECT @@log_bin, @@log_bin_basename").Scan(&logBin, &basename); err != nil {
+		return "", err
+	}
+	if !logBin || basename.String == "" {
+		return "", errors.New(r.t.Get("binary logging is not enabled, please enable log-bin in my.cnf first"))
+	}
+
+	return basename.String, nil
+}
+
+// mysqlBinlogs 获取服务器上的 binlog 文件列表，最后一个为当前正在写入的文件
+func (r *backupRepo) mysqlBinlogs(mysql db.Operator) ([]string, error) {
+	rows, err := mysql.Query("SHOW BINARY LOGS")
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) { _ = rows.Close() }(rows)
+
+	// 不同版本返回的列数不同，只取第一列文件名
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var logs []string
+	for rows.Next() {
+		values := make([]any, len(columns))
+		for i := range values {
+			values[i] = new(sql.RawBytes)
+		}
+		if err = rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		logs = append(logs, string(*values[0].(*sql.RawBytes)))
+	}
+
+	return logs, rows.Err()
+}
+
+// mysqlBinlogSeq 解析 binlog 文件名的前缀和序号，如 mysql-bin.000123 返回 mysql-bin 和 123
+func (r *backupRepo) mysqlBinlogSeq(name string) (string, int, bool) {
+	ext := filepath.Ext(name)
+	seq, err := strconv.Atoi(strings.TrimPrefix(ext, "."))
+	if err != nil {
+		return "", 0, false
+	}
+
+	return strings.TrimSuffix(name, ext), seq, true
+}
+
+// archiveFile 复制文件到归档目录，先写入临时文件避免留下不完整的归档
+// 归档文件保留源文件的修改时间，用于判断过期
+func (r *backupRepo) archiveFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func(in *os.File) { _ = in.Close() }(in)
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	temp := filepath.Join(filepath.Dir(dst), "."+filepath.Base(dst))
+	out, err := os.OpenFile(temp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = stdio.Copy(out, in); err != nil {
+		_ = out.Close()
+		_ = os.Remove(temp)
+		return err
+	}
+	if err = out.Close(); err != nil {
+		_ = os.Remove(temp)
+		return err
+	}
+	if err = os.Chtimes(temp, info.ModTime(), info.ModTime()); err != nil {
+		_ = os.Remove(temp)
+		return err
+	}
+
+	return os.Rename(temp, dst)
+}
+
+// openSnapshot 打开快照仓库，path 为空时使用默认路径
+// 自定义路径会被记录下来，以便通过接口管理其中的快照
+func (r *backupRepo) openSnapshot(path string) (*snapshot.Repository, error) {
+	defaultPath, err := r.GetPath(biz.BackupTypeWebsiteSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		path = defaultPath
+	}
+	path = filepath.Clean(path)
+
+	if path != filepath.Clean(defaultPath) {
+		paths, err := r.setting.GetSlice(biz.SettingKeySnapshotRepos)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(paths, path) {
+			if err = r.setting.SetSlice(biz.SettingKeySnapshotRepos, append(paths, path)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return snapshot.Open(path)
+}
+
+// snapshotRepositories 所有已知的快照仓库目录，包括默认路径和使用过的自定义路径
+func (r *backupRepo) snapshotRepositories() ([]string, error) {
+	defaultPath, err := r.GetPath(biz.BackupTypeWebsiteSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := r.setting.GetSlice(biz.SettingKeySnapshotRepos)
+	if err != nil {
+		return nil, err
+	}
+
+	repos := []string{filepath.Clean(defaultPath)}
+	for _, path := range paths {
+		// 跳过已被删除的仓库
+		if !slices.Contains(repos, path) && io.Exists(filepath.Join(path, "snapshots")) {
+			repos = append(repos, path)
+		}
+	}
+
+	return repos, nil
+}
+
+// findSnapshot 在所有已知的快照仓库中查找快照，返回其所在的仓库
+// target 不为空时快照必须属于该网站
+func (r *backupRepo) findSnapshot(id string, target ...string) (*snapshot.Repository, error) {
+	paths, err := r.snapshotRepositories()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		repo, err := snapshot.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		snap, err := repo.Get(id)
+		if err != nil {
+			repo.Close()
+			continue
+		}
+		if len(target) > 0 && snap.Target != target[0] {
+			repo.Close()
+			return nil, errors.New(r.t.Get("snapshot %s does not belong to %s", id, target[0]))
+		}
+		return repo, nil
+	}
+
+	return nil, errors.New(r.t.Get("snapshot %s not exists", id))
+}
+
+// listSnapshots 网站快照列表，包含所有已知的快照仓库
+func (r *backupRepo) listSnapshots(storage uint) ([]*types.BackupFile, error) {
+	if storage != 0 {
+		return nil, errors.New(r.t.Get("snapshot backups only support local storage"))
+	}
+	paths, err := r.snapshotRepositories()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*types.BackupFile, 0)
+	for _, path := range paths {
+		repo, err := snapshot.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		snapshots, err := repo.List("")
+		repo.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, snap := range snapshots {
+			list = append(list, &types.BackupFile{
+				Name:   snap.ID,
+				Path:   snap.Source,
+				Size:   tools.FormatBytes(float64(snap.Size)),
+				Time:   snap.Time,
+				Target: snap.Target,
+			})
+		}
+	}
+
+	return list, nil
+}
+
+// createWebsiteSnapshot 创建网站增量快照
+func (r *backupRepo) createWebsiteSnapshot(storage uint, path, name string) error {
+	if storage != 0 {
+		return errors.New(r.t.Get("snapshot backups only support local storage"))
+	}
+	website, err := r.website.GetByName(name)
+	if err != nil {
+		return err
+	}
+	repo, err := r.openSnapshot(path)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	start := time.Now()
+	snap, err := repo.Backup(website.Name, website.Path)
+	if err != nil {
+		return err
+	}
+
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Backup time: %s", time.Since(start).String()))
+		fmt.Println(r.t.Get("|-Snapshot ID: %s", snap.ID))
+		fmt.Println(r.t.Get("|-Files: %d, size: %s, newly stored: %s", snap.Files, tools.FormatBytes(float64(snap.Size)), tools.FormatBytes(float64(snap.Added))))
+	}
+	return nil
+}
+
+// deleteSnapshot 删除网站快照并清理无用数据块
+func (r *backupRepo) deleteSnapshot(storage uint, id string) error {
+	if storage != 0 {
+		return errors.New(r.t.Get("snapshot backups only support local storage"))
+	}
+	repo, err := r.findSnapshot(id)
+	if err != nil {
+		return err
 	}
+	defer repo.Close()
 
-	// 切片保留 save 份，删除剩余
-	toDelete := filtered[save:]
-	for _, file := range toDelete {
-		filePath := filepath.Join(path, file.Name())
-		if app.IsCli {
-			fmt.Println(r.t.Get("|-Cleaning expired file: %s", filePath))
-		}
-		if err = os.Remove(filePath); err != nil {
-			return errors.New(r.t.Get("Cleanup failed: %v", err))
-		}
+	if err = repo.Forget(id); err != nil {
+		return err
 	}
 
-	return nil
+	_, _, err = repo.Prune()
+	return err
 }
 
-// GetPath 获取备份路径
-func (r *backupRepo) GetPath(typ biz.BackupType) (string, error) {
-	backupPath, err := r.setting.Get(biz.SettingKeyBackupPath)
+// restoreWebsiteSnapshot 从快照恢复网站
+func (r *backupRepo) restoreWebsiteSnapshot(storage uint, id, target string) error {
+	if storage != 0 {
+		return errors.New(r.t.Get("snapshot backups only support local storage"))
+	}
+	website, err := r.website.GetByName(target)
 	if err != nil {
-		return "", err
+		return err
 	}
-	if !slices.Contains([]biz.BackupType{biz.BackupTypePath, biz.BackupTypeWebsite, biz.BackupTypeMySQL, biz.BackupTypePostgres, biz.BackupTypeRedis, biz.BackupTypePanel}, typ) {
-		return "", errors.New(r.t.Get("unknown backup type"))
+	repo, err := r.findSnapshot(id, website.Name)
+	if err != nil {
+		return err
 	}
+	defer repo.Close()
 
-	backupPath = filepath.Join(backupPath, string(typ))
-	if !io.Exists(backupPath) {
-		if err = os.MkdirAll(backupPath, 0644); err != nil {
-			return "", err
+	// 先恢复到同级临时目录，成功后再替换网站目录，避免恢复失败时网站被删除
+	path := filepath.Clean(website.Path)
+	suffix := strconv.FormatInt(time.Now().UnixNano(), 10)
+	restored := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".restore-"+suffix)
+	if err = repo.Restore(id, restored); err != nil {
+		_ = io.Remove(restored)
+		return err
+	}
+	if err = io.Chown(restored, "www", "www"); err != nil {
+		_ = io.Remove(restored)
+		return err
+	}
+
+	old := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".old-"+suffix)
+	if io.Exists(path) {
+		if err = os.Rename(path, old); err != nil {
+			_ = io.Remove(restored)
+			return err
 		}
 	}
+	if err = os.Rename(restored, path); err != nil {
+		_ = os.Rename(old, path)
+		_ = io.Remove(restored)
+		return err
+	}
 
-	return backupPath, nil
+	return io.Remove(old)
 }
 
 // createWebsite 创建网站备份
@@ -268,12 +1197,26 @@ func (r *backupRepo) createMySQL(to string, name string) error {
 		return err
 	}
 
+	// 开启 binlog 时在备份中记录对应的 binlog 位置，用于时间点恢复
+	// 不导出 GTID_PURGED，否则无法恢复到开启了 GTID 的原服务器
+	args := ""
+	help, _ := shell.Execf("mysqldump --help")
+	if _, err = r.mysqlBinlogBasename(mysql); err == nil {
+		args = "--single-transaction --source-data=2"
+		if !strings.Contains(help, "--source-data") {
+			args = "--single-transaction --master-data=2"
+		}
+	}
+	if strings.Contains(help, "--set-gtid-purged") {
+		args += " --set-gtid-purged=OFF"
+	}
+
 	if err = os.Setenv("MYSQL_PWD", rootPassword); err != nil {
 		return err
 	}
 	start := time.Now()
 	backup := filepath.Join(to, fmt.Sprintf("%s_%s.sql", name, time.Now().Format("20060102150405")))
-	if _, err = shell.Execf(`mysqldump -u root '%s' > '%s'`, name, backup); err != nil {
+	if _, err = shell.Execf(`mysqldump -u root %s '%s' > '%s'`, args, name, backup); err != nil {
 		return err
 	}
 	if err = os.Unsetenv("MYSQL_PWD"); err != nil {
@@ -332,6 +1275,55 @@ func (r *backupRepo) createPostgres(to string, name string) error {
 	return nil
 }
 
+// createRedis 创建 Redis 备份
+// name 数据库服务器名称，仅支持本机 Redis
+func (r *backupRepo) createRedis(to string, name string) error {
+	redis, err := r.localRedis(name)
+	if err != nil {
+		return err
+	}
+	defer redis.Close()
+
+	dir, err := redis.Config("dir")
+	if err != nil {
+		return err
+	}
+	filename, err := redis.Config("dbfilename")
+	if err != nil {
+		return err
+	}
+	rdb := filepath.Join(dir, filename)
+	size := int64(0)
+	if info, err := os.Stat(rdb); err == nil {
+		size = info.Size()
+	}
+	if err = r.preCheckDB(to, size); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	if err = redis.BGSave(30 * time.Minute); err != nil {
+		return err
+	}
+	backup := filepath.Join(to, fmt.Sprintf("%s_%s.rdb", name, time.Now().Format("20060102150405")))
+	if err = r.archiveFile(rdb, backup); err != nil {
+		return err
+	}
+
+	if err = io.Compress(filepath.Dir(backup), []string{filepath.Base(backup)}, backup+".zip"); err != nil {
+		return err
+	}
+	if err = io.Remove(backup); err != nil {
+		return err
+	}
+
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Backup time: %s", time.Since(start).String()))
+		fmt.Println(r.t.Get("|-Backed up to file: %s", filepath.Base(backup+".zip")))
+	}
+	return nil
+}
+
 // createPanel 创建面板备份
 func (r *backupRepo) createPanel(to string) error {
 	backup := filepath.Join(to, fmt.Sprintf("panel_%s.zip", time.Now().Format("20060102150405")))
@@ -401,7 +1393,8 @@ func (r *backupRepo) restoreWebsite(backup, target string) error {
 }
 
 // restoreMySQL 恢复 MySQL 备份
-func (r *backupRepo) restoreMySQL(backup, target string) error {
+// point 不为空时继续重放 binlog 到指定时间点，目标数据库不存在时自动创建，已存在时恢复到临时数据库供检查
+func (r *backupRepo) restoreMySQL(backup, target string, point ...biz.BackupPoint) error {
 	if !io.Exists(backup) {
 		return errors.New(r.t.Get("backup file %s not exists", backup))
 	}
@@ -415,8 +1408,14 @@ func (r *backupRepo) restoreMySQL(backup, target string) error {
 		return err
 	}
 	defer mysql.Close()
-	if exist, _ := mysql.DatabaseExists(target); !exist {
-		return errors.New(r.t.Get("database does not exist: %s", target))
+	exist, _ := mysql.DatabaseExists(target)
+	if !exist {
+		if len(point) == 0 {
+			return errors.New(r.t.Get("database does not exist: %s", target))
+		}
+		if err = mysql.DatabaseCreate(target); err != nil {
+			return err
+		}
 	}
 	if err = os.Setenv("MYSQL_PWD", rootPassword); err != nil {
 		return err
@@ -424,15 +1423,29 @@ func (r *backupRepo) restoreMySQL(backup, target string) error {
 
 	clean := false
 	if !strings.HasSuffix(backup, ".sql") {
-		backup, err = r.autoUnCompressSQL(backup)
+		backup, err = r.autoUnCompress(backup, ".sql")
 		if err != nil {
 			return err
 		}
 		clean = true
 	}
 
-	if _, err = shell.Execf(`mysql -u root '%s' < '%s'`, target, backup); err != nil {
-		return err
+	switch {
+	case len(point) > 0 && exist:
+		if err = r.restoreMySQLPoint(mysql, backup, target, point[0]); err != nil {
+			return err
+		}
+	case len(point) > 0:
+		if _, err = shell.Execf(`mysql -u root '%s' < '%s'`, target, backup); err != nil {
+			return err
+		}
+		if err = r.replayMySQLBinlog(mysql, backup, target, point[0]); err != nil {
+			return err
+		}
+	default:
+		if _, err = shell.Execf(`mysql -u root '%s' < '%s'`, target, backup); err != nil {
+			return err
+		}
 	}
 	if err = os.Unsetenv("MYSQL_PWD"); err != nil {
 		return err
@@ -444,6 +1457,173 @@ func (r *backupRepo) restoreMySQL(backup, target string) error {
 	return nil
 }
 
+// restoreMySQLPoint 时间点恢复到已存在的数据库
+// 在临时数据库 <target>_pitr 中恢复并重放 binlog，目标数据库不受影响
+// 检查临时数据库无误后通过 SwapMySQLPoint 替换目标数据库
+func (r *backupRepo) restoreMySQLPoint(mysql db.Operator, backup, target string, point biz.BackupPoint) error {
+	scratch := mysqlPointDatabase(target)
+	if exist, _ := mysql.DatabaseExists(scratch); exist {
+		return errors.New(r.t.Get("database %s already exists, swap or drop it first", scratch))
+	}
+	if err := mysql.DatabaseCreate(scratch); err != nil {
+		return err
+	}
+
+	// 临时数据库的写入不记录 binlog，避免被归档和重放
+	if _, err := shell.Execf(`mysql -u root --init-command='SET sql_log_bin=0' '%s' < '%s'`, scratch, backup); err != nil {
+		_ = mysql.DatabaseDrop(scratch)
+		return err
+	}
+	if err := r.replayMySQLBinlog(mysql, backup, scratch, point); err != nil {
+		_ = mysql.DatabaseDrop(scratch)
+		return err
+	}
+
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Restored to database %s, check it and then swap it into %s", scratch, target))
+	}
+
+	return nil
+}
+
+// SwapMySQLPoint 用时间点恢复的临时数据库替换目标数据库
+// 替换前备份目标数据库，可通过该备份回滚
+func (r *backupRepo) SwapMySQLPoint(target string) error {
+	rootPassword, err := r.setting.Get(biz.SettingKeyMySQLRootPassword)
+	if err != nil {
+		return err
+	}
+	mysql, err := db.NewMySQL("root", rootPassword, "/tmp/mysql.sock", "unix")
+	if err != nil {
+		return err
+	}
+	defer mysql.Close()
+
+	scratch := mysqlPointDatabase(target)
+	if exist, _ := mysql.DatabaseExists(scratch); !exist {
+		return errors.New(r.t.Get("database does not exist: %s", scratch))
+	}
+
+	path, err := r.GetPath(biz.BackupTypeMySQL)
+	if err != nil {
+		return err
+	}
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Backing up database %s before restore", target))
+	}
+	if err = r.createMySQL(path, target); err != nil {
+		return errors.New(r.t.Get("backup database before restore failed: %v", err))
+	}
+
+	// 重建目标数据库，删除时间点之后新建的表，数据库权限按名称授予，不受影响
+	if err = mysql.DatabaseDrop(target); err != nil {
+		return err
+	}
+	if err = mysql.DatabaseCreate(target); err != nil {
+		return err
+	}
+	if err = os.Setenv("MYSQL_PWD", rootPassword); err != nil {
+		return err
+	}
+	defer func() { _ = os.Unsetenv("MYSQL_PWD") }()
+	if _, err = shell.Execf(`set -o pipefail; mysqldump -u root --single-transaction --routines '%s' | mysql -u root '%s'`, scratch, target); err != nil {
+		return err
+	}
+
+	return mysql.DatabaseDrop(scratch)
+}
+
+// mysqlPointDatabase 时间点恢复使用的临时数据库名称
+func mysqlPointDatabase(target string) string {
+	return target + "_pitr"
+}
+
+// replayMySQLBinlog 从备份记录的 binlog 位置开始重放到指定时间点
+// 优先使用 MySQL 数据目录中的 binlog，已被清理的从归档目录中读取
+func (r *backupRepo) replayMySQLBinlog(mysql db.Operator, backup, target string, point biz.BackupPoint) error {
+	file, err := os.Open(backup)
+	if err != nil {
+		return err
+	}
+	info, err := db.ParseMySQLDump(file)
+	_ = file.Close()
+	if err != nil {
+		return err
+	}
+	if info.LogFile == "" || info.Database == "" {
+		return errors.New(r.t.Get("backup does not contain binlog position, only backups created with binary logging enabled support point-in-time recovery"))
+	}
+
+	logs := make(map[string]string)
+	archive, err := r.GetPath(biz.BackupTypeMySQLBinlog)
+	if err != nil {
+		return err
+	}
+	if entries, err := os.ReadDir(archive); err == nil {
+		for _, entry := range entries {
+			if !entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+				logs[entry.Name()] = filepath.Join(archive, entry.Name())
+			}
+		}
+	}
+	if basename, err := r.mysqlBinlogBasename(mysql); err == nil {
+		names, err := r.mysqlBinlogs(mysql)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			logs[name] = filepath.Join(filepath.Dir(basename), name)
+		}
+	}
+	if _, ok := logs[info.LogFile]; !ok {
+		return errors.New(r.t.Get("binlog %s required for recovery not found", info.LogFile))
+	}
+
+	// 按序号排序，序号超过 999999 后文件名长度会变化，不能按字符串比较
+	prefix, start, ok := r.mysqlBinlogSeq(info.LogFile)
+	if !ok {
+		return errors.New(r.t.Get("invalid binlog file name: %s", info.LogFile))
+	}
+	seqs := make(map[int]string)
+	for name, path := range logs {
+		if base, seq, ok := r.mysqlBinlogSeq(name); ok && base == prefix && seq >= start {
+			seqs[seq] = path
+		}
+	}
+	var files []string
+	for _, seq := range slices.Sorted(maps.Keys(seqs)) {
+		files = append(files, fmt.Sprintf("'%s'", seqs[seq]))
+	}
+
+	args := []string{fmt.Sprintf("--start-position=%d", info.LogPos), fmt.Sprintf("--database='%s'", target)}
+	if info.Database != target {
+		args = append(args, fmt.Sprintf("--rewrite-db='%s->%s'", info.Database, target))
+	}
+	switch {
+	case point.GTID != "":
+		arg, ok := db.MySQLBinlogStopGTID(point.GTID)
+		if !ok {
+			return errors.New(r.t.Get("invalid GTID: %s", point.GTID))
+		}
+		args = append(args, arg)
+	case !point.Time.IsZero():
+		args = append(args, fmt.Sprintf("--stop-datetime='%s'", point.Time.Local().Format(time.DateTime)))
+	}
+	// 事务已在本机执行过，MySQL 需要去掉 GTID 信息否则会被跳过
+	if version, _ := shell.Execf("mysqlbinlog --version"); !strings.Contains(version, "MariaDB") {
+		args = append(args, "--skip-gtids")
+	}
+
+	if app.IsCli {
+		fmt.Println(r.t.Get("|-Replaying binlog from %s:%d", info.LogFile, info.LogPos))
+	}
+	if _, err = shell.Execf(`mysqlbinlog %s %s | mysql -u root`, strings.Join(args, " "), strings.Join(files, " ")); err != nil {
+		return errors.New(r.t.Get("Replay binlog failed: %v", err))
+	}
+
+	return nil
+}
+
 // restorePostgres 恢复 PostgreSQL 备份
 func (r *backupRepo) restorePostgres(backup, target string) error {
 	if !io.Exists(backup) {
@@ -461,7 +1641,7 @@ func (r *backupRepo) restorePostgres(backup, target string) error {
 
 	clean := false
 	if !strings.HasSuffix(backup, ".sql") {
-		backup, err = r.autoUnCompressSQL(backup)
+		backup, err = r.autoUnCompress(backup, ".sql")
 		if err != nil {
 			return err
 		}
@@ -478,6 +1658,150 @@ func (r *backupRepo) restorePostgres(backup, target string) error {
 	return nil
 }
 
+// restoreRedis 恢复 Redis 备份
+// 停止 Redis 后替换 RDB 文件再启动，开启 AOF 时先关闭 AOF 启动以加载 RDB，再重新开启 AOF 重写
+func (r *backupRepo) restoreRedis(backup, target string) (err error) {
+	if !io.Exists(backup) {
+		return errors.New(r.t.Get("backup file %s not exists", backup))
+	}
+
+	redis, err := r.localRedis(target)
+	if err != nil {
+		return err
+	}
+	dir, err := redis.Config("dir")
+	if err != nil {
+		redis.Close()
+		return err
+	}
+	filename, err := redis.Config("dbfilename")
+	if err != nil {
+		redis.Close()
+		return err
+	}
+	appendonly, err := redis.Config("appendonly")
+	redis.Close()
+	if err != nil {
+		return err
+	}
+
+	clean := false
+	if !strings.HasSuffix(backup, ".rdb") {
+		if backup, err = r.autoUnCompress(backup, ".rdb"); err != nil {
+			return err
+		}
+		clean = true
+	}
+
+	config := filepath.Join(app.Root, "server/redis/redis.conf")
+	rdb := filepath.Join(dir, filename)
+	original := ""
+	stopped, backedUp := false, false
+	// 失败时恢复原 RDB 文件和配置并重新启动 Redis
+	defer func() {
+		if err == nil {
+			return
+		}
+		if original != "" {
+			_ = io.Write(config, original, 0644)
+		}
+		if !stopped {
+			return
+		}
+		_ = systemctl.Stop("redis")
+		if backedUp {
+			_ = os.Rename(rdb+".bak", rdb)
+		} else {
+			_ = os.Remove(rdb)
+		}
+		if startErr := systemctl.Start("redis"); startErr != nil {
+			err = errors.Join(err, errors.New(r.t.Get("rollback failed, unable to start Redis: %v", startErr)))
+		}
+	}()
+
+	if appendonly == "yes" {
+		if original, err = io.Read(config); err != nil {
+			return err
+		}
+		if err = io.Write(config, regexp.MustCompile(`(?m)^appendonly\s+yes`).ReplaceAllString(original, "appendonly no"), 0644); err != nil {
+			return err
+		}
+	}
+
+	if err = systemctl.Stop("redis"); err != nil {
+		return err
+	}
+	stopped = true
+	if io.Exists(rdb) {
+		if err = os.Rename(rdb, rdb+".bak"); err != nil {
+			return err
+		}
+		backedUp = true
+	}
+	if err = r.archiveFile(backup, rdb); err != nil {
+		return err
+	}
+	if backedUp {
+		_, _ = shell.Execf("chown --reference='%s' '%s'", rdb+".bak", rdb)
+	}
+	if err = systemctl.Start("redis"); err != nil {
+		return err
+	}
+	// 确认 Redis 能正常加载恢复的数据
+	if redis, err = r.waitRedis(target, time.Minute); err != nil {
+		return err
+	}
+	defer redis.Close()
+	if clean {
+		_ = io.Remove(filepath.Dir(backup))
+	}
+
+	if appendonly == "yes" {
+		if err = io.Write(config, original, 0644); err != nil {
+			return err
+		}
+		if _, err = redis.Exec("CONFIG", "SET", "appendonly", "yes"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// localRedis 连接本机 Redis 数据库服务器
+func (r *backupRepo) localRedis(name string) (*db.Redis, error) {
+	server, err := r.databaseServer.GetByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if server.Type != biz.DatabaseTypeRedis {
+		return nil, errors.New(r.t.Get("database server %s is not a Redis server", name))
+	}
+	if !slices.Contains([]string{"127.0.0.1", "localhost", "::1"}, server.Host) {
+		return nil, errors.New(r.t.Get("only local Redis server supports backup"))
+	}
+
+	return db.NewRedis(server.Username, server.Password, fmt.Sprintf("%s:%d", server.Host, server.Port))
+}
+
+// waitRedis 等待 Redis 启动并加载完数据
+func (r *backupRepo) waitRedis(name string, timeout time.Duration) (*db.Redis, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		redis, err := r.localRedis(name)
+		if err == nil {
+			if _, err = redis.Exec("PING"); err == nil {
+				return redis, nil
+			}
+			redis.Close()
+		}
+		if time.Now().After(deadline) {
+			return nil, err
+		}
+		time.Sleep(time.Second)
+	}
+}
+
 // preCheckPath 预检空间和 inode 是否足够
 // to 备份保存目录
 // path 待备份目录
@@ -536,8 +1860,9 @@ func (r *backupRepo) preCheckDB(to string, size int64) error {
 	return nil
 }
 
-// autoUnCompressSQL 自动处理压缩文件
-func (r *backupRepo) autoUnCompressSQL(backup string) (string, error) {
+// autoUnCompress 自动处理压缩文件
+// ext 压缩包内备份文件的扩展名
+func (r *backupRepo) autoUnCompress(backup, ext string) (string, error) {
 	temp, err := os.MkdirTemp("", "sql-uncompress")
 	if err != nil {
 		return "", err
@@ -552,13 +1877,13 @@ func (r *backupRepo) autoUnCompressSQL(backup string) (string, error) {
 		if len(files) != 1 {
 			return "", errors.New(r.t.Get("The number of files contained in the compressed file is not 1, actual %d", len(files)))
 		}
-		if strings.HasSuffix(files[0].Name(), ".sql") {
+		if strings.HasSuffix(files[0].Name(), ext) {
 			backup = filepath.Join(temp, files[0].Name())
 		}
 	}
 
 	if backup == "" {
-		return "", errors.New(r.t.Get("could not find .sql backup file"))
+		return "", errors.New(r.t.Get("could not find %s backup file", ext))
 	}
 
 	return backup, nil
@@ -602,7 +1927,7 @@ func (r *backupRepo) FixPanel() error {
 	}
 
 	// 从备份目录中找最新的备份文件
-	list, err := r.List(biz.BackupTypePanel)
+	list, err := r.List(biz.BackupTypePanel, 0)
 	if err != nil {
 		return err
 	}
@@ -624,7 +1949,18 @@ func (r *backupRepo) FixPanel() error {
 	if err = io.Remove("/tmp/panel-fix"); err != nil {
 		return errors.New(r.t.Get("Cleaning temporary directory failed: %v", err))
 	}
-	if err = io.UnCompress(latest.Path, "/tmp/panel-fix"); err != nil {
+	backup := latest.Path
+	if latest.Encrypted {
+		temp, err := os.MkdirTemp("", "panel-backup-decrypt")
+		if err != nil {
+			return err
+		}
+		defer func(temp string) { _ = io.Remove(temp) }(temp)
+		if backup, err = r.decrypt(latest.Path, temp); err != nil {
+			return err
+		}
+	}
+	if err = io.UnCompress(backup, "/tmp/panel-fix"); err != nil {
 		return errors.New(r.t.Get("Unzip backup file failed: %v", err))
 	}
 
@@ -747,7 +2083,7 @@ func (r *backupRepo) UpdatePanel(version, url, checksum string) error {
 		fmt.Println(r.t.Get("|-Backup panel data..."))
 	}
 	// 备份面板
-	if err := r.Create(biz.BackupTypePanel, ""); err != nil {
+	if err := r.Create(biz.BackupTypePanel, "", 0); err != nil {
 		return errors.New(r.t.Get("|-Backup panel data failed: %v", err))
 	}
 	if err := io.Compress(filepath.Join(app.Root, "panel/storage"), nil, "/tmp/panel-storage.zip"); err != nil {
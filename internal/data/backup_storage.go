@@ -0,0 +1,103 @@
+package data
+
+import (
+	"errors"
+
+	"github.com/leonelquinteros/gotext"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/internal/http/request"
+	"github.com/acepanel/panel/pkg/storage"
+)
+
+type backupStorageRepo struct {
+	t  *gotext.Locale
+	db *gorm.DB
+}
+
+func NewBackupStorageRepo(t *gotext.Locale, db *gorm.DB) biz.BackupStorageRepo {
+	return &backupStorageRepo{
+		t:  t,
+		db: db,
+	}
+}
+
+func (r *backupStorageRepo) List(page, limit uint) ([]*biz.BackupStorage, int64, error) {
+	list := make([]*biz.BackupStorage, 0)
+	var total int64
+	err := r.db.Model(&biz.BackupStorage{}).Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&list).Error
+	return list, total, err
+}
+
+func (r *backupStorageRepo) Get(id uint) (*biz.BackupStorage, error) {
+	backupStorage := new(biz.BackupStorage)
+	if err := r.db.Where("id = ?", id).First(backupStorage).Error; err != nil {
+		return nil, err
+	}
+
+	return backupStorage, nil
+}
+
+func (r *backupStorageRepo) Create(req *request.BackupStorageCreate) error {
+	if err := r.check(req.Type, req.Info); err != nil {
+		return err
+	}
+
+	backupStorage := &biz.BackupStorage{
+		Name:   req.Name,
+		Type:   req.Type,
+		Info:   req.Info,
+		Remark: req.Remark,
+	}
+
+	return r.db.Create(backupStorage).Error
+}
+
+func (r *backupStorageRepo) Update(req *request.BackupStorageUpdate) error {
+	backupStorage, err := r.Get(req.ID)
+	if err != nil {
+		return err
+	}
+	if err = r.check(req.Type, req.Info); err != nil {
+		return err
+	}
+
+	backupStorage.Name = req.Name
+	backupStorage.Type = req.Type
+	backupStorage.Info = req.Info
+	backupStorage.Remark = req.Remark
+
+	return r.db.Save(backupStorage).Error
+}
+
+func (r *backupStorageRepo) Delete(id uint) error {
+	return r.db.Delete(&biz.BackupStorage{}, id).Error
+}
+
+func (r *backupStorageRepo) Open(id uint) (storage.Storage, error) {
+	backupStorage, err := r.Get(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New(r.t.Get("backup storage %d not exists", id))
+		}
+		return nil, err
+	}
+
+	return storage.New(backupStorage.Type, backupStorage.Info)
+}
+
+// check 检查存储是否可用
+func (r *backupStorageRepo) check(typ storage.Type, info storage.Config) error {
+	s, err := storage.New(typ, info)
+	if err != nil {
+		return errors.New(r.t.Get("failed to connect to backup storage: %v", err))
+	}
+	defer func(s storage.Storage) { _ = storage.Close(s) }(s)
+
+	if _, err = s.List(""); err != nil {
+		return errors.New(r.t.Get("failed to connect to backup storage: %v", err))
+	}
+
+	return nil
+}
@@ -22,22 +22,24 @@ import (
 	pkgcert "github.com/acepanel/panel/pkg/cert"
 	"github.com/acepanel/panel/pkg/io"
 	"github.com/acepanel/panel/pkg/shell"
-	"github.com/acepanel/panel/pkg/systemctl"
 	"github.com/acepanel/panel/pkg/types"
+	"github.com/acepanel/panel/pkg/webserver"
 )
 
 type certRepo struct {
-	t      *gotext.Locale
-	db     *gorm.DB
-	log    *slog.Logger
-	client *acme.Client
+	t       *gotext.Locale
+	db      *gorm.DB
+	log     *slog.Logger
+	setting biz.SettingRepo
+	client  *acme.Client
 }
 
-func NewCertRepo(t *gotext.Locale, db *gorm.DB, log *slog.Logger) biz.CertRepo {
+func NewCertRepo(t *gotext.Locale, db *gorm.DB, log *slog.Logger, setting biz.SettingRepo) biz.CertRepo {
 	return &certRepo{
-		t:   t,
-		db:  db,
-		log: log,
+		t:       t,
+		db:      db,
+		log:     log,
+		setting: setting,
 	}
 }
 
@@ -410,12 +412,12 @@ func (r *certRepo) Deploy(ID, WebsiteID uint) error {
 	if err = io.Write(fmt.Sprintf("%s/server/vhost/cert/%s.key", app.Root, website.Name), cert.Key, 0644); err != nil {
 		return err
 	}
-	if err = systemctl.Reload("nginx"); err != nil {
-		_, err = shell.Execf("nginx -t")
+	webServer, err := r.setting.Get(biz.SettingKeyWebserver, "unknown")
+	if err != nil {
 		return err
 	}
 
-	return nil
+	return webserver.Reload(webserver.Type(webServer))
 }
 
 func (r *certRepo) runScript(cert *biz.Cert) error {
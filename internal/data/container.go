@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"slices"
@@ -343,3 +344,55 @@ func (r *containerRepo) Prune() error {
 	_, err = apiClient.ContainerPrune(context.Background(), client.ContainerPruneOptions{})
 	return err
 }
+
+// Usage 运行中容器的资源占用
+func (r *containerRepo) Usage() ([]types.ContainerUsage, error) {
+	apiClient, err := getDockerClient("/var/run/docker.sock")
+	if err != nil {
+		return nil, err
+	}
+	defer func(apiClient *client.Client) { _ = apiClient.Close() }(apiClient)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := apiClient.ContainerList(ctx, client.ContainerListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make([]types.ContainerUsage, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		stats, err := apiClient.ContainerStats(ctx, item.ID, client.ContainerStatsOptions{})
+		if err != nil {
+			continue
+		}
+		var data container.StatsResponse
+		err = json.NewDecoder(stats.Body).Decode(&data)
+		_ = stats.Body.Close()
+		if err != nil {
+			continue
+		}
+
+		// 与 docker stats 一致，内存占用不含非活动页缓存
+		memory := data.MemoryStats.Usage
+		for _, key := range []string{"inactive_file", "total_inactive_file"} {
+			if inactive, ok := data.MemoryStats.Stats[key]; ok && inactive < memory {
+				memory -= inactive
+				break
+			}
+		}
+
+		if len(item.Names) == 0 {
+			item.Names = append(item.Names, "")
+		}
+		usages = append(usages, types.ContainerUsage{
+			ID:     item.ID,
+			Name:   strings.TrimPrefix(item.Names[0], "/"),
+			CPU:    data.CPUStats.CPUUsage.TotalUsage,
+			Memory: memory,
+		})
+	}
+
+	return usages, nil
+}
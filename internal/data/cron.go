@@ -39,10 +39,10 @@ func (r *cronRepo) Count() (int64, error) {
 	return count, nil
 }
 
-func (r *cronRepo) List(page, limit uint) ([]*biz.Cron, int64, error) {
+func (r *cronRepo) List(owner, page, limit uint) ([]*biz.Cron, int64, error) {
 	cron := make([]*biz.Cron, 0)
 	var total int64
-	err := r.db.Model(&biz.Cron{}).Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&cron).Error
+	err := r.db.Model(&biz.Cron{}).Scopes(ownedBy(owner)).Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&cron).Error
 	return cron, total, err
 }
 
@@ -62,17 +62,25 @@ func (r *cronRepo) Create(req *request.CronCreate) error {
 			script = fmt.Sprintf(`#!/bin/bash
 export PATH=/bin:/sbin:/usr/bin:/usr/sbin:/usr/local/bin:/usr/local/sbin:$PATH
 
-panel-cli backup website -n '%s' -p '%s'
-panel-cli backup clear -t website -f '%s' -s '%d' -p '%s'
-`, req.Target, req.BackupPath, req.Target, req.Save, req.BackupPath)
+panel-cli backup website -n '%s' -p '%s' --storage %d
+panel-cli backup clear -t website -f '%s' -s '%d' -p '%s' --storage %d
+`, req.Target, req.BackupPath, req.BackupStorage, req.Target, req.Save, req.BackupPath, req.BackupStorage)
+		}
+		if req.BackupType == "website_snapshot" {
+			script = fmt.Sprintf(`#!/bin/bash
+export PATH=/bin:/sbin:/usr/bin:/usr/sbin:/usr/local/bin:/usr/local/sbin:$PATH
+
+panel-cli backup snapshot -n '%s' -p '%s'
+panel-cli backup snapshot-clear -n '%s' --daily %d --weekly %d --monthly %d -p '%s'
+`, req.Target, req.BackupPath, req.Target, req.KeepDaily, req.KeepWeekly, req.KeepMonthly, req.BackupPath)
 		}
-		if req.BackupType == "mysql" || req.BackupType == "postgres" {
+		if req.BackupType == "mysql" || req.BackupType == "postgres" || req.BackupType == "redis" {
 			script = fmt.Sprintf(`#!/bin/bash
 export PATH=/bin:/sbin:/usr/bin:/usr/sbin:/usr/local/bin:/usr/local/sbin:$PATH
 
-panel-cli backup database -t '%s' -n '%s' -p '%s'
-panel-cli backup clear -t '%s' -f '%s' -s '%d' -p '%s'
-`, req.BackupType, req.Target, req.BackupPath, req.BackupType, req.Target, req.Save, req.BackupPath)
+panel-cli backup database -t '%s' -n '%s' -p '%s' --storage %d
+panel-cli backup clear -t '%s' -f '%s' -s '%d' -p '%s' --storage %d
+`, req.BackupType, req.Target, req.BackupPath, req.BackupStorage, req.BackupType, req.Target, req.Save, req.BackupPath, req.BackupStorage)
 		}
 	}
 	if req.Type == "cutoff" {
@@ -103,6 +111,7 @@ panel-cli cutoff clear -t website -f '%s' -s '%d' -p '%s'
 	cron.Time = req.Time
 	cron.Shell = shellDir + shellFile + ".sh"
 	cron.Log = shellLogDir + shellFile + ".log"
+	cron.UserID = req.UserID
 
 	if err := r.db.Create(cron).Error; err != nil {
 		return err
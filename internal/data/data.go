@@ -4,8 +4,11 @@ import "github.com/google/wire"
 
 // ProviderSet is data providers.
 var ProviderSet = wire.NewSet(
+	NewAlertRepo,
 	NewAppRepo,
+	NewAuditRepo,
 	NewBackupRepo,
+	NewBackupStorageRepo,
 	NewCacheRepo,
 	NewCertRepo,
 	NewCertAccountRepo,
@@ -20,12 +23,18 @@ var ProviderSet = wire.NewSet(
 	NewDatabaseServerRepo,
 	NewDatabaseUserRepo,
 	NewEnvironmentRepo,
+	NewLoginLockRepo,
 	NewMonitorRepo,
+	NewNotifyRepo,
+	NewOIDCRepo,
+	NewRoleRepo,
 	NewSafeRepo,
 	NewSettingRepo,
 	NewSSHRepo,
 	NewTaskRepo,
 	NewUserRepo,
+	NewUserPasskeyRepo,
+	NewUserSessionRepo,
 	NewUserTokenRepo,
 	NewWebHookRepo,
 	NewWebsiteRepo,
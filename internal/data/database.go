@@ -29,11 +29,18 @@ func NewDatabaseRepo(t *gotext.Locale, db *gorm.DB, server biz.DatabaseServerRep
 	}
 }
 
-func (r *databaseRepo) List(page, limit uint) ([]*biz.Database, int64, error) {
+func (r *databaseRepo) List(owner, page, limit uint) ([]*biz.Database, int64, error) {
 	var databaseServer []*biz.DatabaseServer
 	if err := r.db.Model(&biz.DatabaseServer{}).Order("id desc").Find(&databaseServer).Error; err != nil {
 		return nil, 0, err
 	}
+	var owned map[uint][]string
+	if owner != 0 {
+		var err error
+		if owned, err = r.owned(owner); err != nil {
+			return nil, 0, err
+		}
+	}
 
 	database := make([]*biz.Database, 0)
 	for _, server := range databaseServer {
@@ -44,6 +51,9 @@ func (r *databaseRepo) List(page, limit uint) ([]*biz.Database, int64, error) {
 
 		if databases, err := operator.Databases(); err == nil {
 			for item := range slices.Values(databases) {
+				if owner != 0 && !slices.Contains(owned[server.ID], item.Name) {
+					continue
+				}
 				database = append(database, &biz.Database{
 					Type:     server.Type,
 					Name:     item.Name,
@@ -85,6 +95,7 @@ func (r *databaseRepo) Create(req *request.DatabaseCreate) error {
 				Username: req.Username,
 				Password: req.Password,
 				Host:     req.Host,
+				UserID:   req.UserID,
 			}); err != nil {
 				return err
 			}
@@ -104,6 +115,7 @@ func (r *databaseRepo) Create(req *request.DatabaseCreate) error {
 				Username: req.Username,
 				Password: req.Password,
 				Host:     req.Host,
+				UserID:   req.UserID,
 			}); err != nil {
 				return err
 			}
@@ -161,6 +173,44 @@ func (r *databaseRepo) Comment(req *request.DatabaseComment) error {
 	return nil
 }
 
+func (r *databaseRepo) Owns(owner, serverID uint, name string) (bool, error) {
+	if owner == 0 {
+		return true, nil
+	}
+
+	owned, err := r.owned(owner)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(owned[serverID], name), nil
+}
+
+// owned 用户拥有的数据库，即用户的数据库用户有权限的数据库，按服务器 ID 分组
+func (r *databaseRepo) owned(owner uint) (map[uint][]string, error) {
+	var users []*biz.DatabaseUser
+	if err := r.db.Scopes(ownedBy(owner)).Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	owned := make(map[uint][]string)
+	for _, user := range users {
+		server, err := r.server.Get(user.ServerID)
+		if err != nil {
+			continue
+		}
+		operator, err := r.getOperator(server)
+		if err != nil {
+			continue
+		}
+		privileges, _ := operator.UserPrivileges(user.Username, user.Host)
+		operator.Close()
+		owned[user.ServerID] = append(owned[user.ServerID], privileges...)
+	}
+
+	return owned, nil
+}
+
 func (r *databaseRepo) getOperator(server *biz.DatabaseServer) (db.Operator, error) {
 	switch server.Type {
 	case biz.DatabaseTypeMysql:
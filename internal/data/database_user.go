@@ -35,10 +35,10 @@ func (r *databaseUserRepo) Count() (int64, error) {
 	return count, nil
 }
 
-func (r *databaseUserRepo) List(page, limit uint) ([]*biz.DatabaseUser, int64, error) {
+func (r *databaseUserRepo) List(owner, page, limit uint) ([]*biz.DatabaseUser, int64, error) {
 	user := make([]*biz.DatabaseUser, 0)
 	var total int64
-	err := r.db.Model(&biz.DatabaseUser{}).Preload("Server").Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&user).Error
+	err := r.db.Model(&biz.DatabaseUser{}).Scopes(ownedBy(owner)).Preload("Server").Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&user).Error
 
 	for u := range slices.Values(user) {
 		r.fillUser(u)
@@ -91,6 +91,7 @@ func (r *databaseUserRepo) Create(req *request.DatabaseUserCreate) error {
 		Host:     req.Host,
 		Password: req.Password,
 		Remark:   req.Remark,
+		UserID:   req.UserID,
 	}
 
 	if err = r.db.FirstOrInit(user, user).Error; err != nil {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/moby/moby/client"
+	"gorm.io/gorm"
 )
 
 func getDockerClient(sock string) (*client.Client, error) {
@@ -14,3 +15,13 @@ func getDockerClient(sock string) (*client.Client, error) {
 
 	return apiClient, nil
 }
+
+// ownedBy 按所属用户过滤，owner 为 0 时不过滤（管理员）
+func ownedBy(owner uint) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if owner == 0 {
+			return db
+		}
+		return db.Where("user_id = ?", owner)
+	}
+}
@@ -0,0 +1,206 @@
+package data
+
+import (
+	"errors"
+	"math"
+	"net"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/pkg/firewall"
+)
+
+const (
+	loginMaxDelay    = 30 * time.Second // 失败后等待时间的上限
+	loginBanLockouts = 3                // IP 被锁定多少次后自动封禁
+	loginForgetAfter = 24 * time.Hour   // 超过该时间未再失败则重置计数
+)
+
+type loginLockRepo struct {
+	t       *gotext.Locale
+	db      *gorm.DB
+	setting biz.SettingRepo
+}
+
+func NewLoginLockRepo(t *gotext.Locale, db *gorm.DB, setting biz.SettingRepo) biz.LoginLockRepo {
+	return &loginLockRepo{
+		t:       t,
+		db:      db,
+		setting: setting,
+	}
+}
+
+func (r *loginLockRepo) List(page, limit uint) ([]*biz.LoginLock, int64, error) {
+	// 清理长时间未失败且未封禁的记录
+	if err := r.db.Where("banned = ? AND locked_until < ? AND last_failed_at < ?", false, time.Now(), time.Now().Add(-loginForgetAfter)).Delete(&biz.LoginLock{}).Error; err != nil {
+		return nil, 0, err
+	}
+
+	locks := make([]*biz.LoginLock, 0)
+	var total int64
+	err := r.db.Model(&biz.LoginLock{}).Order("last_failed_at desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&locks).Error
+	for _, lock := range locks {
+		lock.Locked = lock.LockedUntil.After(time.Now())
+	}
+
+	return locks, total, err
+}
+
+func (r *loginLockRepo) Check(username, ip string) error {
+	locks := make([]*biz.LoginLock, 0)
+	if err := r.keys(r.db, username, ip).Find(&locks).Error; err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, lock := range locks {
+		if lock.LockedUntil.After(now) {
+			return errors.New(r.t.Get("too many failed login attempts, please try again in %d minutes", int(math.Ceil(lock.LockedUntil.Sub(now).Minutes()))))
+		}
+		if lock.RetryAt.After(now) {
+			return errors.New(r.t.Get("login failed too frequently, please try again in %d seconds", int(math.Ceil(lock.RetryAt.Sub(now).Seconds()))))
+		}
+	}
+
+	return nil
+}
+
+func (r *loginLockRepo) Fail(username, ip string) error {
+	maxFailures, err := r.setting.GetInt(biz.SettingKeyLoginMaxFailures, 5)
+	if err != nil {
+		return err
+	}
+	if maxFailures <= 0 {
+		return nil
+	}
+	lockMinutes, err := r.setting.GetInt(biz.SettingKeyLoginLockMinutes, 15)
+	if err != nil {
+		return err
+	}
+	if lockMinutes <= 0 {
+		lockMinutes = 15
+	}
+
+	now := time.Now()
+	targets := map[biz.LoginLockType]string{biz.LoginLockTypeUsername: username, biz.LoginLockTypeIP: ip}
+	for typ, key := range targets {
+		if key == "" {
+			continue
+		}
+
+		lock := &biz.LoginLock{Type: typ, Key: key}
+		if err = r.db.Where("type = ? AND key = ?", typ, key).FirstOrInit(lock).Error; err != nil {
+			return err
+		}
+		if now.Sub(lock.LastFailedAt) > loginForgetAfter {
+			lock.Failures = 0
+			lock.Lockouts = 0
+		}
+
+		lock.Failures++
+		lock.LastFailedAt = now
+		if lock.Failures >= uint(maxFailures) {
+			lock.Failures = 0
+			lock.Lockouts++
+			lock.LockedUntil = now.Add(time.Duration(lockMinutes) * time.Minute)
+		} else {
+			// 等待时间随失败次数翻倍：1s、2s、4s……
+			lock.RetryAt = now.Add(min(time.Second<<min(lock.Failures-1, 5), loginMaxDelay))
+		}
+
+		if typ == biz.LoginLockTypeIP && lock.Lockouts >= loginBanLockouts && !lock.Banned {
+			if lock.Banned, err = r.ban(key, firewall.OperationAdd); err != nil {
+				return err
+			}
+		}
+		if err = r.db.Save(lock).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *loginLockRepo) Succeed(username, ip string) error {
+	return r.keys(r.db.Model(&biz.LoginLock{}), username, ip).Updates(map[string]any{
+		"failures": 0,
+		"retry_at": time.Time{},
+	}).Error
+}
+
+func (r *loginLockRepo) Delete(id uint) error {
+	lock := new(biz.LoginLock)
+	if err := r.db.First(lock, id).Error; err != nil {
+		return err
+	}
+
+	return r.delete(lock)
+}
+
+func (r *loginLockRepo) Clear() error {
+	locks := make([]*biz.LoginLock, 0)
+	if err := r.db.Find(&locks).Error; err != nil {
+		return err
+	}
+
+	for _, lock := range locks {
+		if err := r.delete(lock); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *loginLockRepo) delete(lock *biz.LoginLock) error {
+	if lock.Banned {
+		if _, err := r.ban(lock.Key, firewall.OperationRemove); err != nil {
+			return err
+		}
+	}
+
+	return r.db.Delete(lock).Error
+}
+
+// ban 添加或移除 IP 封禁规则，未开启自动封禁或防火墙未运行时跳过
+func (r *loginLockRepo) ban(ip string, operation firewall.Operation) (bool, error) {
+	if operation == firewall.OperationAdd {
+		autoBan, err := r.setting.GetBool(biz.SettingKeyLoginAutoBan)
+		if err != nil || !autoBan {
+			return false, err
+		}
+	}
+
+	// 不封禁本机地址，避免反向代理未正确配置 IP 头时封禁代理自身
+	parsed := net.ParseIP(ip)
+	if parsed == nil || parsed.IsLoopback() || parsed.IsUnspecified() {
+		return false, nil
+	}
+	fw := firewall.NewFirewall()
+	if running, _ := fw.Status(); !running {
+		return false, nil
+	}
+
+	family := "ipv4"
+	if parsed.To4() == nil {
+		family = "ipv6"
+	}
+	if err := fw.RichRules(firewall.FireInfo{
+		Family:    family,
+		Address:   ip,
+		Strategy:  firewall.StrategyDrop,
+		Direction: firewall.DirectionIn,
+	}, operation); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// keys 按用户名和 IP 筛选记录
+func (r *loginLockRepo) keys(query *gorm.DB, username, ip string) *gorm.DB {
+	return query.Where(r.db.Where("type = ? AND key = ?", biz.LoginLockTypeUsername, username).Or("type = ? AND key = ?", biz.LoginLockTypeIP, ip))
+}
@@ -1,15 +1,27 @@
 package data
 
 import (
+	"errors"
 	"time"
 
+	"github.com/shirou/gopsutil/disk"
 	"github.com/spf13/cast"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/acepanel/panel/internal/biz"
 	"github.com/acepanel/panel/internal/http/request"
 )
 
+// 不超过该时长的范围使用原始数据或 5 分钟数据
+const (
+	monitorRawSpan        = 24 * time.Hour
+	monitorFiveMinuteSpan = 14 * 24 * time.Hour
+)
+
+// 查询进程记录时最多向前后查找的时长
+const monitorProcessSpan = 5 * time.Minute
+
 type monitorRepo struct {
 	db      *gorm.DB
 	setting biz.SettingRepo
@@ -31,10 +43,25 @@ func (r monitorRepo) GetSetting() (*request.MonitorSetting, error) {
 	if err != nil {
 		return nil, err
 	}
+	fiveMinuteDays, err := r.setting.GetInt(biz.SettingKeyMonitorFiveMinDays, 90)
+	if err != nil {
+		return nil, err
+	}
+	hourlyDays, err := r.setting.GetInt(biz.SettingKeyMonitorHourlyDays, 365)
+	if err != nil {
+		return nil, err
+	}
+	disks, err := r.setting.GetSlice(biz.SettingKeyMonitorDisks)
+	if err != nil {
+		return nil, err
+	}
 
 	setting := new(request.MonitorSetting)
 	setting.Enabled = cast.ToBool(monitor)
 	setting.Days = cast.ToUint(monitorDays)
+	setting.FiveMinuteDays = uint(fiveMinuteDays)
+	setting.HourlyDays = uint(hourlyDays)
+	setting.Disks = disks
 
 	return setting, nil
 }
@@ -46,19 +73,455 @@ func (r monitorRepo) UpdateSetting(setting *request.MonitorSetting) error {
 	if err := r.setting.Set(biz.SettingKeyMonitorDays, cast.ToString(setting.Days)); err != nil {
 		return err
 	}
+	if err := r.setting.Set(biz.SettingKeyMonitorFiveMinDays, cast.ToString(setting.FiveMinuteDays)); err != nil {
+		return err
+	}
+	if err := r.setting.Set(biz.SettingKeyMonitorHourlyDays, cast.ToString(setting.HourlyDays)); err != nil {
+		return err
+	}
+	if err := r.setting.SetSlice(biz.SettingKeyMonitorDisks, setting.Disks); err != nil {
+		return err
+	}
 
 	return nil
 }
 
 func (r monitorRepo) Clear() error {
-	return r.db.Where("1 = 1").Delete(&biz.Monitor{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&biz.Monitor{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("1 = 1").Delete(&biz.MonitorProcess{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("1 = 1").Delete(&biz.MonitorAggregate{}).Error
+	})
 }
 
-func (r monitorRepo) List(start, end time.Time) ([]*biz.Monitor, error) {
+func (r monitorRepo) List(start, end time.Time) ([]*biz.MonitorAggregate, error) {
+	setting, err := r.GetSetting()
+	if err != nil {
+		return nil, err
+	}
+
+	// 范围越大精度越低，起始时间超出保留期时使用更低的精度
+	span := end.Sub(start)
+	switch {
+	case span <= monitorRawSpan && r.retained(start, setting.Days):
+		return r.listRaw(start, end)
+	case span <= monitorFiveMinuteSpan && r.retained(start, setting.FiveMinuteDays):
+		return r.listAggregate(biz.MonitorResolutionFiveMinute, start, end)
+	default:
+		return r.listAggregate(biz.MonitorResolutionHour, start, end)
+	}
+}
+
+func (r monitorRepo) Rollup(now time.Time) error {
+	if err := r.rollupRaw(now); err != nil {
+		return err
+	}
+	if err := r.rollupFiveMinute(now); err != nil {
+		return err
+	}
+
+	setting, err := r.GetSetting()
+	if err != nil {
+		return err
+	}
+	if setting.Days > 0 {
+		if err = r.db.Where("created_at < ?", now.AddDate(0, 0, -int(setting.Days))).Delete(&biz.Monitor{}).Error; err != nil {
+			return err
+		}
+		if err = r.db.Where("time < ?", now.AddDate(0, 0, -int(setting.Days))).Delete(&biz.MonitorProcess{}).Error; err != nil {
+			return err
+		}
+	}
+	for resolution, days := range map[uint]uint{
+		biz.MonitorResolutionFiveMinute: setting.FiveMinuteDays,
+		biz.MonitorResolutionHour:       setting.HourlyDays,
+	} {
+		if days == 0 {
+			continue
+		}
+		if err = r.db.Where("resolution = ? AND time < ?", resolution, now.AddDate(0, 0, -int(days))).Delete(&biz.MonitorAggregate{}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r monitorRepo) Processes(at time.Time, typ biz.MonitorProcessType, sort string) ([]*biz.MonitorProcess, error) {
+	// 分别取指定时间前后最近的一次采样
+	var sample *biz.MonitorProcess
+	before, after := new(biz.MonitorProcess), new(biz.MonitorProcess)
+	if err := r.db.Where("time BETWEEN ? AND ?", at.Add(-monitorProcessSpan), at).Order("time desc").First(before).Error; err == nil {
+		sample = before
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if err := r.db.Where("time > ? AND time <= ?", at, at.Add(monitorProcessSpan)).Order("time").First(after).Error; err == nil {
+		if sample == nil || after.Time.Sub(at) < at.Sub(sample.Time) {
+			sample = after
+		}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	processes := make([]*biz.MonitorProcess, 0)
+	if sample == nil {
+		return processes, nil
+	}
+
+	query := r.db.Where("time = ?", sample.Time)
+	if typ != "" {
+		query = query.Where("type = ?", typ)
+	}
+	if sort == "memory" {
+		query = query.Order("memory desc")
+	} else {
+		query = query.Order("cpu desc")
+	}
+
+	err := query.Find(&processes).Error
+	return processes, err
+}
+
+// retained 起始时间是否在保留期内
+func (r monitorRepo) retained(start time.Time, days uint) bool {
+	return days == 0 || start.After(time.Now().AddDate(0, 0, -int(days)))
+}
+
+// listRaw 原始数据，第一条仅用于计算流量速率
+func (r monitorRepo) listRaw(start, end time.Time) ([]*biz.MonitorAggregate, error) {
 	monitors := make([]*biz.Monitor, 0)
-	if err := r.db.Where("created_at BETWEEN ? AND ?", start, end).Find(&monitors).Error; err != nil {
+	if err := r.db.Where("created_at BETWEEN ? AND ?", start, end).Order("created_at").Find(&monitors).Error; err != nil {
 		return nil, err
 	}
 
-	return monitors, nil
+	points := make([]*biz.MonitorAggregate, 0, len(monitors))
+	for i := 1; i < len(monitors); i++ {
+		points = append(points, &biz.MonitorAggregate{
+			Resolution: biz.MonitorResolutionRaw,
+			Time:       monitors[i].CreatedAt,
+			Samples:    1,
+			Stats:      monitorSample(monitors[i], monitors[i-1]),
+		})
+	}
+
+	return points, nil
+}
+
+func (r monitorRepo) listAggregate(resolution uint, start, end time.Time) ([]*biz.MonitorAggregate, error) {
+	points := make([]*biz.MonitorAggregate, 0)
+	err := r.db.Where("resolution = ? AND time BETWEEN ? AND ?", resolution, start, end).Order("time").Find(&points).Error
+	return points, err
+}
+
+// rollupRaw 将原始数据汇总为 5 分钟精度，每次最多处理一天以控制内存占用
+func (r monitorRepo) rollupRaw(now time.Time) error {
+	step := time.Duration(biz.MonitorResolutionFiveMinute) * time.Second
+	end := now.Truncate(step)
+
+	from, err := r.rollupFrom(biz.MonitorResolutionFiveMinute, step)
+	if err != nil {
+		return err
+	}
+	if from.IsZero() {
+		first := new(biz.Monitor)
+		if err = r.db.Order("created_at").First(first).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		from = first.CreatedAt.Truncate(step)
+	}
+
+	for from.Before(end) {
+		to := from.Add(24 * time.Hour)
+		if to.After(end) {
+			to = end
+		}
+
+		var prev *biz.Monitor
+		last := new(biz.Monitor)
+		if err = r.db.Where("created_at < ?", from).Order("created_at desc").First(last).Error; err == nil {
+			prev = last
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		monitors := make([]*biz.Monitor, 0)
+		if err = r.db.Where("created_at >= ? AND created_at < ?", from, to).Order("created_at").Find(&monitors).Error; err != nil {
+			return err
+		}
+
+		buckets := newMonitorBuckets(step)
+		for _, monitor := range monitors {
+			buckets.add(monitor.CreatedAt, 1, monitorSample(monitor, prev))
+			prev = monitor
+		}
+		if err = r.saveBuckets(biz.MonitorResolutionFiveMinute, buckets); err != nil {
+			return err
+		}
+
+		from = to
+	}
+
+	return nil
+}
+
+// rollupFiveMinute 将 5 分钟数据汇总为 1 小时精度
+func (r monitorRepo) rollupFiveMinute(now time.Time) error {
+	step := time.Duration(biz.MonitorResolutionHour) * time.Second
+	end := now.Truncate(step)
+
+	from, err := r.rollupFrom(biz.MonitorResolutionHour, step)
+	if err != nil {
+		return err
+	}
+	if from.IsZero() {
+		first := new(biz.MonitorAggregate)
+		if err = r.db.Where("resolution = ?", biz.MonitorResolutionFiveMinute).Order("time").First(first).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		from = first.Time.Truncate(step)
+	}
+	if !from.Before(end) {
+		return nil
+	}
+
+	aggregates := make([]*biz.MonitorAggregate, 0)
+	if err = r.db.Where("resolution = ? AND time >= ? AND time < ?", biz.MonitorResolutionFiveMinute, from, end).Order("time").Find(&aggregates).Error; err != nil {
+		return err
+	}
+
+	buckets := newMonitorBuckets(step)
+	for _, aggregate := range aggregates {
+		buckets.add(aggregate.Time, aggregate.Samples, aggregate.Stats)
+	}
+
+	return r.saveBuckets(biz.MonitorResolutionHour, buckets)
+}
+
+// rollupFrom 下一个待汇总区间的开始时间，尚无汇总数据时返回零值
+func (r monitorRepo) rollupFrom(resolution uint, step time.Duration) (time.Time, error) {
+	last := new(biz.MonitorAggregate)
+	if err := r.db.Where("resolution = ?", resolution).Order("time desc").First(last).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+
+	return last.Time.Add(step), nil
+}
+
+func (r monitorRepo) saveBuckets(resolution uint, buckets *monitorBuckets) error {
+	aggregates := buckets.aggregates(resolution)
+	if len(aggregates) == 0 {
+		return nil
+	}
+
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(aggregates, 100).Error
+}
+
+// monitorSample 将一次原始数据转换为统计值，prev 用于计算流量和磁盘读写速率
+func monitorSample(monitor, prev *biz.Monitor) biz.MonitorStats {
+	var stats biz.MonitorStats
+	info := monitor.Info
+
+	stats.CPU = monitorPoint(info.Percent)
+	if info.Load != nil {
+		stats.Load1 = monitorPoint(info.Load.Load1)
+		stats.Load5 = monitorPoint(info.Load.Load5)
+		stats.Load15 = monitorPoint(info.Load.Load15)
+	}
+	if info.Mem != nil {
+		stats.MemTotal = info.Mem.Total
+		stats.MemUsed = monitorPoint(float64(info.Mem.Used))
+		stats.MemAvailable = monitorPoint(float64(info.Mem.Available))
+	}
+	if info.Swap != nil {
+		stats.SwapTotal = info.Swap.Total
+		stats.SwapUsed = monitorPoint(float64(info.Swap.Used))
+		stats.SwapFree = monitorPoint(float64(info.Swap.Free))
+	}
+	stats.NetSent, stats.NetRecv = monitorNet(monitor)
+	if len(info.DiskUsage) > 0 {
+		stats.DiskUsage = make(map[string]*biz.MonitorStat)
+	}
+	for _, usage := range info.DiskUsage {
+		point := monitorPoint(usage.UsedPercent)
+		stats.DiskUsage[usage.Path] = &point
+	}
+
+	// 计数器回绕或重启后不计算速率
+	if prev != nil {
+		elapsed := monitor.CreatedAt.Sub(prev.CreatedAt).Seconds()
+		sent, recv := monitorNet(prev)
+		if elapsed > 0 && stats.NetSent >= sent && stats.NetRecv >= recv {
+			stats.NetTx = monitorPoint(float64(stats.NetSent-sent) / elapsed)
+			stats.NetRx = monitorPoint(float64(stats.NetRecv-recv) / elapsed)
+		}
+		if len(info.DiskIO) > 0 {
+			stats.DiskIO = make(map[string]*biz.MonitorDiskIO)
+		}
+		for _, io := range info.DiskIO {
+			last, ok := monitorDiskIO(prev, io.Name)
+			if !ok || elapsed <= 0 || io.ReadBytes < last.ReadBytes || io.WriteBytes < last.WriteBytes || io.ReadCount < last.ReadCount || io.WriteCount < last.WriteCount {
+				continue
+			}
+			stats.DiskIO[io.Name] = &biz.MonitorDiskIO{
+				Read:      monitorPoint(float64(io.ReadBytes-last.ReadBytes) / elapsed),
+				Write:     monitorPoint(float64(io.WriteBytes-last.WriteBytes) / elapsed),
+				ReadIOPS:  monitorPoint(float64(io.ReadCount-last.ReadCount) / elapsed),
+				WriteIOPS: monitorPoint(float64(io.WriteCount-last.WriteCount) / elapsed),
+			}
+		}
+	}
+
+	return stats
+}
+
+// monitorDiskIO 指定设备的累计读写数据
+func monitorDiskIO(monitor *biz.Monitor, name string) (disk.IOCountersStat, bool) {
+	for _, io := range monitor.Info.DiskIO {
+		if io.Name == name {
+			return io, true
+		}
+	}
+	return disk.IOCountersStat{}, false
+}
+
+func monitorPoint(value float64) biz.MonitorStat {
+	return biz.MonitorStat{Min: value, Avg: value, Max: value}
+}
+
+// monitorNet 除回环网卡外的累计流量
+func monitorNet(monitor *biz.Monitor) (sent, recv uint64) {
+	for _, net := range monitor.Info.Net {
+		if net.Name == "lo" {
+			continue
+		}
+		sent += net.BytesSent
+		recv += net.BytesRecv
+	}
+	return sent, recv
+}
+
+// monitorStatFields 需要汇总的统计项
+func monitorStatFields(stats *biz.MonitorStats) []*biz.MonitorStat {
+	return []*biz.MonitorStat{
+		&stats.CPU, &stats.Load1, &stats.Load5, &stats.Load15,
+		&stats.MemUsed, &stats.MemAvailable, &stats.SwapUsed, &stats.SwapFree,
+		&stats.NetTx, &stats.NetRx,
+	}
+}
+
+// monitorDiskFields 需要汇总的磁盘统计项，设备和挂载点可能只在部分数据中出现，因此单独计数
+func monitorDiskFields(stats *biz.MonitorStats) map[string]*biz.MonitorStat {
+	fields := make(map[string]*biz.MonitorStat)
+	for name, io := range stats.DiskIO {
+		fields["io:"+name+":read"] = &io.Read
+		fields["io:"+name+":write"] = &io.Write
+		fields["io:"+name+":read_iops"] = &io.ReadIOPS
+		fields["io:"+name+":write_iops"] = &io.WriteIOPS
+	}
+	for path, usage := range stats.DiskUsage {
+		fields["usage:"+path] = usage
+	}
+	return fields
+}
+
+type monitorBucket struct {
+	time    time.Time
+	samples uint
+	stats   biz.MonitorStats
+	weights map[string]uint // 各磁盘统计项的原始数据条数
+}
+
+// monitorBuckets 按区间汇总统计值，平均值按原始数据条数加权
+type monitorBuckets struct {
+	step    time.Duration
+	buckets []*monitorBucket
+}
+
+func newMonitorBuckets(step time.Duration) *monitorBuckets {
+	return &monitorBuckets{step: step}
+}
+
+// add 按时间顺序加入统计值
+func (b *monitorBuckets) add(t time.Time, samples uint, stats biz.MonitorStats) {
+	if samples == 0 {
+		return
+	}
+
+	start := t.Truncate(b.step)
+	if len(b.buckets) == 0 || !b.buckets[len(b.buckets)-1].time.Equal(start) {
+		b.buckets = append(b.buckets, &monitorBucket{time: start, weights: make(map[string]uint)})
+	}
+	bucket := b.buckets[len(b.buckets)-1]
+
+	dst := monitorStatFields(&bucket.stats)
+	for i, src := range monitorStatFields(&stats) {
+		if bucket.samples == 0 {
+			dst[i].Min, dst[i].Max = src.Min, src.Max
+		} else {
+			dst[i].Min, dst[i].Max = min(dst[i].Min, src.Min), max(dst[i].Max, src.Max)
+		}
+		dst[i].Avg += src.Avg * float64(samples)
+	}
+
+	if len(stats.DiskIO) > 0 && bucket.stats.DiskIO == nil {
+		bucket.stats.DiskIO = make(map[string]*biz.MonitorDiskIO)
+	}
+	for name := range stats.DiskIO {
+		if _, ok := bucket.stats.DiskIO[name]; !ok {
+			bucket.stats.DiskIO[name] = new(biz.MonitorDiskIO)
+		}
+	}
+	if len(stats.DiskUsage) > 0 && bucket.stats.DiskUsage == nil {
+		bucket.stats.DiskUsage = make(map[string]*biz.MonitorStat)
+	}
+	for path := range stats.DiskUsage {
+		if _, ok := bucket.stats.DiskUsage[path]; !ok {
+			bucket.stats.DiskUsage[path] = new(biz.MonitorStat)
+		}
+	}
+	fields := monitorDiskFields(&bucket.stats)
+	for key, src := range monitorDiskFields(&stats) {
+		dst := fields[key]
+		if bucket.weights[key] == 0 {
+			dst.Min, dst.Max = src.Min, src.Max
+		} else {
+			dst.Min, dst.Max = min(dst.Min, src.Min), max(dst.Max, src.Max)
+		}
+		dst.Avg += src.Avg * float64(samples)
+		bucket.weights[key] += samples
+	}
+	bucket.stats.MemTotal, bucket.stats.SwapTotal = stats.MemTotal, stats.SwapTotal
+	bucket.stats.NetSent, bucket.stats.NetRecv = stats.NetSent, stats.NetRecv
+	bucket.samples += samples
+}
+
+func (b *monitorBuckets) aggregates(resolution uint) []*biz.MonitorAggregate {
+	aggregates := make([]*biz.MonitorAggregate, 0, len(b.buckets))
+	for _, bucket := range b.buckets {
+		for _, stat := range monitorStatFields(&bucket.stats) {
+			stat.Avg /= float64(bucket.samples)
+		}
+		for key, stat := range monitorDiskFields(&bucket.stats) {
+			stat.Avg /= float64(bucket.weights[key])
+		}
+		aggregates = append(aggregates, &biz.MonitorAggregate{
+			Resolution: resolution,
+			Time:       bucket.time,
+			Samples:    bucket.samples,
+			Stats:      bucket.stats,
+		})
+	}
+
+	return aggregates
 }
@@ -0,0 +1,142 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/internal/http/request"
+	"github.com/acepanel/panel/pkg/notify"
+)
+
+type notifyRepo struct {
+	t  *gotext.Locale
+	db *gorm.DB
+}
+
+func NewNotifyRepo(t *gotext.Locale, db *gorm.DB) biz.NotifyRepo {
+	return &notifyRepo{
+		t:  t,
+		db: db,
+	}
+}
+
+func (r *notifyRepo) List(page, limit uint) ([]*biz.NotifyChannel, int64, error) {
+	channels := make([]*biz.NotifyChannel, 0)
+	var total int64
+	err := r.db.Model(&biz.NotifyChannel{}).Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&channels).Error
+	return channels, total, err
+}
+
+func (r *notifyRepo) Get(id uint) (*biz.NotifyChannel, error) {
+	channel := new(biz.NotifyChannel)
+	if err := r.db.First(channel, id).Error; err != nil {
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+func (r *notifyRepo) Create(req *request.NotifyChannelCreate) error {
+	if _, err := notify.New(req.Type, req.Config); err != nil {
+		return err
+	}
+
+	channel := &biz.NotifyChannel{
+		Name:    req.Name,
+		Type:    req.Type,
+		Config:  req.Config,
+		Events:  r.events(req.Events),
+		Enabled: req.Enabled,
+	}
+
+	return r.db.Create(channel).Error
+}
+
+func (r *notifyRepo) Update(req *request.NotifyChannelUpdate) error {
+	channel, err := r.Get(req.ID)
+	if err != nil {
+		return err
+	}
+	if _, err = notify.New(req.Type, req.Config); err != nil {
+		return err
+	}
+
+	channel.Name = req.Name
+	channel.Type = req.Type
+	channel.Config = req.Config
+	channel.Events = r.events(req.Events)
+	channel.Enabled = req.Enabled
+
+	return r.db.Save(channel).Error
+}
+
+func (r *notifyRepo) Delete(id uint) error {
+	return r.db.Delete(&biz.NotifyChannel{}, id).Error
+}
+
+func (r *notifyRepo) Test(id uint) error {
+	channel, err := r.Get(id)
+	if err != nil {
+		return err
+	}
+
+	return r.send(channel, biz.NotifyEventTest, r.t.Get("Test notification"), r.t.Get("This is a test notification, the channel %s is working.", channel.Name))
+}
+
+func (r *notifyRepo) Notify(event biz.NotifyEvent, title, content string) error {
+	channels := make([]*biz.NotifyChannel, 0)
+	if err := r.db.Where("enabled = ?", true).Find(&channels).Error; err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, channel := range channels {
+		if !slices.Contains(channel.Events, event) {
+			continue
+		}
+		if err := r.send(channel, event, title, content); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", channel.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *notifyRepo) send(channel *biz.NotifyChannel, event biz.NotifyEvent, title, content string) error {
+	sender, err := notify.New(channel.Type, channel.Config)
+	if err != nil {
+		return err
+	}
+
+	// 标题带上面板名称，便于区分多台服务器
+	var names []string
+	if err = r.db.Model(&biz.Setting{}).Where("key = ?", biz.SettingKeyName).Pluck("value", &names).Error; err == nil && len(names) > 0 && names[0] != "" {
+		title = "[" + names[0] + "] " + title
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	return sender.Send(ctx, &notify.Message{
+		Event:   string(event),
+		Title:   title,
+		Content: content,
+		Time:    time.Now(),
+	})
+}
+
+func (r *notifyRepo) events(events []string) []biz.NotifyEvent {
+	result := make([]biz.NotifyEvent, 0, len(events))
+	for _, event := range events {
+		result = append(result, biz.NotifyEvent(event))
+	}
+
+	return result
+}
@@ -0,0 +1,234 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+	"github.com/libtnb/utils/crypt"
+	"github.com/libtnb/utils/str"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/internal/app"
+	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/internal/http/request"
+	"github.com/acepanel/panel/pkg/oidc"
+)
+
+var oidcUsernameInvalid = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+type oidcRepo struct {
+	t       *gotext.Locale
+	db      *gorm.DB
+	setting biz.SettingRepo
+	user    biz.UserRepo
+
+	mu     sync.Mutex
+	client *oidc.Client
+	key    string // 生成 client 时的配置，变化后重新发现
+}
+
+func NewOIDCRepo(t *gotext.Locale, db *gorm.DB, setting biz.SettingRepo, user biz.UserRepo) biz.OIDCRepo {
+	return &oidcRepo{
+		t:       t,
+		db:      db,
+		setting: setting,
+		user:    user,
+	}
+}
+
+func (r *oidcRepo) GetConfig() (*request.SettingOIDC, error) {
+	value, err := r.setting.Get(biz.SettingKeyOIDC, "{}")
+	if err != nil {
+		return nil, err
+	}
+
+	conf := new(request.SettingOIDC)
+	if err = json.Unmarshal([]byte(value), conf); err != nil {
+		return nil, err
+	}
+
+	crypter, err := crypt.NewXChacha20Poly1305([]byte(app.Key))
+	if err != nil {
+		return nil, err
+	}
+	if decrypted, err := crypter.Decrypt(conf.ClientSecret); err == nil {
+		conf.ClientSecret = string(decrypted)
+	}
+
+	return conf, nil
+}
+
+func (r *oidcRepo) UpdateConfig(req *request.SettingOIDC) error {
+	if req.Enabled && (req.Issuer == "" || req.ClientID == "") {
+		return errors.New(r.t.Get("issuer and client id are required"))
+	}
+	// 角色为 0 表示管理员，自动创建的用户必须指定角色
+	if req.AutoCreate && req.RoleID == 0 {
+		return errors.New(r.t.Get("role is required for auto created users"))
+	}
+
+	// 提交掩码时保留原密钥
+	if req.ClientSecret == biz.OIDCSecretMask {
+		conf, err := r.GetConfig()
+		if err != nil {
+			return err
+		}
+		req.ClientSecret = conf.ClientSecret
+	}
+	crypter, err := crypt.NewXChacha20Poly1305([]byte(app.Key))
+	if err != nil {
+		return err
+	}
+	if req.ClientSecret, err = crypter.Encrypt([]byte(req.ClientSecret)); err != nil {
+		return err
+	}
+
+	value, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	return r.setting.Set(biz.SettingKeyOIDC, string(value))
+}
+
+func (r *oidcRepo) AuthCodeURL(ctx context.Context, redirectURL string) (*oidc.Auth, error) {
+	client, _, err := r.getClient(ctx, redirectURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.AuthCodeURL()
+}
+
+func (r *oidcRepo) Login(ctx context.Context, redirectURL, code, verifier, nonce string) (*biz.User, error) {
+	client, conf, err := r.getClient(ctx, redirectURL)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := client.Exchange(ctx, code, verifier, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	// 优先使用已关联的身份
+	subject := identity.Issuer + "#" + identity.Subject
+	user := new(biz.User)
+	if err = r.db.Where("oidc = ?", subject).First(user).Error; err == nil {
+		return user, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// 邮箱已验证时关联同邮箱的用户
+	if identity.Email != "" && identity.EmailVerified {
+		if err = r.db.Where("LOWER(email) = ? AND oidc = ''", strings.ToLower(identity.Email)).First(user).Error; err == nil {
+			if err = r.db.Model(user).Update("oidc", subject).Error; err != nil {
+				return nil, err
+			}
+			return user, nil
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	if !conf.AutoCreate {
+		return nil, errors.New(r.t.Get("no panel user is linked to this identity"))
+	}
+	if conf.RoleID == 0 {
+		return nil, errors.New(r.t.Get("role is required for auto created users"))
+	}
+
+	username, err := r.username(identity)
+	if err != nil {
+		return nil, err
+	}
+	if user, err = r.user.Create(username, str.Random(32), identity.Email); err != nil {
+		return nil, err
+	}
+	user.OIDC = subject
+	user.RoleID = conf.RoleID
+	if err = r.db.Model(user).Select("oidc", "role_id").Updates(user).Error; err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// getClient 按当前配置获取客户端，配置未变化时复用发现结果
+func (r *oidcRepo) getClient(ctx context.Context, redirectURL string) (*oidc.Client, *request.SettingOIDC, error) {
+	conf, err := r.GetConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !conf.Enabled {
+		return nil, nil, errors.New(r.t.Get("OIDC login is not enabled"))
+	}
+	if conf.RedirectURL != "" {
+		redirectURL = conf.RedirectURL
+	}
+
+	key, err := json.Marshal([]any{conf, redirectURL})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.client != nil && r.key == string(key) {
+		return r.client, conf, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	client, err := oidc.New(ctx, oidc.Config{
+		Issuer:         conf.Issuer,
+		ClientID:       conf.ClientID,
+		ClientSecret:   conf.ClientSecret,
+		RedirectURL:    redirectURL,
+		Scopes:         conf.Scopes,
+		UsernameClaim:  conf.UsernameClaim,
+		GroupsClaim:    conf.GroupsClaim,
+		AllowedGroups:  conf.AllowedGroups,
+		AllowedDomains: conf.AllowedDomains,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r.client = client
+	r.key = string(key)
+	return client, conf, nil
+}
+
+// username 根据身份生成不重复的用户名
+func (r *oidcRepo) username(identity *oidc.Identity) (string, error) {
+	base := identity.Username
+	if base == "" {
+		base, _, _ = strings.Cut(identity.Email, "@")
+	}
+	base = strings.Trim(oidcUsernameInvalid.ReplaceAllString(base, "_"), "_")
+	if base == "" {
+		base = "oidc"
+	}
+
+	username := base
+	for range 10 {
+		var count int64
+		if err := r.db.Unscoped().Model(&biz.User{}).Where("username = ?", username).Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return username, nil
+		}
+		username = base + "_" + strings.ToLower(str.Random(4))
+	}
+
+	return "", errors.New(r.t.Get("failed to generate username for %s", base))
+}
@@ -0,0 +1,113 @@
+package data
+
+import (
+	"errors"
+
+	"github.com/leonelquinteros/gotext"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/internal/http/request"
+)
+
+type roleRepo struct {
+	t  *gotext.Locale
+	db *gorm.DB
+}
+
+func NewRoleRepo(t *gotext.Locale, db *gorm.DB) biz.RoleRepo {
+	return &roleRepo{
+		t:  t,
+		db: db,
+	}
+}
+
+func (r *roleRepo) List(page, limit uint) ([]*biz.Role, int64, error) {
+	roles := make([]*biz.Role, 0)
+	var total int64
+	err := r.db.Model(&biz.Role{}).Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&roles).Error
+	return roles, total, err
+}
+
+func (r *roleRepo) Get(id uint) (*biz.Role, error) {
+	role := new(biz.Role)
+	if err := r.db.First(role, id).Error; err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+func (r *roleRepo) Create(req *request.RoleCreate) (*biz.Role, error) {
+	role := &biz.Role{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	}
+	if err := r.db.Create(role).Error; err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+func (r *roleRepo) Update(req *request.RoleUpdate) error {
+	role, err := r.Get(req.ID)
+	if err != nil {
+		return err
+	}
+
+	role.Name = req.Name
+	role.Description = req.Description
+	role.Permissions = req.Permissions
+
+	return r.db.Save(role).Error
+}
+
+func (r *roleRepo) Delete(id uint) error {
+	// 角色为空表示管理员，删除仍在使用的角色会导致越权
+	var users, tokens int64
+	if err := r.db.Model(&biz.User{}).Where("role_id = ?", id).Count(&users).Error; err != nil {
+		return err
+	}
+	if err := r.db.Model(&biz.UserToken{}).Where("role_id = ?", id).Count(&tokens).Error; err != nil {
+		return err
+	}
+	if users > 0 || tokens > 0 {
+		return errors.New(r.t.Get("role is in use by %d users and %d tokens", users, tokens))
+	}
+
+	return r.db.Delete(&biz.Role{}, id).Error
+}
+
+func (r *roleRepo) Allowed(userID, tokenID uint, group string, write bool) (bool, error) {
+	user := new(biz.User)
+	if err := r.db.First(user, userID).Error; err != nil {
+		return false, err
+	}
+
+	roleIDs := []uint{user.RoleID}
+	if tokenID != 0 {
+		token := new(biz.UserToken)
+		if err := r.db.First(token, tokenID).Error; err != nil {
+			return false, err
+		}
+		roleIDs = append(roleIDs, token.RoleID)
+	}
+
+	// 令牌的权限不超过所属用户
+	for _, id := range roleIDs {
+		if id == 0 {
+			continue
+		}
+		role, err := r.Get(id)
+		if err != nil {
+			return false, err
+		}
+		if !role.Allow(group, write) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
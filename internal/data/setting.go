@@ -13,6 +13,7 @@ import (
 	"github.com/acepanel/panel/internal/app"
 	"github.com/acepanel/panel/internal/biz"
 	"github.com/acepanel/panel/internal/http/request"
+	"github.com/acepanel/panel/pkg/backupcrypto"
 	"github.com/acepanel/panel/pkg/cert"
 	"github.com/acepanel/panel/pkg/config"
 	"github.com/acepanel/panel/pkg/firewall"
@@ -221,6 +222,22 @@ func (r *settingRepo) GetPanel() (*request.SettingPanel, error) {
 	if err != nil {
 		return nil, err
 	}
+	backupEncrypt, err := r.Get(biz.SettingKeyBackupEncrypt)
+	if err != nil {
+		return nil, err
+	}
+	backupPassphrase, err := r.Get(biz.SettingKeyBackupPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	backupRecipient, err := r.Get(biz.SettingKeyBackupRecipient)
+	if err != nil {
+		return nil, err
+	}
+	backupIdentity, err := r.Get(biz.SettingKeyBackupIdentity)
+	if err != nil {
+		return nil, err
+	}
 	hiddenMenu, err := r.GetSlice(biz.SettingHiddenMenu)
 	if err != nil {
 		return nil, err
@@ -229,6 +246,26 @@ func (r *settingRepo) GetPanel() (*request.SettingPanel, error) {
 	if err != nil {
 		return nil, err
 	}
+	auditDays, err := r.GetInt(biz.SettingKeyAuditDays, 180)
+	if err != nil {
+		return nil, err
+	}
+	loginMaxFailures, err := r.GetInt(biz.SettingKeyLoginMaxFailures, 5)
+	if err != nil {
+		return nil, err
+	}
+	loginLockMinutes, err := r.GetInt(biz.SettingKeyLoginLockMinutes, 15)
+	if err != nil {
+		return nil, err
+	}
+	loginAutoBan, err := r.GetBool(biz.SettingKeyLoginAutoBan)
+	if err != nil {
+		return nil, err
+	}
+	metricsToken, err := r.Get(biz.SettingKeyMetricsToken)
+	if err != nil {
+		return nil, err
+	}
 	ip, err := r.Get(biz.SettingKeyPublicIPs)
 	if err != nil {
 		return nil, err
@@ -248,27 +285,36 @@ func (r *settingRepo) GetPanel() (*request.SettingPanel, error) {
 	}
 
 	return &request.SettingPanel{
-		Name:        name,
-		Channel:     channel,
-		Locale:      r.conf.App.Locale,
-		Entrance:    r.conf.HTTP.Entrance,
-		OfflineMode: offlineMode,
-		AutoUpdate:  autoUpdate,
-		Lifetime:    r.conf.Session.Lifetime,
-		IPHeader:    r.conf.HTTP.IPHeader,
-		BindDomain:  r.conf.HTTP.BindDomain,
-		BindIP:      r.conf.HTTP.BindIP,
-		BindUA:      r.conf.HTTP.BindUA,
-		WebsitePath: websitePath,
-		BackupPath:  backupPath,
-		HiddenMenu:  hiddenMenu,
-		CustomLogo:  customLogo,
-		Port:        r.conf.HTTP.Port,
-		HTTPS:       r.conf.HTTP.TLS,
-		ACME:        r.conf.HTTP.ACME,
-		PublicIP:    publicIP,
-		Cert:        crt,
-		Key:         key,
+		Name:             name,
+		Channel:          channel,
+		Locale:           r.conf.App.Locale,
+		Entrance:         r.conf.HTTP.Entrance,
+		OfflineMode:      offlineMode,
+		AutoUpdate:       autoUpdate,
+		Lifetime:         r.conf.Session.Lifetime,
+		IPHeader:         r.conf.HTTP.IPHeader,
+		BindDomain:       r.conf.HTTP.BindDomain,
+		BindIP:           r.conf.HTTP.BindIP,
+		BindUA:           r.conf.HTTP.BindUA,
+		WebsitePath:      websitePath,
+		BackupPath:       backupPath,
+		BackupEncrypt:    backupEncrypt,
+		BackupPassphrase: backupPassphrase,
+		BackupRecipient:  backupRecipient,
+		BackupIdentity:   backupIdentity,
+		HiddenMenu:       hiddenMenu,
+		CustomLogo:       customLogo,
+		AuditDays:        uint(auditDays),
+		LoginMaxFailures: uint(loginMaxFailures),
+		LoginLockMinutes: uint(loginLockMinutes),
+		LoginAutoBan:     loginAutoBan,
+		MetricsToken:     metricsToken,
+		Port:             r.conf.HTTP.Port,
+		HTTPS:            r.conf.HTTP.TLS,
+		ACME:             r.conf.HTTP.ACME,
+		PublicIP:         publicIP,
+		Cert:             crt,
+		Key:              key,
 	}, nil
 }
 
@@ -291,12 +337,30 @@ func (r *settingRepo) UpdatePanel(req *request.SettingPanel) (bool, error) {
 	if err := r.Set(biz.SettingKeyBackupPath, req.BackupPath); err != nil {
 		return false, err
 	}
+	if err := r.updateBackupEncrypt(req); err != nil {
+		return false, err
+	}
 	if err := r.SetSlice(biz.SettingHiddenMenu, req.HiddenMenu); err != nil {
 		return false, err
 	}
 	if err := r.Set(biz.SettingKeyCustomLogo, req.CustomLogo); err != nil {
 		return false, err
 	}
+	if err := r.Set(biz.SettingKeyAuditDays, cast.ToString(req.AuditDays)); err != nil {
+		return false, err
+	}
+	if err := r.Set(biz.SettingKeyLoginMaxFailures, cast.ToString(req.LoginMaxFailures)); err != nil {
+		return false, err
+	}
+	if err := r.Set(biz.SettingKeyLoginLockMinutes, cast.ToString(req.LoginLockMinutes)); err != nil {
+		return false, err
+	}
+	if err := r.Set(biz.SettingKeyLoginAutoBan, cast.ToString(req.LoginAutoBan)); err != nil {
+		return false, err
+	}
+	if err := r.Set(biz.SettingKeyMetricsToken, req.MetricsToken); err != nil {
+		return false, err
+	}
 	if err := r.SetSlice(biz.SettingKeyPublicIPs, req.PublicIP); err != nil {
 		return false, err
 	}
@@ -376,6 +440,33 @@ func (r *settingRepo) UpdatePanel(req *request.SettingPanel) (bool, error) {
 	return restartFlag, nil
 }
 
+// updateBackupEncrypt 更新备份加密设置
+func (r *settingRepo) updateBackupEncrypt(req *request.SettingPanel) error {
+	conf := backupcrypto.Config{
+		Mode:       backupcrypto.Mode(req.BackupEncrypt),
+		Passphrase: req.BackupPassphrase,
+		Recipient:  req.BackupRecipient,
+		Identity:   req.BackupIdentity,
+	}
+	if err := conf.Check(); err != nil {
+		return errors.New(r.t.Get("invalid backup encryption settings: %v", err))
+	}
+
+	settings := map[biz.SettingKey]string{
+		biz.SettingKeyBackupEncrypt:    req.BackupEncrypt,
+		biz.SettingKeyBackupPassphrase: req.BackupPassphrase,
+		biz.SettingKeyBackupRecipient:  req.BackupRecipient,
+		biz.SettingKeyBackupIdentity:   req.BackupIdentity,
+	}
+	for key, value := range settings {
+		if err := r.Set(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *settingRepo) UpdateCert(req *request.SettingCert) error {
 	if r.task.HasRunningTask() {
 		return errors.New(r.t.Get("background task is running, modifying some settings is prohibited, please try again later"))
@@ -13,18 +13,20 @@ import (
 )
 
 type taskRepo struct {
-	t     *gotext.Locale
-	db    *gorm.DB
-	log   *slog.Logger
-	queue *queue.Queue
+	t      *gotext.Locale
+	db     *gorm.DB
+	log    *slog.Logger
+	queue  *queue.Queue
+	notify biz.NotifyRepo
 }
 
-func NewTaskRepo(t *gotext.Locale, db *gorm.DB, log *slog.Logger, queue *queue.Queue) biz.TaskRepo {
+func NewTaskRepo(t *gotext.Locale, db *gorm.DB, log *slog.Logger, queue *queue.Queue, notify biz.NotifyRepo) biz.TaskRepo {
 	return &taskRepo{
-		t:     t,
-		db:    db,
-		log:   log,
-		queue: queue,
+		t:      t,
+		db:     db,
+		log:    log,
+		queue:  queue,
+		notify: notify,
 	}
 }
 
@@ -34,6 +36,23 @@ func (r *taskRepo) HasRunningTask() bool {
 	return count > 0
 }
 
+func (r *taskRepo) StatusCount() (map[biz.TaskStatus]int64, error) {
+	var rows []struct {
+		Status biz.TaskStatus
+		Count  int64
+	}
+	if err := r.db.Model(&biz.Task{}).Select("status, COUNT(*) AS count").Group("status").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[biz.TaskStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+
+	return counts, nil
+}
+
 func (r *taskRepo) List(page, limit uint) ([]*biz.Task, int64, error) {
 	tasks := make([]*biz.Task, 0)
 	var total int64
@@ -69,7 +88,7 @@ func (r *taskRepo) Push(task *biz.Task) error {
 		return err
 	}
 
-	return r.queue.Push(queuejob.NewProcessTask(r.log, r), []any{
+	return r.queue.Push(queuejob.NewProcessTask(r.t, r.log, r, r.notify), []any{
 		task.ID,
 	})
 }
@@ -3,6 +3,8 @@ package data
 import (
 	"errors"
 	"image"
+	"maps"
+	"slices"
 
 	"github.com/leonelquinteros/gotext"
 	"github.com/libtnb/utils/hash"
@@ -84,7 +86,13 @@ func (r *userRepo) UpdatePassword(id uint, password string) error {
 	}
 
 	user.Password = value
-	return r.db.Save(user).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err = tx.Save(user).Error; err != nil {
+			return err
+		}
+		// 修改密码后吊销该用户已登录的会话
+		return revokeUserSessions(tx, id, "")
+	})
 }
 
 func (r *userRepo) UpdateEmail(id uint, email string) error {
@@ -97,6 +105,43 @@ func (r *userRepo) UpdateEmail(id uint, email string) error {
 	return r.db.Save(user).Error
 }
 
+func (r *userRepo) UpdateRole(id uint, roleID uint) error {
+	user, err := r.Get(id)
+	if err != nil {
+		return err
+	}
+
+	user.RoleID = roleID
+	return r.db.Save(user).Error
+}
+
+func (r *userRepo) UpdateResources(id uint, typ string, ids []uint) error {
+	table, ok := biz.OwnedResources[typ]
+	if !ok {
+		return errors.New(r.t.Get("unsupported resource type: %s", typ))
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(table).Where("user_id = ?", id).Update("user_id", 0).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return tx.Table(table).Where("id IN ?", ids).Update("user_id", id).Error
+	})
+}
+
+func (r *userRepo) Owns(id uint, table string, resource uint) (bool, error) {
+	if !slices.Contains(slices.Collect(maps.Values(biz.OwnedResources)), table) {
+		return false, errors.New(r.t.Get("unsupported resource type: %s", table))
+	}
+
+	var count int64
+	err := r.db.Table(table).Where("id = ? AND user_id = ?", resource, id).Count(&count).Error
+	return count > 0, err
+}
+
 func (r *userRepo) Delete(id uint) error {
 	var count int64
 	if err := r.db.Model(&biz.User{}).Count(&count).Error; err != nil {
@@ -115,6 +160,18 @@ func (r *userRepo) Delete(id uint) error {
 		if err := tx.Model(&user).Association("Tokens").Delete(); err != nil {
 			return err
 		}
+		if err := tx.Where("user_id = ?", user.ID).Delete(&biz.UserPasskey{}).Error; err != nil {
+			return err
+		}
+		if err := revokeUserSessions(tx, user.ID, ""); err != nil {
+			return err
+		}
+		// 释放用户拥有的资源，交还管理员
+		for table := range maps.Values(biz.OwnedResources) {
+			if err := tx.Table(table).Where("user_id = ?", user.ID).Update("user_id", 0).Error; err != nil {
+				return err
+			}
+		}
 		return tx.Delete(&user).Error
 	})
 }
@@ -182,7 +239,13 @@ func (r *userRepo) UpdateTwoFA(id uint, code, secret string) error {
 	}
 
 	user.TwoFA = secret
-	return r.db.Save(user).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err = tx.Save(user).Error; err != nil {
+			return err
+		}
+		// 修改 2FA 后吊销该用户已登录的会话
+		return revokeUserSessions(tx, id, "")
+	})
 }
 
 func (r *userRepo) CheckTwoFA(id uint, code string) (bool, error) {
@@ -0,0 +1,219 @@
+package data
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/leonelquinteros/gotext"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/internal/biz"
+)
+
+type userPasskeyRepo struct {
+	t  *gotext.Locale
+	db *gorm.DB
+}
+
+func NewUserPasskeyRepo(t *gotext.Locale, db *gorm.DB) biz.UserPasskeyRepo {
+	return &userPasskeyRepo{
+		t:  t,
+		db: db,
+	}
+}
+
+func (r *userPasskeyRepo) List(userID uint) ([]*biz.UserPasskey, error) {
+	passkeys := make([]*biz.UserPasskey, 0)
+	err := r.db.Where("user_id = ?", userID).Order("id desc").Find(&passkeys).Error
+	return passkeys, err
+}
+
+func (r *userPasskeyRepo) Count(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&biz.UserPasskey{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *userPasskeyRepo) BeginRegistration(rp *webauthn.WebAuthn, userID uint) (*protocol.CredentialCreation, *webauthn.SessionData, error) {
+	user, err := r.user(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 排除已注册的凭据，尽量创建可发现凭据以支持免密码登录
+	exclusions := make([]protocol.CredentialDescriptor, 0, len(user.credentials))
+	for _, credential := range user.credentials {
+		exclusions = append(exclusions, credential.Descriptor())
+	}
+
+	return rp.BeginRegistration(user,
+		webauthn.WithExclusions(exclusions),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementPreferred),
+	)
+}
+
+func (r *userPasskeyRepo) FinishRegistration(rp *webauthn.WebAuthn, userID uint, name string, session webauthn.SessionData, response *protocol.ParsedCredentialCreationData) (*biz.UserPasskey, error) {
+	user, err := r.user(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := rp.CreateCredential(user, session, response)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = r.t.Get("Passkey %d", len(user.credentials)+1)
+	}
+	passkey := &biz.UserPasskey{
+		UserID:       userID,
+		Name:         name,
+		CredentialID: base64.RawURLEncoding.EncodeToString(credential.ID),
+		Credential:   *credential,
+	}
+	if err = r.db.Create(passkey).Error; err != nil {
+		return nil, err
+	}
+
+	return passkey, nil
+}
+
+func (r *userPasskeyRepo) BeginLogin(rp *webauthn.WebAuthn, username string) (*protocol.CredentialAssertion, *webauthn.SessionData, error) {
+	// 免密码登录必须验证用户身份（PIN、生物识别等）
+	if username == "" {
+		return rp.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+	}
+
+	found := new(biz.User)
+	if err := r.db.Where("username = ?", username).First(found).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errors.New(r.t.Get("no passkey registered"))
+		}
+		return nil, nil, err
+	}
+	user, err := r.user(found.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(user.credentials) == 0 {
+		return nil, nil, errors.New(r.t.Get("no passkey registered"))
+	}
+
+	return rp.BeginLogin(user)
+}
+
+func (r *userPasskeyRepo) FinishLogin(rp *webauthn.WebAuthn, userID uint, session webauthn.SessionData, response *protocol.ParsedCredentialAssertionData) (*biz.User, error) {
+	var user *passkeyUser
+	var credential *webauthn.Credential
+	var err error
+
+	if userID == 0 {
+		var found webauthn.User
+		found, credential, err = rp.ValidatePasskeyLogin(func(rawID, userHandle []byte) (webauthn.User, error) {
+			passkey := new(biz.UserPasskey)
+			if err := r.db.Where("credential_id = ?", base64.RawURLEncoding.EncodeToString(rawID)).First(passkey).Error; err != nil {
+				return nil, errors.New(r.t.Get("passkey not found"))
+			}
+			owner, err := r.user(passkey.UserID)
+			if err != nil {
+				return nil, err
+			}
+			if !bytes.Equal(owner.WebAuthnID(), userHandle) {
+				return nil, errors.New(r.t.Get("passkey not found"))
+			}
+			return owner, nil
+		}, session, response)
+		if err != nil {
+			return nil, err
+		}
+		user = found.(*passkeyUser)
+	} else {
+		if user, err = r.user(userID); err != nil {
+			return nil, err
+		}
+		if credential, err = rp.ValidateLogin(user, session, response); err != nil {
+			return nil, err
+		}
+	}
+
+	// 签名计数回退说明凭据可能被克隆
+	if credential.Authenticator.CloneWarning {
+		return nil, errors.New(r.t.Get("passkey may have been cloned, please remove it and register again"))
+	}
+
+	passkey := new(biz.UserPasskey)
+	if err = r.db.Where("user_id = ? AND credential_id = ?", user.user.ID, base64.RawURLEncoding.EncodeToString(credential.ID)).First(passkey).Error; err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	passkey.Credential = *credential
+	passkey.LastUsedAt = &now
+	if err = r.db.Save(passkey).Error; err != nil {
+		return nil, err
+	}
+
+	return user.user, nil
+}
+
+func (r *userPasskeyRepo) Delete(userID, id uint) error {
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&biz.UserPasskey{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(r.t.Get("passkey not found"))
+	}
+
+	return nil
+}
+
+func (r *userPasskeyRepo) Clear(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&biz.UserPasskey{}).Error
+}
+
+// user 加载用户及其凭据
+func (r *userPasskeyRepo) user(userID uint) (*passkeyUser, error) {
+	user := new(biz.User)
+	if err := r.db.First(user, userID).Error; err != nil {
+		return nil, err
+	}
+
+	passkeys, err := r.List(userID)
+	if err != nil {
+		return nil, err
+	}
+	credentials := make([]webauthn.Credential, 0, len(passkeys))
+	for _, passkey := range passkeys {
+		credentials = append(credentials, passkey.Credential)
+	}
+
+	return &passkeyUser{user: user, credentials: credentials}, nil
+}
+
+// passkeyUser 实现 webauthn.User
+type passkeyUser struct {
+	user        *biz.User
+	credentials []webauthn.Credential
+}
+
+func (u *passkeyUser) WebAuthnID() []byte {
+	return []byte(strconv.FormatUint(uint64(u.user.ID), 10))
+}
+
+func (u *passkeyUser) WebAuthnName() string {
+	return u.user.Username
+}
+
+func (u *passkeyUser) WebAuthnDisplayName() string {
+	return u.user.Username
+}
+
+func (u *passkeyUser) WebAuthnCredentials() []webauthn.Credential {
+	return u.credentials
+}
@@ -0,0 +1,150 @@
+package data
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/pkg/config"
+)
+
+// validSessions 最近校验通过的会话，缓存期内不再查询数据库
+var validSessions sync.Map
+
+// validSession 会话校验缓存
+type validSession struct {
+	userID   uint
+	ip       string
+	expireAt time.Time
+}
+
+type userSessionRepo struct {
+	t    *gotext.Locale
+	conf *config.Config
+	db   *gorm.DB
+}
+
+func NewUserSessionRepo(t *gotext.Locale, conf *config.Config, db *gorm.DB) biz.UserSessionRepo {
+	return &userSessionRepo{
+		t:    t,
+		conf: conf,
+		db:   db,
+	}
+}
+
+func (r *userSessionRepo) List(userID uint) ([]*biz.UserSession, error) {
+	// 会话数据过期后会被回收，这里顺带清理失效的索引
+	if err := r.db.Where("last_seen_at < ?", r.expiredAt()).Delete(&biz.UserSession{}).Error; err != nil {
+		return nil, err
+	}
+
+	userSessions := make([]*biz.UserSession, 0)
+	err := r.db.Where("user_id = ?", userID).Order("last_seen_at desc").Find(&userSessions).Error
+	return userSessions, err
+}
+
+func (r *userSessionRepo) Create(userID uint, sessionID, ip, userAgent string) error {
+	userSession := new(biz.UserSession)
+	if err := r.db.Where("session_id = ?", sessionID).FirstOrInit(userSession).Error; err != nil {
+		return err
+	}
+
+	userSession.UserID = userID
+	userSession.SessionID = sessionID
+	userSession.IP = ip
+	userSession.UserAgent = userAgent
+	userSession.LastSeenAt = time.Now()
+	return r.db.Save(userSession).Error
+}
+
+func (r *userSessionRepo) Validate(userID uint, sessionID, ip string) (bool, error) {
+	// 缓存 10 秒，吊销会话时会同时清除缓存
+	if cached, ok := validSessions.Load(sessionID); ok {
+		if item := cached.(validSession); item.userID == userID && item.ip == ip && time.Now().Before(item.expireAt) {
+			return true, nil
+		}
+	}
+
+	userSession := new(biz.UserSession)
+	if err := r.db.Where("session_id = ? AND user_id = ?", sessionID, userID).First(userSession).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// 每分钟最多更新一次，避免每个请求都写库
+	if time.Since(userSession.LastSeenAt) >= time.Minute || userSession.IP != ip {
+		if err := r.db.Model(userSession).Updates(map[string]any{
+			"ip":           ip,
+			"last_seen_at": time.Now(),
+		}).Error; err != nil {
+			return false, err
+		}
+	}
+
+	validSessions.Store(sessionID, validSession{userID: userID, ip: ip, expireAt: time.Now().Add(10 * time.Second)})
+	return true, nil
+}
+
+func (r *userSessionRepo) Delete(userID, id uint) error {
+	userSession := new(biz.UserSession)
+	if err := r.db.Where("id = ? AND user_id = ?", id, userID).First(userSession).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New(r.t.Get("session not found"))
+		}
+		return err
+	}
+
+	validSessions.Delete(userSession.SessionID)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM sessions WHERE id = ?", userSession.SessionID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(userSession).Error
+	})
+}
+
+func (r *userSessionRepo) DeleteBySessionID(sessionID string) error {
+	validSessions.Delete(sessionID)
+	return r.db.Where("session_id = ?", sessionID).Delete(&biz.UserSession{}).Error
+}
+
+func (r *userSessionRepo) Revoke(userID uint, except string) error {
+	return revokeUserSessions(r.db, userID, except)
+}
+
+// expiredAt 超过会话有效期未访问的会话视为失效
+func (r *userSessionRepo) expiredAt() time.Time {
+	return time.Now().Add(-time.Duration(r.conf.Session.Lifetime) * time.Minute)
+}
+
+// revokeUserSessions 删除会话索引及对应的会话数据，userID 为 0 时处理所有用户
+func revokeUserSessions(db *gorm.DB, userID uint, except string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&biz.UserSession{}).Where("session_id <> ?", except)
+		if userID != 0 {
+			query = query.Where("user_id = ?", userID)
+		}
+
+		var sessionIDs []string
+		if err := query.Pluck("session_id", &sessionIDs).Error; err != nil {
+			return err
+		}
+		if len(sessionIDs) == 0 {
+			return nil
+		}
+		for _, sessionID := range sessionIDs {
+			validSessions.Delete(sessionID)
+		}
+
+		if err := tx.Exec("DELETE FROM sessions WHERE id IN ?", sessionIDs).Error; err != nil {
+			return err
+		}
+		return tx.Where("session_id IN ?", sessionIDs).Delete(&biz.UserSession{}).Error
+	})
+}
@@ -44,13 +44,19 @@ func (r userTokenRepo) List(userID, page, limit uint) ([]*biz.UserToken, int64,
 	return userTokens, total, err
 }
 
-func (r userTokenRepo) Create(userID uint, ips []string, expired time.Time) (*biz.UserToken, error) {
+func (r userTokenRepo) Create(userID uint, ips []string, roleID uint, scopes []string, websiteIDs []uint, expired time.Time) (*biz.UserToken, error) {
 	token := str.Random(32)
 	userToken := &biz.UserToken{
-		UserID:    userID,
-		Token:     token,
-		IPs:       ips,
-		ExpiredAt: expired,
+		UserID:     userID,
+		Token:      token,
+		IPs:        ips,
+		RoleID:     roleID,
+		Scopes:     scopes,
+		WebsiteIDs: websiteIDs,
+		ExpiredAt:  expired,
+	}
+	if !userToken.WebsiteScoped() {
+		return nil, errors.New(r.t.Get("tokens restricted to websites can only have website scopes"))
 	}
 	if err := r.db.Create(userToken).Error; err != nil {
 		return nil, err
@@ -79,14 +85,20 @@ func (r userTokenRepo) Delete(id uint) error {
 	return r.db.Delete(userToken).Error
 }
 
-func (r userTokenRepo) Update(id uint, ips []string, expired time.Time) (*biz.UserToken, error) {
+func (r userTokenRepo) Update(id uint, ips []string, roleID uint, scopes []string, websiteIDs []uint, expired time.Time) (*biz.UserToken, error) {
 	userToken := new(biz.UserToken)
 	if err := r.db.First(userToken, id).Error; err != nil {
 		return nil, err
 	}
 
 	userToken.IPs = ips
+	userToken.RoleID = roleID
+	userToken.Scopes = scopes
+	userToken.WebsiteIDs = websiteIDs
 	userToken.ExpiredAt = expired
+	if !userToken.WebsiteScoped() {
+		return nil, errors.New(r.t.Get("tokens restricted to websites can only have website scopes"))
+	}
 
 	if err := r.db.Save(userToken).Error; err != nil {
 		return nil, err
@@ -95,31 +107,31 @@ func (r userTokenRepo) Update(id uint, ips []string, expired time.Time) (*biz.Us
 	return userToken, nil
 }
 
-func (r userTokenRepo) ValidateReq(req *http.Request) (uint, error) {
+func (r userTokenRepo) ValidateReq(req *http.Request) (*biz.UserToken, error) {
 	// Authorization: HMAC-SHA256 Credential=<token_id>, Signature=<signature>
 	var algorithm string
 	var id uint
 	var signature string
 	if _, err := fmt.Sscanf(req.Header.Get("Authorization"), "%s Credential=%d, Signature=%s", &algorithm, &id, &signature); err != nil {
-		return 0, errors.New(r.t.Get("invalid header: %v", err))
+		return nil, errors.New(r.t.Get("invalid header: %v", err))
 	}
 	if algorithm != "HMAC-SHA256" {
-		return 0, errors.New(r.t.Get("invalid signature"))
+		return nil, errors.New(r.t.Get("invalid signature"))
 	}
 
 	// 获取用户令牌
 	userToken, err := r.Get(id)
 	if err != nil {
-		return 0, errors.New(r.t.Get("invalid signature")) // 不应返回原始报错，防止猜测令牌ID
+		return nil, errors.New(r.t.Get("invalid signature")) // 不应返回原始报错，防止猜测令牌ID
 	}
 	if userToken.ExpiredAt.Before(time.Now()) {
-		return 0, errors.New(r.t.Get("token expired"))
+		return nil, errors.New(r.t.Get("token expired"))
 	}
 
 	// 步骤一：构造规范化请求
 	body, err := io.ReadAll(req.Body)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	req.Body = io.NopCloser(bytes.NewReader(body))
 	canonicalRequest := fmt.Sprintf("%s\n%s\n%s\n%s", req.Method, req.URL.Path, req.URL.Query().Encode(), str.SHA256(string(body)))
@@ -133,26 +145,25 @@ func (r userTokenRepo) ValidateReq(req *http.Request) (uint, error) {
 
 	// 步骤四：验证签名
 	if subtle.ConstantTimeCompare([]byte(signature), []byte(validSignature)) != 1 {
-		return 0, errors.New(r.t.Get("invalid signature"))
+		return nil, errors.New(r.t.Get("invalid signature"))
 	}
 
 	// 步骤五：验证时间戳
 	if timestamp == 0 || timestamp < (time.Now().Unix()-300) {
-		return 0, errors.New(r.t.Get("signature expired"))
+		return nil, errors.New(r.t.Get("signature expired"))
 	}
 
 	// 步骤六：验证IP
+	ip := req.RemoteAddr
+	ipHeader := r.conf.HTTP.IPHeader
+	if ipHeader != "" && req.Header.Get(ipHeader) != "" {
+		ip = strings.Split(req.Header.Get(ipHeader), ",")[0]
+	}
+	ip, _, err = net.SplitHostPort(strings.TrimSpace(ip))
+	if err != nil {
+		ip = req.RemoteAddr
+	}
 	if len(userToken.IPs) > 0 {
-		ip := req.RemoteAddr
-		ipHeader := r.conf.HTTP.IPHeader
-		if ipHeader != "" && req.Header.Get(ipHeader) != "" {
-			ip = strings.Split(req.Header.Get(ipHeader), ",")[0]
-		}
-		ip, _, err = net.SplitHostPort(strings.TrimSpace(ip))
-		if err != nil {
-			ip = req.RemoteAddr
-		}
-
 		allowed := false
 		requestIP := net.ParseIP(ip)
 		if requestIP != nil {
@@ -173,11 +184,57 @@ func (r userTokenRepo) ValidateReq(req *http.Request) (uint, error) {
 			}
 		}
 		if !allowed {
-			return 0, errors.New(r.t.Get("invalid request ip: %s", ip))
+			return nil, errors.New(r.t.Get("invalid request ip: %s", ip))
+		}
+	}
+
+	// 步骤七：验证权限范围
+	group, write, websiteID := r.scope(req)
+	if !userToken.Allow(group, write, websiteID) {
+		return nil, errors.New(r.t.Get("token scope does not allow access to %s", group))
+	}
+
+	// 记录最后使用情况，不触发钩子避免重复加密令牌
+	now := time.Now()
+	if err = r.db.Model(userToken).UpdateColumns(map[string]any{
+		"last_used_at": now,
+		"last_used_ip": ip,
+	}).Error; err != nil {
+		return nil, err
+	}
+	userToken.LastUsedAt = &now
+	userToken.LastUsedIP = ip
+
+	return userToken, nil
+}
+
+// scope 获取请求访问的路由组、是否为写操作及网站 ID
+func (r userTokenRepo) scope(req *http.Request) (string, bool, uint) {
+	group, websiteID := r.target(req.URL.Path)
+	write := req.Method != http.MethodGet && req.Method != http.MethodHead && req.Method != http.MethodOptions
+
+	return group, write, websiteID
+}
+
+// target 根据请求路径获取路由组及网站 ID，与 route 中的路由组保持一致
+// WebSocket 路由不允许令牌访问，MustLogin 会在校验令牌前拒绝
+func (r userTokenRepo) target(path string) (string, uint) {
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(path, "/api"), "/"), "/")
+	group := segments[0]
+	switch group {
+	case "users", "user_tokens", "roles":
+		return biz.PermissionAdmin, 0
+	case "apps":
+		if len(segments) > 1 {
+			return group + "/" + segments[1], 0
+		}
+	case "website":
+		if len(segments) > 1 {
+			return group, cast.ToUint(segments[1])
 		}
 	}
 
-	return userToken.UserID, nil
+	return group, 0
 }
 
 func (r userTokenRepo) hmacsha256(data string, secret string) string {
@@ -0,0 +1,41 @@
+package data
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/acepanel/panel/internal/biz"
+)
+
+type UserTokenTestSuite struct {
+	suite.Suite
+	repo userTokenRepo
+}
+
+func TestUserTokenTestSuite(t *testing.T) {
+	suite.Run(t, &UserTokenTestSuite{})
+}
+
+func (s *UserTokenTestSuite) TestScope() {
+	tests := []struct {
+		method, path string
+		group        string
+		write        bool
+		websiteID    uint
+	}{
+		{http.MethodGet, "/api/website/3", "website", false, 3},
+		{http.MethodPost, "/api/website/3/status", "website", true, 3},
+		{http.MethodGet, "/api/apps/nginx/load", "apps/nginx", false, 0},
+		{http.MethodGet, "/api/users", biz.PermissionAdmin, false, 0},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		group, write, websiteID := s.repo.scope(req)
+		s.Equal(tt.group, group, tt.path)
+		s.Equal(tt.write, write, tt.path)
+		s.Equal(tt.websiteID, websiteID, tt.path)
+	}
+}
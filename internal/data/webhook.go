@@ -30,10 +30,10 @@ func NewWebHookRepo(t *gotext.Locale, db *gorm.DB) biz.WebHookRepo {
 	}
 }
 
-func (r *webhookRepo) List(page, limit uint) ([]*biz.WebHook, int64, error) {
+func (r *webhookRepo) List(owner, page, limit uint) ([]*biz.WebHook, int64, error) {
 	webhooks := make([]*biz.WebHook, 0)
 	var total int64
-	err := r.db.Model(&biz.WebHook{}).Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&webhooks).Error
+	err := r.db.Model(&biz.WebHook{}).Scopes(ownedBy(owner)).Order("id desc").Count(&total).Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&webhooks).Error
 	return webhooks, total, err
 }
 
@@ -71,6 +71,7 @@ func (r *webhookRepo) Create(req *request.WebHookCreate) (*biz.WebHook, error) {
 		Raw:    req.Raw,
 		User:   req.User,
 		Status: true,
+		UserID: req.UserID,
 	}
 
 	if err := r.db.Create(webhook).Error; err != nil {
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/leonelquinteros/gotext"
@@ -26,12 +27,21 @@ import (
 	"github.com/acepanel/panel/pkg/io"
 	"github.com/acepanel/panel/pkg/punycode"
 	"github.com/acepanel/panel/pkg/shell"
-	"github.com/acepanel/panel/pkg/systemctl"
 	"github.com/acepanel/panel/pkg/types"
 	"github.com/acepanel/panel/pkg/webserver"
 	webservertypes "github.com/acepanel/panel/pkg/webserver/types"
 )
 
+// websiteLocks 按网站名称串行化配置修改，避免用户编辑与健康检查等后台任务互相覆盖
+var websiteLocks sync.Map
+
+// lockConfig 锁定网站配置，返回解锁函数
+func lockConfig(name string) func() {
+	mu, _ := websiteLocks.LoadOrStore(name, new(sync.Mutex))
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
 type websiteRepo struct {
 	t              *gotext.Locale
 	db             *gorm.DB
@@ -201,15 +211,16 @@ func (r *websiteRepo) GetByName(name string) (*types.WebsiteSetting, error) {
 	return r.Get(website.ID)
 }
 
-func (r *websiteRepo) List(typ string, page, limit uint) ([]*biz.Website, int64, error) {
+func (r *websiteRepo) List(owner uint, typ string, page, limit uint) ([]*biz.Website, int64, error) {
 	websites := make([]*biz.Website, 0)
 	var total int64
 
-	if err := r.db.Model(&biz.Website{}).Count(&total).Error; err != nil {
+	if err := r.db.Model(&biz.Website{}).Scopes(ownedBy(owner)).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	query := r.db
+	query := r.db.Scopes(ownedBy(owner))
+
 	if typ != "all" {
 		query = query.Where("type = ?", typ)
 	}
@@ -232,7 +243,34 @@ func (r *websiteRepo) List(typ string, page, limit uint) ([]*biz.Website, int64,
 	return websites, total, nil
 }
 
-func (r *websiteRepo) Create(req *request.WebsiteCreate) (*biz.Website, error) {
+func (r *websiteRepo) Paths(owner uint) ([]string, error) {
+	var paths []string
+	err := r.db.Model(&biz.Website{}).Scopes(ownedBy(owner)).Pluck("path", &paths).Error
+	return paths, err
+}
+
+func (r *websiteRepo) AccessLogs() (map[string]string, error) {
+	var websites []*biz.Website
+	if err := r.db.Find(&websites).Error; err != nil {
+		return nil, err
+	}
+
+	// 从站点配置中读取访问日志路径，关闭访问日志的网站不统计
+	logs := make(map[string]string, len(websites))
+	for _, website := range websites {
+		vhost, err := r.getVhost(website)
+		if err != nil {
+			continue
+		}
+		if path := vhost.AccessLog(); path != "" && path != "off" {
+			logs[website.Name] = path
+		}
+	}
+
+	return logs, nil
+}
+
+func (r *websiteRepo) Create(ctx context.Context, req *request.WebsiteCreate) (*biz.Website, error) {
 	w := &biz.Website{
 		Name:   req.Name,
 		Type:   biz.WebsiteType(req.Type),
@@ -240,13 +278,22 @@ func (r *websiteRepo) Create(req *request.WebsiteCreate) (*biz.Website, error) {
 		Path:   req.Path,
 		SSL:    false,
 		Remark: req.Remark,
+		UserID: req.UserID,
 	}
+	defer lockConfig(w.Name)()
 
 	vhost, err := r.getVhost(w)
 	if err != nil {
 		return nil, err
 	}
 
+	// 配置快照，出错或校验失败时回滚
+	snapshot, err := r.newSnapshot(w)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = snapshot.Restore() }()
+
 	// 创建配置文件目录
 	if err = os.MkdirAll(filepath.Join(app.Root, "sites", req.Name, "config", "site"), 0644); err != nil {
 		return nil, err
@@ -287,8 +334,15 @@ func (r *websiteRepo) Create(req *request.WebsiteCreate) (*biz.Website, error) {
 		return nil, err
 	}
 	// 404 页面
-	// TODO 需要兼容 Apache
-	if err = vhost.SetConfig("010-error-404.conf", "site", `error_page 404 /404.html;`); err != nil {
+	webServer, err := r.setting.Get(biz.SettingKeyWebserver)
+	if err != nil {
+		return nil, err
+	}
+	errorPage, static, err := webserver.DefaultSiteConfig(webserver.Type(webServer))
+	if err != nil {
+		return nil, err
+	}
+	if err = vhost.SetConfig("010-error-404.conf", "site", errorPage); err != nil {
 		return nil, err
 	}
 
@@ -312,23 +366,7 @@ func (r *websiteRepo) Create(req *request.WebsiteCreate) (*biz.Website, error) {
 		if err = phpVhost.SetConfig("010-rewrite.conf", "site", ""); err != nil {
 			return nil, err
 		}
-		// TODO 需要兼容 Apache
-		if err = phpVhost.SetConfig("010-cache.conf", "site", `# browser cache
-location ~ .*\.(bmp|jpg|jpeg|png|gif|svg|ico|tiff|webp|avif|heif|heic|jxl)$ {
-    expires 30d;
-    access_log /dev/null;
-    error_log /dev/null;
-}
-location ~ .*\.(js|css|ttf|otf|woff|woff2|eot)$ {
-    expires 6h;
-    access_log /dev/null;
-    error_log /dev/null;
-}
-# deny sensitive files
-location ~ ^/(\.user.ini|\.htaccess|\.git|\.svn|\.env) {
-    return 404;
-}
-`); err != nil {
+		if err = phpVhost.SetConfig("010-cache.conf", "site", static); err != nil {
 			return nil, err
 		}
 	}
@@ -407,13 +445,16 @@ location ~ ^/(\.user.ini|\.htaccess|\.git|\.svn|\.env) {
 		_, _ = shell.Execf(`chattr +i '%s'`, userIni)
 	}
 
-	// 创建面板网站
+	// 先创建面板网站，名称冲突等数据库错误时不会生效配置
 	if err = r.db.Create(w).Error; err != nil {
 		return nil, err
 	}
-
-	// 重载 Web 服务器
-	if err = r.reloadWebServer(); err != nil {
+	// 校验配置并重载 Web 服务器，失败时删除已创建的网站记录
+	if err = r.applyConfig(snapshot); err != nil {
+		_ = r.db.Unscoped().Delete(w).Error
+		return nil, err
+	}
+	if err = r.createRevision(ctx, w, "create"); err != nil {
 		return nil, err
 	}
 
@@ -432,6 +473,7 @@ location ~ ^/(\.user.ini|\.htaccess|\.git|\.svn|\.env) {
 			Password:   req.DBPassword,
 			Host:       "localhost",
 			Comment:    fmt.Sprintf("website %s", req.Name),
+			UserID:     req.UserID,
 		}); err != nil {
 			return nil, err
 		}
@@ -440,17 +482,25 @@ location ~ ^/(\.user.ini|\.htaccess|\.git|\.svn|\.env) {
 	return w, nil
 }
 
-func (r *websiteRepo) Update(req *request.WebsiteUpdate) error {
+func (r *websiteRepo) Update(ctx context.Context, req *request.WebsiteUpdate) error {
 	website := new(biz.Website)
 	if err := r.db.Where("id", req.ID).First(website).Error; err != nil {
 		return err
 	}
+	defer lockConfig(website.Name)()
 
 	vhost, err := r.getVhost(website)
 	if err != nil {
 		return err
 	}
 
+	// 配置快照，出错或校验失败时回滚
+	snapshot, err := r.newSnapshot(website)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = snapshot.Restore() }()
+
 	// 监听地址
 	if err = vhost.SetListen(req.Listens); err != nil {
 		return err
@@ -568,11 +618,14 @@ func (r *websiteRepo) Update(req *request.WebsiteUpdate) error {
 	if err = vhost.Save(); err != nil {
 		return err
 	}
-	if err = r.db.Save(website).Error; err != nil {
+	if err = r.applyConfig(snapshot); err != nil {
+		return err
+	}
+	if err = r.createRevision(ctx, website, "update"); err != nil {
 		return err
 	}
 
-	return r.reloadWebServer()
+	return r.db.Save(website).Error
 }
 
 func (r *websiteRepo) Delete(req *request.WebsiteDelete) error {
@@ -583,6 +636,7 @@ func (r *websiteRepo) Delete(req *request.WebsiteDelete) error {
 	if website.Cert != nil {
 		return errors.New(r.t.Get("website %s has bound certificates, please delete the certificate first", website.Name))
 	}
+	defer lockConfig(website.Name)()
 
 	_ = io.Remove(filepath.Join(app.Root, "sites", website.Name))
 
@@ -600,6 +654,12 @@ func (r *websiteRepo) Delete(req *request.WebsiteDelete) error {
 		}
 	}
 
+	if err := r.db.Where("website_id = ?", website.ID).Delete(&biz.WebsiteRevision{}).Error; err != nil {
+		return err
+	}
+	if err := r.db.Where("website_id = ?", website.ID).Delete(&biz.WebsiteUpstreamStatus{}).Error; err != nil {
+		return err
+	}
 	if err := r.db.Delete(website).Error; err != nil {
 		return err
 	}
@@ -627,14 +687,22 @@ func (r *websiteRepo) UpdateRemark(id uint, remark string) error {
 	return r.db.Save(website).Error
 }
 
-func (r *websiteRepo) ResetConfig(id uint) error {
+func (r *websiteRepo) ResetConfig(ctx context.Context, id uint) error {
 	website := new(biz.Website)
 	if err := r.db.Where("id", id).First(&website).Error; err != nil {
 		return err
 	}
+	defer lockConfig(website.Name)()
+
+	// 配置快照，出错或校验失败时回滚
+	snapshot, err := r.newSnapshot(website)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = snapshot.Restore() }()
 
 	// 清空配置
-	_, err := shell.Execf(`rm -rf '%s'`, fmt.Sprintf("%s/sites/%s/config/*", app.Root, website.Name))
+	_, err = shell.Execf(`rm -rf '%s'`, fmt.Sprintf("%s/sites/%s/config/*", app.Root, website.Name))
 	if err != nil {
 		return err
 	}
@@ -678,38 +746,51 @@ func (r *websiteRepo) ResetConfig(id uint) error {
 		}
 	}
 
-	website.Status = true
-	website.SSL = false
-	if err = r.db.Save(website).Error; err != nil {
+	if err = r.applyConfig(snapshot); err != nil {
+		return err
+	}
+	if err = r.createRevision(ctx, website, "reset"); err != nil {
 		return err
 	}
 
-	return r.reloadWebServer()
+	website.Status = true
+	website.SSL = false
+	return r.db.Save(website).Error
 }
 
-func (r *websiteRepo) UpdateStatus(id uint, status bool) error {
+func (r *websiteRepo) UpdateStatus(ctx context.Context, id uint, status bool) error {
 	website := new(biz.Website)
 	if err := r.db.Where("id", id).First(&website).Error; err != nil {
 		return err
 	}
+	defer lockConfig(website.Name)()
 
 	vhost, err := r.getVhost(website)
 	if err != nil {
 		return err
 	}
+
+	snapshot, err := r.newSnapshot(website)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = snapshot.Restore() }()
+
 	if err = vhost.SetEnable(status); err != nil {
 		return err
 	}
 	if err = vhost.Save(); err != nil {
 		return err
 	}
-
-	website.Status = status
-	if err = r.db.Save(website).Error; err != nil {
+	if err = r.applyConfig(snapshot); err != nil {
+		return err
+	}
+	if err = r.createRevision(ctx, website, "status"); err != nil {
 		return err
 	}
 
-	return r.reloadWebServer()
+	website.Status = status
+	return r.db.Save(website).Error
 }
 
 func (r *websiteRepo) UpdateCert(req *request.WebsiteUpdateCert) error {
@@ -724,21 +805,28 @@ func (r *websiteRepo) UpdateCert(req *request.WebsiteUpdateCert) error {
 	if _, err := cert.ParseKey(req.Key); err != nil {
 		return errors.New(r.t.Get("failed to parse private key: %v", err))
 	}
+	defer lockConfig(website.Name)()
+
+	snapshot, err := r.newSnapshot(website)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = snapshot.Restore() }()
 
 	certPath := filepath.Join(app.Root, "sites", website.Name, "config", "fullchain.pem")
 	keyPath := filepath.Join(app.Root, "sites", website.Name, "config", "privatekey.key")
-	if err := io.Write(certPath, req.Cert, 0644); err != nil {
+	if err = io.Write(certPath, req.Cert, 0644); err != nil {
 		return err
 	}
-	if err := io.Write(keyPath, req.Key, 0644); err != nil {
+	if err = io.Write(keyPath, req.Key, 0644); err != nil {
 		return err
 	}
 
 	if website.SSL {
-		return r.reloadWebServer()
+		return r.applyConfig(snapshot)
 	}
 
-	return nil
+	return snapshot.Discard()
 }
 
 func (r *websiteRepo) ObtainCert(ctx context.Context, id uint) error {
@@ -817,25 +905,36 @@ func (r *websiteRepo) getPHPVersion(name string) uint {
 	return vhost.PHP()
 }
 
-func (r *websiteRepo) reloadWebServer() error {
+// applyConfig 测试 Web 服务器配置，通过后重载，未通过时回滚到快照并返回解析后的错误
+func (r *websiteRepo) applyConfig(snapshot *webserver.Snapshot) error {
+	if err := r.testWebServer(); err != nil {
+		if rErr := snapshot.Restore(); rErr != nil {
+			return fmt.Errorf("%s: %w", r.t.Get("configuration test failed and rollback failed: %v", rErr), err)
+		}
+		return fmt.Errorf("%s: %w", r.t.Get("configuration test failed, changes have been rolled back"), err)
+	}
+	if err := snapshot.Discard(); err != nil {
+		return err
+	}
+
+	return r.reloadWebServer()
+}
+
+// testWebServer 测试 Web 服务器配置
+func (r *websiteRepo) testWebServer() error {
 	webServer, err := r.setting.Get(biz.SettingKeyWebserver, "unknown")
 	if err != nil {
 		return err
 	}
-	switch webServer {
-	case "nginx":
-		if err = systemctl.Reload("nginx"); err != nil {
-			_, err = shell.Execf("nginx -t")
-			return err
-		}
-	case "apache":
-		if err = systemctl.Reload("httpd"); err != nil {
-			_, err = shell.Execf("apachectl configtest")
-			return err
-		}
-	default:
-		return errors.New(r.t.Get("unsupported web server: %s", webServer))
+
+	return webserver.Test(webserver.Type(webServer))
+}
+
+func (r *websiteRepo) reloadWebServer() error {
+	webServer, err := r.setting.Get(biz.SettingKeyWebserver, "unknown")
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return webserver.Reload(webserver.Type(webServer))
 }
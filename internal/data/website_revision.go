@@ -0,0 +1,252 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cast"
+
+	"github.com/acepanel/panel/internal/app"
+	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/pkg/io"
+	"github.com/acepanel/panel/pkg/webserver"
+)
+
+// websiteRevisionLimit 每个网站保留的配置版本数量，健康检查自动记录的版本单独计数
+const websiteRevisionLimit = 50
+
+// websiteRevisionHealthCheck 健康检查自动标记上游服务器状态时记录的版本
+const websiteRevisionHealthCheck = "health_check"
+
+func (r *websiteRepo) Revisions(id uint, page, limit uint) ([]*biz.WebsiteRevision, int64, error) {
+	revisions := make([]*biz.WebsiteRevision, 0)
+	var total int64
+
+	query := r.db.Model(&biz.WebsiteRevision{}).Where("website_id = ?", id)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("version DESC").Offset(int((page - 1) * limit)).Limit(int(limit)).Find(&revisions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	for _, revision := range revisions {
+		revision.FileNames = slices.Sorted(maps.Keys(revision.Files))
+	}
+
+	return revisions, total, nil
+}
+
+func (r *websiteRepo) DiffRevisions(id, from, to uint) ([]*biz.WebsiteRevisionDiff, error) {
+	fromRevision, err := r.getRevision(id, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := r.getRevision(id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	names := slices.Collect(maps.Keys(fromRevision.Files))
+	for name := range toRevision.Files {
+		if _, ok := fromRevision.Files[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	diffs := make([]*biz.WebsiteRevisionDiff, 0)
+	for _, name := range names {
+		a, b := fromRevision.Files[name], toRevision.Files[name]
+		if a == b {
+			continue
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(a),
+			B:        splitLines(b),
+			FromFile: fmt.Sprintf("v%d/%s", fromRevision.Version, name),
+			ToFile:   fmt.Sprintf("v%d/%s", toRevision.Version, name),
+			Context:  3,
+		})
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, &biz.WebsiteRevisionDiff{File: name, Diff: diff})
+	}
+
+	return diffs, nil
+}
+
+func (r *websiteRepo) RestoreRevision(ctx context.Context, id, revisionID uint) error {
+	website := new(biz.Website)
+	if err := r.db.Where("id", id).First(website).Error; err != nil {
+		return err
+	}
+	revision, err := r.getRevision(id, revisionID)
+	if err != nil {
+		return err
+	}
+	defer lockConfig(website.Name)()
+
+	snapshot, err := r.newSnapshot(website)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = snapshot.Restore() }()
+
+	// 删除该版本中不存在的配置文件，再写入该版本的所有配置文件
+	dir := filepath.Join(app.Root, "sites", website.Name, "config")
+	current, err := readConfigFiles(dir)
+	if err != nil {
+		return err
+	}
+	for name := range current {
+		if _, ok := revision.Files[name]; !ok {
+			if err = os.Remove(filepath.Join(dir, name)); err != nil {
+				return err
+			}
+		}
+	}
+	for name, content := range revision.Files {
+		if !filepath.IsLocal(name) {
+			return errors.New(r.t.Get("invalid config file path in revision: %s", name))
+		}
+		if err = io.Write(filepath.Join(dir, name), content, 0644); err != nil {
+			return err
+		}
+	}
+
+	if err = r.applyConfig(snapshot); err != nil {
+		return err
+	}
+	if err = r.createRevision(ctx, website, "restore"); err != nil {
+		return err
+	}
+
+	// 同步启用状态和 SSL 状态
+	vhost, err := r.getVhost(website)
+	if err != nil {
+		return err
+	}
+	website.Status = vhost.Enable()
+	website.SSL = vhost.SSL()
+	return r.db.Save(website).Error
+}
+
+func (r *websiteRepo) getRevision(websiteID, id uint) (*biz.WebsiteRevision, error) {
+	revision := new(biz.WebsiteRevision)
+	if err := r.db.Where("id = ? AND website_id = ?", id, websiteID).First(revision).Error; err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+// newSnapshot 为网站配置目录创建快照
+// 配置与最新版本不一致时（首次记录或在面板外修改过），先将当前配置记录为新版本
+func (r *websiteRepo) newSnapshot(website *biz.Website) (*webserver.Snapshot, error) {
+	if website.ID != 0 {
+		if err := r.createRevision(context.Background(), website, "sync"); err != nil {
+			return nil, err
+		}
+	}
+
+	return webserver.NewSnapshot(filepath.Join(app.Root, "sites", website.Name, "config"))
+}
+
+// createRevision 将网站当前的配置记录为新版本，与最新版本相同时不记录
+func (r *websiteRepo) createRevision(ctx context.Context, website *biz.Website, action string) error {
+	files, err := readConfigFiles(filepath.Join(app.Root, "sites", website.Name, "config"))
+	if err != nil {
+		return err
+	}
+
+	latest := new(biz.WebsiteRevision)
+	if err = r.db.Where("website_id = ?", website.ID).Order("version DESC").Limit(1).Find(latest).Error; err != nil {
+		return err
+	}
+	if latest.ID != 0 && maps.Equal(latest.Files, files) {
+		return nil
+	}
+
+	revision := &biz.WebsiteRevision{
+		WebsiteID: website.ID,
+		Version:   latest.Version + 1,
+		Action:    action,
+		UserID:    cast.ToUint(ctx.Value("user_id")),
+		Files:     files,
+	}
+	if revision.UserID != 0 {
+		user := new(biz.User)
+		if err = r.db.Where("id", revision.UserID).First(user).Error; err == nil {
+			revision.Author = user.Username
+		}
+	}
+	if err = r.db.Create(revision).Error; err != nil {
+		return err
+	}
+
+	// 清理超出保留数量的旧版本，自动记录的版本不会挤掉用户修改的历史
+	query := r.db.Model(&biz.WebsiteRevision{}).Where("website_id = ?", website.ID)
+	if action == websiteRevisionHealthCheck {
+		query = query.Where("action = ?", websiteRevisionHealthCheck)
+	} else {
+		query = query.Where("action <> ?", websiteRevisionHealthCheck)
+	}
+	var ids []uint
+	if err = query.Order("version DESC").Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+	if len(ids) > websiteRevisionLimit {
+		return r.db.Where("id IN ?", ids[websiteRevisionLimit:]).Delete(&biz.WebsiteRevision{}).Error
+	}
+
+	return nil
+}
+
+// readConfigFiles 读取配置目录下所有的 .conf 文件，证书和私钥不纳入版本记录
+func readConfigFiles(dir string) (map[string]string, error) {
+	files := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() || !strings.HasSuffix(d.Name(), ".conf") {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(content)
+		return nil
+	})
+
+	return files, err
+}
+
+// splitLines 按行拆分文件内容，与 difflib.SplitLines 不同的是不会在末尾多出一个空行
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+
+	lines[len(lines)-1] += "\n"
+	return lines
+}
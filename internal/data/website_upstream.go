@@ -0,0 +1,203 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/pkg/webserver"
+	webservertypes "github.com/acepanel/panel/pkg/webserver/types"
+)
+
+func (r *websiteRepo) UpstreamStatuses(id uint) ([]*biz.WebsiteUpstreamStatus, error) {
+	statuses := make([]*biz.WebsiteUpstreamStatus, 0)
+	if err := r.db.Where("website_id = ?", id).Order("upstream ASC, server ASC").Find(&statuses).Error; err != nil {
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+func (r *websiteRepo) CheckUpstreams() error {
+	var websites []*biz.Website
+	if err := r.db.Where("type = ?", biz.WebsiteTypeProxy).Find(&websites).Error; err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, website := range websites {
+		if err := r.checkUpstreams(website); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", website.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkUpstreams 探测网站的上游服务器并更新健康状态
+// 开启 MarkDown 时将不可用的服务器在配置中标记为 down，恢复后取消，只取消由健康检查标记的服务器
+func (r *websiteRepo) checkUpstreams(website *biz.Website) error {
+	vhost, err := r.getVhost(website)
+	if err != nil {
+		return err
+	}
+	proxyVhost, ok := vhost.(webservertypes.ProxyVhost)
+	if !ok {
+		return nil
+	}
+
+	var existing []*biz.WebsiteUpstreamStatus
+	if err = r.db.Where("website_id = ?", website.ID).Find(&existing).Error; err != nil {
+		return err
+	}
+	previous := make(map[[2]string]*biz.WebsiteUpstreamStatus)
+	for _, status := range existing {
+		previous[[2]string{status.Upstream, status.Server}] = status
+	}
+
+	upstreams := proxyVhost.Upstreams()
+
+	var statuses, toggled []*biz.WebsiteUpstreamStatus
+	for _, name := range slices.Sorted(maps.Keys(upstreams)) {
+		check := upstreams[name].HealthCheck
+		servers := slices.Sorted(maps.Keys(upstreams[name].Servers))
+		if check == nil {
+			// 关闭健康检查后取消之前标记的 down
+			for _, server := range servers {
+				if status := previous[[2]string{name, server}]; status != nil && status.MarkedDown {
+					status.MarkedDown = false
+					statuses = append(statuses, status)
+					toggled = append(toggled, status)
+				}
+			}
+			continue
+		}
+
+		// 并发探测同一上游的所有服务器
+		errs := make([]error, len(servers))
+		latencies := make([]time.Duration, len(servers))
+		var wg sync.WaitGroup
+		for i, server := range servers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				latencies[i], errs[i] = webserver.CheckUpstreamServer(server, *check)
+			}()
+		}
+		wg.Wait()
+
+		for i, server := range servers {
+			status := previous[[2]string{name, server}]
+			if status == nil {
+				status = &biz.WebsiteUpstreamStatus{WebsiteID: website.ID, Upstream: name, Server: server, Healthy: true}
+			}
+			status.CheckedAt = time.Now()
+			status.Latency = latencies[i].Milliseconds()
+			if errs[i] != nil {
+				status.Error = errs[i].Error()
+				status.Fails++
+				status.Passes = 0
+				if status.Fails >= max(check.Fails, 1) {
+					status.Healthy = false
+				}
+			} else {
+				status.Error = ""
+				status.Passes++
+				status.Fails = 0
+				if status.Passes >= max(check.Passes, 1) {
+					status.Healthy = true
+				}
+			}
+			statuses = append(statuses, status)
+
+			down := check.MarkDown && !status.Healthy
+			if down && !upstreams[name].ServerOptions[server].Down || !down && status.MarkedDown {
+				status.MarkedDown = down
+				toggled = append(toggled, status)
+			}
+		}
+	}
+
+	if len(toggled) > 0 {
+		if err = r.applyUpstreams(website, toggled); err != nil {
+			// 配置未生效，恢复标记状态，下次探测时重试
+			for _, status := range toggled {
+				status.MarkedDown = !status.MarkedDown
+			}
+		}
+	}
+
+	// 保存状态，清理不再检查的上游服务器
+	ids := make([]uint, 0, len(statuses))
+	for _, status := range statuses {
+		if dbErr := r.db.Save(status).Error; dbErr != nil {
+			return dbErr
+		}
+		ids = append(ids, status.ID)
+	}
+	query := r.db.Where("website_id = ?", website.ID)
+	if len(ids) > 0 {
+		query = query.Where("id NOT IN ?", ids)
+	}
+	if dbErr := query.Delete(&biz.WebsiteUpstreamStatus{}).Error; dbErr != nil {
+		return dbErr
+	}
+
+	return err
+}
+
+// applyUpstreams 将健康检查的标记写入上游配置，校验通过后重载 Web 服务器
+// 探测期间配置可能已被用户修改，因此加锁后重新读取配置，只修改仍存在的服务器
+func (r *websiteRepo) applyUpstreams(website *biz.Website, toggled []*biz.WebsiteUpstreamStatus) error {
+	defer lockConfig(website.Name)()
+
+	vhost, err := r.getVhost(website)
+	if err != nil {
+		return err
+	}
+	proxyVhost, ok := vhost.(webservertypes.ProxyVhost)
+	if !ok {
+		return errors.New("website is no longer a proxy website")
+	}
+
+	upstreams := proxyVhost.Upstreams()
+	for _, status := range toggled {
+		upstream, ok := upstreams[status.Upstream]
+		if !ok {
+			continue
+		}
+		if _, ok = upstream.Servers[status.Server]; !ok {
+			continue
+		}
+		if upstream.ServerOptions == nil {
+			upstream.ServerOptions = make(map[string]webservertypes.UpstreamServer)
+		}
+		options := upstream.ServerOptions[status.Server]
+		options.Down = status.MarkedDown
+		upstream.ServerOptions[status.Server] = options
+		upstreams[status.Upstream] = upstream
+	}
+
+	snapshot, err := r.newSnapshot(website)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = snapshot.Restore() }()
+
+	if err = proxyVhost.SetUpstreams(upstreams); err != nil {
+		return err
+	}
+	if err = vhost.Save(); err != nil {
+		return err
+	}
+	if err = r.applyConfig(snapshot); err != nil {
+		return err
+	}
+
+	return r.createRevision(context.Background(), website, websiteRevisionHealthCheck)
+}
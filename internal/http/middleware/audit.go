@@ -0,0 +1,190 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/spf13/cast"
+
+	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/pkg/config"
+	"github.com/acepanel/panel/pkg/redact"
+)
+
+const (
+	auditBodyLimit     = 1 << 20 // 仅读取 1MB 以内的请求体
+	auditSummaryLimit  = 2048
+	auditResponseLimit = 4096
+)
+
+// auditTargets 请求体中用于标识操作对象的字段
+var auditTargets = []string{"id", "name", "username", "slug", "path", "domain"}
+
+// Audit 记录 API 的所有写操作，需在 MustLogin 之后使用
+func Audit(conf *config.Config, log *slog.Logger, audit biz.AuditRepo) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, "/api") || r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body := auditBody(r)
+			response := &limitedBuffer{limit: auditResponseLimit}
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(response)
+
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			record := &biz.Audit{
+				Source:  biz.AuditSourceAPI,
+				UserID:  cast.ToUint(r.Context().Value("user_id")),
+				TokenID: cast.ToUint(r.Context().Value("user_token_id")),
+				IP:      ClientIP(conf, r),
+				Method:  r.Method,
+				Route:   r.URL.Path,
+				Path:    r.URL.Path,
+				Status:  status,
+				Success: status < http.StatusBadRequest,
+			}
+
+			var targets []string
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				if pattern := rctx.RoutePattern(); pattern != "" {
+					record.Route = pattern
+				}
+				for i, key := range rctx.URLParams.Keys {
+					if key == "*" || i >= len(rctx.URLParams.Values) {
+						continue
+					}
+					targets = append(targets, key+"="+rctx.URLParams.Values[i])
+				}
+			}
+			summary, fields := auditSummary(r, body)
+			for _, key := range auditTargets {
+				if v, ok := fields[key]; ok && !slices.Contains(targets, key+"="+v) {
+					targets = append(targets, key+"="+v)
+				}
+			}
+			record.Target = redact.Truncate(strings.Join(targets, " "), 255)
+			record.Summary = redact.Truncate(summary, auditSummaryLimit)
+			if !record.Success {
+				var resp struct {
+					Msg string `json:"msg"`
+				}
+				if err := json.Unmarshal(response.Bytes(), &resp); err == nil {
+					record.Message = redact.Truncate(resp.Msg, 1024)
+				}
+			}
+
+			if err := audit.Create(record); err != nil {
+				log.Warn("[Audit] failed to create audit record", slog.String("route", record.Route), slog.Any("err", err))
+			}
+		})
+	}
+}
+
+// ClientIP 获取请求 IP，优先使用配置的 IP 头
+func ClientIP(conf *config.Config, r *http.Request) string {
+	ip := r.RemoteAddr
+	if conf.HTTP.IPHeader != "" && r.Header.Get(conf.HTTP.IPHeader) != "" {
+		ip = strings.Split(r.Header.Get(conf.HTTP.IPHeader), ",")[0]
+	}
+	ip = strings.TrimSpace(ip)
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		return host
+	}
+
+	return ip
+}
+
+// auditBody 读取 JSON 和表单请求体并放回，文件上传等请求不读取
+func auditBody(r *http.Request) []byte {
+	if r.Body == nil || r.ContentLength > auditBodyLimit {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/json" && mediaType != "application/x-www-form-urlencoded" {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, auditBodyLimit+1))
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+	if err != nil || len(body) > auditBodyLimit {
+		return nil
+	}
+
+	return body
+}
+
+// auditSummary 返回脱敏后的请求参数及其中的顶层字段
+func auditSummary(r *http.Request, body []byte) (string, map[string]string) {
+	fields := make(map[string]string)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch {
+	case len(body) == 0:
+		if mediaType != "" {
+			return "[" + mediaType + "]", fields
+		}
+		return "", fields
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return "", fields
+		}
+		values = redact.Form(values)
+		for _, key := range auditTargets {
+			if v := values.Get(key); v != "" {
+				fields[key] = v
+			}
+		}
+		return values.Encode(), fields
+	default:
+		masked, ok := redact.JSON(body)
+		if !ok {
+			return "", fields
+		}
+		var top map[string]any
+		if err := json.Unmarshal(masked, &top); err == nil {
+			for _, key := range auditTargets {
+				switch v := top[key].(type) {
+				case string:
+					if v != "" {
+						fields[key] = v
+					}
+				case float64:
+					fields[key] = strconv.FormatFloat(v, 'f', -1, 64)
+				}
+			}
+		}
+		return string(masked), fields
+	}
+}
+
+// limitedBuffer 仅保留前 limit 字节，用于读取响应中的错误信息
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remain := b.limit - b.Len(); remain > 0 {
+		b.Buffer.Write(p[:min(len(p), remain)])
+	}
+	return len(p), nil
+}
@@ -112,8 +112,8 @@ func Entrance(t *gotext.Locale, conf *config.Config, session *sessions.Manager)
 				return
 			}
 
-			// 情况四：Webhook 访问，跳过验证
-			if strings.HasPrefix(r.URL.Path, "/webhook/") {
+			// 情况四：Webhook 和 Prometheus 抓取，使用各自的令牌验证
+			if strings.HasPrefix(r.URL.Path, "/webhook/") || r.URL.Path == "/metrics" {
 				next.ServeHTTP(w, r)
 				return
 			}
@@ -20,19 +20,26 @@ import (
 	"github.com/acepanel/panel/internal/app"
 	"github.com/acepanel/panel/internal/biz"
 	"github.com/acepanel/panel/pkg/config"
+	"github.com/acepanel/panel/pkg/metrics"
 )
 
 var ProviderSet = wire.NewSet(NewMiddlewares)
 
 type Middlewares struct {
-	conf      *config.Config
-	log       *slog.Logger
-	session   *sessions.Manager
-	appRepo   biz.AppRepo
-	userToken biz.UserTokenRepo
+	t           *gotext.Locale
+	conf        *config.Config
+	log         *slog.Logger
+	session     *sessions.Manager
+	appRepo     biz.AppRepo
+	userToken   biz.UserTokenRepo
+	role        biz.RoleRepo
+	user        biz.UserRepo
+	audit       biz.AuditRepo
+	userSession biz.UserSessionRepo
+	metrics     *metrics.HTTP
 }
 
-func NewMiddlewares(conf *config.Config, session *sessions.Manager, appRepo biz.AppRepo, userToken biz.UserTokenRepo) *Middlewares {
+func NewMiddlewares(t *gotext.Locale, conf *config.Config, session *sessions.Manager, appRepo biz.AppRepo, userToken biz.UserTokenRepo, role biz.RoleRepo, user biz.UserRepo, audit biz.AuditRepo, userSession biz.UserSessionRepo, metrics *metrics.HTTP) *Middlewares {
 	tjLogger := &timberjack.Logger{
 		Filename:    filepath.Join(app.Root, "panel/storage/logs/http.log"),
 		MaxSize:     10,
@@ -41,11 +48,17 @@ func NewMiddlewares(conf *config.Config, session *sessions.Manager, appRepo biz.
 	}
 
 	return &Middlewares{
-		conf:      conf,
-		log:       slog.New(slog.NewJSONHandler(tjLogger, &slog.HandlerOptions{Level: slog.LevelInfo})),
-		session:   session,
-		appRepo:   appRepo,
-		userToken: userToken,
+		t:           t,
+		conf:        conf,
+		log:         slog.New(slog.NewJSONHandler(tjLogger, &slog.HandlerOptions{Level: slog.LevelInfo})),
+		session:     session,
+		appRepo:     appRepo,
+		userToken:   userToken,
+		role:        role,
+		user:        user,
+		audit:       audit,
+		userSession: userSession,
+		metrics:     metrics,
 	}
 }
 
@@ -63,6 +76,7 @@ func (r *Middlewares) Globals(t *gotext.Locale, mux *chi.Mux) []func(http.Handle
 
 	return []func(http.Handler) http.Handler{
 		middleware.Recoverer,
+		r.metrics.Middleware,
 		httplog.RequestLogger(r.log, &httplog.Options{
 			Level:             slog.LevelInfo,
 			LogRequestHeaders: []string{"User-Agent"},
@@ -80,7 +94,24 @@ func (r *Middlewares) Globals(t *gotext.Locale, mux *chi.Mux) []func(http.Handle
 		sessionmiddleware.StartSession(r.session),
 		Status(t),
 		Entrance(t, r.conf, r.session),
-		MustLogin(t, r.conf, r.session, r.userToken),
+		MustLogin(t, r.conf, r.session, r.userToken, r.userSession),
+		Owner(r.user),
+		Audit(r.conf, r.log, r.audit),
 		MustInstall(t, r.appRepo),
 	}
 }
+
+// Permission 路由组权限中间件
+func (r *Middlewares) Permission(group string) func(http.Handler) http.Handler {
+	return MustPermission(r.t, r.role, group)
+}
+
+// PermissionOrSelf 路由组权限中间件，用户修改自己的账号时不需要权限，用于带 {id} 参数的路由
+func (r *Middlewares) PermissionOrSelf(group string) func(http.Handler) http.Handler {
+	return MustPermissionOrSelf(r.t, r.role, group)
+}
+
+// Own 资源归属中间件，用于带 {id} 参数的路由
+func (r *Middlewares) Own(table string) func(http.Handler) http.Handler {
+	return MustOwn(r.t, r.user, table)
+}
@@ -19,7 +19,7 @@ import (
 )
 
 // MustLogin 确保已登录
-func MustLogin(t *gotext.Locale, conf *config.Config, session *sessions.Manager, userToken biz.UserTokenRepo) func(next http.Handler) http.Handler {
+func MustLogin(t *gotext.Locale, conf *config.Config, session *sessions.Manager, userToken biz.UserTokenRepo, userSession biz.UserSessionRepo) func(next http.Handler) http.Handler {
 	// 白名单
 	whiteList := []string{
 		"/api/user/key",
@@ -27,6 +27,11 @@ func MustLogin(t *gotext.Locale, conf *config.Config, session *sessions.Manager,
 		"/api/user/logout",
 		"/api/user/is_login",
 		"/api/user/is_2fa",
+		"/api/user/passkey/options",
+		"/api/user/passkey/login",
+		"/api/user/oidc",
+		"/api/user/oidc/login",
+		"/api/user/oidc/callback",
 		"/api/home/panel",
 	}
 	return func(next http.Handler) http.Handler {
@@ -51,10 +56,13 @@ func MustLogin(t *gotext.Locale, conf *config.Config, session *sessions.Manager,
 					return
 				}
 				// API 请求验证
-				if userID, err = userToken.ValidateReq(r); err != nil {
+				token, err := userToken.ValidateReq(r)
+				if err != nil {
 					Abort(w, http.StatusUnauthorized, "%v", err)
 					return
 				}
+				userID = token.UserID
+				r = r.WithContext(context.WithValue(r.Context(), "user_token_id", token.ID)) // nolint:staticcheck
 			} else {
 				if sess.Missing("user_id") {
 					Abort(w, http.StatusUnauthorized, t.Get("session expired, please login again"))
@@ -83,6 +91,26 @@ func MustLogin(t *gotext.Locale, conf *config.Config, session *sessions.Manager,
 				}
 
 				userID = cast.ToUint(sess.Get("user_id"))
+				// 升级前登录的会话没有索引，补建索引避免升级后所有用户被迫重新登录
+				if !cast.ToBool(sess.Get("indexed")) {
+					if err = userSession.Create(userID, sess.GetID(), ClientIP(conf, r), r.UserAgent()); err != nil {
+						Abort(w, http.StatusInternalServerError, "%v", err)
+						return
+					}
+					sess.Put("indexed", true)
+				}
+				// 会话被吊销后索引不存在，即使会话数据仍在也需重新登录
+				valid, err := userSession.Validate(userID, sess.GetID(), ClientIP(conf, r))
+				if err != nil {
+					Abort(w, http.StatusInternalServerError, "%v", err)
+					return
+				}
+				if !valid {
+					sess.Forget("user_id")
+					Abort(w, http.StatusUnauthorized, t.Get("session has been revoked, please login again"))
+					return
+				}
+
 				refreshAt := cast.ToInt64(sess.Get("refresh_at")) // 上次刷新的时间戳
 				// 距离上次刷新时间超过 10 分钟刷新 Cookie 有效期
 				if time.Now().Unix()-refreshAt > 600 {
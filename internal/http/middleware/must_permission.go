@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leonelquinteros/gotext"
+	"github.com/spf13/cast"
+
+	"github.com/acepanel/panel/internal/biz"
+)
+
+// MustPermission 确保当前用户及令牌拥有路由组权限，需在 MustLogin 之后使用
+func MustPermission(t *gotext.Locale, role biz.RoleRepo, group string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID := cast.ToUint(r.Context().Value("user_id"))
+			tokenID := cast.ToUint(r.Context().Value("user_token_id"))
+			// 未登录只可能是 MustLogin 白名单中的接口
+			if userID == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			// WebSocket 可执行命令，视为写操作
+			write := r.Method != http.MethodGet && r.Method != http.MethodHead && r.Method != http.MethodOptions || r.Header.Get("Upgrade") != ""
+
+			allowed, err := role.Allowed(userID, tokenID, group, write)
+			if err != nil {
+				Abort(w, http.StatusInternalServerError, "%v", err)
+				return
+			}
+			if !allowed {
+				Abort(w, http.StatusForbidden, t.Get("permission denied: %s", group))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// MustPermissionOrSelf 路由参数 id 为当前用户时放行，用于修改自己的账号信息，否则同 MustPermission
+// 通过 API 令牌访问时不放行，令牌仍需拥有路由组权限
+func MustPermissionOrSelf(t *gotext.Locale, role biz.RoleRepo, group string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		permission := MustPermission(t, role, group)(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID := cast.ToUint(r.Context().Value("user_id"))
+			tokenID := cast.ToUint(r.Context().Value("user_token_id"))
+			if userID != 0 && tokenID == 0 && cast.ToUint(chi.URLParam(r, "id")) == userID {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			permission.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/leonelquinteros/gotext"
+	"github.com/spf13/cast"
+
+	"github.com/acepanel/panel/internal/biz"
+)
+
+// Owner 写入当前用户的资源归属 owner_id，管理员为 0 表示不限制，需在 MustLogin 之后使用
+func Owner(user biz.UserRepo) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID := cast.ToUint(r.Context().Value("user_id"))
+			if userID == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			u, err := user.Get(userID)
+			if err != nil {
+				Abort(w, http.StatusUnauthorized, "%v", err)
+				return
+			}
+			if u.RoleID != 0 {
+				r = r.WithContext(context.WithValue(r.Context(), "owner_id", u.ID)) // nolint:staticcheck
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// MustOwn 确保路由参数 id 对应的资源属于当前用户
+func MustOwn(t *gotext.Locale, user biz.UserRepo, table string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			owner := cast.ToUint(r.Context().Value("owner_id"))
+			if owner == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			owned, err := user.Owns(owner, table, cast.ToUint(chi.URLParam(r, "id")))
+			if err != nil {
+				Abort(w, http.StatusInternalServerError, "%v", err)
+				return
+			}
+			if !owned {
+				Abort(w, http.StatusForbidden, t.Get("permission denied: %s", table))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
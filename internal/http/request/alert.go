@@ -0,0 +1,29 @@
+package request
+
+type AlertRuleCreate struct {
+	Name       string  `json:"name" validate:"required|notExists:alert_rules,name"`
+	Metric     string  `json:"metric" validate:"required|in:cpu,memory,swap,load1,load5,load15,disk,service"`
+	Target     string  `json:"target" validate:"requiredIf:Metric,disk,service"`
+	Operator   string  `json:"operator" validate:"required|in:gt,lt"`
+	Threshold  float64 `json:"threshold" validate:"min:0"`
+	Hysteresis float64 `json:"hysteresis" validate:"min:0"`
+	Duration   uint    `json:"duration" validate:"max:1440"`
+	Enabled    bool    `json:"enabled"`
+}
+
+type AlertRuleUpdate struct {
+	ID         uint    `uri:"id" validate:"required|exists:alert_rules,id"`
+	Name       string  `json:"name" validate:"required"`
+	Metric     string  `json:"metric" validate:"required|in:cpu,memory,swap,load1,load5,load15,disk,service"`
+	Target     string  `json:"target" validate:"requiredIf:Metric,disk,service"`
+	Operator   string  `json:"operator" validate:"required|in:gt,lt"`
+	Threshold  float64 `json:"threshold" validate:"min:0"`
+	Hysteresis float64 `json:"hysteresis" validate:"min:0"`
+	Duration   uint    `json:"duration" validate:"max:1440"`
+	Enabled    bool    `json:"enabled"`
+}
+
+type AlertList struct {
+	Status string `json:"status" form:"status" query:"status" validate:"in:firing,resolved"`
+	Paginate
+}
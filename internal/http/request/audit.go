@@ -0,0 +1,38 @@
+package request
+
+import "net/http"
+
+type AuditList struct {
+	Source  string `query:"source" validate:"in:api,cli"`
+	UserID  uint   `query:"user_id"`
+	IP      string `query:"ip"`
+	Route   string `query:"route"`   // 模糊匹配路由或命令
+	Keyword string `query:"keyword"` // 模糊匹配操作对象、参数和失败原因
+	Success string `query:"success" validate:"in:true,false"`
+	Start   string `query:"start" validate:"date"` // 开始日期，如 2026-01-01
+	End     string `query:"end" validate:"date"`   // 结束日期，包含当天
+	Page    uint   `query:"page" validate:"min:1"`
+	Limit   uint   `query:"limit" validate:"min:1|max:10000"`
+}
+
+func (r *AuditList) Prepare(_ *http.Request) error {
+	if r.Page == 0 {
+		r.Page = 1
+	}
+	if r.Limit == 0 {
+		r.Limit = 10
+	}
+	return nil
+}
+
+type AuditExport struct {
+	Source  string `query:"source" validate:"in:api,cli"`
+	UserID  uint   `query:"user_id"`
+	IP      string `query:"ip"`
+	Route   string `query:"route"`
+	Keyword string `query:"keyword"`
+	Success string `query:"success" validate:"in:true,false"`
+	Start   string `query:"start" validate:"date"`
+	End     string `query:"end" validate:"date"`
+	Format  string `query:"format" validate:"in:csv,json"` // 默认 csv
+}
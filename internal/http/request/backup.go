@@ -1,15 +1,21 @@
 package request
 
-import "mime/multipart"
+import (
+	"mime/multipart"
+
+	"github.com/acepanel/panel/pkg/storage"
+)
 
 type BackupList struct {
-	Type string `uri:"type" form:"type" validate:"required|in:path,website,mysql,postgres,redis,panel"`
+	Type    string `uri:"type" form:"type" validate:"required|in:path,website,mysql,postgres,redis,panel,website_snapshot,mysql_binlog"`
+	Storage uint   `json:"storage" form:"storage" query:"storage"`
 }
 
 type BackupCreate struct {
-	Type   string `uri:"type" form:"type" validate:"required|in:website,mysql,postgres,redis,panel"`
-	Target string `json:"target" form:"target" validate:"required|regex:^[a-zA-Z0-9_-]+$"`
-	Path   string `json:"path" form:"path"`
+	Type    string `uri:"type" form:"type" validate:"required|in:website,mysql,postgres,redis,panel,website_snapshot"`
+	Target  string `json:"target" form:"target" validate:"required|regex:^[a-zA-Z0-9_-]+$"`
+	Path    string `json:"path" form:"path"`
+	Storage uint   `json:"storage" form:"storage"`
 }
 
 type BackupUpload struct {
@@ -18,12 +24,53 @@ type BackupUpload struct {
 }
 
 type BackupFile struct {
-	Type string `uri:"type" form:"type" validate:"required|in:website,mysql,postgres,redis,panel"`
-	File string `json:"file" form:"file" validate:"required"`
+	Type    string `uri:"type" form:"type" validate:"required|in:website,mysql,postgres,redis,panel,website_snapshot"`
+	File    string `json:"file" form:"file" validate:"required"`
+	Storage uint   `json:"storage" form:"storage"`
 }
 
 type BackupRestore struct {
-	Type   string `uri:"type" form:"type" validate:"required|in:website,mysql,postgres,redis,panel"`
+	Type    string `uri:"type" form:"type" validate:"required|in:website,mysql,postgres,redis,panel,website_snapshot"`
+	File    string `json:"file" form:"file" validate:"required"`
+	Target  string `json:"target" form:"target" validate:"required|regex:^[a-zA-Z0-9_-]+$"`
+	Storage uint   `json:"storage" form:"storage"`
+	Time    string `json:"time" form:"time"` // MySQL 时间点恢复，格式 2006-01-02 15:04:05
+	GTID    string `json:"gtid" form:"gtid"` // MySQL 时间点恢复到指定 GTID
+}
+
+type BackupSwapPoint struct {
+	Type   string `uri:"type" form:"type" validate:"required|in:mysql"`
+	Target string `json:"target" form:"target" validate:"required|regex:^[a-zA-Z0-9_-]+$"`
+}
+
+type BackupSnapshotFiles struct {
+	ID string `uri:"id" validate:"required"`
+}
+
+type BackupSnapshotRestoreFile struct {
+	ID     string `uri:"id" validate:"required"`
 	File   string `json:"file" form:"file" validate:"required"`
 	Target string `json:"target" form:"target" validate:"required|regex:^[a-zA-Z0-9_-]+$"`
 }
+
+type BackupSnapshotClear struct {
+	Target  string `json:"target" form:"target" validate:"required|regex:^[a-zA-Z0-9_-]+$"`
+	Daily   int    `json:"daily" form:"daily" validate:"min:0"`
+	Weekly  int    `json:"weekly" form:"weekly" validate:"min:0"`
+	Monthly int    `json:"monthly" form:"monthly" validate:"min:0"`
+}
+
+type BackupStorageCreate struct {
+	Name   string         `json:"name" form:"name" validate:"required|notExists:backup_storages,name"`
+	Type   storage.Type   `json:"type" form:"type" validate:"required|in:s3,sftp,webdav"`
+	Info   storage.Config `json:"info" form:"info"`
+	Remark string         `json:"remark" form:"remark"`
+}
+
+type BackupStorageUpdate struct {
+	ID     uint           `json:"id" form:"id" uri:"id" validate:"required|exists:backup_storages,id"`
+	Name   string         `json:"name" form:"name" validate:"required"`
+	Type   storage.Type   `json:"type" form:"type" validate:"required|in:s3,sftp,webdav"`
+	Info   storage.Config `json:"info" form:"info"`
+	Remark string         `json:"remark" form:"remark"`
+}
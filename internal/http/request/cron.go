@@ -1,24 +1,29 @@
 package request
 
 type CronCreate struct {
-	Name       string `form:"name" json:"name" validate:"required|notExists:crons,name"`
-	Type       string `form:"type" json:"type" validate:"required"`
-	Time       string `form:"time" json:"time" validate:"required|cron"`
-	Script     string `form:"script" json:"script"`
-	BackupType string `form:"backup_type" json:"backup_type" validate:"requiredIf:Type,backup"`
-	BackupPath string `form:"backup_path" json:"backup_path"`
-	Target     string `form:"target" json:"target" validate:"requiredIf:Type,backup,cutoff"`
-	Save       int    `form:"save" json:"save" validate:"required"`
+	Name          string `form:"name" json:"name" validate:"required|notExists:crons,name"`
+	Type          string `form:"type" json:"type" validate:"required"`
+	Time          string `form:"time" json:"time" validate:"required|cron"`
+	Script        string `form:"script" json:"script"`
+	BackupType    string `form:"backup_type" json:"backup_type" validate:"requiredIf:Type,backup"`
+	BackupPath    string `form:"backup_path" json:"backup_path"`
+	BackupStorage uint   `form:"backup_storage" json:"backup_storage"`
+	Target        string `form:"target" json:"target" validate:"requiredIf:Type,backup,cutoff"`
+	Save          int    `form:"save" json:"save" validate:"requiredUnless:BackupType,website_snapshot"`
+	KeepDaily     int    `form:"keep_daily" json:"keep_daily"`
+	KeepWeekly    int    `form:"keep_weekly" json:"keep_weekly"`
+	KeepMonthly   int    `form:"keep_monthly" json:"keep_monthly"`
+	UserID        uint   `form:"user_id" json:"user_id" validate:"exists:users,id"` // 所属用户，非管理员创建时为自身
 }
 
 type CronUpdate struct {
-	ID     uint   `form:"id" json:"id" validate:"required|exists:crons,id"`
+	ID     uint   `form:"id" json:"id" uri:"id" validate:"required|exists:crons,id"`
 	Name   string `form:"name" json:"name" validate:"required"`
 	Time   string `form:"time" json:"time" validate:"required|cron"`
 	Script string `form:"script" json:"script" validate:"required"`
 }
 
 type CronStatus struct {
-	ID     uint `form:"id" json:"id" validate:"required|exists:crons,id"`
+	ID     uint `form:"id" json:"id" uri:"id" validate:"required|exists:crons,id"`
 	Status bool `form:"status" json:"status"`
 }
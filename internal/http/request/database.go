@@ -8,6 +8,7 @@ type DatabaseCreate struct {
 	Password   string `form:"password" json:"password" validate:"requiredIf:CreateUser,true"`
 	Host       string `form:"host" json:"host"`
 	Comment    string `form:"comment" json:"comment"`
+	UserID     uint   `form:"user_id" json:"user_id" validate:"exists:users,id"` // 新建数据库用户的所属用户
 }
 
 type DatabaseDelete struct {
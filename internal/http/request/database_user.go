@@ -7,16 +7,17 @@ type DatabaseUserCreate struct {
 	Host       string   `form:"host" json:"host"`
 	Privileges []string `form:"privileges" json:"privileges"`
 	Remark     string   `form:"remark" json:"remark"`
+	UserID     uint     `form:"user_id" json:"user_id" validate:"exists:users,id"` // 所属用户，非管理员创建时为自身
 }
 
 type DatabaseUserUpdate struct {
-	ID         uint     `form:"id" json:"id" validate:"required|exists:database_users,id"`
+	ID         uint     `form:"id" json:"id" uri:"id" validate:"required|exists:database_users,id"`
 	Password   string   `form:"password" json:"password"`
 	Privileges []string `form:"privileges" json:"privileges"`
 	Remark     string   `form:"remark" json:"remark"`
 }
 
 type DatabaseUserUpdateRemark struct {
-	ID     uint   `form:"id" json:"id" validate:"required|exists:database_users,id"`
+	ID     uint   `form:"id" json:"id" uri:"id" validate:"required|exists:database_users,id"`
 	Remark string `form:"remark" json:"remark"`
 }
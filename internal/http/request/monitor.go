@@ -1,11 +1,20 @@
 package request
 
 type MonitorSetting struct {
-	Enabled bool `json:"enabled"`
-	Days    uint `json:"days"`
+	Enabled        bool     `json:"enabled"`
+	Days           uint     `json:"days"`             // 原始数据保留天数，0 为永久保留
+	FiveMinuteDays uint     `json:"five_minute_days"` // 5 分钟汇总数据保留天数
+	HourlyDays     uint     `json:"hourly_days"`      // 1 小时汇总数据保留天数
+	Disks          []string `json:"disks"`            // 记录读写速率的磁盘设备，为空时记录全部
 }
 
 type MonitorList struct {
 	Start int64 `json:"start"`
 	End   int64 `json:"end"`
 }
+
+type MonitorProcessList struct {
+	Time int64  `json:"time" form:"time" query:"time" validate:"required"`
+	Type string `json:"type" form:"type" query:"type" validate:"in:process,service,container"`
+	Sort string `json:"sort" form:"sort" query:"sort" validate:"in:cpu,memory"`
+}
@@ -0,0 +1,36 @@
+package request
+
+import (
+	"net/http"
+
+	"github.com/acepanel/panel/pkg/notify"
+)
+
+type NotifyChannelCreate struct {
+	Name    string         `json:"name" validate:"required|notExists:notify_channels,name"`
+	Type    notify.Type    `json:"type" validate:"required|in:smtp,telegram,webhook"`
+	Config  map[string]any `json:"config" validate:"required"`
+	Events  []string       `json:"events"`
+	Enabled bool           `json:"enabled"`
+}
+
+func (r *NotifyChannelCreate) Rules(_ *http.Request) map[string]string {
+	return map[string]string{
+		"Events.*": "required|in:cert_renew,task_failed,backup_failed,disk_full,alert",
+	}
+}
+
+type NotifyChannelUpdate struct {
+	ID      uint           `uri:"id" validate:"required|exists:notify_channels,id"`
+	Name    string         `json:"name" validate:"required"`
+	Type    notify.Type    `json:"type" validate:"required|in:smtp,telegram,webhook"`
+	Config  map[string]any `json:"config" validate:"required"`
+	Events  []string       `json:"events"`
+	Enabled bool           `json:"enabled"`
+}
+
+func (r *NotifyChannelUpdate) Rules(_ *http.Request) map[string]string {
+	return map[string]string{
+		"Events.*": "required|in:cert_renew,task_failed,backup_failed,disk_full,alert",
+	}
+}
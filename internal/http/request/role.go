@@ -0,0 +1,33 @@
+package request
+
+import "net/http"
+
+type RoleCreate struct {
+	Name        string   `json:"name" validate:"required|notExists:roles,name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions" validate:"required"`
+}
+
+func (r *RoleCreate) Rules(_ *http.Request) map[string]string {
+	return map[string]string{
+		"Permissions.*": "required|regex:^(\\*|[a-z_]+(/[a-z0-9_-]+)?)(:read)?$",
+	}
+}
+
+type RoleUpdate struct {
+	ID          uint     `uri:"id" validate:"required|exists:roles,id"`
+	Name        string   `json:"name" validate:"required"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions" validate:"required"`
+}
+
+func (r *RoleUpdate) Rules(_ *http.Request) map[string]string {
+	return map[string]string{
+		"Permissions.*": "required|regex:^(\\*|[a-z_]+(/[a-z0-9_-]+)?)(:read)?$",
+	}
+}
+
+type UserUpdateRole struct {
+	ID     uint `uri:"id" validate:"required|exists:users,id"`
+	RoleID uint `json:"role_id" validate:"exists:roles,id"`
+}
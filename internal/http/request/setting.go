@@ -3,28 +3,37 @@ package request
 import "net/http"
 
 type SettingPanel struct {
-	Name        string   `json:"name" validate:"required"`
-	Channel     string   `json:"channel" validate:"required|in:stable,beta"`
-	Locale      string   `json:"locale" validate:"required"`
-	Entrance    string   `json:"entrance" validate:"required"`
-	OfflineMode bool     `json:"offline_mode"`
-	AutoUpdate  bool     `json:"auto_update"`
-	TwoFA       bool     `json:"two_fa"`
-	Lifetime    uint     `json:"lifetime" validate:"required|min:10|max:43200"` // 登录超时，单位：分
-	IPHeader    string   `json:"ip_header"`
-	BindDomain  []string `json:"bind_domain"`
-	BindIP      []string `json:"bind_ip"`
-	BindUA      []string `json:"bind_ua"`
-	WebsitePath string   `json:"website_path" validate:"required"`
-	BackupPath  string   `json:"backup_path" validate:"required"`
-	HiddenMenu  []string `json:"hidden_menu"`                      // 隐藏的菜单项
-	CustomLogo  string   `json:"custom_logo" validate:"isFullURL"` // 自定义 Logo URL
-	Port        uint     `json:"port" validate:"required|min:1|max:65535"`
-	HTTPS       bool     `json:"https"`
-	ACME        bool     `json:"acme"`
-	PublicIP    []string `json:"public_ip"`
-	Cert        string   `json:"cert" validate:"required"`
-	Key         string   `json:"key" validate:"required"`
+	Name             string   `json:"name" validate:"required"`
+	Channel          string   `json:"channel" validate:"required|in:stable,beta"`
+	Locale           string   `json:"locale" validate:"required"`
+	Entrance         string   `json:"entrance" validate:"required"`
+	OfflineMode      bool     `json:"offline_mode"`
+	AutoUpdate       bool     `json:"auto_update"`
+	TwoFA            bool     `json:"two_fa"`
+	Lifetime         uint     `json:"lifetime" validate:"required|min:10|max:43200"` // 登录超时，单位：分
+	IPHeader         string   `json:"ip_header"`
+	BindDomain       []string `json:"bind_domain"`
+	BindIP           []string `json:"bind_ip"`
+	BindUA           []string `json:"bind_ua"`
+	WebsitePath      string   `json:"website_path" validate:"required"`
+	BackupPath       string   `json:"backup_path" validate:"required"`
+	BackupEncrypt    string   `json:"backup_encrypt" validate:"in:passphrase,age"` // 备份加密方式，空为不加密
+	BackupPassphrase string   `json:"backup_passphrase" validate:"requiredIf:BackupEncrypt,passphrase"`
+	BackupRecipient  string   `json:"backup_recipient" validate:"requiredIf:BackupEncrypt,age"` // age 公钥
+	BackupIdentity   string   `json:"backup_identity"`                                          // age 私钥，恢复时使用
+	HiddenMenu       []string `json:"hidden_menu"`                                              // 隐藏的菜单项
+	CustomLogo       string   `json:"custom_logo" validate:"isFullURL"`                         // 自定义 Logo URL
+	AuditDays        uint     `json:"audit_days" validate:"max:3650"`                           // 审计日志保留天数，0 为永久保留
+	LoginMaxFailures uint     `json:"login_max_failures" validate:"max:100"`                    // 登录失败锁定阈值，0 为不限制
+	LoginLockMinutes uint     `json:"login_lock_minutes" validate:"max:10080"`                  // 锁定时长，单位：分
+	LoginAutoBan     bool     `json:"login_auto_ban"`                                           // 反复被锁定的 IP 自动加入防火墙封禁
+	MetricsToken     string   `json:"metrics_token" validate:"minLen:16"`                       // Prometheus 抓取令牌，为空时关闭 /metrics
+	Port             uint     `json:"port" validate:"required|min:1|max:65535"`
+	HTTPS            bool     `json:"https"`
+	ACME             bool     `json:"acme"`
+	PublicIP         []string `json:"public_ip"`
+	Cert             string   `json:"cert" validate:"required"`
+	Key              string   `json:"key" validate:"required"`
 }
 
 func (r *SettingPanel) Rules(_ *http.Request) map[string]string {
@@ -39,3 +48,19 @@ type SettingCert struct {
 	Cert string `json:"cert" validate:"required"`
 	Key  string `json:"key" validate:"required"`
 }
+
+type SettingOIDC struct {
+	Enabled        bool     `json:"enabled"`
+	Name           string   `json:"name"` // 登录按钮显示的名称
+	Issuer         string   `json:"issuer" validate:"isFullURL"`
+	ClientID       string   `json:"client_id"`
+	ClientSecret   string   `json:"client_secret"`
+	RedirectURL    string   `json:"redirect_url" validate:"isFullURL"` // 为空时按访问地址生成，反向代理时需填写
+	Scopes         []string `json:"scopes"`
+	UsernameClaim  string   `json:"username_claim"`
+	GroupsClaim    string   `json:"groups_claim"`
+	AllowedGroups  []string `json:"allowed_groups"`
+	AllowedDomains []string `json:"allowed_domains"`
+	AutoCreate     bool     `json:"auto_create"`                        // 自动创建不存在的用户
+	RoleID         uint     `json:"role_id" validate:"exists:roles,id"` // 自动创建用户的角色，开启自动创建时必填
+}
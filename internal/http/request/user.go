@@ -1,14 +1,17 @@
 package request
 
+import "encoding/json"
+
 type UserID struct {
 	ID uint `json:"id" validate:"required|exists:users,id"`
 }
 
 type UserLogin struct {
-	Username  string `json:"username" validate:"required"` // encrypted with RSA-OAEP
-	Password  string `json:"password" validate:"required"` // encrypted with RSA-OAEP
-	SafeLogin bool   `json:"safe_login"`
-	PassCode  string `json:"pass_code"`
+	Username  string          `json:"username" validate:"required"` // encrypted with RSA-OAEP
+	Password  string          `json:"password" validate:"required"` // encrypted with RSA-OAEP
+	SafeLogin bool            `json:"safe_login"`
+	PassCode  string          `json:"pass_code"`
+	Passkey   json.RawMessage `json:"passkey"` // 通行密钥断言，可代替 2FA 验证码
 }
 
 type UserIsTwoFA struct {
@@ -41,3 +44,23 @@ type UserUpdateTwoFA struct {
 	Secret string `json:"secret"`
 	Code   string `json:"code"`
 }
+
+type UserUpdateResources struct {
+	ID   uint   `uri:"id" validate:"required|exists:users,id"`
+	Type string `json:"type" validate:"required|in:website,database_user,cron,webhook"`
+	IDs  []uint `json:"ids"`
+}
+
+type UserPasskeyOptions struct {
+	Username string `query:"username"` // 为空时为免密码登录
+}
+
+type UserPasskeyLogin struct {
+	Credential json.RawMessage `json:"credential"`
+	SafeLogin  bool            `json:"safe_login"`
+}
+
+type UserPasskeyCreate struct {
+	Name       string          `json:"name" validate:"maxLen:50"`
+	Credential json.RawMessage `json:"credential"`
+}
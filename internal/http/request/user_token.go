@@ -8,25 +8,35 @@ type UserTokenList struct {
 }
 
 type UserTokenCreate struct {
-	UserID    uint     `json:"user_id" validate:"required|exists:users,id"`
-	IPs       []string `json:"ips"`
-	ExpiredAt int64    `json:"expired_at" validate:"required"`
+	UserID     uint     `json:"user_id" validate:"required|exists:users,id"`
+	IPs        []string `json:"ips"`
+	RoleID     uint     `json:"role_id" validate:"exists:roles,id"`
+	Scopes     []string `json:"scopes"`
+	WebsiteIDs []uint   `json:"website_ids"`
+	ExpiredAt  int64    `json:"expired_at" validate:"required"`
 }
 
 func (r *UserTokenCreate) Rules(_ *http.Request) map[string]string {
 	return map[string]string{
-		"IPs.*": "required|ipcidr",
+		"IPs.*":        "required|ipcidr",
+		"Scopes.*":     "required|regex:^(\\*|[a-z_]+(/[a-z0-9_-]+)?)(:read)?$",
+		"WebsiteIDs.*": "required|exists:websites,id",
 	}
 }
 
 type UserTokenUpdate struct {
-	ID        uint     `uri:"id"`
-	IPs       []string `json:"ips"`
-	ExpiredAt int64    `json:"expired_at" validate:"required"`
+	ID         uint     `uri:"id"`
+	IPs        []string `json:"ips"`
+	RoleID     uint     `json:"role_id" validate:"exists:roles,id"`
+	Scopes     []string `json:"scopes"`
+	WebsiteIDs []uint   `json:"website_ids"`
+	ExpiredAt  int64    `json:"expired_at" validate:"required"`
 }
 
 func (r *UserTokenUpdate) Rules(_ *http.Request) map[string]string {
 	return map[string]string{
-		"IPs.*": "required|ipcidr",
+		"IPs.*":        "required|ipcidr",
+		"Scopes.*":     "required|regex:^(\\*|[a-z_]+(/[a-z0-9_-]+)?)(:read)?$",
+		"WebsiteIDs.*": "required|exists:websites,id",
 	}
 }
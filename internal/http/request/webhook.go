@@ -5,6 +5,7 @@ type WebHookCreate struct {
 	Script string `json:"script" form:"script" validate:"required"`
 	Raw    bool   `json:"raw" form:"raw"`
 	User   string `json:"user" form:"user"`
+	UserID uint   `json:"user_id" form:"user_id" validate:"exists:users,id"` // 所属用户，非管理员创建时为自身
 }
 
 type WebHookUpdate struct {
@@ -1,6 +1,8 @@
 package request
 
 import (
+	"net/http"
+
 	"github.com/acepanel/panel/pkg/webserver/types"
 )
 
@@ -29,19 +31,20 @@ type WebsiteCreate struct {
 	DBUser     string   `form:"db_user" json:"db_user" validate:"requiredIf:DB,true"`
 	DBPassword string   `form:"db_password" json:"db_password" validate:"requiredIf:DB,true"`
 	Remark     string   `form:"remark" json:"remark"`
+	UserID     uint     `form:"user_id" json:"user_id" validate:"exists:users,id"` // 所属用户，非管理员创建时为自身
 
 	PHP   uint   `form:"php" json:"php" validate:"requiredIf:Type,php"`       // 仅 PHP 网站需要
 	Proxy string `form:"proxy" json:"proxy" validate:"requiredIf:Type,proxy"` // 仅反向代理网站需要
 }
 
 type WebsiteDelete struct {
-	ID   uint `form:"id" json:"id" validate:"required|exists:websites,id"`
+	ID   uint `form:"id" json:"id" uri:"id" validate:"required|exists:websites,id"`
 	Path bool `form:"path" json:"path"`
 	DB   bool `form:"db" json:"db"`
 }
 
 type WebsiteUpdate struct {
-	ID      uint           `form:"id" json:"id" validate:"required|exists:websites,id"`
+	ID      uint           `form:"id" json:"id" uri:"id" validate:"required|exists:websites,id"`
 	Listens []types.Listen `form:"listens" json:"listens" validate:"required|isSlice"`
 	Domains []string       `form:"domains" json:"domains" validate:"required|isSlice"`
 	Path    string         `form:"path" json:"path" validate:"required"` // 网站目录
@@ -68,13 +71,23 @@ type WebsiteUpdate struct {
 	Proxies   []types.Proxy             `json:"proxies"`
 }
 
+func (r *WebsiteUpdate) Prepare(_ *http.Request) error {
+	for _, proxy := range r.Proxies {
+		if err := proxy.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 type WebsiteUpdateRemark struct {
-	ID     uint   `form:"id" json:"id" validate:"required|exists:websites,id"`
+	ID     uint   `form:"id" json:"id" uri:"id" validate:"required|exists:websites,id"`
 	Remark string `form:"remark" json:"remark"`
 }
 
 type WebsiteUpdateStatus struct {
-	ID     uint `json:"id" form:"id" validate:"required|exists:websites,id"`
+	ID     uint `json:"id" form:"id" uri:"id" validate:"required|exists:websites,id"`
 	Status bool `json:"status" form:"status"`
 }
 
@@ -83,3 +96,19 @@ type WebsiteUpdateCert struct {
 	Cert string `json:"cert" validate:"required"`
 	Key  string `json:"key" validate:"required"`
 }
+
+type WebsiteRevisionList struct {
+	ID uint `uri:"id" validate:"required|exists:websites,id"`
+	Paginate
+}
+
+type WebsiteRevisionDiff struct {
+	ID   uint `uri:"id" validate:"required|exists:websites,id"`
+	From uint `query:"from" form:"from" validate:"required"`
+	To   uint `query:"to" form:"to" validate:"required"`
+}
+
+type WebsiteRevisionRestore struct {
+	ID       uint `uri:"id" validate:"required|exists:websites,id"`
+	Revision uint `uri:"revision" validate:"required"`
+}
@@ -0,0 +1,31 @@
+package job
+
+import (
+	"log/slog"
+
+	"github.com/acepanel/panel/internal/app"
+	"github.com/acepanel/panel/internal/biz"
+)
+
+// AuditClear 清理过期审计日志
+type AuditClear struct {
+	log       *slog.Logger
+	auditRepo biz.AuditRepo
+}
+
+func NewAuditClear(log *slog.Logger, audit biz.AuditRepo) *AuditClear {
+	return &AuditClear{
+		log:       log,
+		auditRepo: audit,
+	}
+}
+
+func (r *AuditClear) Run() {
+	if app.Status != app.StatusNormal {
+		return
+	}
+
+	if err := r.auditRepo.ClearExpired(); err != nil {
+		r.log.Warn("[AuditClear] failed to clear expired audit logs", slog.Any("err", err))
+	}
+}
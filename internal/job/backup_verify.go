@@ -0,0 +1,74 @@
+package job
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/leonelquinteros/gotext"
+	"gorm.io/gorm"
+
+	"github.com/acepanel/panel/internal/app"
+	"github.com/acepanel/panel/internal/biz"
+)
+
+// BackupVerify 备份定期校验
+type BackupVerify struct {
+	t          *gotext.Locale
+	db         *gorm.DB
+	log        *slog.Logger
+	backupRepo biz.BackupRepo
+	notifyRepo biz.NotifyRepo
+}
+
+func NewBackupVerify(t *gotext.Locale, db *gorm.DB, log *slog.Logger, backup biz.BackupRepo, notify biz.NotifyRepo) *BackupVerify {
+	return &BackupVerify{
+		t:          t,
+		db:         db,
+		log:        log,
+		backupRepo: backup,
+		notifyRepo: notify,
+	}
+}
+
+func (r *BackupVerify) Run() {
+	if app.Status != app.StatusNormal {
+		return
+	}
+
+	storages := []uint{0}
+	var ids []uint
+	if err := r.db.Model(&biz.BackupStorage{}).Pluck("id", &ids).Error; err != nil {
+		r.log.Warn("[BackupVerify] failed to get backup storages", slog.Any("err", err))
+		return
+	}
+	storages = append(storages, ids...)
+
+	// 未校验或 7 天内未校验的备份重新校验
+	expire := time.Now().AddDate(0, 0, -7)
+	types := []biz.BackupType{biz.BackupTypeWebsite, biz.BackupTypeMySQL, biz.BackupTypePostgres, biz.BackupTypeRedis, biz.BackupTypePanel}
+	for _, storage := range storages {
+		for _, typ := range types {
+			list, err := r.backupRepo.List(typ, storage)
+			if err != nil {
+				r.log.Warn("[BackupVerify] failed to list backups", slog.String("type", string(typ)), slog.Uint64("storage", uint64(storage)), slog.Any("err", err))
+				continue
+			}
+			for _, file := range list {
+				if file.VerifiedAt != nil && file.VerifiedAt.After(expire) {
+					continue
+				}
+				check, err := r.backupRepo.Verify(typ, storage, file.Name)
+				if err != nil {
+					r.log.Warn("[BackupVerify] failed to verify backup", slog.String("type", string(typ)), slog.String("file", file.Name), slog.Any("err", err))
+					continue
+				}
+				if check.Status == biz.BackupCheckStatusFailed {
+					r.log.Warn("[BackupVerify] backup verification failed", slog.String("type", string(typ)), slog.String("file", file.Name), slog.String("reason", check.Message))
+					if err = r.notifyRepo.Notify(biz.NotifyEventBackupFailed, r.t.Get("Backup verification failed"), r.t.Get("Backup %s failed verification: %s", file.Name, check.Message)); err != nil {
+						r.log.Warn("[BackupVerify] failed to send notification", slog.Any("err", err))
+					}
+				}
+			}
+		}
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/leonelquinteros/gotext"
+
 	"github.com/acepanel/panel/internal/http/request"
 	"github.com/acepanel/panel/pkg/config"
 	"github.com/acepanel/panel/pkg/tools"
@@ -18,22 +20,26 @@ import (
 
 // CertRenew 证书续签
 type CertRenew struct {
+	t               *gotext.Locale
 	conf            *config.Config
 	db              *gorm.DB
 	log             *slog.Logger
 	settingRepo     biz.SettingRepo
 	certRepo        biz.CertRepo
 	certAccountRepo biz.CertAccountRepo
+	notifyRepo      biz.NotifyRepo
 }
 
-func NewCertRenew(conf *config.Config, db *gorm.DB, log *slog.Logger, setting biz.SettingRepo, cert biz.CertRepo, certAccount biz.CertAccountRepo) *CertRenew {
+func NewCertRenew(t *gotext.Locale, conf *config.Config, db *gorm.DB, log *slog.Logger, setting biz.SettingRepo, cert biz.CertRepo, certAccount biz.CertAccountRepo, notify biz.NotifyRepo) *CertRenew {
 	return &CertRenew{
+		t:               t,
 		conf:            conf,
 		db:              db,
 		log:             log,
 		settingRepo:     setting,
 		certRepo:        cert,
 		certAccountRepo: certAccount,
+		notifyRepo:      notify,
 	}
 }
 
@@ -68,6 +74,7 @@ func (r *CertRenew) Run() {
 		if time.Now().After(cert.RenewalInfo.SelectedTime) {
 			if _, err := r.certRepo.Renew(cert.ID); err != nil {
 				r.log.Warn("[CertRenew] failed to renew cert", slog.Any("err", err))
+				r.notify(r.t.Get("Failed to renew certificate %v: %v", cert.Domains, err))
 			}
 		}
 	}
@@ -108,6 +115,7 @@ func (r *CertRenew) Run() {
 		crt, key, err := r.certRepo.ObtainPanel(account, ips)
 		if err != nil {
 			r.log.Warn("[CertRenew] failed to obtain ACME cert", slog.Any("err", err))
+			r.notify(r.t.Get("Failed to renew panel certificate: %v", err))
 			return
 		}
 
@@ -122,5 +130,11 @@ func (r *CertRenew) Run() {
 		r.log.Info("[CertRenew] panel cert renewed successfully")
 		tools.RestartPanel()
 	}
+}
 
+// notify 发送证书续签失败通知
+func (r *CertRenew) notify(content string) {
+	if err := r.notifyRepo.Notify(biz.NotifyEventCertRenew, r.t.Get("Certificate renewal failed"), content); err != nil {
+		r.log.Warn("[CertRenew] failed to send notification", slog.Any("err", err))
+	}
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/acepanel/panel/pkg/config"
 	"github.com/google/wire"
+	"github.com/leonelquinteros/gotext"
 	"github.com/robfig/cron/v3"
 	"gorm.io/gorm"
 
@@ -14,6 +15,7 @@ import (
 var ProviderSet = wire.NewSet(NewJobs)
 
 type Jobs struct {
+	t           *gotext.Locale
 	conf        *config.Config
 	db          *gorm.DB
 	log         *slog.Logger
@@ -23,10 +25,19 @@ type Jobs struct {
 	backup      biz.BackupRepo
 	cache       biz.CacheRepo
 	task        biz.TaskRepo
+	audit       biz.AuditRepo
+	notify      biz.NotifyRepo
+	alert       biz.AlertRepo
+	monitor     biz.MonitorRepo
+	app         biz.AppRepo
+	environment biz.EnvironmentRepo
+	container   biz.ContainerRepo
+	website     biz.WebsiteRepo
 }
 
-func NewJobs(conf *config.Config, db *gorm.DB, log *slog.Logger, setting biz.SettingRepo, cert biz.CertRepo, certAccount biz.CertAccountRepo, backup biz.BackupRepo, cache biz.CacheRepo, task biz.TaskRepo) *Jobs {
+func NewJobs(t *gotext.Locale, conf *config.Config, db *gorm.DB, log *slog.Logger, setting biz.SettingRepo, cert biz.CertRepo, certAccount biz.CertAccountRepo, backup biz.BackupRepo, cache biz.CacheRepo, task biz.TaskRepo, audit biz.AuditRepo, notify biz.NotifyRepo, alert biz.AlertRepo, monitor biz.MonitorRepo, appRepo biz.AppRepo, environment biz.EnvironmentRepo, container biz.ContainerRepo, website biz.WebsiteRepo) *Jobs {
 	return &Jobs{
+		t:           t,
 		conf:        conf,
 		db:          db,
 		log:         log,
@@ -36,14 +47,34 @@ func NewJobs(conf *config.Config, db *gorm.DB, log *slog.Logger, setting biz.Set
 		backup:      backup,
 		cache:       cache,
 		task:        task,
+		audit:       audit,
+		notify:      notify,
+		alert:       alert,
+		monitor:     monitor,
+		app:         appRepo,
+		environment: environment,
+		container:   container,
+		website:     website,
 	}
 }
 
 func (r *Jobs) Register(c *cron.Cron) error {
-	if _, err := c.AddJob("* * * * *", NewMonitoring(r.db, r.log, r.setting)); err != nil {
+	if _, err := c.AddJob("* * * * *", NewMonitoring(r.t, r.db, r.log, r.setting, r.monitor, r.notify, r.alert, r.app, r.environment, r.container)); err != nil {
 		return err
 	}
-	if _, err := c.AddJob("0 4 * * *", NewCertRenew(r.conf, r.db, r.log, r.setting, r.cert, r.certAccount)); err != nil {
+	if _, err := c.AddJob("* * * * *", NewUpstreamHealth(r.log, r.website)); err != nil {
+		return err
+	}
+	if _, err := c.AddJob("0 4 * * *", NewCertRenew(r.t, r.conf, r.db, r.log, r.setting, r.cert, r.certAccount, r.notify)); err != nil {
+		return err
+	}
+	if _, err := c.AddJob("*/5 * * * *", NewMySQLBinlog(r.log, r.backup, r.setting)); err != nil {
+		return err
+	}
+	if _, err := c.AddJob("0 3 * * *", NewBackupVerify(r.t, r.db, r.log, r.backup, r.notify)); err != nil {
+		return err
+	}
+	if _, err := c.AddJob("30 3 * * *", NewAuditClear(r.log, r.audit)); err != nil {
 		return err
 	}
 	if _, err := c.AddJob("0 2 * * *", NewPanelTask(r.db, r.log, r.backup, r.cache, r.task, r.setting)); err != nil {
@@ -1,29 +1,79 @@
 package job
 
 import (
+	"cmp"
+	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
+	"github.com/leonelquinteros/gotext"
+	"github.com/shirou/gopsutil/process"
 	"github.com/spf13/cast"
 	"gorm.io/gorm"
 
 	"github.com/acepanel/panel/internal/app"
 	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/pkg/systemctl"
 	"github.com/acepanel/panel/pkg/tools"
+	"github.com/acepanel/panel/pkg/types"
 )
 
+// 磁盘使用率达到该值时发送通知，同一挂载点 24 小时内只通知一次
+const (
+	diskFullPercent  = 90
+	diskFullInterval = 24 * time.Hour
+)
+
+// 每次采样分别记录 CPU 和内存占用最高的进程数
+const monitorTopProcesses = 10
+
+// monitorServices 应用对应的服务名，未列出的应用服务名与应用代号相同
+var monitorServices = map[string][]string{
+	"codeserver": {"code-server"},
+	"frp":        {"frps", "frpc"},
+	"mariadb":    {"mysqld"},
+	"mysql":      {"mysqld"},
+	"openresty":  {"nginx"},
+	"percona":    {"mysqld"},
+	"phpmyadmin": nil,
+	"pureftpd":   {"pure-ftpd"},
+	"rsync":      {"rsyncd"},
+	"s3fs":       nil,
+	"supervisor": {"supervisor", "supervisord"},
+}
+
 // Monitoring 系统监控
 type Monitoring struct {
-	db          *gorm.DB
-	log         *slog.Logger
-	settingRepo biz.SettingRepo
+	t               *gotext.Locale
+	db              *gorm.DB
+	log             *slog.Logger
+	settingRepo     biz.SettingRepo
+	monitorRepo     biz.MonitorRepo
+	notifyRepo      biz.NotifyRepo
+	alertRepo       biz.AlertRepo
+	appRepo         biz.AppRepo
+	environmentRepo biz.EnvironmentRepo
+	containerRepo   biz.ContainerRepo
+	diskFull        map[string]time.Time     // 挂载点上次通知时间
+	cpuTimes        map[string]time.Duration // 上次采样的累计 CPU 时间
+	lastSample      time.Time
 }
 
-func NewMonitoring(db *gorm.DB, log *slog.Logger, setting biz.SettingRepo) *Monitoring {
+func NewMonitoring(t *gotext.Locale, db *gorm.DB, log *slog.Logger, setting biz.SettingRepo, monitor biz.MonitorRepo, notify biz.NotifyRepo, alert biz.AlertRepo, appRepo biz.AppRepo, environment biz.EnvironmentRepo, container biz.ContainerRepo) *Monitoring {
 	return &Monitoring{
-		db:          db,
-		log:         log,
-		settingRepo: setting,
+		t:               t,
+		db:              db,
+		log:             log,
+		settingRepo:     setting,
+		monitorRepo:     monitor,
+		notifyRepo:      notify,
+		alertRepo:       alert,
+		appRepo:         appRepo,
+		environmentRepo: environment,
+		containerRepo:   container,
+		diskFull:        make(map[string]time.Time),
+		cpuTimes:        make(map[string]time.Duration),
 	}
 }
 
@@ -37,7 +87,15 @@ func (r *Monitoring) Run() {
 		return
 	}
 
-	info := tools.CurrentInfo(nil, nil)
+	disks, err := r.settingRepo.GetSlice(biz.SettingKeyMonitorDisks)
+	if err != nil {
+		r.log.Warn("[Monitor] failed to get monitor disks", slog.Any("err", err))
+	}
+	info := tools.CurrentInfo(nil, disks)
+	r.checkDisk(info)
+	if err = r.alertRepo.Evaluate(info); err != nil {
+		r.log.Warn("[Monitor] failed to evaluate alert rules", slog.Any("err", err))
+	}
 
 	// 去除部分数据以减少数据库存储
 	info.Disk = nil
@@ -51,18 +109,183 @@ func (r *Monitoring) Run() {
 		r.log.Warn("[Monitor] failed to create monitor record", slog.Any("err", err))
 		return
 	}
+	r.recordProcesses(time.Now())
 
-	// 删除过期数据
-	dayStr, err := r.settingRepo.Get(biz.SettingKeyMonitorDays)
-	if err != nil {
+	// 汇总并删除过期数据
+	if app.Status != app.StatusNormal {
 		return
 	}
-	day := cast.ToInt(dayStr)
-	if day <= 0 || app.Status != app.StatusNormal {
+	if err = r.monitorRepo.Rollup(time.Now()); err != nil {
+		r.log.Warn("[Monitor] failed to rollup monitor record", slog.Any("err", err))
 		return
 	}
-	if err = r.db.Where("created_at < ?", time.Now().AddDate(0, 0, -day).Format(time.DateTime)).Delete(&biz.Monitor{}).Error; err != nil {
-		r.log.Warn("[Monitor] failed to delete monitor record", slog.Any("err", err))
+}
+
+// checkDisk 检查磁盘使用率，超过阈值时发送通知
+func (r *Monitoring) checkDisk(info types.CurrentInfo) {
+	for _, usage := range info.DiskUsage {
+		if usage.UsedPercent < diskFullPercent {
+			delete(r.diskFull, usage.Path)
+			continue
+		}
+		if last, ok := r.diskFull[usage.Path]; ok && time.Since(last) < diskFullInterval {
+			continue
+		}
+
+		r.diskFull[usage.Path] = time.Now()
+		content := r.t.Get("Disk %s is %s full, %s free", usage.Path, fmt.Sprintf("%.1f%%", usage.UsedPercent), tools.FormatBytes(float64(usage.Free)))
+		if err := r.notifyRepo.Notify(biz.NotifyEventDiskFull, r.t.Get("Disk space is running low"), content); err != nil {
+			r.log.Warn("[Monitor] failed to send notification", slog.Any("err", err))
+		}
+	}
+}
+
+// recordProcesses 记录占用最高的进程，以及面板管理的服务和容器的资源占用
+func (r *Monitoring) recordProcesses(now time.Time) {
+	times := make(map[string]time.Duration)
+	// percent 根据两次采样间的 CPU 时间计算占用率，无上次采样时从 since 开始计算
+	percent := func(key string, cpu time.Duration, since time.Time) float64 {
+		times[key] = cpu
+		prev, ok := r.cpuTimes[key]
+		span := now.Sub(r.lastSample)
+		if !ok || prev > cpu {
+			if since.IsZero() {
+				return 0
+			}
+			prev, span = 0, now.Sub(since)
+		}
+		if span <= 0 {
+			return 0
+		}
+		return float64(cpu-prev) / float64(span) * 100
+	}
+
+	records := r.topProcesses(now, percent)
+	records = append(records, r.serviceUsages(now, percent)...)
+	records = append(records, r.containerUsages(now, percent)...)
+	r.cpuTimes, r.lastSample = times, now
+
+	if len(records) == 0 {
 		return
 	}
+	if err := r.db.CreateInBatches(records, 100).Error; err != nil {
+		r.log.Warn("[Monitor] failed to create process record", slog.Any("err", err))
+	}
+}
+
+// topProcesses CPU 和内存占用最高的进程
+func (r *Monitoring) topProcesses(now time.Time, percent func(string, time.Duration, time.Time) float64) []*biz.MonitorProcess {
+	procs, err := process.Processes()
+	if err != nil {
+		r.log.Warn("[Monitor] failed to list processes", slog.Any("err", err))
+		return nil
+	}
+
+	type usage struct {
+		proc   *process.Process
+		cpu    float64
+		memory uint64
+	}
+	usages := make([]usage, 0, len(procs))
+	for _, proc := range procs {
+		t, err := proc.Times()
+		if err != nil {
+			continue
+		}
+		mem, err := proc.MemoryInfo()
+		if err != nil {
+			continue
+		}
+		created, _ := proc.CreateTime()
+		// 使用启动时间区分复用的 PID
+		key := fmt.Sprintf("process:%d:%d", proc.Pid, created)
+		cpu := time.Duration((t.User + t.System) * float64(time.Second))
+		usages = append(usages, usage{proc: proc, cpu: percent(key, cpu, time.UnixMilli(created)), memory: mem.RSS})
+	}
+
+	top := make(map[int32]usage)
+	slices.SortFunc(usages, func(a, b usage) int { return cmp.Compare(b.cpu, a.cpu) })
+	for _, item := range usages[:min(monitorTopProcesses, len(usages))] {
+		top[item.proc.Pid] = item
+	}
+	slices.SortFunc(usages, func(a, b usage) int { return cmp.Compare(b.memory, a.memory) })
+	for _, item := range usages[:min(monitorTopProcesses, len(usages))] {
+		top[item.proc.Pid] = item
+	}
+
+	records := make([]*biz.MonitorProcess, 0, len(top))
+	for _, item := range top {
+		record := &biz.MonitorProcess{
+			Time:   now,
+			Type:   biz.MonitorProcessTypeProcess,
+			PID:    item.proc.Pid,
+			CPU:    item.cpu,
+			Memory: item.memory,
+		}
+		record.Name, _ = item.proc.Name()
+		record.Username, _ = item.proc.Username()
+		record.Command, _ = item.proc.Cmdline()
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// serviceUsages 已安装应用和 PHP 的服务资源占用，未运行的服务不记录
+func (r *Monitoring) serviceUsages(now time.Time, percent func(string, time.Duration, time.Time) float64) []*biz.MonitorProcess {
+	services := []string{"panel"}
+	installed, err := r.appRepo.Installed()
+	if err != nil {
+		r.log.Warn("[Monitor] failed to list installed apps", slog.Any("err", err))
+	}
+	for _, item := range installed {
+		if names, ok := monitorServices[item.Slug]; ok {
+			services = append(services, names...)
+		} else {
+			services = append(services, item.Slug)
+		}
+	}
+	for _, slug := range r.environmentRepo.InstalledSlugs("php") {
+		services = append(services, "php-fpm-"+slug)
+	}
+	slices.Sort(services)
+
+	records := make([]*biz.MonitorProcess, 0, len(services))
+	for _, name := range slices.Compact(services) {
+		cpu, memory, err := systemctl.Usage(name)
+		if err != nil {
+			continue
+		}
+		records = append(records, &biz.MonitorProcess{
+			Time:   now,
+			Type:   biz.MonitorProcessTypeService,
+			Name:   name,
+			CPU:    percent("service:"+name, cpu, time.Time{}),
+			Memory: memory,
+		})
+	}
+
+	return records
+}
+
+// containerUsages 运行中容器的资源占用，未安装容器引擎时不记录
+func (r *Monitoring) containerUsages(now time.Time, percent func(string, time.Duration, time.Time) float64) []*biz.MonitorProcess {
+	usages, err := r.containerRepo.Usage()
+	if err != nil {
+		return nil
+	}
+
+	records := make([]*biz.MonitorProcess, 0, len(usages))
+	for _, item := range usages {
+		records = append(records, &biz.MonitorProcess{
+			Time:    now,
+			Type:    biz.MonitorProcessTypeContainer,
+			Name:    item.Name,
+			Command: item.ID,
+			CPU:     percent("container:"+item.ID, time.Duration(item.CPU), time.Time{}),
+			Memory:  item.Memory,
+		})
+	}
+
+	return records
 }
@@ -0,0 +1,36 @@
+package job
+
+import (
+	"log/slog"
+
+	"github.com/acepanel/panel/internal/app"
+	"github.com/acepanel/panel/internal/biz"
+)
+
+// MySQLBinlog MySQL binlog 归档
+type MySQLBinlog struct {
+	log         *slog.Logger
+	backupRepo  biz.BackupRepo
+	settingRepo biz.SettingRepo
+}
+
+func NewMySQLBinlog(log *slog.Logger, backup biz.BackupRepo, setting biz.SettingRepo) *MySQLBinlog {
+	return &MySQLBinlog{
+		log:         log,
+		backupRepo:  backup,
+		settingRepo: setting,
+	}
+}
+
+func (r *MySQLBinlog) Run() {
+	if app.Status != app.StatusNormal {
+		return
+	}
+	if archive, err := r.settingRepo.GetBool(biz.SettingKeyMySQLBinlogArchive); err != nil || !archive {
+		return
+	}
+
+	if err := r.backupRepo.ArchiveMySQLBinlog(); err != nil {
+		r.log.Warn("[MySQLBinlog] failed to archive binlog", slog.Any("err", err))
+	}
+}
@@ -60,13 +60,13 @@ func (r *PanelTask) Run() {
 	}
 
 	// 备份面板
-	if err := r.backupRepo.Create(biz.BackupTypePanel, ""); err != nil {
+	if err := r.backupRepo.Create(biz.BackupTypePanel, "", 0); err != nil {
 		r.log.Warn("[PanelTask] failed to backup panel", slog.Any("err", err))
 	}
 
 	// 清理备份
 	if path, err := r.backupRepo.GetPath("panel"); err == nil {
-		if err = r.backupRepo.ClearExpired(path, "panel_", 10); err != nil {
+		if err = r.backupRepo.ClearExpired(biz.BackupTypePanel, 0, path, "panel_", 10); err != nil {
 			r.log.Warn("[PanelTask] failed to clear backup", slog.Any("err", err))
 		}
 	}
@@ -0,0 +1,31 @@
+package job
+
+import (
+	"log/slog"
+
+	"github.com/acepanel/panel/internal/app"
+	"github.com/acepanel/panel/internal/biz"
+)
+
+// UpstreamHealth 反向代理上游服务器健康检查
+type UpstreamHealth struct {
+	log         *slog.Logger
+	websiteRepo biz.WebsiteRepo
+}
+
+func NewUpstreamHealth(log *slog.Logger, website biz.WebsiteRepo) *UpstreamHealth {
+	return &UpstreamHealth{
+		log:         log,
+		websiteRepo: website,
+	}
+}
+
+func (r *UpstreamHealth) Run() {
+	if app.Status != app.StatusNormal {
+		return
+	}
+
+	if err := r.websiteRepo.CheckUpstreams(); err != nil {
+		r.log.Warn("[UpstreamHealth] failed to check upstreams", slog.Any("err", err))
+	}
+}
@@ -50,4 +50,227 @@ func init() {
 			)
 		},
 	})
+
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261018-backup-storage",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(
+				&biz.BackupStorage{},
+			)
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(
+				&biz.BackupStorage{},
+			)
+		},
+	})
+
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261018-backup-check",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(
+				&biz.BackupCheck{},
+			)
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(
+				&biz.BackupCheck{},
+			)
+		},
+	})
+
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261018-role",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(
+				&biz.Role{},
+				&biz.User{},
+				&biz.UserToken{},
+			)
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropColumn(&biz.User{}, "role_id"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropColumn(&biz.UserToken{}, "role_id"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropTable(
+				&biz.Role{},
+			)
+		},
+	})
+
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261018-owner",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(
+				&biz.Cron{},
+				&biz.DatabaseUser{},
+				&biz.WebHook{},
+				&biz.Website{},
+			)
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, model := range []any{&biz.Cron{}, &biz.DatabaseUser{}, &biz.WebHook{}, &biz.Website{}} {
+				if err := tx.Migrator().DropColumn(model, "user_id"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261018-audit",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(
+				&biz.Audit{},
+			)
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(
+				&biz.Audit{},
+			)
+		},
+	})
+
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261018-passkey",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(
+				&biz.UserPasskey{},
+			)
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(
+				&biz.UserPasskey{},
+			)
+		},
+	})
+
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261018-oidc",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(
+				&biz.User{},
+			)
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&biz.User{}, "oidc")
+		},
+	})
+
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261018-session",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(
+				&biz.UserSession{},
+			)
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&biz.UserSession{})
+		},
+	})
+
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261018-token-scope",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(
+				&biz.UserToken{},
+			)
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range []string{"scopes", "website_ids", "last_used_at", "last_used_ip"} {
+				if err := tx.Migrator().DropColumn(&biz.UserToken{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	})
+
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261018-login-lock",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(
+				&biz.LoginLock{},
+			)
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&biz.LoginLock{})
+		},
+	})
+
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261018-notify",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(
+				&biz.NotifyChannel{},
+			)
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&biz.NotifyChannel{})
+		},
+	})
+
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261018-alert",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(
+				&biz.AlertRule{},
+				&biz.Alert{},
+			)
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&biz.AlertRule{}, &biz.Alert{})
+		},
+	})
+
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261018-monitor-aggregate",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(
+				&biz.MonitorAggregate{},
+			)
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&biz.MonitorAggregate{})
+		},
+	})
+
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261018-monitor-process",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(
+				&biz.MonitorProcess{},
+			)
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&biz.MonitorProcess{})
+		},
+	})
+
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261018-website-revision",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(
+				&biz.WebsiteRevision{},
+			)
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&biz.WebsiteRevision{})
+		},
+	})
+	Migrations = append(Migrations, &gormigrate.Migration{
+		ID: "20261018-website-upstream-status",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(
+				&biz.WebsiteUpstreamStatus{},
+			)
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&biz.WebsiteUpstreamStatus{})
+		},
+	})
 }
@@ -4,22 +4,28 @@ import (
 	"errors"
 	"log/slog"
 
+	"github.com/leonelquinteros/gotext"
+
 	"github.com/acepanel/panel/internal/biz"
 	"github.com/acepanel/panel/pkg/shell"
 )
 
 // ProcessTask 处理面板任务
 type ProcessTask struct {
-	log      *slog.Logger
-	taskRepo biz.TaskRepo
-	taskID   uint
+	t          *gotext.Locale
+	log        *slog.Logger
+	taskRepo   biz.TaskRepo
+	notifyRepo biz.NotifyRepo
+	taskID     uint
 }
 
 // NewProcessTask 实例化 ProcessTask
-func NewProcessTask(log *slog.Logger, taskRepo biz.TaskRepo) *ProcessTask {
+func NewProcessTask(t *gotext.Locale, log *slog.Logger, taskRepo biz.TaskRepo, notifyRepo biz.NotifyRepo) *ProcessTask {
 	return &ProcessTask{
-		log:      log,
-		taskRepo: taskRepo,
+		t:          t,
+		log:        log,
+		taskRepo:   taskRepo,
+		notifyRepo: notifyRepo,
 	}
 }
 
@@ -53,4 +59,12 @@ func (r *ProcessTask) Handle(args ...any) error {
 func (r *ProcessTask) ErrHandle(err error) {
 	r.log.Warn("[ProcessTask] background task failed", slog.Any("task_id", r.taskID), slog.Any("err", err))
 	_ = r.taskRepo.UpdateStatus(r.taskID, biz.TaskStatusFailed)
+
+	name := ""
+	if task, getErr := r.taskRepo.Get(r.taskID); getErr == nil {
+		name = task.Name
+	}
+	if notifyErr := r.notifyRepo.Notify(biz.NotifyEventTaskFailed, r.t.Get("Background task failed"), r.t.Get("Task %s failed: %v", name, err)); notifyErr != nil {
+		r.log.Warn("[ProcessTask] failed to send notification", slog.Any("task_id", r.taskID), slog.Any("err", notifyErr))
+	}
 }
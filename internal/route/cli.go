@@ -20,7 +20,7 @@ func NewCli(t *gotext.Locale, cli *service.CliService) *Cli {
 }
 
 func (route *Cli) Commands() []*cli.Command {
-	return []*cli.Command{
+	commands := []*cli.Command{
 		{
 			Name:   "restart",
 			Usage:  route.t.Get("Restart panel service"),
@@ -80,6 +80,16 @@ func (route *Cli) Commands() []*cli.Command {
 					Usage:  route.t.Get("Change user 2FA"),
 					Action: route.cli.UserTwoFA,
 				},
+				{
+					Name:   "passkey",
+					Usage:  route.t.Get("Remove all passkeys of user"),
+					Action: route.cli.UserPasskey,
+				},
+				{
+					Name:   "logout-all",
+					Usage:  route.t.Get("Log out all sessions of user, or of all users if no username is given"),
+					Action: route.cli.UserLogoutAll,
+				},
 			},
 		},
 		{
@@ -306,6 +316,10 @@ func (route *Cli) Commands() []*cli.Command {
 							Aliases: []string{"p"},
 							Usage:   route.t.Get("Save directory (default path if not filled)"),
 						},
+						&cli.UintFlag{
+							Name:  "storage",
+							Usage: route.t.Get("Backup storage ID (local if not filled)"),
+						},
 					},
 				},
 				{
@@ -330,6 +344,10 @@ func (route *Cli) Commands() []*cli.Command {
 							Aliases: []string{"p"},
 							Usage:   route.t.Get("Save directory (default path if not filled)"),
 						},
+						&cli.UintFlag{
+							Name:  "storage",
+							Usage: route.t.Get("Backup storage ID (local if not filled)"),
+						},
 					},
 				},
 				{
@@ -342,6 +360,131 @@ func (route *Cli) Commands() []*cli.Command {
 							Aliases: []string{"p"},
 							Usage:   route.t.Get("Save directory (default path if not filled)"),
 						},
+						&cli.UintFlag{
+							Name:  "storage",
+							Usage: route.t.Get("Backup storage ID (local if not filled)"),
+						},
+					},
+				},
+				{
+					Name:   "snapshot",
+					Usage:  route.t.Get("Create incremental website snapshot"),
+					Action: route.cli.BackupSnapshot,
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     "name",
+							Aliases:  []string{"n"},
+							Usage:    route.t.Get("Website name"),
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:    "path",
+							Aliases: []string{"p"},
+							Usage:   route.t.Get("Snapshot repository directory (default path if not filled)"),
+						},
+					},
+				},
+				{
+					Name:   "snapshot-clear",
+					Usage:  route.t.Get("Clear website snapshots by retention policy"),
+					Action: route.cli.BackupSnapshotClear,
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     "name",
+							Aliases:  []string{"n"},
+							Usage:    route.t.Get("Website name"),
+							Required: true,
+						},
+						&cli.IntFlag{
+							Name:  "daily",
+							Usage: route.t.Get("Number of daily snapshots to keep"),
+						},
+						&cli.IntFlag{
+							Name:  "weekly",
+							Usage: route.t.Get("Number of weekly snapshots to keep"),
+						},
+						&cli.IntFlag{
+							Name:  "monthly",
+							Usage: route.t.Get("Number of monthly snapshots to keep"),
+						},
+						&cli.StringFlag{
+							Name:    "path",
+							Aliases: []string{"p"},
+							Usage:   route.t.Get("Snapshot repository directory (default path if not filled)"),
+						},
+					},
+				},
+				{
+					Name:   "restore",
+					Usage:  route.t.Get("Restore backup"),
+					Action: route.cli.BackupRestore,
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     "type",
+							Aliases:  []string{"t"},
+							Usage:    route.t.Get("Backup type"),
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     "file",
+							Aliases:  []string{"f"},
+							Usage:    route.t.Get("Backup file"),
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     "name",
+							Aliases:  []string{"n"},
+							Usage:    route.t.Get("Restore target name"),
+							Required: true,
+						},
+						&cli.UintFlag{
+							Name:  "storage",
+							Usage: route.t.Get("Backup storage ID (local if not filled)"),
+						},
+						&cli.StringFlag{
+							Name:  "time",
+							Usage: route.t.Get("MySQL point-in-time recovery: replay binlog up to this time (e.g. 2006-01-02 15:04:05)"),
+						},
+						&cli.StringFlag{
+							Name:  "gtid",
+							Usage: route.t.Get("MySQL point-in-time recovery: replay binlog up to this GTID"),
+						},
+					},
+				},
+				{
+					Name:   "swap",
+					Usage:  route.t.Get("Replace MySQL database with its point-in-time recovery result"),
+					Action: route.cli.BackupSwap,
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     "name",
+							Aliases:  []string{"n"},
+							Usage:    route.t.Get("Restore target name"),
+							Required: true,
+						},
+					},
+				},
+				{
+					Name:   "verify",
+					Usage:  route.t.Get("Verify backup"),
+					Action: route.cli.BackupVerify,
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     "type",
+							Aliases:  []string{"t"},
+							Usage:    route.t.Get("Backup type"),
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     "file",
+							Aliases:  []string{"f"},
+							Usage:    route.t.Get("Backup file"),
+							Required: true,
+						},
+						&cli.UintFlag{
+							Name:  "storage",
+							Usage: route.t.Get("Backup storage ID (local if not filled)"),
+						},
 					},
 				},
 				{
@@ -372,6 +515,10 @@ func (route *Cli) Commands() []*cli.Command {
 							Aliases: []string{"p"},
 							Usage:   route.t.Get("Backup directory (default path if not filled)"),
 						},
+						&cli.UintFlag{
+							Name:  "storage",
+							Usage: route.t.Get("Backup storage ID (local if not filled)"),
+						},
 					},
 				},
 			},
@@ -507,4 +654,18 @@ func (route *Cli) Commands() []*cli.Command {
 			Action: route.cli.Init,
 		},
 	}
+
+	return route.audit(commands)
+}
+
+// audit 为全部命令记录审计日志
+func (route *Cli) audit(commands []*cli.Command) []*cli.Command {
+	for _, command := range commands {
+		if command.Action != nil {
+			command.Action = route.cli.Audit(command.Action)
+		}
+		route.audit(command.Commands)
+	}
+
+	return commands
 }
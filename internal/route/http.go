@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/acepanel/panel/internal/biz"
 	"github.com/acepanel/panel/internal/http/middleware"
 	"github.com/acepanel/panel/internal/service"
 	"github.com/acepanel/panel/pkg/apploader"
@@ -17,8 +18,11 @@ import (
 
 type Http struct {
 	conf             *config.Config
+	middlewares      *middleware.Middlewares
 	user             *service.UserService
 	userToken        *service.UserTokenService
+	role             *service.RoleService
+	audit            *service.AuditService
 	home             *service.HomeService
 	task             *service.TaskService
 	website          *service.WebsiteService
@@ -26,6 +30,7 @@ type Http struct {
 	databaseServer   *service.DatabaseServerService
 	databaseUser     *service.DatabaseUserService
 	backup           *service.BackupService
+	backupStorage    *service.BackupStorageService
 	cert             *service.CertService
 	certDNS          *service.CertDNSService
 	certAccount      *service.CertAccountService
@@ -43,6 +48,7 @@ type Http struct {
 	containerImage   *service.ContainerImageService
 	containerVolume  *service.ContainerVolumeService
 	file             *service.FileService
+	metrics          *service.MetricsService
 	monitor          *service.MonitorService
 	setting          *service.SettingService
 	systemctl        *service.SystemctlService
@@ -51,13 +57,17 @@ type Http struct {
 	toolboxSSH       *service.ToolboxSSHService
 	toolboxDisk      *service.ToolboxDiskService
 	webhook          *service.WebHookService
+	notify           *service.NotifyService
 	apps             *apploader.Loader
 }
 
 func NewHttp(
 	conf *config.Config,
+	middlewares *middleware.Middlewares,
 	user *service.UserService,
 	userToken *service.UserTokenService,
+	role *service.RoleService,
+	audit *service.AuditService,
 	home *service.HomeService,
 	task *service.TaskService,
 	website *service.WebsiteService,
@@ -65,6 +75,7 @@ func NewHttp(
 	databaseServer *service.DatabaseServerService,
 	databaseUser *service.DatabaseUserService,
 	backup *service.BackupService,
+	backupStorage *service.BackupStorageService,
 	cert *service.CertService,
 	certDNS *service.CertDNSService,
 	certAccount *service.CertAccountService,
@@ -82,6 +93,7 @@ func NewHttp(
 	containerImage *service.ContainerImageService,
 	containerVolume *service.ContainerVolumeService,
 	file *service.FileService,
+	metrics *service.MetricsService,
 	monitor *service.MonitorService,
 	setting *service.SettingService,
 	systemctl *service.SystemctlService,
@@ -90,12 +102,16 @@ func NewHttp(
 	toolboxSSH *service.ToolboxSSHService,
 	toolboxDisk *service.ToolboxDiskService,
 	webhook *service.WebHookService,
+	notify *service.NotifyService,
 	apps *apploader.Loader,
 ) *Http {
 	return &Http{
 		conf:             conf,
+		middlewares:      middlewares,
 		user:             user,
 		userToken:        userToken,
+		role:             role,
+		audit:            audit,
 		home:             home,
 		task:             task,
 		website:          website,
@@ -103,6 +119,7 @@ func NewHttp(
 		databaseServer:   databaseServer,
 		databaseUser:     databaseUser,
 		backup:           backup,
+		backupStorage:    backupStorage,
 		cert:             cert,
 		certDNS:          certDNS,
 		certAccount:      certAccount,
@@ -120,6 +137,7 @@ func NewHttp(
 		containerImage:   containerImage,
 		containerVolume:  containerVolume,
 		file:             file,
+		metrics:          metrics,
 		monitor:          monitor,
 		setting:          setting,
 		systemctl:        systemctl,
@@ -128,6 +146,7 @@ func NewHttp(
 		toolboxSSH:       toolboxSSH,
 		toolboxDisk:      toolboxDisk,
 		webhook:          webhook,
+		notify:           notify,
 		apps:             apps,
 	}
 }
@@ -141,27 +160,75 @@ func (route *Http) Register(r *chi.Mux) {
 			r.Get("/is_login", route.user.IsLogin)
 			r.Get("/is_2fa", route.user.IsTwoFA)
 			r.Get("/info", route.user.Info)
+			r.Get("/passkey/options", route.user.PasskeyOptions)
+			r.With(middleware.Throttle(route.conf.HTTP.IPHeader, 5, time.Minute)).Post("/passkey/login", route.user.PasskeyLogin)
+			r.Get("/passkeys", route.user.PasskeyList)
+			r.Post("/passkeys/options", route.user.PasskeyRegisterOptions)
+			r.Post("/passkeys", route.user.PasskeyCreate)
+			r.Delete("/passkeys/{id}", route.user.PasskeyDelete)
+			r.Get("/sessions", route.user.SessionList)
+			r.Delete("/sessions", route.user.SessionClear)
+			r.Delete("/sessions/{id}", route.user.SessionDelete)
+			r.Get("/oidc", route.user.OIDCInfo)
+			r.Get("/oidc/login", route.user.OIDCLogin)
+			r.With(middleware.Throttle(route.conf.HTTP.IPHeader, 10, time.Minute)).Get("/oidc/callback", route.user.OIDCCallback)
 		})
 
 		r.Route("/users", func(r chi.Router) {
-			r.Get("/", route.user.List)
-			r.Post("/", route.user.Create)
-			r.Post("/{id}/username", route.user.UpdateUsername)
-			r.Post("/{id}/password", route.user.UpdatePassword)
-			r.Post("/{id}/email", route.user.UpdateEmail)
-			r.Get("/{id}/2fa", route.user.GenerateTwoFA)
-			r.Post("/{id}/2fa", route.user.UpdateTwoFA)
-			r.Delete("/{id}", route.user.Delete)
+			// 普通用户可以修改自己的用户名、密码、邮箱和两步验证
+			r.Group(func(r chi.Router) {
+				r.Use(route.middlewares.PermissionOrSelf(biz.PermissionAdmin))
+				r.Post("/{id}/username", route.user.UpdateUsername)
+				r.Post("/{id}/password", route.user.UpdatePassword)
+				r.Post("/{id}/email", route.user.UpdateEmail)
+				r.Get("/{id}/2fa", route.user.GenerateTwoFA)
+				r.Post("/{id}/2fa", route.user.UpdateTwoFA)
+			})
+			r.Group(func(r chi.Router) {
+				r.Use(route.middlewares.Permission(biz.PermissionAdmin))
+				r.Get("/", route.user.List)
+				r.Post("/", route.user.Create)
+				r.Get("/login_locks", route.user.LoginLockList)
+				r.Delete("/login_locks", route.user.LoginLockClear)
+				r.Delete("/login_locks/{id}", route.user.LoginLockDelete)
+				r.Post("/{id}/role", route.user.UpdateRole)
+				r.Post("/{id}/resources", route.user.UpdateResources)
+				r.Get("/{id}/sessions", route.user.UserSessionList)
+				r.Delete("/{id}/sessions", route.user.UserSessionClear)
+				r.Delete("/{id}", route.user.Delete)
+			})
 		})
 
 		r.Route("/user_tokens", func(r chi.Router) {
+			r.Use(route.middlewares.Permission(biz.PermissionAdmin))
+
 			r.Get("/", route.userToken.List)
 			r.Post("/", route.userToken.Create)
 			r.Put("/{id}", route.userToken.Update)
 			r.Delete("/{id}", route.userToken.Delete)
 		})
 
+		r.Route("/roles", func(r chi.Router) {
+			r.Use(route.middlewares.Permission(biz.PermissionAdmin))
+
+			r.Get("/", route.role.List)
+			r.Get("/permissions", route.role.Permissions)
+			r.Post("/", route.role.Create)
+			r.Get("/{id}", route.role.Get)
+			r.Put("/{id}", route.role.Update)
+			r.Delete("/{id}", route.role.Delete)
+		})
+
+		r.Route("/audit", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("audit"))
+
+			r.Get("/", route.audit.List)
+			r.Get("/export", route.audit.Export)
+		})
+
 		r.Route("/home", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("home"))
+
 			r.Get("/panel", route.home.Panel)
 			r.Get("/apps", route.home.Apps)
 			r.Post("/current", route.home.Current)
@@ -175,6 +242,8 @@ func (route *Http) Register(r *chi.Mux) {
 		})
 
 		r.Route("/task", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("task"))
+
 			r.Get("/status", route.task.Status)
 			r.Get("/", route.task.List)
 			r.Get("/{id}", route.task.Get)
@@ -182,23 +251,34 @@ func (route *Http) Register(r *chi.Mux) {
 		})
 
 		r.Route("/website", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("website"))
+
 			r.Get("/rewrites", route.website.GetRewrites)
 			r.Get("/default_config", route.website.GetDefaultConfig)
-			r.Post("/default_config", route.website.UpdateDefaultConfig)
+			r.With(route.middlewares.Permission(biz.PermissionAdmin)).Post("/default_config", route.website.UpdateDefaultConfig)
 			r.Post("/cert", route.website.UpdateCert)
 			r.Get("/", route.website.List)
 			r.Post("/", route.website.Create)
-			r.Get("/{id}", route.website.Get)
-			r.Put("/{id}", route.website.Update)
-			r.Delete("/{id}", route.website.Delete)
-			r.Delete("/{id}/log", route.website.ClearLog)
-			r.Post("/{id}/update_remark", route.website.UpdateRemark)
-			r.Post("/{id}/reset_config", route.website.ResetConfig)
-			r.Post("/{id}/status", route.website.UpdateStatus)
-			r.Post("/{id}/obtain_cert", route.website.ObtainCert)
+			r.Group(func(r chi.Router) {
+				r.Use(route.middlewares.Own("websites"))
+				r.Get("/{id}", route.website.Get)
+				r.Put("/{id}", route.website.Update)
+				r.Delete("/{id}", route.website.Delete)
+				r.Delete("/{id}/log", route.website.ClearLog)
+				r.Post("/{id}/update_remark", route.website.UpdateRemark)
+				r.Post("/{id}/reset_config", route.website.ResetConfig)
+				r.Post("/{id}/status", route.website.UpdateStatus)
+				r.Post("/{id}/obtain_cert", route.website.ObtainCert)
+				r.Get("/{id}/revisions", route.website.Revisions)
+				r.Get("/{id}/revisions/diff", route.website.DiffRevisions)
+				r.Post("/{id}/revisions/{revision}/restore", route.website.RestoreRevision)
+				r.Get("/{id}/upstreams/status", route.website.UpstreamStatuses)
+			})
 		})
 
 		r.Route("/database", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("database"))
+
 			r.Get("/", route.database.List)
 			r.Post("/", route.database.Create)
 			r.Delete("/", route.database.Delete)
@@ -206,6 +286,8 @@ func (route *Http) Register(r *chi.Mux) {
 		})
 
 		r.Route("/database_server", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("database_server"))
+
 			r.Get("/", route.databaseServer.List)
 			r.Post("/", route.databaseServer.Create)
 			r.Get("/{id}", route.databaseServer.Get)
@@ -216,23 +298,47 @@ func (route *Http) Register(r *chi.Mux) {
 		})
 
 		r.Route("/database_user", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("database_user"))
+
 			r.Get("/", route.databaseUser.List)
 			r.Post("/", route.databaseUser.Create)
-			r.Get("/{id}", route.databaseUser.Get)
-			r.Put("/{id}", route.databaseUser.Update)
-			r.Put("/{id}/remark", route.databaseUser.UpdateRemark)
-			r.Delete("/{id}", route.databaseUser.Delete)
+			r.Group(func(r chi.Router) {
+				r.Use(route.middlewares.Own("database_users"))
+				r.Get("/{id}", route.databaseUser.Get)
+				r.Put("/{id}", route.databaseUser.Update)
+				r.Put("/{id}/remark", route.databaseUser.UpdateRemark)
+				r.Delete("/{id}", route.databaseUser.Delete)
+			})
+		})
+
+		r.Route("/backup_storage", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("backup_storage"))
+
+			r.Get("/", route.backupStorage.List)
+			r.Post("/", route.backupStorage.Create)
+			r.Put("/{id}", route.backupStorage.Update)
+			r.Get("/{id}", route.backupStorage.Get)
+			r.Delete("/{id}", route.backupStorage.Delete)
 		})
 
 		r.Route("/backup", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("backup"))
+
 			r.Get("/{type}", route.backup.List)
 			r.Post("/{type}", route.backup.Create)
 			r.Post("/{type}/upload", route.backup.Upload)
 			r.Delete("/{type}/delete", route.backup.Delete)
 			r.Post("/{type}/restore", route.backup.Restore)
+			r.Post("/{type}/swap_point", route.backup.SwapPoint)
+			r.Post("/{type}/verify", route.backup.Verify)
+			r.Get("/snapshot/{id}/files", route.backup.SnapshotFiles)
+			r.Post("/snapshot/{id}/restore_file", route.backup.SnapshotRestoreFile)
+			r.Post("/snapshot/clear", route.backup.SnapshotClear)
 		})
 
 		r.Route("/cert", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("cert"))
+
 			r.Get("/ca_providers", route.cert.CAProviders)
 			r.Get("/dns_providers", route.cert.DNSProviders)
 			r.Get("/algorithms", route.cert.Algorithms)
@@ -267,6 +373,8 @@ func (route *Http) Register(r *chi.Mux) {
 		})
 
 		r.Route("/app", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("app"))
+
 			r.Get("/categories", route.app.Categories)
 			r.Get("/list", route.app.List)
 			r.Post("/install", route.app.Install)
@@ -278,6 +386,8 @@ func (route *Http) Register(r *chi.Mux) {
 		})
 
 		r.Route("/environment", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("environment"))
+
 			r.Get("/types", route.environment.Types)
 			r.Get("/list", route.environment.List)
 			r.Post("/install", route.environment.Install)
@@ -302,15 +412,22 @@ func (route *Http) Register(r *chi.Mux) {
 		})
 
 		r.Route("/cron", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("cron"))
+
 			r.Get("/", route.cron.List)
 			r.Post("/", route.cron.Create)
-			r.Put("/{id}", route.cron.Update)
-			r.Get("/{id}", route.cron.Get)
-			r.Delete("/{id}", route.cron.Delete)
-			r.Post("/{id}/status", route.cron.Status)
+			r.Group(func(r chi.Router) {
+				r.Use(route.middlewares.Own("crons"))
+				r.Put("/{id}", route.cron.Update)
+				r.Get("/{id}", route.cron.Get)
+				r.Delete("/{id}", route.cron.Delete)
+				r.Post("/{id}/status", route.cron.Status)
+			})
 		})
 
 		r.Route("/process", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("process"))
+
 			r.Get("/", route.process.List)
 			r.Get("/detail", route.process.Detail)
 			r.Post("/kill", route.process.Kill)
@@ -318,6 +435,8 @@ func (route *Http) Register(r *chi.Mux) {
 		})
 
 		r.Route("/safe", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("safe"))
+
 			r.Get("/ssh", route.safe.GetSSH)
 			r.Post("/ssh", route.safe.UpdateSSH)
 			r.Get("/ping", route.safe.GetPingStatus)
@@ -325,6 +444,8 @@ func (route *Http) Register(r *chi.Mux) {
 		})
 
 		r.Route("/firewall", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("firewall"))
+
 			r.Get("/status", route.firewall.GetStatus)
 			r.Post("/status", route.firewall.UpdateStatus)
 			r.Get("/rule", route.firewall.GetRules)
@@ -339,6 +460,8 @@ func (route *Http) Register(r *chi.Mux) {
 		})
 
 		r.Route("/ssh", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("ssh"))
+
 			r.Get("/", route.ssh.List)
 			r.Post("/", route.ssh.Create)
 			r.Put("/{id}", route.ssh.Update)
@@ -347,6 +470,8 @@ func (route *Http) Register(r *chi.Mux) {
 		})
 
 		r.Route("/container", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("container"))
+
 			r.Route("/container", func(r chi.Router) {
 				r.Get("/", route.container.List)
 				r.Get("/search", route.container.Search)
@@ -392,6 +517,8 @@ func (route *Http) Register(r *chi.Mux) {
 		})
 
 		r.Route("/file", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("file"))
+
 			r.Post("/create", route.file.Create)
 			r.Get("/content", route.file.Content)
 			r.Post("/save", route.file.Save)
@@ -411,19 +538,36 @@ func (route *Http) Register(r *chi.Mux) {
 		})
 
 		r.Route("/monitor", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("monitor"))
+
 			r.Get("/setting", route.monitor.GetSetting)
 			r.Post("/setting", route.monitor.UpdateSetting)
 			r.Post("/clear", route.monitor.Clear)
 			r.Get("/list", route.monitor.List)
+			r.Get("/processes", route.monitor.Processes)
+			r.Get("/alert_rules", route.monitor.AlertRuleList)
+			r.Post("/alert_rules", route.monitor.AlertRuleCreate)
+			r.Get("/alert_rules/{id}", route.monitor.AlertRuleGet)
+			r.Put("/alert_rules/{id}", route.monitor.AlertRuleUpdate)
+			r.Delete("/alert_rules/{id}", route.monitor.AlertRuleDelete)
+			r.Get("/alerts", route.monitor.AlertList)
+			r.Delete("/alerts", route.monitor.AlertClear)
+			r.Delete("/alerts/{id}", route.monitor.AlertDelete)
 		})
 
 		r.Route("/setting", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("setting"))
+
 			r.Get("/", route.setting.Get)
 			r.Post("/", route.setting.Update)
 			r.Post("/cert", route.setting.UpdateCert)
+			r.With(route.middlewares.Permission(biz.PermissionAdmin)).Get("/oidc", route.setting.GetOIDC)
+			r.With(route.middlewares.Permission(biz.PermissionAdmin)).Post("/oidc", route.setting.UpdateOIDC)
 		})
 
 		r.Route("/systemctl", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("systemctl"))
+
 			r.Get("/status", route.systemctl.Status)
 			r.Get("/is_enabled", route.systemctl.IsEnabled)
 			r.Post("/enable", route.systemctl.Enable)
@@ -435,6 +579,8 @@ func (route *Http) Register(r *chi.Mux) {
 		})
 
 		r.Route("/toolbox_system", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("toolbox_system"))
+
 			r.Get("/dns", route.toolboxSystem.GetDNS)
 			r.Post("/dns", route.toolboxSystem.UpdateDNS)
 			r.Get("/swap", route.toolboxSystem.GetSWAP)
@@ -450,10 +596,14 @@ func (route *Http) Register(r *chi.Mux) {
 		})
 
 		r.Route("/toolbox_benchmark", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("toolbox_benchmark"))
+
 			r.Post("/test", route.toolboxBenchmark.Test)
 		})
 
 		r.Route("/toolbox_ssh", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("toolbox_ssh"))
+
 			r.Get("/info", route.toolboxSSH.GetInfo)
 			r.Post("/port", route.toolboxSSH.UpdatePort)
 			r.Post("/password_auth", route.toolboxSSH.UpdatePasswordAuth)
@@ -465,6 +615,8 @@ func (route *Http) Register(r *chi.Mux) {
 		})
 
 		r.Route("/toolbox_disk", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("toolbox_disk"))
+
 			r.Get("/list", route.toolboxDisk.List)
 			r.Post("/partitions", route.toolboxDisk.GetPartitions)
 			r.Post("/mount", route.toolboxDisk.Mount)
@@ -484,15 +636,34 @@ func (route *Http) Register(r *chi.Mux) {
 		})
 
 		r.Route("/webhook", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("webhook"))
+
 			r.Get("/", route.webhook.List)
 			r.Post("/", route.webhook.Create)
-			r.Put("/{id}", route.webhook.Update)
-			r.Get("/{id}", route.webhook.Get)
-			r.Delete("/{id}", route.webhook.Delete)
+			r.Group(func(r chi.Router) {
+				r.Use(route.middlewares.Own("web_hooks"))
+				r.Put("/{id}", route.webhook.Update)
+				r.Get("/{id}", route.webhook.Get)
+				r.Delete("/{id}", route.webhook.Delete)
+			})
+		})
+
+		r.Route("/notify", func(r chi.Router) {
+			r.Use(route.middlewares.Permission("notify"))
+
+			r.Get("/events", route.notify.Events)
+			r.Get("/", route.notify.List)
+			r.Post("/", route.notify.Create)
+			r.Put("/{id}", route.notify.Update)
+			r.Get("/{id}", route.notify.Get)
+			r.Delete("/{id}", route.notify.Delete)
+			r.Post("/{id}/test", route.notify.Test)
 		})
 
 		r.Route("/apps", func(r chi.Router) {
-			route.apps.Register(r)
+			route.apps.Register(r, func(slug string) func(http.Handler) http.Handler {
+				return route.middlewares.Permission("apps/" + slug)
+			})
 		})
 	})
 
@@ -500,6 +671,9 @@ func (route *Http) Register(r *chi.Mux) {
 	r.Get("/webhook/{key}", route.webhook.Call)
 	r.Post("/webhook/{key}", route.webhook.Call)
 
+	// Prometheus 抓取接口
+	r.Get("/metrics", route.metrics.Metrics)
+
 	r.NotFound(func(writer http.ResponseWriter, request *http.Request) {
 		// /api 开头的返回 404
 		if strings.HasPrefix(request.URL.Path, "/api") {
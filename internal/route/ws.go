@@ -3,21 +3,27 @@ package route
 import (
 	"github.com/go-chi/chi/v5"
 
+	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/internal/http/middleware"
 	"github.com/acepanel/panel/internal/service"
 )
 
 type Ws struct {
-	ws *service.WsService
+	middlewares *middleware.Middlewares
+	ws          *service.WsService
 }
 
-func NewWs(ws *service.WsService) *Ws {
+func NewWs(middlewares *middleware.Middlewares, ws *service.WsService) *Ws {
 	return &Ws{
-		ws: ws,
+		middlewares: middlewares,
+		ws:          ws,
 	}
 }
 
 func (route *Ws) Register(r *chi.Mux) {
 	r.Route("/api/ws", func(r chi.Router) {
+		// 终端和命令执行均以 root 运行，只允许管理员访问
+		r.Use(route.middlewares.Permission(biz.PermissionAdmin))
 		r.Get("/ssh", route.ws.Session)
 		r.Get("/exec", route.ws.Exec)
 	})
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/libtnb/chix"
+
+	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/internal/http/request"
+)
+
+type AuditService struct {
+	auditRepo biz.AuditRepo
+}
+
+func NewAuditService(audit biz.AuditRepo) *AuditService {
+	return &AuditService{
+		auditRepo: audit,
+	}
+}
+
+func (s *AuditService) List(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.AuditList](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	audits, total, err := s.auditRepo.List(req)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, chix.M{
+		"total": total,
+		"items": audits,
+	})
+}
+
+// Export 导出符合条件的全部审计日志
+func (s *AuditService) Export(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.AuditExport](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	// Limit 为 0 时不分页
+	audits, _, err := s.auditRepo.List(&request.AuditList{
+		Source:  req.Source,
+		UserID:  req.UserID,
+		IP:      req.IP,
+		Route:   req.Route,
+		Keyword: req.Keyword,
+		Success: req.Success,
+		Start:   req.Start,
+		End:     req.End,
+	})
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	name := fmt.Sprintf("audit-%s", time.Now().Format("20060102150405"))
+	if req.Format == "json" {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.json"`, name))
+		_ = json.NewEncoder(w).Encode(audits)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, name))
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"id", "created_at", "source", "user_id", "token_id", "operator", "ip", "method", "route", "path", "target", "summary", "status", "success", "message"})
+	for _, audit := range audits {
+		_ = writer.Write([]string{
+			strconv.FormatUint(uint64(audit.ID), 10),
+			audit.CreatedAt.Format(time.DateTime),
+			string(audit.Source),
+			strconv.FormatUint(uint64(audit.UserID), 10),
+			strconv.FormatUint(uint64(audit.TokenID), 10),
+			audit.Operator,
+			audit.IP,
+			audit.Method,
+			audit.Route,
+			audit.Path,
+			audit.Target,
+			audit.Summary,
+			strconv.Itoa(audit.Status),
+			strconv.FormatBool(audit.Success),
+			audit.Message,
+		})
+	}
+	writer.Flush()
+}
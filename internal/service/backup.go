@@ -5,25 +5,41 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
+	"time"
 
 	"github.com/leonelquinteros/gotext"
 	"github.com/libtnb/chix"
 
 	"github.com/acepanel/panel/internal/biz"
 	"github.com/acepanel/panel/internal/http/request"
+	"github.com/acepanel/panel/pkg/backupcrypto"
 	"github.com/acepanel/panel/pkg/io"
+	"github.com/acepanel/panel/pkg/snapshot"
+	"github.com/acepanel/panel/pkg/types"
 )
 
+// backupTargetPattern 从备份文件名中取出目标名称，如 example_20060102150405.zip
+var backupTargetPattern = regexp.MustCompile(`^(.+)_\d{14}\.`)
+
 type BackupService struct {
-	t          *gotext.Locale
-	backupRepo biz.BackupRepo
+	t                  *gotext.Locale
+	backupRepo         biz.BackupRepo
+	websiteRepo        biz.WebsiteRepo
+	databaseRepo       biz.DatabaseRepo
+	databaseServerRepo biz.DatabaseServerRepo
+	userRepo           biz.UserRepo
 }
 
-func NewBackupService(t *gotext.Locale, backup biz.BackupRepo) *BackupService {
+func NewBackupService(t *gotext.Locale, backup biz.BackupRepo, website biz.WebsiteRepo, database biz.DatabaseRepo, databaseServer biz.DatabaseServerRepo, user biz.UserRepo) *BackupService {
 	return &BackupService{
-		t:          t,
-		backupRepo: backup,
+		t:                  t,
+		backupRepo:         backup,
+		websiteRepo:        website,
+		databaseRepo:       database,
+		databaseServerRepo: databaseServer,
+		userRepo:           user,
 	}
 }
 
@@ -34,7 +50,17 @@ func (s *BackupService) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	list, _ := s.backupRepo.List(biz.BackupType(req.Type))
+	list, _ := s.backupRepo.List(biz.BackupType(req.Type), req.Storage)
+	if owner := ownerID(r); owner != 0 {
+		owned := make(map[string]bool)
+		list = slices.DeleteFunc(list, func(item *types.BackupFile) bool {
+			target := s.fileTarget(biz.BackupType(req.Type), item)
+			if _, ok := owned[target]; !ok {
+				owned[target], _ = s.ownsTarget(owner, biz.BackupType(req.Type), target)
+			}
+			return !owned[target]
+		})
+	}
 	paged, total := Paginate(r, list)
 
 	Success(w, chix.M{
@@ -50,7 +76,16 @@ func (s *BackupService) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = s.backupRepo.Create(biz.BackupType(req.Type), req.Target, req.Path); err != nil {
+	if !s.owns(w, r, biz.BackupType(req.Type), req.Target) {
+		return
+	}
+	// 备份以 root 执行，非管理员只能使用默认存储和路径
+	if ownerID(r) != 0 && (req.Path != "" || req.Storage != 0) {
+		Error(w, http.StatusForbidden, s.t.Get("only backups with default storage and path are allowed"))
+		return
+	}
+
+	if err = s.backupRepo.Create(biz.BackupType(req.Type), req.Target, req.Storage, req.Path); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
@@ -72,11 +107,14 @@ func (s *BackupService) Upload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 只允许上传 .sql .zip .tar .gz .tgz .bz2 .xz .7z
-	if !slices.Contains([]string{".sql", ".zip", ".tar", ".gz", ".tgz", ".bz2", ".xz", ".7z"}, filepath.Ext(req.File.Filename)) {
+	// 只允许上传 .sql .zip .tar .gz .tgz .bz2 .xz .7z .age
+	if !slices.Contains([]string{".sql", ".zip", ".tar", ".gz", ".tgz", ".bz2", ".xz", ".7z", backupcrypto.Ext}, filepath.Ext(req.File.Filename)) {
 		Error(w, http.StatusForbidden, s.t.Get("unsupported file type"))
 		return
 	}
+	if !s.owns(w, r, biz.BackupType(req.Type), s.fileTarget(biz.BackupType(req.Type), &types.BackupFile{Name: req.File.Filename})) {
+		return
+	}
 
 	path, err := s.backupRepo.GetPath(biz.BackupType(req.Type))
 	if err != nil {
@@ -111,7 +149,11 @@ func (s *BackupService) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = s.backupRepo.Delete(biz.BackupType(req.Type), req.File); err != nil {
+	if !s.owns(w, r, biz.BackupType(req.Type), s.findTarget(biz.BackupType(req.Type), req.Storage, req.File)) {
+		return
+	}
+
+	if err = s.backupRepo.Delete(biz.BackupType(req.Type), req.Storage, req.File); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
@@ -119,6 +161,26 @@ func (s *BackupService) Delete(w http.ResponseWriter, r *http.Request) {
 	Success(w, nil)
 }
 
+func (s *BackupService) Verify(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.BackupFile](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if !s.owns(w, r, biz.BackupType(req.Type), s.findTarget(biz.BackupType(req.Type), req.Storage, req.File)) {
+		return
+	}
+
+	check, err := s.backupRepo.Verify(biz.BackupType(req.Type), req.Storage, req.File)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, check)
+}
+
 func (s *BackupService) Restore(w http.ResponseWriter, r *http.Request) {
 	req, err := Bind[request.BackupRestore](r)
 	if err != nil {
@@ -126,10 +188,188 @@ func (s *BackupService) Restore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = s.backupRepo.Restore(biz.BackupType(req.Type), req.File, req.Target); err != nil {
+	// 不能将其他用户的备份恢复到自己的网站或数据库
+	if !s.owns(w, r, biz.BackupType(req.Type), req.Target, s.findTarget(biz.BackupType(req.Type), req.Storage, req.File)) {
+		return
+	}
+
+	var point []biz.BackupPoint
+	if req.Time != "" || req.GTID != "" {
+		recovery := biz.BackupPoint{GTID: req.GTID}
+		if req.Time != "" {
+			if recovery.Time, err = time.ParseInLocation(time.DateTime, req.Time, time.Local); err != nil {
+				Error(w, http.StatusUnprocessableEntity, s.t.Get("invalid time: %s", req.Time))
+				return
+			}
+		}
+		point = append(point, recovery)
+	}
+
+	if err = s.backupRepo.Restore(biz.BackupType(req.Type), req.Storage, req.File, req.Target, point...); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+// SwapPoint 确认时间点恢复结果，用临时数据库替换目标数据库
+func (s *BackupService) SwapPoint(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.BackupSwapPoint](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if !s.owns(w, r, biz.BackupType(req.Type), req.Target) {
+		return
+	}
+
+	if err = s.backupRepo.SwapMySQLPoint(req.Target); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *BackupService) SnapshotFiles(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.BackupSnapshotFiles](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if !s.owns(w, r, biz.BackupTypeWebsiteSnapshot, s.findTarget(biz.BackupTypeWebsiteSnapshot, 0, req.ID)) {
+		return
+	}
+
+	files, err := s.backupRepo.SnapshotFiles(req.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, files)
+}
+
+func (s *BackupService) SnapshotRestoreFile(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.BackupSnapshotRestoreFile](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if !s.owns(w, r, biz.BackupTypeWebsiteSnapshot, req.Target, s.findTarget(biz.BackupTypeWebsiteSnapshot, 0, req.ID)) {
+		return
+	}
+
+	if err = s.backupRepo.RestoreSnapshotFile(req.ID, req.File, req.Target); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *BackupService) SnapshotClear(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.BackupSnapshotClear](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if !s.owns(w, r, biz.BackupTypeWebsiteSnapshot, req.Target) {
+		return
+	}
+
+	policy := snapshot.Policy{Daily: req.Daily, Weekly: req.Weekly, Monthly: req.Monthly}
+	if err = s.backupRepo.ClearSnapshots("", req.Target, policy); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
 
 	Success(w, nil)
 }
+
+// owns 检查备份目标是否都属于当前用户，不属于时返回错误响应
+func (s *BackupService) owns(w http.ResponseWriter, r *http.Request, typ biz.BackupType, targets ...string) bool {
+	owner := ownerID(r)
+	for _, target := range targets {
+		owned, err := s.ownsTarget(owner, typ, target)
+		if err != nil {
+			Error(w, http.StatusInternalServerError, "%v", err)
+			return false
+		}
+		if !owned {
+			Error(w, http.StatusForbidden, s.t.Get("permission denied: %s", "backups"))
+			return false
+		}
+	}
+
+	return true
+}
+
+// ownsTarget 检查备份目标是否属于用户，owner 为 0 时不限制
+// 普通用户只能管理自己网站和本地数据库的备份
+func (s *BackupService) ownsTarget(owner uint, typ biz.BackupType, target string) (bool, error) {
+	if owner == 0 {
+		return true, nil
+	}
+	if target == "" {
+		return false, nil
+	}
+
+	switch typ {
+	case biz.BackupTypeWebsite, biz.BackupTypeWebsiteSnapshot:
+		website, err := s.websiteRepo.GetByName(target)
+		if err != nil {
+			return false, nil
+		}
+		return s.userRepo.Owns(owner, "websites", website.ID)
+	case biz.BackupTypeMySQL, biz.BackupTypePostgres:
+		name := "local_mysql"
+		if typ == biz.BackupTypePostgres {
+			name = "local_postgresql"
+		}
+		server, err := s.databaseServerRepo.GetByName(name)
+		if err != nil {
+			return false, nil
+		}
+		return s.databaseRepo.Owns(owner, server.ID, target)
+	}
+
+	return false, nil
+}
+
+// fileTarget 备份文件对应的目标名称，无法识别时返回空
+func (s *BackupService) fileTarget(typ biz.BackupType, file *types.BackupFile) string {
+	if typ == biz.BackupTypeWebsiteSnapshot {
+		return file.Target
+	}
+	// 本地存储可以使用绝对路径，普通用户只能使用备份目录中的文件名
+	if filepath.Base(file.Name) != file.Name {
+		return ""
+	}
+	if matches := backupTargetPattern.FindStringSubmatch(file.Name); len(matches) == 2 {
+		return matches[1]
+	}
+
+	return ""
+}
+
+// findTarget 按文件名查找备份对应的目标名称，快照需要从快照列表中查找
+func (s *BackupService) findTarget(typ biz.BackupType, storage uint, name string) string {
+	if typ != biz.BackupTypeWebsiteSnapshot {
+		return s.fileTarget(typ, &types.BackupFile{Name: name})
+	}
+
+	list, _ := s.backupRepo.List(typ, storage)
+	for _, item := range list {
+		if item.Name == name {
+			return item.Target
+		}
+	}
+
+	return ""
+}
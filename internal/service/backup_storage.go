@@ -0,0 +1,100 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/libtnb/chix"
+
+	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/internal/http/request"
+)
+
+type BackupStorageService struct {
+	backupStorageRepo biz.BackupStorageRepo
+}
+
+func NewBackupStorageService(backupStorage biz.BackupStorageRepo) *BackupStorageService {
+	return &BackupStorageService{
+		backupStorageRepo: backupStorage,
+	}
+}
+
+func (s *BackupStorageService) List(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.Paginate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	backupStorages, total, err := s.backupStorageRepo.List(req.Page, req.Limit)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, chix.M{
+		"total": total,
+		"items": backupStorages,
+	})
+}
+
+func (s *BackupStorageService) Create(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.BackupStorageCreate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.backupStorageRepo.Create(req); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *BackupStorageService) Update(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.BackupStorageUpdate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.backupStorageRepo.Update(req); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *BackupStorageService) Get(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	backupStorage, err := s.backupStorageRepo.Get(req.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, backupStorage)
+}
+
+func (s *BackupStorageService) Delete(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.backupStorageRepo.Delete(req.ID); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
@@ -9,6 +9,7 @@ import (
 	"math/rand/v2"
 	stdos "os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -30,6 +31,8 @@ import (
 	"github.com/acepanel/panel/pkg/io"
 	"github.com/acepanel/panel/pkg/ntp"
 	"github.com/acepanel/panel/pkg/os"
+	"github.com/acepanel/panel/pkg/redact"
+	"github.com/acepanel/panel/pkg/snapshot"
 	"github.com/acepanel/panel/pkg/systemctl"
 	"github.com/acepanel/panel/pkg/tools"
 )
@@ -49,10 +52,13 @@ type CliService struct {
 	databaseServerRepo biz.DatabaseServerRepo
 	certRepo           biz.CertRepo
 	certAccountRepo    biz.CertAccountRepo
+	auditRepo          biz.AuditRepo
+	userSessionRepo    biz.UserSessionRepo
+	notifyRepo         biz.NotifyRepo
 	hash               hash.Hasher
 }
 
-func NewCliService(t *gotext.Locale, conf *config.Config, db *gorm.DB, appRepo biz.AppRepo, cache biz.CacheRepo, user biz.UserRepo, setting biz.SettingRepo, backup biz.BackupRepo, website biz.WebsiteRepo, databaseServer biz.DatabaseServerRepo, cert biz.CertRepo, certAccount biz.CertAccountRepo) *CliService {
+func NewCliService(t *gotext.Locale, conf *config.Config, db *gorm.DB, appRepo biz.AppRepo, cache biz.CacheRepo, user biz.UserRepo, setting biz.SettingRepo, backup biz.BackupRepo, website biz.WebsiteRepo, databaseServer biz.DatabaseServerRepo, cert biz.CertRepo, certAccount biz.CertAccountRepo, audit biz.AuditRepo, userSession biz.UserSessionRepo, notify biz.NotifyRepo) *CliService {
 	return &CliService{
 		hr:                 `+----------------------------------------------------`,
 		api:                api.NewAPI(app.Version, app.Locale),
@@ -68,10 +74,68 @@ func NewCliService(t *gotext.Locale, conf *config.Config, db *gorm.DB, appRepo b
 		databaseServerRepo: databaseServer,
 		certRepo:           cert,
 		certAccountRepo:    certAccount,
+		auditRepo:          audit,
+		userSessionRepo:    userSession,
+		notifyRepo:         notify,
 		hash:               hash.NewArgon2id(),
 	}
 }
 
+// Audit 记录命令的执行结果，审计日志写入失败不影响命令本身
+func (s *CliService) Audit(action cli.ActionFunc) cli.ActionFunc {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		err := action(ctx, cmd)
+
+		name := strings.TrimPrefix(cmd.FullName(), cmd.Root().Name+" ")
+		args := redact.Args(stdos.Args[1:])
+		// 位置参数中的敏感值
+		var secrets []string
+		switch name {
+		case "user password":
+			secrets = append(secrets, cmd.Args().Get(1))
+		case "setting write":
+			if redact.Sensitive(cmd.Args().Get(0)) {
+				secrets = append(secrets, cmd.Args().Get(1))
+			}
+		}
+		for i, arg := range args {
+			if arg != "" && slices.Contains(secrets, arg) {
+				args[i] = redact.Mask
+			}
+		}
+
+		target := cmd.Args().First()
+		if v := cmd.String("name"); v != "" {
+			target = "name=" + v
+		}
+		operator := stdos.Getenv("SUDO_USER")
+		if operator == "" {
+			operator = stdos.Getenv("USER")
+		}
+		ip := ""
+		if fields := strings.Fields(stdos.Getenv("SSH_CLIENT")); len(fields) > 0 {
+			ip = fields[0]
+		}
+
+		record := &biz.Audit{
+			Source:   biz.AuditSourceCLI,
+			Operator: operator,
+			IP:       ip,
+			Route:    name,
+			Target:   redact.Truncate(target, 255),
+			Summary:  redact.Truncate(strings.Join(args, " "), 2048),
+			Success:  err == nil,
+		}
+		if err != nil {
+			record.Status = 1
+			record.Message = redact.Truncate(err.Error(), 1024)
+		}
+		_ = s.auditRepo.Create(record)
+
+		return err
+	}
+}
+
 func (s *CliService) Restart(ctx context.Context, cmd *cli.Command) error {
 	if err := systemctl.Restart("panel"); err != nil {
 		return err
@@ -254,12 +318,7 @@ func (s *CliService) UserPassword(ctx context.Context, cmd *cli.Command) error {
 		return errors.New(s.t.Get("Failed to get user: %v", err))
 	}
 
-	hashed, err := s.hash.Make(password)
-	if err != nil {
-		return errors.New(s.t.Get("Failed to generate password: %v", err))
-	}
-	user.Password = hashed
-	if err = s.db.Save(user).Error; err != nil {
+	if err := s.userRepo.UpdatePassword(user.ID, password); err != nil {
 		return errors.New(s.t.Get("Failed to change password: %v", err))
 	}
 
@@ -267,6 +326,54 @@ func (s *CliService) UserPassword(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
+func (s *CliService) UserPasskey(ctx context.Context, cmd *cli.Command) error {
+	user := new(biz.User)
+	username := cmd.Args().Get(0)
+	if username == "" {
+		return errors.New(s.t.Get("Username cannot be empty"))
+	}
+
+	if err := s.db.Where("username", username).First(user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New(s.t.Get("User not exists"))
+		}
+		return errors.New(s.t.Get("Failed to get user: %v", err))
+	}
+
+	result := s.db.Where("user_id = ?", user.ID).Delete(&biz.UserPasskey{})
+	if result.Error != nil {
+		return errors.New(s.t.Get("Failed to remove passkeys: %v", result.Error))
+	}
+
+	fmt.Println(s.t.Get("Removed %d passkeys for user %s", result.RowsAffected, username))
+	return nil
+}
+
+// UserLogoutAll 吊销用户的全部登录会话，未指定用户名时吊销所有用户
+func (s *CliService) UserLogoutAll(ctx context.Context, cmd *cli.Command) error {
+	user := new(biz.User)
+	username := cmd.Args().Get(0)
+	if username != "" {
+		if err := s.db.Where("username", username).First(user).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New(s.t.Get("User not exists"))
+			}
+			return errors.New(s.t.Get("Failed to get user: %v", err))
+		}
+	}
+
+	if err := s.userSessionRepo.Revoke(user.ID, ""); err != nil {
+		return errors.New(s.t.Get("Failed to revoke sessions: %v", err))
+	}
+
+	if username == "" {
+		fmt.Println(s.t.Get("All users have been logged out"))
+	} else {
+		fmt.Println(s.t.Get("User %s has been logged out", username))
+	}
+	return nil
+}
+
 func (s *CliService) UserTwoFA(ctx context.Context, cmd *cli.Command) error {
 	user := new(biz.User)
 	username := cmd.Args().Get(0)
@@ -283,8 +390,7 @@ func (s *CliService) UserTwoFA(ctx context.Context, cmd *cli.Command) error {
 
 	// 已开启，关闭2FA
 	if user.TwoFA != "" {
-		user.TwoFA = ""
-		if err := s.db.Save(user).Error; err != nil {
+		if err := s.userRepo.UpdateTwoFA(user.ID, "", ""); err != nil {
 			return errors.New(s.t.Get("Failed to change 2FA status: %v", err))
 		}
 		fmt.Println(s.t.Get("2FA disabled for user %s", username))
@@ -530,7 +636,7 @@ func (s *CliService) WebsiteCreate(ctx context.Context, cmd *cli.Command) error
 		DB:      false,
 	}
 
-	website, err := s.websiteRepo.Create(req)
+	website, err := s.websiteRepo.Create(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -619,8 +725,8 @@ func (s *CliService) BackupWebsite(ctx context.Context, cmd *cli.Command) error
 	fmt.Println(s.hr)
 	fmt.Println(s.t.Get("|-Backup type: website"))
 	fmt.Println(s.t.Get("|-Backup target: %s", cmd.String("name")))
-	if err := s.backupRepo.Create(biz.BackupTypeWebsite, cmd.String("name"), cmd.String("path")); err != nil {
-		return errors.New(s.t.Get("Backup failed: %v", err))
+	if err := s.backupRepo.Create(biz.BackupTypeWebsite, cmd.String("name"), cmd.Uint("storage"), cmd.String("path")); err != nil {
+		return s.backupFailed(biz.BackupTypeWebsite, cmd.String("name"), err)
 	}
 	fmt.Println(s.hr)
 	fmt.Println(s.t.Get("☆ Backup successful [%s]", time.Now().Format(time.DateTime)))
@@ -635,8 +741,8 @@ func (s *CliService) BackupDatabase(ctx context.Context, cmd *cli.Command) error
 	fmt.Println(s.t.Get("|-Backup type: database"))
 	fmt.Println(s.t.Get("|-Database: %s", cmd.String("type")))
 	fmt.Println(s.t.Get("|-Backup target: %s", cmd.String("name")))
-	if err := s.backupRepo.Create(biz.BackupType(cmd.String("type")), cmd.String("name"), cmd.String("path")); err != nil {
-		return errors.New(s.t.Get("Backup failed: %v", err))
+	if err := s.backupRepo.Create(biz.BackupType(cmd.String("type")), cmd.String("name"), cmd.Uint("storage"), cmd.String("path")); err != nil {
+		return s.backupFailed(biz.BackupType(cmd.String("type")), cmd.String("name"), err)
 	}
 	fmt.Println(s.hr)
 	fmt.Println(s.t.Get("☆ Backup successful [%s]", time.Now().Format(time.DateTime)))
@@ -649,8 +755,8 @@ func (s *CliService) BackupPanel(ctx context.Context, cmd *cli.Command) error {
 	fmt.Println(s.t.Get("★ Start backup [%s]", time.Now().Format(time.DateTime)))
 	fmt.Println(s.hr)
 	fmt.Println(s.t.Get("|-Backup type: panel"))
-	if err := s.backupRepo.Create(biz.BackupTypePanel, "", cmd.String("path")); err != nil {
-		return errors.New(s.t.Get("Backup failed: %v", err))
+	if err := s.backupRepo.Create(biz.BackupTypePanel, "", cmd.Uint("storage"), cmd.String("path")); err != nil {
+		return s.backupFailed(biz.BackupTypePanel, "", err)
 	}
 	fmt.Println(s.hr)
 	fmt.Println(s.t.Get("☆ Backup successful [%s]", time.Now().Format(time.DateTime)))
@@ -658,13 +764,134 @@ func (s *CliService) BackupPanel(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
-func (s *CliService) BackupClear(ctx context.Context, cmd *cli.Command) error {
-	path, err := s.backupRepo.GetPath(biz.BackupType(cmd.String("type")))
+func (s *CliService) BackupSnapshot(ctx context.Context, cmd *cli.Command) error {
+	fmt.Println(s.hr)
+	fmt.Println(s.t.Get("★ Start backup [%s]", time.Now().Format(time.DateTime)))
+	fmt.Println(s.hr)
+	fmt.Println(s.t.Get("|-Backup type: website snapshot"))
+	fmt.Println(s.t.Get("|-Backup target: %s", cmd.String("name")))
+	if err := s.backupRepo.Create(biz.BackupTypeWebsiteSnapshot, cmd.String("name"), 0, cmd.String("path")); err != nil {
+		return s.backupFailed(biz.BackupTypeWebsiteSnapshot, cmd.String("name"), err)
+	}
+	fmt.Println(s.hr)
+	fmt.Println(s.t.Get("☆ Backup successful [%s]", time.Now().Format(time.DateTime)))
+	fmt.Println(s.hr)
+	return nil
+}
+
+// backupFailed 发送备份失败通知，通知发送失败不影响命令本身
+func (s *CliService) backupFailed(typ biz.BackupType, target string, err error) error {
+	content := s.t.Get("Backup of %s %s failed: %v", typ, target, err)
+	if notifyErr := s.notifyRepo.Notify(biz.NotifyEventBackupFailed, s.t.Get("Backup failed"), content); notifyErr != nil {
+		fmt.Println(s.t.Get("|-Failed to send notification: %v", notifyErr))
+	}
+
+	return errors.New(s.t.Get("Backup failed: %v", err))
+}
+
+func (s *CliService) BackupSnapshotClear(ctx context.Context, cmd *cli.Command) error {
+	policy := snapshot.Policy{
+		Daily:   cmd.Int("daily"),
+		Weekly:  cmd.Int("weekly"),
+		Monthly: cmd.Int("monthly"),
+	}
+
+	fmt.Println(s.hr)
+	fmt.Println(s.t.Get("★ Start cleaning [%s]", time.Now().Format(time.DateTime)))
+	fmt.Println(s.hr)
+	fmt.Println(s.t.Get("|-Cleaning type: website snapshot"))
+	fmt.Println(s.t.Get("|-Cleaning target: %s", cmd.String("name")))
+	fmt.Println(s.t.Get("|-Keep daily: %d, weekly: %d, monthly: %d", policy.Daily, policy.Weekly, policy.Monthly))
+	if err := s.backupRepo.ClearSnapshots(cmd.String("path"), cmd.String("name"), policy); err != nil {
+		return errors.New(s.t.Get("Cleaning failed: %v", err))
+	}
+	fmt.Println(s.hr)
+	fmt.Println(s.t.Get("☆ Cleaning successful [%s]", time.Now().Format(time.DateTime)))
+	fmt.Println(s.hr)
+	return nil
+}
+
+func (s *CliService) BackupRestore(ctx context.Context, cmd *cli.Command) error {
+	var point []biz.BackupPoint
+	if cmd.String("time") != "" || cmd.String("gtid") != "" {
+		recovery := biz.BackupPoint{GTID: cmd.String("gtid")}
+		if cmd.String("time") != "" {
+			var err error
+			if recovery.Time, err = time.ParseInLocation(time.DateTime, cmd.String("time"), time.Local); err != nil {
+				return errors.New(s.t.Get("invalid time: %s", cmd.String("time")))
+			}
+		}
+		point = append(point, recovery)
+	}
+
+	fmt.Println(s.hr)
+	fmt.Println(s.t.Get("★ Start restore [%s]", time.Now().Format(time.DateTime)))
+	fmt.Println(s.hr)
+	fmt.Println(s.t.Get("|-Restore type: %s", cmd.String("type")))
+	fmt.Println(s.t.Get("|-Backup file: %s", cmd.String("file")))
+	fmt.Println(s.t.Get("|-Restore target: %s", cmd.String("name")))
+	if len(point) > 0 {
+		if point[0].GTID != "" {
+			fmt.Println(s.t.Get("|-Recover to GTID: %s", point[0].GTID))
+		} else {
+			fmt.Println(s.t.Get("|-Recover to time: %s", point[0].Time.Format(time.DateTime)))
+		}
+	}
+	if err := s.backupRepo.Restore(biz.BackupType(cmd.String("type")), cmd.Uint("storage"), cmd.String("file"), cmd.String("name"), point...); err != nil {
+		return errors.New(s.t.Get("Restore failed: %v", err))
+	}
+	fmt.Println(s.hr)
+	fmt.Println(s.t.Get("☆ Restore successful [%s]", time.Now().Format(time.DateTime)))
+	fmt.Println(s.hr)
+	return nil
+}
+
+func (s *CliService) BackupSwap(ctx context.Context, cmd *cli.Command) error {
+	fmt.Println(s.hr)
+	fmt.Println(s.t.Get("★ Start restore [%s]", time.Now().Format(time.DateTime)))
+	fmt.Println(s.hr)
+	fmt.Println(s.t.Get("|-Restore target: %s", cmd.String("name")))
+	if err := s.backupRepo.SwapMySQLPoint(cmd.String("name")); err != nil {
+		return errors.New(s.t.Get("Restore failed: %v", err))
+	}
+	fmt.Println(s.hr)
+	fmt.Println(s.t.Get("☆ Restore successful [%s]", time.Now().Format(time.DateTime)))
+	fmt.Println(s.hr)
+	return nil
+}
+
+func (s *CliService) BackupVerify(ctx context.Context, cmd *cli.Command) error {
+	fmt.Println(s.hr)
+	fmt.Println(s.t.Get("★ Start verification [%s]", time.Now().Format(time.DateTime)))
+	fmt.Println(s.hr)
+	fmt.Println(s.t.Get("|-Backup type: %s", cmd.String("type")))
+	fmt.Println(s.t.Get("|-Backup file: %s", cmd.String("file")))
+	check, err := s.backupRepo.Verify(biz.BackupType(cmd.String("type")), cmd.Uint("storage"), cmd.String("file"))
 	if err != nil {
-		return err
+		return errors.New(s.t.Get("Verification failed: %v", err))
 	}
-	if cmd.String("path") != "" {
-		path = cmd.String("path")
+	fmt.Println(s.t.Get("|-Checksum: %s", check.Checksum))
+	if check.Status != biz.BackupCheckStatusPassed {
+		return errors.New(s.t.Get("Verification failed: %v", check.Message))
+	}
+	fmt.Println(s.hr)
+	fmt.Println(s.t.Get("☆ Verification successful [%s]", time.Now().Format(time.DateTime)))
+	fmt.Println(s.hr)
+	return nil
+}
+
+func (s *CliService) BackupClear(ctx context.Context, cmd *cli.Command) error {
+	path := cmd.String("path")
+	if path == "" {
+		// 远程存储默认使用备份类型作为目录
+		if cmd.Uint("storage") != 0 {
+			path = cmd.String("type")
+		} else {
+			var err error
+			if path, err = s.backupRepo.GetPath(biz.BackupType(cmd.String("type"))); err != nil {
+				return err
+			}
+		}
 	}
 
 	fmt.Println(s.hr)
@@ -673,7 +900,7 @@ func (s *CliService) BackupClear(ctx context.Context, cmd *cli.Command) error {
 	fmt.Println(s.t.Get("|-Cleaning type: %s", cmd.String("type")))
 	fmt.Println(s.t.Get("|-Cleaning target: %s", cmd.String("file")))
 	fmt.Println(s.t.Get("|-Keep count: %d", cmd.Int("save")))
-	if err = s.backupRepo.ClearExpired(path, cmd.String("file"), cmd.Int("save")); err != nil {
+	if err := s.backupRepo.ClearExpired(biz.BackupType(cmd.String("type")), cmd.Uint("storage"), path, cmd.String("file"), cmd.Int("save")); err != nil {
 		return errors.New(s.t.Get("Cleaning failed: %v", err))
 	}
 	fmt.Println(s.hr)
@@ -721,7 +948,7 @@ func (s *CliService) CutoffClear(ctx context.Context, cmd *cli.Command) error {
 	fmt.Println(s.t.Get("|-Cleaning type: %s", cmd.String("type")))
 	fmt.Println(s.t.Get("|-Cleaning target: %s", cmd.String("file")))
 	fmt.Println(s.t.Get("|-Keep count: %d", cmd.Int("save")))
-	if err := s.backupRepo.ClearExpired(path, cmd.String("file"), cmd.Int("save")); err != nil {
+	if err := s.backupRepo.ClearExpired("", 0, path, cmd.String("file"), cmd.Int("save")); err != nil {
 		return err
 	}
 	fmt.Println(s.hr)
@@ -918,7 +1145,14 @@ func (s *CliService) Init(ctx context.Context, cmd *cli.Command) error {
 		{Key: biz.SettingKeyChannel, Value: "stable"},
 		{Key: biz.SettingKeyVersion, Value: app.Version},
 		{Key: biz.SettingKeyMonitor, Value: "true"},
-		{Key: biz.SettingKeyMonitorDays, Value: "30"},
+		{Key: biz.SettingKeyMonitorDays, Value: "7"},
+		{Key: biz.SettingKeyMonitorFiveMinDays, Value: "90"},
+		{Key: biz.SettingKeyMonitorHourlyDays, Value: "365"},
+		{Key: biz.SettingKeyMonitorDisks, Value: "[]"},
+		{Key: biz.SettingKeyAuditDays, Value: "180"},
+		{Key: biz.SettingKeyLoginMaxFailures, Value: "5"},
+		{Key: biz.SettingKeyLoginLockMinutes, Value: "15"},
+		{Key: biz.SettingKeyLoginAutoBan, Value: "false"},
 		{Key: biz.SettingKeyBackupPath, Value: filepath.Join(app.Root, "backup")},
 		{Key: biz.SettingKeyWebsitePath, Value: filepath.Join(app.Root, "sites")},
 		{Key: biz.SettingKeyWebsiteTLSVersions, Value: `["TLSv1.2","TLSv1.3"]`},
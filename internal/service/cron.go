@@ -2,20 +2,30 @@ package service
 
 import (
 	"net/http"
+	"slices"
+	"strings"
 
+	"github.com/leonelquinteros/gotext"
 	"github.com/libtnb/chix"
 
 	"github.com/acepanel/panel/internal/biz"
 	"github.com/acepanel/panel/internal/http/request"
+	"github.com/acepanel/panel/pkg/io"
 )
 
 type CronService struct {
-	cronRepo biz.CronRepo
+	t           *gotext.Locale
+	cronRepo    biz.CronRepo
+	websiteRepo biz.WebsiteRepo
+	userRepo    biz.UserRepo
 }
 
-func NewCronService(cron biz.CronRepo) *CronService {
+func NewCronService(t *gotext.Locale, cron biz.CronRepo, website biz.WebsiteRepo, user biz.UserRepo) *CronService {
 	return &CronService{
-		cronRepo: cron,
+		t:           t,
+		cronRepo:    cron,
+		websiteRepo: website,
+		userRepo:    user,
 	}
 }
 
@@ -26,7 +36,7 @@ func (s *CronService) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cron, total, err := s.cronRepo.List(req.Page, req.Limit)
+	cron, total, err := s.cronRepo.List(ownerID(r), req.Page, req.Limit)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -45,6 +55,25 @@ func (s *CronService) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 计划任务以 root 执行，非管理员只能为自己的网站创建备份和日志切割任务
+	if owner := ownerID(r); owner != 0 {
+		allowed := req.Type == "backup" && slices.Contains([]string{"website", "website_snapshot"}, req.BackupType) || req.Type == "cutoff"
+		if !allowed || req.BackupPath != "" {
+			Error(w, http.StatusForbidden, s.t.Get("only website backup and log cutoff tasks with default path are allowed"))
+			return
+		}
+		website, err := s.websiteRepo.GetByName(req.Target)
+		if err != nil {
+			Error(w, http.StatusInternalServerError, "%v", err)
+			return
+		}
+		if owned, _ := s.userRepo.Owns(owner, "websites", website.ID); !owned {
+			Error(w, http.StatusForbidden, s.t.Get("permission denied: %s", "websites"))
+			return
+		}
+		req.UserID = owner
+	}
+
 	if err = s.cronRepo.Create(req); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -60,6 +89,20 @@ func (s *CronService) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 非管理员不能修改脚本内容
+	if ownerID(r) != 0 {
+		cron, err := s.cronRepo.Get(req.ID)
+		if err != nil {
+			Error(w, http.StatusInternalServerError, "%v", err)
+			return
+		}
+		script, _ := io.Read(cron.Shell)
+		if strings.ReplaceAll(req.Script, "\r\n", "\n") != script {
+			Error(w, http.StatusForbidden, s.t.Get("permission denied: %s", "crons"))
+			return
+		}
+	}
+
 	if err = s.cronRepo.Update(req); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -3,6 +3,7 @@ package service
 import (
 	"net/http"
 
+	"github.com/leonelquinteros/gotext"
 	"github.com/libtnb/chix"
 
 	"github.com/acepanel/panel/internal/biz"
@@ -10,11 +11,13 @@ import (
 )
 
 type DatabaseService struct {
+	t            *gotext.Locale
 	databaseRepo biz.DatabaseRepo
 }
 
-func NewDatabaseService(database biz.DatabaseRepo) *DatabaseService {
+func NewDatabaseService(t *gotext.Locale, database biz.DatabaseRepo) *DatabaseService {
 	return &DatabaseService{
+		t:            t,
 		databaseRepo: database,
 	}
 }
@@ -26,7 +29,7 @@ func (s *DatabaseService) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	databases, total, err := s.databaseRepo.List(req.Page, req.Limit)
+	databases, total, err := s.databaseRepo.List(ownerID(r), req.Page, req.Limit)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -45,6 +48,10 @@ func (s *DatabaseService) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if owner := ownerID(r); owner != 0 {
+		req.UserID = owner
+	}
+
 	if err = s.databaseRepo.Create(req); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -60,6 +67,10 @@ func (s *DatabaseService) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.owns(w, r, req.ServerID, req.Name) {
+		return
+	}
+
 	if err = s.databaseRepo.Delete(req.ServerID, req.Name); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -75,6 +86,10 @@ func (s *DatabaseService) Comment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.owns(w, r, req.ServerID, req.Name) {
+		return
+	}
+
 	if err = s.databaseRepo.Comment(req); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -82,3 +97,18 @@ func (s *DatabaseService) Comment(w http.ResponseWriter, r *http.Request) {
 
 	Success(w, nil)
 }
+
+// owns 检查数据库是否属于当前用户，不属于时返回错误响应
+func (s *DatabaseService) owns(w http.ResponseWriter, r *http.Request, serverID uint, name string) bool {
+	owned, err := s.databaseRepo.Owns(ownerID(r), serverID, name)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return false
+	}
+	if !owned {
+		Error(w, http.StatusForbidden, s.t.Get("permission denied: %s", "databases"))
+		return false
+	}
+
+	return true
+}
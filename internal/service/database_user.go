@@ -26,7 +26,7 @@ func (s *DatabaseUserService) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	users, total, err := s.databaseUserRepo.List(req.Page, req.Limit)
+	users, total, err := s.databaseUserRepo.List(ownerID(r), req.Page, req.Limit)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -45,6 +45,10 @@ func (s *DatabaseUserService) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if owner := ownerID(r); owner != 0 {
+		req.UserID = owner
+	}
+
 	if err = s.databaseUserRepo.Create(req); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
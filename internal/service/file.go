@@ -31,14 +31,16 @@ import (
 )
 
 type FileService struct {
-	t        *gotext.Locale
-	taskRepo biz.TaskRepo
+	t           *gotext.Locale
+	taskRepo    biz.TaskRepo
+	websiteRepo biz.WebsiteRepo
 }
 
-func NewFileService(t *gotext.Locale, task biz.TaskRepo) *FileService {
+func NewFileService(t *gotext.Locale, task biz.TaskRepo, website biz.WebsiteRepo) *FileService {
 	return &FileService{
-		t:        t,
-		taskRepo: task,
+		t:           t,
+		taskRepo:    task,
+		websiteRepo: website,
 	}
 }
 
@@ -49,6 +51,10 @@ func (s *FileService) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.checkPath(w, r, req.Path) {
+		return
+	}
+
 	if !req.Dir {
 		if _, err = shell.Execf("touch %s", req.Path); err != nil {
 			Error(w, http.StatusInternalServerError, "%v", err)
@@ -72,6 +78,10 @@ func (s *FileService) Content(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.checkPath(w, r, req.Path) {
+		return
+	}
+
 	fileInfo, err := stdos.Stat(req.Path)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
@@ -110,6 +120,10 @@ func (s *FileService) Save(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.checkPath(w, r, req.Path) {
+		return
+	}
+
 	fileInfo, err := stdos.Stat(req.Path)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
@@ -131,6 +145,10 @@ func (s *FileService) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.checkPath(w, r, req.Path) {
+		return
+	}
+
 	banned := []string{"/", app.Root, filepath.Join(app.Root, "server"), filepath.Join(app.Root, "panel")}
 	if slices.Contains(banned, req.Path) {
 		Error(w, http.StatusForbidden, s.t.Get("please don't do this"))
@@ -152,6 +170,9 @@ func (s *FileService) Upload(w http.ResponseWriter, r *http.Request) {
 	}
 
 	path := r.FormValue("path")
+	if !s.checkPath(w, r, path) {
+		return
+	}
 	_, handler, err := r.FormFile("file")
 	if err != nil {
 		Error(w, http.StatusInternalServerError, s.t.Get("upload file error: %v", err))
@@ -196,6 +217,10 @@ func (s *FileService) Exist(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.checkPath(w, r, paths...) {
+		return
+	}
+
 	var results []bool
 	for item := range slices.Values(paths) {
 		results = append(results, io.Exists(item))
@@ -214,6 +239,12 @@ func (s *FileService) Move(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	for item := range slices.Values(req) {
+		if !s.checkPath(w, r, item.Source, item.Target) {
+			return
+		}
+	}
+
 	for item := range slices.Values(req) {
 		if io.Exists(item.Target) && !item.Force {
 			continue
@@ -243,6 +274,12 @@ func (s *FileService) Copy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	for item := range slices.Values(req) {
+		if !s.checkPath(w, r, item.Source, item.Target) {
+			return
+		}
+	}
+
 	for item := range slices.Values(req) {
 		if io.Exists(item.Target) && !item.Force {
 			continue
@@ -269,6 +306,10 @@ func (s *FileService) Download(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.checkPath(w, r, req.Path) {
+		return
+	}
+
 	info, err := stdos.Stat(req.Path)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
@@ -291,6 +332,10 @@ func (s *FileService) RemoteDownload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.checkPath(w, r, req.Path) {
+		return
+	}
+
 	timestamp := time.Now().Format("20060102150405")
 	task := new(biz.Task)
 	task.Name = s.t.Get("Download remote file %v", filepath.Base(req.Path))
@@ -313,6 +358,10 @@ func (s *FileService) Info(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.checkPath(w, r, req.Path) {
+		return
+	}
+
 	info, err := stdos.Stat(req.Path)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
@@ -337,6 +386,10 @@ func (s *FileService) Size(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.checkPath(w, r, req.Path) {
+		return
+	}
+
 	info, err := stdos.Stat(req.Path)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
@@ -367,12 +420,21 @@ func (s *FileService) Permission(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.checkPath(w, r, req.Path) {
+		return
+	}
+
 	// 解析成8进制
 	mode, err := strconv.ParseUint(req.Mode, 8, 64)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
+	// 非管理员不能修改属主或设置特殊权限位
+	if ownerID(r) != 0 && (req.Owner != "www" || req.Group != "www" || mode&^0777 != 0) {
+		Error(w, http.StatusForbidden, s.t.Get("please don't do this"))
+		return
+	}
 
 	if err = io.Chmod(req.Path, stdos.FileMode(mode)); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
@@ -393,6 +455,17 @@ func (s *FileService) Compress(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	paths := []string{req.Dir, req.File}
+	for item := range slices.Values(req.Paths) {
+		if !filepath.IsAbs(item) {
+			item = filepath.Join(req.Dir, item)
+		}
+		paths = append(paths, item)
+	}
+	if !s.checkPath(w, r, paths...) {
+		return
+	}
+
 	if err = io.Compress(req.Dir, req.Paths, req.File); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -409,6 +482,10 @@ func (s *FileService) UnCompress(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.checkPath(w, r, req.File, req.Path) {
+		return
+	}
+
 	if err = io.UnCompress(req.File, req.Path); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -434,6 +511,10 @@ func (s *FileService) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !s.checkPath(w, r, req.Path) {
+		return
+	}
+
 	var list []stdos.DirEntry
 	if req.Keyword != "" {
 		list, err = io.SearchX(req.Path, req.Keyword, req.Sub)
@@ -532,3 +613,25 @@ func (s *FileService) setPermission(path string, mode stdos.FileMode, owner, gro
 	_ = io.Chmod(path, mode)
 	_ = io.Chown(path, owner, group)
 }
+
+// checkPath 非管理员只能访问自己网站目录下的文件
+func (s *FileService) checkPath(w http.ResponseWriter, r *http.Request, paths ...string) bool {
+	owner := ownerID(r)
+	if owner == 0 {
+		return true
+	}
+
+	roots, err := s.websiteRepo.Paths(owner)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return false
+	}
+	for path := range slices.Values(paths) {
+		if !io.Within(path, roots...) {
+			Error(w, http.StatusForbidden, s.t.Get("access denied: %s", path))
+			return false
+		}
+	}
+
+	return true
+}
@@ -13,14 +13,16 @@ import (
 )
 
 type FileService struct {
-	t        *gotext.Locale
-	taskRepo biz.TaskRepo
+	t           *gotext.Locale
+	taskRepo    biz.TaskRepo
+	websiteRepo biz.WebsiteRepo
 }
 
-func NewFileService(t *gotext.Locale, task biz.TaskRepo) *FileService {
+func NewFileService(t *gotext.Locale, task biz.TaskRepo, website biz.WebsiteRepo) *FileService {
 	return &FileService{
-		t:        t,
-		taskRepo: task,
+		t:           t,
+		taskRepo:    task,
+		websiteRepo: website,
 	}
 }
 
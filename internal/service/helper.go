@@ -8,6 +8,7 @@ import (
 
 	"github.com/gookit/validate"
 	"github.com/libtnb/chix"
+	"github.com/spf13/cast"
 
 	"github.com/acepanel/panel/internal/http/request"
 )
@@ -147,3 +148,8 @@ func Paginate[T any](r *http.Request, items []T) (pagedItems []T, total uint) {
 
 	return items[start:end], total
 }
+
+// ownerID 当前用户的资源归属 ID，管理员为 0 表示不限制
+func ownerID(r *http.Request) uint {
+	return cast.ToUint(r.Context().Value("owner_id"))
+}
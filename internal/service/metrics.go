@@ -0,0 +1,99 @@
+package service
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/leonelquinteros/gotext"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/acepanel/panel/internal/app"
+	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/pkg/metrics"
+	"github.com/acepanel/panel/pkg/queue"
+)
+
+type MetricsService struct {
+	t           *gotext.Locale
+	settingRepo biz.SettingRepo
+	handler     http.Handler
+}
+
+func NewMetricsService(t *gotext.Locale, log *slog.Logger, setting biz.SettingRepo, task biz.TaskRepo, website biz.WebsiteRepo, queue *queue.Queue, httpMetrics *metrics.HTTP) *MetricsService {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		metrics.NewHost(),
+		metrics.NewAccessLog(website.AccessLogs),
+		httpMetrics,
+		&panelCollector{task: task, queue: queue},
+	)
+
+	return &MetricsService{
+		t:           t,
+		settingRepo: setting,
+		handler: promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+			ErrorLog:      slog.NewLogLogger(log.Handler(), slog.LevelWarn),
+			ErrorHandling: promhttp.ContinueOnError,
+		}),
+	}
+}
+
+// Metrics Prometheus 抓取接口，使用 Bearer 令牌验证，未设置令牌时关闭
+func (s *MetricsService) Metrics(w http.ResponseWriter, r *http.Request) {
+	token, err := s.settingRepo.Get(biz.SettingKeyMetricsToken)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	if token == "" {
+		Error(w, http.StatusNotFound, s.t.Get("metrics endpoint is disabled"))
+		return
+	}
+
+	auth, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || subtle.ConstantTimeCompare([]byte(auth), []byte(token)) != 1 {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+		Error(w, http.StatusUnauthorized, s.t.Get("invalid metrics token"))
+		return
+	}
+
+	s.handler.ServeHTTP(w, r)
+}
+
+var (
+	panelInfoDesc   = prometheus.NewDesc("ace_panel_info", "Panel version information.", []string{"version"}, nil)
+	queueLengthDesc = prometheus.NewDesc("ace_queue_length", "Jobs waiting in the panel queue.", nil, nil)
+	tasksDesc       = prometheus.NewDesc("ace_tasks", "Panel background tasks by status.", []string{"status"}, nil)
+)
+
+// panelCollector 面板内部状态
+type panelCollector struct {
+	task  biz.TaskRepo
+	queue *queue.Queue
+}
+
+func (c *panelCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- panelInfoDesc
+	ch <- queueLengthDesc
+	ch <- tasksDesc
+}
+
+func (c *panelCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(panelInfoDesc, prometheus.GaugeValue, 1, app.Version)
+	ch <- prometheus.MustNewConstMetric(queueLengthDesc, prometheus.GaugeValue, float64(c.queue.Len()))
+
+	counts, err := c.task.StatusCount()
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(tasksDesc, err)
+		return
+	}
+	for _, status := range []biz.TaskStatus{biz.TaskStatusWaiting, biz.TaskStatusRunning, biz.TaskStatusSuccess, biz.TaskStatusFailed} {
+		ch <- prometheus.MustNewConstMetric(tasksDesc, prometheus.GaugeValue, float64(counts[status]), string(status))
+	}
+}
@@ -13,12 +13,14 @@ import (
 type MonitorService struct {
 	settingRepo biz.SettingRepo
 	monitorRepo biz.MonitorRepo
+	alertRepo   biz.AlertRepo
 }
 
-func NewMonitorService(setting biz.SettingRepo, monitor biz.MonitorRepo) *MonitorService {
+func NewMonitorService(setting biz.SettingRepo, monitor biz.MonitorRepo, alert biz.AlertRepo) *MonitorService {
 	return &MonitorService{
 		settingRepo: setting,
 		monitorRepo: monitor,
+		alertRepo:   alert,
 	}
 }
 
@@ -63,64 +65,110 @@ func (s *MonitorService) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	monitors, err := s.monitorRepo.List(time.UnixMilli(req.Start), time.UnixMilli(req.End))
+	points, err := s.monitorRepo.List(time.UnixMilli(req.Start), time.UnixMilli(req.End))
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
-	if len(monitors) == 0 {
+	if len(points) == 0 {
 		Success(w, types.MonitorData{})
 		return
 	}
 
+	// 内存、流量和磁盘读写单位为 MB
+	mb := func(v float64) string {
+		return fmt.Sprintf("%.2f", v/1024/1024)
+	}
+
 	var list types.MonitorData
-	var bytesSent uint64
-	var bytesRecv uint64
-	var bytesSent2 uint64
-	var bytesRecv2 uint64
-	for _, net := range monitors[0].Info.Net {
-		if net.Name == "lo" {
-			continue
+	list.Resolution = points[0].Resolution
+	list.DiskIO = make(map[string]*types.DiskIO)
+	list.DiskUsage = make(map[string][]string)
+	for _, point := range points {
+		for name := range point.Stats.DiskIO {
+			list.DiskIO[name] = new(types.DiskIO)
 		}
-		bytesSent += net.BytesSent
-		bytesRecv += net.BytesRecv
-	}
-	for i, monitor := range monitors {
-		// 跳过第一条数据，因为第一条数据的流量为 0
-		if i == 0 {
-			// MB
-			list.Mem.Total = fmt.Sprintf("%.2f", float64(monitor.Info.Mem.Total)/1024/1024)
-			list.SWAP.Total = fmt.Sprintf("%.2f", float64(monitor.Info.Swap.Total)/1024/1024)
-			continue
+		for path := range point.Stats.DiskUsage {
+			list.DiskUsage[path] = make([]string, 0, len(points))
 		}
-		for _, net := range monitor.Info.Net {
-			if net.Name == "lo" {
+	}
+	list.Mem.Total = mb(float64(points[len(points)-1].Stats.MemTotal))
+	list.SWAP.Total = mb(float64(points[len(points)-1].Stats.SwapTotal))
+	for _, point := range points {
+		stats := point.Stats
+		list.Times = append(list.Times, point.Time.Format(time.DateTime))
+		list.Load.Load1 = append(list.Load.Load1, stats.Load1.Avg)
+		list.Load.Load5 = append(list.Load.Load5, stats.Load5.Avg)
+		list.Load.Load15 = append(list.Load.Load15, stats.Load15.Avg)
+		list.CPU.Percent = append(list.CPU.Percent, fmt.Sprintf("%.2f", stats.CPU.Avg))
+		list.Mem.Available = append(list.Mem.Available, mb(stats.MemAvailable.Avg))
+		list.Mem.Used = append(list.Mem.Used, mb(stats.MemUsed.Avg))
+		list.SWAP.Used = append(list.SWAP.Used, mb(stats.SwapUsed.Avg))
+		list.SWAP.Free = append(list.SWAP.Free, mb(stats.SwapFree.Avg))
+		list.Net.Sent = append(list.Net.Sent, mb(float64(stats.NetSent)))
+		list.Net.Recv = append(list.Net.Recv, mb(float64(stats.NetRecv)))
+		list.Net.Tx = append(list.Net.Tx, mb(stats.NetTx.Avg))
+		list.Net.Rx = append(list.Net.Rx, mb(stats.NetRx.Avg))
+		// 设备或挂载点在部分时间点没有数据时填充 -
+		for name, item := range list.DiskIO {
+			io, ok := stats.DiskIO[name]
+			if !ok {
+				item.Read = append(item.Read, "-")
+				item.Write = append(item.Write, "-")
+				item.ReadIOPS = append(item.ReadIOPS, "-")
+				item.WriteIOPS = append(item.WriteIOPS, "-")
+				if point.Resolution != biz.MonitorResolutionRaw {
+					item.ReadMax = append(item.ReadMax, "-")
+					item.WriteMax = append(item.WriteMax, "-")
+				}
 				continue
 			}
-			bytesSent2 += net.BytesSent
-			bytesRecv2 += net.BytesRecv
+			item.Read = append(item.Read, mb(io.Read.Avg))
+			item.Write = append(item.Write, mb(io.Write.Avg))
+			item.ReadIOPS = append(item.ReadIOPS, fmt.Sprintf("%.2f", io.ReadIOPS.Avg))
+			item.WriteIOPS = append(item.WriteIOPS, fmt.Sprintf("%.2f", io.WriteIOPS.Avg))
+			if point.Resolution != biz.MonitorResolutionRaw {
+				item.ReadMax = append(item.ReadMax, mb(io.Read.Max))
+				item.WriteMax = append(item.WriteMax, mb(io.Write.Max))
+			}
+		}
+		for path := range list.DiskUsage {
+			if usage, ok := stats.DiskUsage[path]; ok {
+				list.DiskUsage[path] = append(list.DiskUsage[path], fmt.Sprintf("%.2f", usage.Avg))
+			} else {
+				list.DiskUsage[path] = append(list.DiskUsage[path], "-")
+			}
+		}
+
+		// 汇总数据额外返回区间内的最小值和最大值
+		if point.Resolution == biz.MonitorResolutionRaw {
+			continue
 		}
-		list.Times = append(list.Times, monitor.CreatedAt.Format(time.DateTime))
-		list.Load.Load1 = append(list.Load.Load1, monitor.Info.Load.Load1)
-		list.Load.Load5 = append(list.Load.Load5, monitor.Info.Load.Load5)
-		list.Load.Load15 = append(list.Load.Load15, monitor.Info.Load.Load15)
-		list.CPU.Percent = append(list.CPU.Percent, fmt.Sprintf("%.2f", monitor.Info.Percent))
-		list.Mem.Available = append(list.Mem.Available, fmt.Sprintf("%.2f", float64(monitor.Info.Mem.Available)/1024/1024))
-		list.Mem.Used = append(list.Mem.Used, fmt.Sprintf("%.2f", float64(monitor.Info.Mem.Used)/1024/1024))
-		list.SWAP.Used = append(list.SWAP.Used, fmt.Sprintf("%.2f", float64(monitor.Info.Swap.Used)/1024/1024))
-		list.SWAP.Free = append(list.SWAP.Free, fmt.Sprintf("%.2f", float64(monitor.Info.Swap.Free)/1024/1024))
-		list.Net.Sent = append(list.Net.Sent, fmt.Sprintf("%.2f", float64(bytesSent2/1024/1024)))
-		list.Net.Recv = append(list.Net.Recv, fmt.Sprintf("%.2f", float64(bytesRecv2/1024/1024)))
-
-		// 监控频率为 1 分钟，所以这里除以 60 即可得到每秒的流量
-		list.Net.Tx = append(list.Net.Tx, fmt.Sprintf("%.2f", float64(bytesSent2-bytesSent)/60/1024/1024))
-		list.Net.Rx = append(list.Net.Rx, fmt.Sprintf("%.2f", float64(bytesRecv2-bytesRecv)/60/1024/1024))
-
-		bytesSent = bytesSent2
-		bytesRecv = bytesRecv2
-		bytesSent2 = 0
-		bytesRecv2 = 0
+		list.Load.Load1Max = append(list.Load.Load1Max, stats.Load1.Max)
+		list.CPU.Min = append(list.CPU.Min, fmt.Sprintf("%.2f", stats.CPU.Min))
+		list.CPU.Max = append(list.CPU.Max, fmt.Sprintf("%.2f", stats.CPU.Max))
+		list.Mem.UsedMin = append(list.Mem.UsedMin, mb(stats.MemUsed.Min))
+		list.Mem.UsedMax = append(list.Mem.UsedMax, mb(stats.MemUsed.Max))
+		list.Net.TxMax = append(list.Net.TxMax, mb(stats.NetTx.Max))
+		list.Net.RxMax = append(list.Net.RxMax, mb(stats.NetRx.Max))
 	}
 
 	Success(w, list)
 }
+
+// Processes 指定时间资源占用最高的进程、服务和容器
+func (s *MonitorService) Processes(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.MonitorProcessList](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	processes, err := s.monitorRepo.Processes(time.UnixMilli(req.Time), biz.MonitorProcessType(req.Type), req.Sort)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, processes)
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/libtnb/chix"
+
+	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/internal/http/request"
+)
+
+// AlertRuleList 告警规则列表
+func (s *MonitorService) AlertRuleList(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.Paginate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	rules, total, err := s.alertRepo.RuleList(req.Page, req.Limit)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, chix.M{
+		"total": total,
+		"items": rules,
+	})
+}
+
+func (s *MonitorService) AlertRuleCreate(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.AlertRuleCreate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.alertRepo.RuleCreate(req); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *MonitorService) AlertRuleGet(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	rule, err := s.alertRepo.RuleGet(req.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, rule)
+}
+
+func (s *MonitorService) AlertRuleUpdate(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.AlertRuleUpdate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.alertRepo.RuleUpdate(req); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *MonitorService) AlertRuleDelete(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.alertRepo.RuleDelete(req.ID); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+// AlertList 告警记录，可按状态筛选
+func (s *MonitorService) AlertList(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.AlertList](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	alerts, total, err := s.alertRepo.List(biz.AlertStatus(req.Status), req.Page, req.Limit)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, chix.M{
+		"total": total,
+		"items": alerts,
+	})
+}
+
+func (s *MonitorService) AlertDelete(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.alertRepo.Delete(req.ID); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+// AlertClear 清空已恢复的告警记录
+func (s *MonitorService) AlertClear(w http.ResponseWriter, r *http.Request) {
+	if err := s.alertRepo.Clear(); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
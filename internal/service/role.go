@@ -0,0 +1,114 @@
+package service
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/libtnb/chix"
+
+	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/internal/http/request"
+	"github.com/acepanel/panel/pkg/apploader"
+)
+
+type RoleService struct {
+	roleRepo biz.RoleRepo
+}
+
+func NewRoleService(role biz.RoleRepo) *RoleService {
+	return &RoleService{
+		roleRepo: role,
+	}
+}
+
+func (s *RoleService) List(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.Paginate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	roles, total, err := s.roleRepo.List(req.Page, req.Limit)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, chix.M{
+		"total": total,
+		"items": roles,
+	})
+}
+
+// Permissions 可分配的路由组
+func (s *RoleService) Permissions(w http.ResponseWriter, r *http.Request) {
+	groups := slices.Clone(biz.PermissionGroups)
+	for _, slug := range apploader.Slugs() {
+		groups = append(groups, "apps/"+slug)
+	}
+	slices.Sort(groups)
+
+	Success(w, groups)
+}
+
+func (s *RoleService) Get(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	role, err := s.roleRepo.Get(req.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, role)
+}
+
+func (s *RoleService) Create(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.RoleCreate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	role, err := s.roleRepo.Create(req)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, role)
+}
+
+func (s *RoleService) Update(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.RoleUpdate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.roleRepo.Update(req); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+func (s *RoleService) Delete(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.roleRepo.Delete(req.ID); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
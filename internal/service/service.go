@@ -5,7 +5,9 @@ import "github.com/google/wire"
 // ProviderSet is service providers.
 var ProviderSet = wire.NewSet(
 	NewAppService,
+	NewAuditService,
 	NewBackupService,
+	NewBackupStorageService,
 	NewCertService,
 	NewCertAccountService,
 	NewCertDNSService,
@@ -24,8 +26,11 @@ var ProviderSet = wire.NewSet(
 	NewFileService,
 	NewFirewallService,
 	NewHomeService,
+	NewMetricsService,
 	NewMonitorService,
+	NewNotifyService,
 	NewProcessService,
+	NewRoleService,
 	NewSafeService,
 	NewSettingService,
 	NewSSHService,
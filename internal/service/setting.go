@@ -19,15 +19,17 @@ type SettingService struct {
 	settingRepo     biz.SettingRepo
 	certRepo        biz.CertRepo
 	certAccountRepo biz.CertAccountRepo
+	oidcRepo        biz.OIDCRepo
 }
 
-func NewSettingService(t *gotext.Locale, db *gorm.DB, setting biz.SettingRepo, cert biz.CertRepo, certAccount biz.CertAccountRepo) *SettingService {
+func NewSettingService(t *gotext.Locale, db *gorm.DB, setting biz.SettingRepo, cert biz.CertRepo, certAccount biz.CertAccountRepo, oidc biz.OIDCRepo) *SettingService {
 	return &SettingService{
 		t:               t,
 		db:              db,
 		settingRepo:     setting,
 		certRepo:        cert,
 		certAccountRepo: certAccount,
+		oidcRepo:        oidc,
 	}
 }
 
@@ -126,3 +128,31 @@ func (s *SettingService) UpdateCert(w http.ResponseWriter, r *http.Request) {
 
 	Success(w, nil)
 }
+
+func (s *SettingService) GetOIDC(w http.ResponseWriter, r *http.Request) {
+	conf, err := s.oidcRepo.GetConfig()
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	if conf.ClientSecret != "" {
+		conf.ClientSecret = biz.OIDCSecretMask
+	}
+
+	Success(w, conf)
+}
+
+func (s *SettingService) UpdateOIDC(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.SettingOIDC](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.oidcRepo.UpdateConfig(req); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
@@ -6,6 +6,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/gob"
+	"errors"
 	"fmt"
 	"image/png"
 	"net"
@@ -26,19 +27,29 @@ import (
 )
 
 type UserService struct {
-	t        *gotext.Locale
-	conf     *config.Config
-	session  *sessions.Manager
-	userRepo biz.UserRepo
+	t           *gotext.Locale
+	conf        *config.Config
+	session     *sessions.Manager
+	userRepo    biz.UserRepo
+	roleRepo    biz.RoleRepo
+	passkeyRepo biz.UserPasskeyRepo
+	oidcRepo    biz.OIDCRepo
+	sessionRepo biz.UserSessionRepo
+	lockRepo    biz.LoginLockRepo
 }
 
-func NewUserService(t *gotext.Locale, conf *config.Config, session *sessions.Manager, user biz.UserRepo) *UserService {
+func NewUserService(t *gotext.Locale, conf *config.Config, session *sessions.Manager, user biz.UserRepo, role biz.RoleRepo, passkey biz.UserPasskeyRepo, oidc biz.OIDCRepo, userSession biz.UserSessionRepo, loginLock biz.LoginLockRepo) *UserService {
 	gob.Register(rsa.PrivateKey{}) // 必须注册 rsa.PrivateKey 类型否则无法反序列化 session 中的 key
 	return &UserService{
-		t:        t,
-		conf:     conf,
-		session:  session,
-		userRepo: user,
+		t:           t,
+		conf:        conf,
+		session:     session,
+		userRepo:    user,
+		roleRepo:    role,
+		passkeyRepo: passkey,
+		oidcRepo:    oidc,
+		sessionRepo: userSession,
+		lockRepo:    loginLock,
 	}
 }
 
@@ -86,38 +97,79 @@ func (s *UserService) Login(w http.ResponseWriter, r *http.Request) {
 
 	decryptedUsername, _ := rsacrypto.DecryptData(&key, req.Username)
 	decryptedPassword, _ := rsacrypto.DecryptData(&key, req.Password)
-	user, err := s.userRepo.CheckPassword(string(decryptedUsername), string(decryptedPassword))
+	username, ip := string(decryptedUsername), s.clientIP(r)
+	if err = s.lockRepo.Check(username, ip); err != nil {
+		Error(w, http.StatusTooManyRequests, "%v", err)
+		return
+	}
+
+	user, err := s.userRepo.CheckPassword(username, string(decryptedPassword))
 	if err != nil {
-		Error(w, http.StatusForbidden, "%v", err)
+		s.loginFailed(w, username, ip, err)
 		return
 	}
 
-	if user.TwoFA != "" {
-		if valid := totp.Validate(req.PassCode, user.TwoFA); !valid {
-			Error(w, http.StatusForbidden, s.t.Get("invalid 2FA code"))
-			return
-		}
+	if err = s.checkTwoFA(r, sess, user, req); err != nil {
+		s.loginFailed(w, username, ip, err)
+		return
 	}
 
-	// 重新生成会话 ID
-	if err = sess.Regenerate(true); err != nil {
+	if err = s.login(r, sess, user, req.SafeLogin); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	if err = s.lockRepo.Succeed(username, ip); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
 
-	// 安全登录下，将当前客户端与会话绑定
-	// 安全登录只在未启用面板 HTTPS 时生效
-	ip := r.RemoteAddr
-	ipHeader := s.conf.HTTP.IPHeader
-	if ipHeader != "" && r.Header.Get(ipHeader) != "" {
-		ip = strings.Split(r.Header.Get(ipHeader), ",")[0]
+	Success(w, nil)
+}
+
+// loginFailed 记录登录失败次数后返回错误
+func (s *UserService) loginFailed(w http.ResponseWriter, username, ip string, err error) {
+	if failErr := s.lockRepo.Fail(username, ip); failErr != nil {
+		Error(w, http.StatusInternalServerError, "%v", failErr)
+		return
 	}
-	ip, _, err = net.SplitHostPort(strings.TrimSpace(ip))
+
+	Error(w, http.StatusForbidden, "%v", err)
+}
+
+// checkTwoFA 校验第二验证因素，开启 TOTP 或注册了通行密钥的用户需通过其中之一
+func (s *UserService) checkTwoFA(r *http.Request, sess *sessions.Session, user *biz.User, req *request.UserLogin) error {
+	passkeys, err := s.passkeyRepo.Count(user.ID)
 	if err != nil {
-		ip = r.RemoteAddr
+		return err
 	}
+	if user.TwoFA == "" && passkeys == 0 {
+		return nil
+	}
+
+	if len(req.Passkey) > 0 && passkeys > 0 {
+		if _, err = s.finishPasskeyLogin(r, sess, user.ID, req.Passkey); err != nil {
+			return errors.New(s.t.Get("passkey verification failed: %v", err))
+		}
+		return nil
+	}
+	if user.TwoFA != "" && totp.Validate(req.PassCode, user.TwoFA) {
+		return nil
+	}
+
+	return errors.New(s.t.Get("invalid 2FA code"))
+}
 
-	if req.SafeLogin && !s.conf.HTTP.TLS {
+// login 登录成功后写入会话
+func (s *UserService) login(r *http.Request, sess *sessions.Session, user *biz.User, safeLogin bool) error {
+	// 重新生成会话 ID
+	if err := sess.Regenerate(true); err != nil {
+		return err
+	}
+
+	// 安全登录下，将当前客户端与会话绑定
+	// 安全登录只在未启用面板 HTTPS 时生效
+	ip := s.clientIP(r)
+	if safeLogin && !s.conf.HTTP.TLS {
 		sess.Put("safe_login", true)
 		sess.Put("safe_client", fmt.Sprintf("%x", sha256.Sum256([]byte(ip))))
 	} else {
@@ -127,14 +179,52 @@ func (s *UserService) Login(w http.ResponseWriter, r *http.Request) {
 
 	sess.Put("user_id", user.ID)
 	sess.Put("refresh_at", time.Now().Unix())
+	sess.Put("indexed", true) // 已建立会话索引
 	sess.Forget("key")
-	Success(w, nil)
+	sess.Forget("passkey_login")
+
+	return s.sessionRepo.Create(user.ID, sess.GetID(), ip, r.UserAgent())
+}
+
+// keepSession 修改密码或 2FA 会吊销用户的全部会话，操作自己时保留当前会话
+func (s *UserService) keepSession(r *http.Request, id uint) error {
+	if id != cast.ToUint(r.Context().Value("user_id")) || r.Context().Value("user_token_id") != nil {
+		return nil
+	}
+
+	sess, err := s.session.GetSession(r)
+	if err != nil {
+		return err
+	}
+
+	return s.sessionRepo.Create(id, sess.GetID(), s.clientIP(r), r.UserAgent())
+}
+
+// clientIP 取请求 IP
+func (s *UserService) clientIP(r *http.Request) string {
+	ip := r.RemoteAddr
+	ipHeader := s.conf.HTTP.IPHeader
+	if ipHeader != "" && r.Header.Get(ipHeader) != "" {
+		ip = strings.Split(r.Header.Get(ipHeader), ",")[0]
+	}
+	ip, _, err := net.SplitHostPort(strings.TrimSpace(ip))
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	return ip
 }
 
 func (s *UserService) Logout(w http.ResponseWriter, r *http.Request) {
 	sess, err := s.session.GetSession(r)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	if err = s.sessionRepo.DeleteBySessionID(sess.GetID()); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
 	}
 
 	sess.Forget("user_id")
@@ -184,11 +274,22 @@ func (s *UserService) Info(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 管理员拥有全部权限
+	role := &biz.Role{Name: biz.PermissionAdmin, Permissions: []string{biz.PermissionAll}}
+	if user.RoleID != 0 {
+		if role, err = s.roleRepo.Get(user.RoleID); err != nil {
+			ErrorSystem(w)
+			return
+		}
+	}
+
 	Success(w, chix.M{
-		"id":       user.ID,
-		"role":     []string{"admin"},
-		"username": user.Username,
-		"email":    user.Email,
+		"id":          user.ID,
+		"role":        []string{role.Name},
+		"role_id":     user.RoleID,
+		"permissions": role.Permissions,
+		"username":    user.Username,
+		"email":       user.Email,
 	})
 }
 
@@ -253,6 +354,10 @@ func (s *UserService) UpdatePassword(w http.ResponseWriter, r *http.Request) {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
+	if err = s.keepSession(r, req.ID); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
 
 	Success(w, nil)
 }
@@ -272,6 +377,42 @@ func (s *UserService) UpdateEmail(w http.ResponseWriter, r *http.Request) {
 	Success(w, nil)
 }
 
+func (s *UserService) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.UserUpdateRole](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if req.ID == cast.ToUint(r.Context().Value("user_id")) {
+		Error(w, http.StatusForbidden, s.t.Get("cannot change your own role"))
+		return
+	}
+
+	if err = s.userRepo.UpdateRole(req.ID, req.RoleID); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+// UpdateResources 分配用户拥有的资源
+func (s *UserService) UpdateResources(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.UserUpdateResources](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.userRepo.UpdateResources(req.ID, req.Type, req.IDs); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
 func (s *UserService) GenerateTwoFA(w http.ResponseWriter, r *http.Request) {
 	req, err := Bind[request.UserID](r)
 	if err != nil {
@@ -309,6 +450,10 @@ func (s *UserService) UpdateTwoFA(w http.ResponseWriter, r *http.Request) {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
+	if err = s.keepSession(r, req.ID); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
 
 	Success(w, nil)
 }
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/libtnb/chix"
+	"github.com/spf13/cast"
+)
+
+// OIDCInfo 登录页展示的 OIDC 登录入口
+func (s *UserService) OIDCInfo(w http.ResponseWriter, r *http.Request) {
+	conf, err := s.oidcRepo.GetConfig()
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, chix.M{
+		"enabled": conf.Enabled,
+		"name":    conf.Name,
+	})
+}
+
+// OIDCLogin 生成身份提供方的授权地址，由前端跳转
+func (s *UserService) OIDCLogin(w http.ResponseWriter, r *http.Request) {
+	sess, err := s.session.GetSession(r)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	auth, err := s.oidcRepo.AuthCodeURL(r.Context(), s.oidcRedirectURL(r))
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	sess.Put("oidc_state", auth.State)
+	sess.Put("oidc_nonce", auth.Nonce)
+	sess.Put("oidc_verifier", auth.Verifier)
+
+	Success(w, chix.M{
+		"url": auth.URL,
+	})
+}
+
+// OIDCCallback 身份提供方回调，登录成功后跳转到面板首页
+func (s *UserService) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	sess, err := s.session.GetSession(r)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	// 无论成功与否，state 等参数只能使用一次
+	state := cast.ToString(sess.Pull("oidc_state"))
+	nonce := cast.ToString(sess.Pull("oidc_nonce"))
+	verifier := cast.ToString(sess.Pull("oidc_verifier"))
+
+	query := r.URL.Query()
+	if msg := query.Get("error"); msg != "" {
+		s.oidcFailed(w, r, msg+": "+query.Get("error_description"))
+		return
+	}
+	if state == "" || query.Get("state") != state {
+		s.oidcFailed(w, r, s.t.Get("invalid OIDC state, please try again"))
+		return
+	}
+
+	user, err := s.oidcRepo.Login(r.Context(), s.oidcRedirectURL(r), query.Get("code"), verifier, nonce)
+	if err != nil {
+		s.oidcFailed(w, r, err.Error())
+		return
+	}
+	if err = s.login(r, sess, user, false); err != nil {
+		s.oidcFailed(w, r, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+// oidcRedirectURL 根据访问地址生成默认回调地址
+func (s *UserService) oidcRedirectURL(r *http.Request) string {
+	scheme := "http"
+	if s.conf.HTTP.TLS {
+		scheme = "https"
+	}
+
+	return scheme + "://" + r.Host + "/api/user/oidc/callback"
+}
+
+// oidcFailed 回调失败时带上错误信息跳转回登录页
+func (s *UserService) oidcFailed(w http.ResponseWriter, r *http.Request, msg string) {
+	http.Redirect(w, r, "/login?oidc_error="+url.QueryEscape(msg), http.StatusFound)
+}
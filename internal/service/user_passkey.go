@@ -0,0 +1,250 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/libtnb/sessions"
+	"github.com/spf13/cast"
+
+	"github.com/acepanel/panel/internal/biz"
+	"github.com/acepanel/panel/internal/http/request"
+)
+
+// PasskeyOptions 生成通行密钥登录挑战
+func (s *UserService) PasskeyOptions(w http.ResponseWriter, r *http.Request) {
+	sess, err := s.session.GetSession(r)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	req, err := Bind[request.UserPasskeyOptions](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	rp, err := s.relyingParty(r)
+	if err != nil {
+		Error(w, http.StatusForbidden, "%v", err)
+		return
+	}
+
+	assertion, data, err := s.passkeyRepo.BeginLogin(rp, req.Username)
+	if err != nil {
+		Error(w, http.StatusForbidden, "%v", err)
+		return
+	}
+	if err = putPasskeySession(sess, "passkey_login", data); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, assertion)
+}
+
+// PasskeyLogin 使用通行密钥免密码登录
+func (s *UserService) PasskeyLogin(w http.ResponseWriter, r *http.Request) {
+	sess, err := s.session.GetSession(r)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	req, err := Bind[request.UserPasskeyLogin](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	// 免密码登录时不知道用户名，仅按 IP 统计失败次数
+	ip := s.clientIP(r)
+	if err = s.lockRepo.Check("", ip); err != nil {
+		Error(w, http.StatusTooManyRequests, "%v", err)
+		return
+	}
+
+	user, err := s.finishPasskeyLogin(r, sess, 0, req.Credential)
+	if err != nil {
+		s.loginFailed(w, "", ip, errors.New(s.t.Get("passkey verification failed: %v", err)))
+		return
+	}
+
+	if err = s.login(r, sess, user, req.SafeLogin); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	if err = s.lockRepo.Succeed(user.Username, ip); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+// PasskeyList 当前用户的通行密钥
+func (s *UserService) PasskeyList(w http.ResponseWriter, r *http.Request) {
+	passkeys, err := s.passkeyRepo.List(cast.ToUint(r.Context().Value("user_id")))
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, passkeys)
+}
+
+// PasskeyRegisterOptions 生成通行密钥注册挑战
+func (s *UserService) PasskeyRegisterOptions(w http.ResponseWriter, r *http.Request) {
+	sess, err := s.session.GetSession(r)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	rp, err := s.relyingParty(r)
+	if err != nil {
+		Error(w, http.StatusForbidden, "%v", err)
+		return
+	}
+
+	creation, data, err := s.passkeyRepo.BeginRegistration(rp, cast.ToUint(r.Context().Value("user_id")))
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	if err = putPasskeySession(sess, "passkey_register", data); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, creation)
+}
+
+// PasskeyCreate 完成通行密钥注册
+func (s *UserService) PasskeyCreate(w http.ResponseWriter, r *http.Request) {
+	sess, err := s.session.GetSession(r)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	req, err := Bind[request.UserPasskeyCreate](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	data, err := pullPasskeySession(sess, "passkey_register")
+	if err != nil {
+		Error(w, http.StatusForbidden, s.t.Get("passkey challenge expired, please try again"))
+		return
+	}
+	response, err := protocol.ParseCredentialCreationResponseBytes(req.Credential)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+	rp, err := s.relyingParty(r)
+	if err != nil {
+		Error(w, http.StatusForbidden, "%v", err)
+		return
+	}
+
+	passkey, err := s.passkeyRepo.FinishRegistration(rp, cast.ToUint(r.Context().Value("user_id")), req.Name, *data, response)
+	if err != nil {
+		Error(w, http.StatusForbidden, "%v", err)
+		return
+	}
+
+	Success(w, passkey)
+}
+
+// PasskeyDelete 吊销当前用户的通行密钥
+func (s *UserService) PasskeyDelete(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.passkeyRepo.Delete(cast.ToUint(r.Context().Value("user_id")), req.ID); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+// finishPasskeyLogin 校验会话中的登录挑战，userID 为 0 时为免密码登录
+func (s *UserService) finishPasskeyLogin(r *http.Request, sess *sessions.Session, userID uint, credential json.RawMessage) (*biz.User, error) {
+	data, err := pullPasskeySession(sess, "passkey_login")
+	if err != nil {
+		return nil, errors.New(s.t.Get("passkey challenge expired, please try again"))
+	}
+	response, err := protocol.ParseCredentialRequestResponseBytes(credential)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := s.relyingParty(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.passkeyRepo.FinishLogin(rp, userID, *data, response)
+}
+
+// relyingParty 根据访问地址生成 WebAuthn 依赖方，通行密钥与访问面板的域名绑定
+func (s *UserService) relyingParty(r *http.Request) (*webauthn.WebAuthn, error) {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		// 同源 GET 请求浏览器不发送 Origin
+		if referer, err := url.Parse(r.Referer()); err == nil && referer.Host != "" {
+			origin = referer.Scheme + "://" + referer.Host
+		}
+	}
+	u, err := url.Parse(origin)
+	if origin == "" || err != nil || u.Host == "" {
+		return nil, errors.New(s.t.Get("invalid request origin"))
+	}
+	if u.Host != r.Host && !slices.Contains(s.conf.HTTP.BindDomain, u.Hostname()) {
+		return nil, errors.New(s.t.Get("invalid request origin"))
+	}
+
+	return webauthn.New(&webauthn.Config{
+		RPID:          u.Hostname(),
+		RPDisplayName: "AcePanel",
+		RPOrigins:     []string{u.Scheme + "://" + u.Host},
+	})
+}
+
+// putPasskeySession 保存 WebAuthn 挑战，会话中仅存放 JSON 字符串
+func putPasskeySession(sess *sessions.Session, key string, data *webauthn.SessionData) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	sess.Put(key, string(encoded))
+	return nil
+}
+
+// pullPasskeySession 取出并删除 WebAuthn 挑战，防止重放
+func pullPasskeySession(sess *sessions.Session, key string) (*webauthn.SessionData, error) {
+	encoded := cast.ToString(sess.Pull(key))
+	if encoded == "" {
+		return nil, errors.New("session not found")
+	}
+
+	data := new(webauthn.SessionData)
+	if err := json.Unmarshal([]byte(encoded), data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
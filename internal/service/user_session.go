@@ -0,0 +1,101 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/spf13/cast"
+
+	"github.com/acepanel/panel/internal/http/request"
+)
+
+// SessionList 当前用户已登录的会话
+func (s *UserService) SessionList(w http.ResponseWriter, r *http.Request) {
+	sess, err := s.session.GetSession(r)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	userSessions, err := s.sessionRepo.List(cast.ToUint(r.Context().Value("user_id")))
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	for _, userSession := range userSessions {
+		userSession.Current = userSession.SessionID == sess.GetID()
+	}
+
+	Success(w, userSessions)
+}
+
+// SessionDelete 吊销当前用户的指定会话
+func (s *UserService) SessionDelete(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.sessionRepo.Delete(cast.ToUint(r.Context().Value("user_id")), req.ID); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+// SessionClear 吊销当前用户除本会话外的全部会话
+func (s *UserService) SessionClear(w http.ResponseWriter, r *http.Request) {
+	sess, err := s.session.GetSession(r)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	if err = s.sessionRepo.Revoke(cast.ToUint(r.Context().Value("user_id")), sess.GetID()); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+// UserSessionList 指定用户已登录的会话
+func (s *UserService) UserSessionList(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.UserID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	userSessions, err := s.sessionRepo.List(req.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, userSessions)
+}
+
+// UserSessionClear 强制指定用户退出全部会话
+func (s *UserService) UserSessionClear(w http.ResponseWriter, r *http.Request) {
+	sess, err := s.session.GetSession(r)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	req, err := Bind[request.UserID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	// 保留操作者自己的当前会话
+	if err = s.sessionRepo.Revoke(req.ID, sess.GetID()); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
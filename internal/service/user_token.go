@@ -59,7 +59,7 @@ func (s *UserTokenService) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userToken, err := s.userTokenRepo.Create(req.UserID, req.IPs, expiredAt)
+	userToken, err := s.userTokenRepo.Create(req.UserID, req.IPs, req.RoleID, req.Scopes, req.WebsiteIDs, expiredAt)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -67,13 +67,16 @@ func (s *UserTokenService) Create(w http.ResponseWriter, r *http.Request) {
 
 	// 手动组装响应，因为 Token 设置了 json:"-"
 	Success(w, chix.M{
-		"id":         userToken.ID,
-		"user_id":    userToken.UserID,
-		"token":      userToken.Token,
-		"ips":        userToken.IPs,
-		"expired_at": userToken.ExpiredAt,
-		"created_at": userToken.CreatedAt,
-		"updated_at": userToken.UpdatedAt,
+		"id":          userToken.ID,
+		"user_id":     userToken.UserID,
+		"token":       userToken.Token,
+		"ips":         userToken.IPs,
+		"role_id":     userToken.RoleID,
+		"scopes":      userToken.Scopes,
+		"website_ids": userToken.WebsiteIDs,
+		"expired_at":  userToken.ExpiredAt,
+		"created_at":  userToken.CreatedAt,
+		"updated_at":  userToken.UpdatedAt,
 	})
 }
 
@@ -94,7 +97,7 @@ func (s *UserTokenService) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userToken, err := s.userTokenRepo.Update(req.ID, req.IPs, expiredAt)
+	userToken, err := s.userTokenRepo.Update(req.ID, req.IPs, req.RoleID, req.Scopes, req.WebsiteIDs, expiredAt)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -26,7 +26,7 @@ func (s *WebHookService) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	webhooks, total, err := s.webhookRepo.List(req.Page, req.Limit)
+	webhooks, total, err := s.webhookRepo.List(ownerID(r), req.Page, req.Limit)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -61,6 +61,12 @@ func (s *WebHookService) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 非管理员的钩子只能以 www 用户执行
+	if owner := ownerID(r); owner != 0 {
+		req.UserID = owner
+		req.User = "www"
+	}
+
 	webhook, err := s.webhookRepo.Create(req)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
@@ -77,6 +83,10 @@ func (s *WebHookService) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if ownerID(r) != 0 {
+		req.User = "www"
+	}
+
 	if err = s.webhookRepo.Update(req); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -1,26 +1,45 @@
 package service
 
 import (
+	"fmt"
+	"maps"
 	"net/http"
+	"net/url"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
 
+	"github.com/leonelquinteros/gotext"
 	"github.com/libtnb/chix"
 
 	"github.com/acepanel/panel/internal/app"
 	"github.com/acepanel/panel/internal/biz"
 	"github.com/acepanel/panel/internal/http/request"
 	"github.com/acepanel/panel/pkg/io"
+	webservertypes "github.com/acepanel/panel/pkg/webserver/types"
 )
 
+// upstreamNamePattern 上游名称只允许字母、数字、下划线、横线和点
+var upstreamNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// upstreamServerOptions 非管理员可使用的上游服务器参数
+var upstreamServerOptions = []string{"weight", "max_conns", "max_fails", "fail_timeout", "backup", "down", "resolve", "slow_start"}
+
 type WebsiteService struct {
+	t           *gotext.Locale
 	websiteRepo biz.WebsiteRepo
 	settingRepo biz.SettingRepo
+	userRepo    biz.UserRepo
 }
 
-func NewWebsiteService(website biz.WebsiteRepo, setting biz.SettingRepo) *WebsiteService {
+func NewWebsiteService(t *gotext.Locale, website biz.WebsiteRepo, setting biz.SettingRepo, user biz.UserRepo) *WebsiteService {
 	return &WebsiteService{
+		t:           t,
 		websiteRepo: website,
 		settingRepo: setting,
+		userRepo:    user,
 	}
 }
 
@@ -73,6 +92,18 @@ func (s *WebsiteService) UpdateCert(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if owner := ownerID(r); owner != 0 {
+		website, err := s.websiteRepo.GetByName(req.Name)
+		if err != nil {
+			Error(w, http.StatusInternalServerError, "%v", err)
+			return
+		}
+		if owned, _ := s.userRepo.Owns(owner, "websites", website.ID); !owned {
+			Error(w, http.StatusForbidden, s.t.Get("permission denied: %s", "websites"))
+			return
+		}
+	}
+
 	if err = s.websiteRepo.UpdateCert(req); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -89,7 +120,7 @@ func (s *WebsiteService) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	websites, total, err := s.websiteRepo.List(req.Type, req.Page, req.Limit)
+	websites, total, err := s.websiteRepo.List(ownerID(r), req.Type, req.Page, req.Limit)
 	if err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
@@ -108,12 +139,18 @@ func (s *WebsiteService) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 非管理员只能使用默认网站目录，并归属到自身
+	if owner := ownerID(r); owner != 0 {
+		req.UserID = owner
+		req.Path = ""
+	}
+
 	if len(req.Path) == 0 {
 		req.Path, _ = s.settingRepo.Get(biz.SettingKeyWebsitePath)
 		req.Path = filepath.Join(req.Path, req.Name, "public")
 	}
 
-	if _, err = s.websiteRepo.Create(req); err != nil {
+	if _, err = s.websiteRepo.Create(r.Context(), req); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
@@ -144,7 +181,41 @@ func (s *WebsiteService) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = s.websiteRepo.Update(req); err != nil {
+	// 非管理员不能将网站目录和运行目录移出原网站目录
+	if ownerID(r) != 0 {
+		website, err := s.websiteRepo.Get(req.ID)
+		if err != nil {
+			Error(w, http.StatusInternalServerError, "%v", err)
+			return
+		}
+		if !io.Within(req.Path, website.Path) || !io.Within(req.Root, website.Path) {
+			Error(w, http.StatusForbidden, s.t.Get("website directory must be within %s", website.Path))
+			return
+		}
+		// 伪静态只能使用预设规则，避免写入任意服务器指令
+		if rewrite := strings.TrimSpace(req.Rewrite); rewrite != "" && rewrite != strings.TrimSpace(website.Rewrite) {
+			rewrites, err := s.websiteRepo.GetRewrites()
+			if err != nil {
+				Error(w, http.StatusInternalServerError, "%v", err)
+				return
+			}
+			if !slices.ContainsFunc(slices.Collect(maps.Values(rewrites)), func(preset string) bool {
+				return strings.TrimSpace(preset) == rewrite
+			}) {
+				Error(w, http.StatusForbidden, s.t.Get("only preset rewrite rules are allowed"))
+				return
+			}
+		}
+		// 反向代理配置有修改时校验其中不含指令注入
+		if !reflect.DeepEqual(req.Upstreams, website.Upstreams) || !reflect.DeepEqual(req.Proxies, website.Proxies) {
+			if err = checkProxyConfig(req.Upstreams, req.Proxies); err != nil {
+				Error(w, http.StatusForbidden, s.t.Get("invalid proxy config: %v", err))
+				return
+			}
+		}
+	}
+
+	if err = s.websiteRepo.Update(r.Context(), req); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
@@ -204,7 +275,7 @@ func (s *WebsiteService) ResetConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = s.websiteRepo.ResetConfig(req.ID); err != nil {
+	if err = s.websiteRepo.ResetConfig(r.Context(), req.ID); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
@@ -219,7 +290,7 @@ func (s *WebsiteService) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = s.websiteRepo.UpdateStatus(req.ID, req.Status); err != nil {
+	if err = s.websiteRepo.UpdateStatus(r.Context(), req.ID, req.Status); err != nil {
 		Error(w, http.StatusInternalServerError, "%v", err)
 		return
 	}
@@ -241,3 +312,147 @@ func (s *WebsiteService) ObtainCert(w http.ResponseWriter, r *http.Request) {
 
 	Success(w, nil)
 }
+
+// Revisions 网站配置版本列表
+func (s *WebsiteService) Revisions(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.WebsiteRevisionList](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	revisions, total, err := s.websiteRepo.Revisions(req.ID, req.Page, req.Limit)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, chix.M{
+		"total": total,
+		"items": revisions,
+	})
+}
+
+// DiffRevisions 比较两个网站配置版本
+func (s *WebsiteService) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.WebsiteRevisionDiff](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	diffs, err := s.websiteRepo.DiffRevisions(req.ID, req.From, req.To)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, diffs)
+}
+
+// RestoreRevision 恢复网站配置版本
+func (s *WebsiteService) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.WebsiteRevisionRestore](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	if err = s.websiteRepo.RestoreRevision(r.Context(), req.ID, req.Revision); err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, nil)
+}
+
+// UpstreamStatuses 上游服务器健康状态
+func (s *WebsiteService) UpstreamStatuses(w http.ResponseWriter, r *http.Request) {
+	req, err := Bind[request.ID](r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, "%v", err)
+		return
+	}
+
+	statuses, err := s.websiteRepo.UpstreamStatuses(req.ID)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+
+	Success(w, statuses)
+}
+
+// checkProxyConfig 校验非管理员提交的反向代理配置，禁止写入额外指令和本地路径
+func checkProxyConfig(upstreams map[string]webservertypes.Upstream, proxies []webservertypes.Proxy) error {
+	for name, upstream := range upstreams {
+		if !upstreamNamePattern.MatchString(name) {
+			return fmt.Errorf("upstream name %q", name)
+		}
+		if err := checkProxyValues(upstream.Algo); err != nil {
+			return err
+		}
+		for server, options := range upstream.Servers {
+			if strings.HasPrefix(server, "unix") || strings.ContainsAny(server, " \t") {
+				return fmt.Errorf("upstream server %q", server)
+			}
+			if err := checkProxyValues(server); err != nil {
+				return err
+			}
+			for _, option := range strings.Fields(options) {
+				key, _, _ := strings.Cut(option, "=")
+				if !slices.Contains(upstreamServerOptions, key) {
+					return fmt.Errorf("upstream server option %q", option)
+				}
+				if err := checkProxyValues(option); err != nil {
+					return err
+				}
+			}
+		}
+		if upstream.HealthCheck != nil {
+			if err := checkProxyValues(upstream.HealthCheck.Type, upstream.HealthCheck.Path); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, proxy := range proxies {
+		u, err := url.Parse(proxy.Pass)
+		if err != nil || u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("proxy pass %q", proxy.Pass)
+		}
+		values := []string{proxy.Location, proxy.Pass, proxy.Host, proxy.SNI}
+		values = append(values, proxy.Resolver...)
+		for from, to := range proxy.Replaces {
+			values = append(values, from, to)
+		}
+		for _, headers := range []webservertypes.ProxyHeaders{proxy.RequestHeaders, proxy.ResponseHeaders} {
+			for name, value := range headers.Set {
+				values = append(values, name, value)
+			}
+			values = append(values, headers.Remove...)
+		}
+		if proxy.CORS != nil {
+			values = append(values, proxy.CORS.Origins...)
+			values = append(values, proxy.CORS.Methods...)
+			values = append(values, proxy.CORS.Headers...)
+			values = append(values, proxy.CORS.ExposeHeaders...)
+		}
+		if err = checkProxyValues(values...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkProxyValues 检查配置值中是否包含可以结束当前指令或插入新指令的字符
+func checkProxyValues(values ...string) error {
+	for _, value := range values {
+		if strings.ContainsAny(value, ";{}\"'`\\#\r\n") {
+			return fmt.Errorf("value %q contains forbidden characters", value)
+		}
+	}
+
+	return nil
+}
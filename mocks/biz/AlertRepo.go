@@ -0,0 +1,505 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package biz
+
+import (
+	biz "github.com/acepanel/panel/internal/biz"
+	mock "github.com/stretchr/testify/mock"
+
+	request "github.com/acepanel/panel/internal/http/request"
+
+	types "github.com/acepanel/panel/pkg/types"
+)
+
+// AlertRepo is an autogenerated mock type for the AlertRepo type
+type AlertRepo struct {
+	mock.Mock
+}
+
+type AlertRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AlertRepo) EXPECT() *AlertRepo_Expecter {
+	return &AlertRepo_Expecter{mock: &_m.Mock}
+}
+
+// Clear provides a mock function with no fields
+func (_m *AlertRepo) Clear() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Clear")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AlertRepo_Clear_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Clear'
+type AlertRepo_Clear_Call struct {
+	*mock.Call
+}
+
+// Clear is a helper method to define mock.On call
+func (_e *AlertRepo_Expecter) Clear() *AlertRepo_Clear_Call {
+	return &AlertRepo_Clear_Call{Call: _e.mock.On("Clear")}
+}
+
+func (_c *AlertRepo_Clear_Call) Run(run func()) *AlertRepo_Clear_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *AlertRepo_Clear_Call) Return(_a0 error) *AlertRepo_Clear_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AlertRepo_Clear_Call) RunAndReturn(run func() error) *AlertRepo_Clear_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *AlertRepo) Delete(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AlertRepo_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type AlertRepo_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - id uint
+func (_e *AlertRepo_Expecter) Delete(id interface{}) *AlertRepo_Delete_Call {
+	return &AlertRepo_Delete_Call{Call: _e.mock.On("Delete", id)}
+}
+
+func (_c *AlertRepo_Delete_Call) Run(run func(id uint)) *AlertRepo_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *AlertRepo_Delete_Call) Return(_a0 error) *AlertRepo_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AlertRepo_Delete_Call) RunAndReturn(run func(uint) error) *AlertRepo_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Evaluate provides a mock function with given fields: info
+func (_m *AlertRepo) Evaluate(info types.CurrentInfo) error {
+	ret := _m.Called(info)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Evaluate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(types.CurrentInfo) error); ok {
+		r0 = rf(info)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AlertRepo_Evaluate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Evaluate'
+type AlertRepo_Evaluate_Call struct {
+	*mock.Call
+}
+
+// Evaluate is a helper method to define mock.On call
+//   - info types.CurrentInfo
+func (_e *AlertRepo_Expecter) Evaluate(info interface{}) *AlertRepo_Evaluate_Call {
+	return &AlertRepo_Evaluate_Call{Call: _e.mock.On("Evaluate", info)}
+}
+
+func (_c *AlertRepo_Evaluate_Call) Run(run func(info types.CurrentInfo)) *AlertRepo_Evaluate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(types.CurrentInfo))
+	})
+	return _c
+}
+
+func (_c *AlertRepo_Evaluate_Call) Return(_a0 error) *AlertRepo_Evaluate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AlertRepo_Evaluate_Call) RunAndReturn(run func(types.CurrentInfo) error) *AlertRepo_Evaluate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: status, page, limit
+func (_m *AlertRepo) List(status biz.AlertStatus, page uint, limit uint) ([]*biz.Alert, int64, error) {
+	ret := _m.Called(status, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*biz.Alert
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(biz.AlertStatus, uint, uint) ([]*biz.Alert, int64, error)); ok {
+		return rf(status, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(biz.AlertStatus, uint, uint) []*biz.Alert); ok {
+		r0 = rf(status, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.Alert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(biz.AlertStatus, uint, uint) int64); ok {
+		r1 = rf(status, page, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(biz.AlertStatus, uint, uint) error); ok {
+		r2 = rf(status, page, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AlertRepo_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type AlertRepo_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - status biz.AlertStatus
+//   - page uint
+//   - limit uint
+func (_e *AlertRepo_Expecter) List(status interface{}, page interface{}, limit interface{}) *AlertRepo_List_Call {
+	return &AlertRepo_List_Call{Call: _e.mock.On("List", status, page, limit)}
+}
+
+func (_c *AlertRepo_List_Call) Run(run func(status biz.AlertStatus, page uint, limit uint)) *AlertRepo_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(biz.AlertStatus), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *AlertRepo_List_Call) Return(_a0 []*biz.Alert, _a1 int64, _a2 error) *AlertRepo_List_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *AlertRepo_List_Call) RunAndReturn(run func(biz.AlertStatus, uint, uint) ([]*biz.Alert, int64, error)) *AlertRepo_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RuleCreate provides a mock function with given fields: req
+func (_m *AlertRepo) RuleCreate(req *request.AlertRuleCreate) error {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RuleCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*request.AlertRuleCreate) error); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AlertRepo_RuleCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RuleCreate'
+type AlertRepo_RuleCreate_Call struct {
+	*mock.Call
+}
+
+// RuleCreate is a helper method to define mock.On call
+//   - req *request.AlertRuleCreate
+func (_e *AlertRepo_Expecter) RuleCreate(req interface{}) *AlertRepo_RuleCreate_Call {
+	return &AlertRepo_RuleCreate_Call{Call: _e.mock.On("RuleCreate", req)}
+}
+
+func (_c *AlertRepo_RuleCreate_Call) Run(run func(req *request.AlertRuleCreate)) *AlertRepo_RuleCreate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*request.AlertRuleCreate))
+	})
+	return _c
+}
+
+func (_c *AlertRepo_RuleCreate_Call) Return(_a0 error) *AlertRepo_RuleCreate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AlertRepo_RuleCreate_Call) RunAndReturn(run func(*request.AlertRuleCreate) error) *AlertRepo_RuleCreate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RuleDelete provides a mock function with given fields: id
+func (_m *AlertRepo) RuleDelete(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RuleDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AlertRepo_RuleDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RuleDelete'
+type AlertRepo_RuleDelete_Call struct {
+	*mock.Call
+}
+
+// RuleDelete is a helper method to define mock.On call
+//   - id uint
+func (_e *AlertRepo_Expecter) RuleDelete(id interface{}) *AlertRepo_RuleDelete_Call {
+	return &AlertRepo_RuleDelete_Call{Call: _e.mock.On("RuleDelete", id)}
+}
+
+func (_c *AlertRepo_RuleDelete_Call) Run(run func(id uint)) *AlertRepo_RuleDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *AlertRepo_RuleDelete_Call) Return(_a0 error) *AlertRepo_RuleDelete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AlertRepo_RuleDelete_Call) RunAndReturn(run func(uint) error) *AlertRepo_RuleDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RuleGet provides a mock function with given fields: id
+func (_m *AlertRepo) RuleGet(id uint) (*biz.AlertRule, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RuleGet")
+	}
+
+	var r0 *biz.AlertRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*biz.AlertRule, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *biz.AlertRule); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*biz.AlertRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AlertRepo_RuleGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RuleGet'
+type AlertRepo_RuleGet_Call struct {
+	*mock.Call
+}
+
+// RuleGet is a helper method to define mock.On call
+//   - id uint
+func (_e *AlertRepo_Expecter) RuleGet(id interface{}) *AlertRepo_RuleGet_Call {
+	return &AlertRepo_RuleGet_Call{Call: _e.mock.On("RuleGet", id)}
+}
+
+func (_c *AlertRepo_RuleGet_Call) Run(run func(id uint)) *AlertRepo_RuleGet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *AlertRepo_RuleGet_Call) Return(_a0 *biz.AlertRule, _a1 error) *AlertRepo_RuleGet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AlertRepo_RuleGet_Call) RunAndReturn(run func(uint) (*biz.AlertRule, error)) *AlertRepo_RuleGet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RuleList provides a mock function with given fields: page, limit
+func (_m *AlertRepo) RuleList(page uint, limit uint) ([]*biz.AlertRule, int64, error) {
+	ret := _m.Called(page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RuleList")
+	}
+
+	var r0 []*biz.AlertRule
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uint, uint) ([]*biz.AlertRule, int64, error)); ok {
+		return rf(page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint) []*biz.AlertRule); ok {
+		r0 = rf(page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.AlertRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint) int64); ok {
+		r1 = rf(page, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uint, uint) error); ok {
+		r2 = rf(page, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AlertRepo_RuleList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RuleList'
+type AlertRepo_RuleList_Call struct {
+	*mock.Call
+}
+
+// RuleList is a helper method to define mock.On call
+//   - page uint
+//   - limit uint
+func (_e *AlertRepo_Expecter) RuleList(page interface{}, limit interface{}) *AlertRepo_RuleList_Call {
+	return &AlertRepo_RuleList_Call{Call: _e.mock.On("RuleList", page, limit)}
+}
+
+func (_c *AlertRepo_RuleList_Call) Run(run func(page uint, limit uint)) *AlertRepo_RuleList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *AlertRepo_RuleList_Call) Return(_a0 []*biz.AlertRule, _a1 int64, _a2 error) *AlertRepo_RuleList_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *AlertRepo_RuleList_Call) RunAndReturn(run func(uint, uint) ([]*biz.AlertRule, int64, error)) *AlertRepo_RuleList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RuleUpdate provides a mock function with given fields: req
+func (_m *AlertRepo) RuleUpdate(req *request.AlertRuleUpdate) error {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RuleUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*request.AlertRuleUpdate) error); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AlertRepo_RuleUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RuleUpdate'
+type AlertRepo_RuleUpdate_Call struct {
+	*mock.Call
+}
+
+// RuleUpdate is a helper method to define mock.On call
+//   - req *request.AlertRuleUpdate
+func (_e *AlertRepo_Expecter) RuleUpdate(req interface{}) *AlertRepo_RuleUpdate_Call {
+	return &AlertRepo_RuleUpdate_Call{Call: _e.mock.On("RuleUpdate", req)}
+}
+
+func (_c *AlertRepo_RuleUpdate_Call) Run(run func(req *request.AlertRuleUpdate)) *AlertRepo_RuleUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*request.AlertRuleUpdate))
+	})
+	return _c
+}
+
+func (_c *AlertRepo_RuleUpdate_Call) Return(_a0 error) *AlertRepo_RuleUpdate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AlertRepo_RuleUpdate_Call) RunAndReturn(run func(*request.AlertRuleUpdate) error) *AlertRepo_RuleUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAlertRepo creates a new instance of AlertRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAlertRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AlertRepo {
+	mock := &AlertRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
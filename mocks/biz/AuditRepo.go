@@ -0,0 +1,193 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package biz
+
+import (
+	biz "github.com/acepanel/panel/internal/biz"
+	mock "github.com/stretchr/testify/mock"
+
+	request "github.com/acepanel/panel/internal/http/request"
+)
+
+// AuditRepo is an autogenerated mock type for the AuditRepo type
+type AuditRepo struct {
+	mock.Mock
+}
+
+type AuditRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuditRepo) EXPECT() *AuditRepo_Expecter {
+	return &AuditRepo_Expecter{mock: &_m.Mock}
+}
+
+// ClearExpired provides a mock function with no fields
+func (_m *AuditRepo) ClearExpired() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearExpired")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuditRepo_ClearExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClearExpired'
+type AuditRepo_ClearExpired_Call struct {
+	*mock.Call
+}
+
+// ClearExpired is a helper method to define mock.On call
+func (_e *AuditRepo_Expecter) ClearExpired() *AuditRepo_ClearExpired_Call {
+	return &AuditRepo_ClearExpired_Call{Call: _e.mock.On("ClearExpired")}
+}
+
+func (_c *AuditRepo_ClearExpired_Call) Run(run func()) *AuditRepo_ClearExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *AuditRepo_ClearExpired_Call) Return(_a0 error) *AuditRepo_ClearExpired_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuditRepo_ClearExpired_Call) RunAndReturn(run func() error) *AuditRepo_ClearExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: audit
+func (_m *AuditRepo) Create(audit *biz.Audit) error {
+	ret := _m.Called(audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*biz.Audit) error); ok {
+		r0 = rf(audit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuditRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type AuditRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - audit *biz.Audit
+func (_e *AuditRepo_Expecter) Create(audit interface{}) *AuditRepo_Create_Call {
+	return &AuditRepo_Create_Call{Call: _e.mock.On("Create", audit)}
+}
+
+func (_c *AuditRepo_Create_Call) Run(run func(audit *biz.Audit)) *AuditRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*biz.Audit))
+	})
+	return _c
+}
+
+func (_c *AuditRepo_Create_Call) Return(_a0 error) *AuditRepo_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuditRepo_Create_Call) RunAndReturn(run func(*biz.Audit) error) *AuditRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: req
+func (_m *AuditRepo) List(req *request.AuditList) ([]*biz.Audit, int64, error) {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*biz.Audit
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*request.AuditList) ([]*biz.Audit, int64, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(*request.AuditList) []*biz.Audit); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.Audit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*request.AuditList) int64); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(*request.AuditList) error); ok {
+		r2 = rf(req)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AuditRepo_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type AuditRepo_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - req *request.AuditList
+func (_e *AuditRepo_Expecter) List(req interface{}) *AuditRepo_List_Call {
+	return &AuditRepo_List_Call{Call: _e.mock.On("List", req)}
+}
+
+func (_c *AuditRepo_List_Call) Run(run func(req *request.AuditList)) *AuditRepo_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*request.AuditList))
+	})
+	return _c
+}
+
+func (_c *AuditRepo_List_Call) Return(_a0 []*biz.Audit, _a1 int64, _a2 error) *AuditRepo_List_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *AuditRepo_List_Call) RunAndReturn(run func(*request.AuditList) ([]*biz.Audit, int64, error)) *AuditRepo_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuditRepo creates a new instance of AuditRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuditRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuditRepo {
+	mock := &AuditRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -6,6 +6,8 @@ import (
 	biz "github.com/acepanel/panel/internal/biz"
 	mock "github.com/stretchr/testify/mock"
 
+	snapshot "github.com/acepanel/panel/pkg/snapshot"
+
 	types "github.com/acepanel/panel/pkg/types"
 )
 
@@ -22,17 +24,62 @@ func (_m *BackupRepo) EXPECT() *BackupRepo_Expecter {
 	return &BackupRepo_Expecter{mock: &_m.Mock}
 }
 
-// ClearExpired provides a mock function with given fields: path, prefix, save
-func (_m *BackupRepo) ClearExpired(path string, prefix string, save int) error {
-	ret := _m.Called(path, prefix, save)
+// ArchiveMySQLBinlog provides a mock function with no fields
+func (_m *BackupRepo) ArchiveMySQLBinlog() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveMySQLBinlog")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BackupRepo_ArchiveMySQLBinlog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveMySQLBinlog'
+type BackupRepo_ArchiveMySQLBinlog_Call struct {
+	*mock.Call
+}
+
+// ArchiveMySQLBinlog is a helper method to define mock.On call
+func (_e *BackupRepo_Expecter) ArchiveMySQLBinlog() *BackupRepo_ArchiveMySQLBinlog_Call {
+	return &BackupRepo_ArchiveMySQLBinlog_Call{Call: _e.mock.On("ArchiveMySQLBinlog")}
+}
+
+func (_c *BackupRepo_ArchiveMySQLBinlog_Call) Run(run func()) *BackupRepo_ArchiveMySQLBinlog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *BackupRepo_ArchiveMySQLBinlog_Call) Return(_a0 error) *BackupRepo_ArchiveMySQLBinlog_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BackupRepo_ArchiveMySQLBinlog_Call) RunAndReturn(run func() error) *BackupRepo_ArchiveMySQLBinlog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClearExpired provides a mock function with given fields: typ, storage, path, prefix, save
+func (_m *BackupRepo) ClearExpired(typ biz.BackupType, storage uint, path string, prefix string, save int) error {
+	ret := _m.Called(typ, storage, path, prefix, save)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ClearExpired")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, int) error); ok {
-		r0 = rf(path, prefix, save)
+	if rf, ok := ret.Get(0).(func(biz.BackupType, uint, string, string, int) error); ok {
+		r0 = rf(typ, storage, path, prefix, save)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -46,16 +93,18 @@ type BackupRepo_ClearExpired_Call struct {
 }
 
 // ClearExpired is a helper method to define mock.On call
+//   - typ biz.BackupType
+//   - storage uint
 //   - path string
 //   - prefix string
 //   - save int
-func (_e *BackupRepo_Expecter) ClearExpired(path interface{}, prefix interface{}, save interface{}) *BackupRepo_ClearExpired_Call {
-	return &BackupRepo_ClearExpired_Call{Call: _e.mock.On("ClearExpired", path, prefix, save)}
+func (_e *BackupRepo_Expecter) ClearExpired(typ interface{}, storage interface{}, path interface{}, prefix interface{}, save interface{}) *BackupRepo_ClearExpired_Call {
+	return &BackupRepo_ClearExpired_Call{Call: _e.mock.On("ClearExpired", typ, storage, path, prefix, save)}
 }
 
-func (_c *BackupRepo_ClearExpired_Call) Run(run func(path string, prefix string, save int)) *BackupRepo_ClearExpired_Call {
+func (_c *BackupRepo_ClearExpired_Call) Run(run func(typ biz.BackupType, storage uint, path string, prefix string, save int)) *BackupRepo_ClearExpired_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(int))
+		run(args[0].(biz.BackupType), args[1].(uint), args[2].(string), args[3].(string), args[4].(int))
 	})
 	return _c
 }
@@ -65,19 +114,67 @@ func (_c *BackupRepo_ClearExpired_Call) Return(_a0 error) *BackupRepo_ClearExpir
 	return _c
 }
 
-func (_c *BackupRepo_ClearExpired_Call) RunAndReturn(run func(string, string, int) error) *BackupRepo_ClearExpired_Call {
+func (_c *BackupRepo_ClearExpired_Call) RunAndReturn(run func(biz.BackupType, uint, string, string, int) error) *BackupRepo_ClearExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClearSnapshots provides a mock function with given fields: path, target, policy
+func (_m *BackupRepo) ClearSnapshots(path string, target string, policy snapshot.Policy) error {
+	ret := _m.Called(path, target, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearSnapshots")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, snapshot.Policy) error); ok {
+		r0 = rf(path, target, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BackupRepo_ClearSnapshots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClearSnapshots'
+type BackupRepo_ClearSnapshots_Call struct {
+	*mock.Call
+}
+
+// ClearSnapshots is a helper method to define mock.On call
+//   - path string
+//   - target string
+//   - policy snapshot.Policy
+func (_e *BackupRepo_Expecter) ClearSnapshots(path interface{}, target interface{}, policy interface{}) *BackupRepo_ClearSnapshots_Call {
+	return &BackupRepo_ClearSnapshots_Call{Call: _e.mock.On("ClearSnapshots", path, target, policy)}
+}
+
+func (_c *BackupRepo_ClearSnapshots_Call) Run(run func(path string, target string, policy snapshot.Policy)) *BackupRepo_ClearSnapshots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(snapshot.Policy))
+	})
+	return _c
+}
+
+func (_c *BackupRepo_ClearSnapshots_Call) Return(_a0 error) *BackupRepo_ClearSnapshots_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BackupRepo_ClearSnapshots_Call) RunAndReturn(run func(string, string, snapshot.Policy) error) *BackupRepo_ClearSnapshots_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Create provides a mock function with given fields: typ, target, path
-func (_m *BackupRepo) Create(typ biz.BackupType, target string, path ...string) error {
+// Create provides a mock function with given fields: typ, target, storage, path
+func (_m *BackupRepo) Create(typ biz.BackupType, target string, storage uint, path ...string) error {
 	_va := make([]interface{}, len(path))
 	for _i := range path {
 		_va[_i] = path[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, typ, target)
+	_ca = append(_ca, typ, target, storage)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
@@ -86,8 +183,8 @@ func (_m *BackupRepo) Create(typ biz.BackupType, target string, path ...string)
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(biz.BackupType, string, ...string) error); ok {
-		r0 = rf(typ, target, path...)
+	if rf, ok := ret.Get(0).(func(biz.BackupType, string, uint, ...string) error); ok {
+		r0 = rf(typ, target, storage, path...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -103,21 +200,22 @@ type BackupRepo_Create_Call struct {
 // Create is a helper method to define mock.On call
 //   - typ biz.BackupType
 //   - target string
+//   - storage uint
 //   - path ...string
-func (_e *BackupRepo_Expecter) Create(typ interface{}, target interface{}, path ...interface{}) *BackupRepo_Create_Call {
+func (_e *BackupRepo_Expecter) Create(typ interface{}, target interface{}, storage interface{}, path ...interface{}) *BackupRepo_Create_Call {
 	return &BackupRepo_Create_Call{Call: _e.mock.On("Create",
-		append([]interface{}{typ, target}, path...)...)}
+		append([]interface{}{typ, target, storage}, path...)...)}
 }
 
-func (_c *BackupRepo_Create_Call) Run(run func(typ biz.BackupType, target string, path ...string)) *BackupRepo_Create_Call {
+func (_c *BackupRepo_Create_Call) Run(run func(typ biz.BackupType, target string, storage uint, path ...string)) *BackupRepo_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		variadicArgs := make([]string, len(args)-2)
-		for i, a := range args[2:] {
+		variadicArgs := make([]string, len(args)-3)
+		for i, a := range args[3:] {
 			if a != nil {
 				variadicArgs[i] = a.(string)
 			}
 		}
-		run(args[0].(biz.BackupType), args[1].(string), variadicArgs...)
+		run(args[0].(biz.BackupType), args[1].(string), args[2].(uint), variadicArgs...)
 	})
 	return _c
 }
@@ -127,7 +225,7 @@ func (_c *BackupRepo_Create_Call) Return(_a0 error) *BackupRepo_Create_Call {
 	return _c
 }
 
-func (_c *BackupRepo_Create_Call) RunAndReturn(run func(biz.BackupType, string, ...string) error) *BackupRepo_Create_Call {
+func (_c *BackupRepo_Create_Call) RunAndReturn(run func(biz.BackupType, string, uint, ...string) error) *BackupRepo_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -179,17 +277,17 @@ func (_c *BackupRepo_CutoffLog_Call) RunAndReturn(run func(string, string) error
 	return _c
 }
 
-// Delete provides a mock function with given fields: typ, name
-func (_m *BackupRepo) Delete(typ biz.BackupType, name string) error {
-	ret := _m.Called(typ, name)
+// Delete provides a mock function with given fields: typ, storage, name
+func (_m *BackupRepo) Delete(typ biz.BackupType, storage uint, name string) error {
+	ret := _m.Called(typ, storage, name)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(biz.BackupType, string) error); ok {
-		r0 = rf(typ, name)
+	if rf, ok := ret.Get(0).(func(biz.BackupType, uint, string) error); ok {
+		r0 = rf(typ, storage, name)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -204,14 +302,15 @@ type BackupRepo_Delete_Call struct {
 
 // Delete is a helper method to define mock.On call
 //   - typ biz.BackupType
+//   - storage uint
 //   - name string
-func (_e *BackupRepo_Expecter) Delete(typ interface{}, name interface{}) *BackupRepo_Delete_Call {
-	return &BackupRepo_Delete_Call{Call: _e.mock.On("Delete", typ, name)}
+func (_e *BackupRepo_Expecter) Delete(typ interface{}, storage interface{}, name interface{}) *BackupRepo_Delete_Call {
+	return &BackupRepo_Delete_Call{Call: _e.mock.On("Delete", typ, storage, name)}
 }
 
-func (_c *BackupRepo_Delete_Call) Run(run func(typ biz.BackupType, name string)) *BackupRepo_Delete_Call {
+func (_c *BackupRepo_Delete_Call) Run(run func(typ biz.BackupType, storage uint, name string)) *BackupRepo_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(biz.BackupType), args[1].(string))
+		run(args[0].(biz.BackupType), args[1].(uint), args[2].(string))
 	})
 	return _c
 }
@@ -221,7 +320,7 @@ func (_c *BackupRepo_Delete_Call) Return(_a0 error) *BackupRepo_Delete_Call {
 	return _c
 }
 
-func (_c *BackupRepo_Delete_Call) RunAndReturn(run func(biz.BackupType, string) error) *BackupRepo_Delete_Call {
+func (_c *BackupRepo_Delete_Call) RunAndReturn(run func(biz.BackupType, uint, string) error) *BackupRepo_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -327,9 +426,9 @@ func (_c *BackupRepo_GetPath_Call) RunAndReturn(run func(biz.BackupType) (string
 	return _c
 }
 
-// List provides a mock function with given fields: typ
-func (_m *BackupRepo) List(typ biz.BackupType) ([]*types.BackupFile, error) {
-	ret := _m.Called(typ)
+// List provides a mock function with given fields: typ, storage
+func (_m *BackupRepo) List(typ biz.BackupType, storage uint) ([]*types.BackupFile, error) {
+	ret := _m.Called(typ, storage)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
@@ -337,19 +436,19 @@ func (_m *BackupRepo) List(typ biz.BackupType) ([]*types.BackupFile, error) {
 
 	var r0 []*types.BackupFile
 	var r1 error
-	if rf, ok := ret.Get(0).(func(biz.BackupType) ([]*types.BackupFile, error)); ok {
-		return rf(typ)
+	if rf, ok := ret.Get(0).(func(biz.BackupType, uint) ([]*types.BackupFile, error)); ok {
+		return rf(typ, storage)
 	}
-	if rf, ok := ret.Get(0).(func(biz.BackupType) []*types.BackupFile); ok {
-		r0 = rf(typ)
+	if rf, ok := ret.Get(0).(func(biz.BackupType, uint) []*types.BackupFile); ok {
+		r0 = rf(typ, storage)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*types.BackupFile)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(biz.BackupType) error); ok {
-		r1 = rf(typ)
+	if rf, ok := ret.Get(1).(func(biz.BackupType, uint) error); ok {
+		r1 = rf(typ, storage)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -364,13 +463,14 @@ type BackupRepo_List_Call struct {
 
 // List is a helper method to define mock.On call
 //   - typ biz.BackupType
-func (_e *BackupRepo_Expecter) List(typ interface{}) *BackupRepo_List_Call {
-	return &BackupRepo_List_Call{Call: _e.mock.On("List", typ)}
+//   - storage uint
+func (_e *BackupRepo_Expecter) List(typ interface{}, storage interface{}) *BackupRepo_List_Call {
+	return &BackupRepo_List_Call{Call: _e.mock.On("List", typ, storage)}
 }
 
-func (_c *BackupRepo_List_Call) Run(run func(typ biz.BackupType)) *BackupRepo_List_Call {
+func (_c *BackupRepo_List_Call) Run(run func(typ biz.BackupType, storage uint)) *BackupRepo_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(biz.BackupType))
+		run(args[0].(biz.BackupType), args[1].(uint))
 	})
 	return _c
 }
@@ -380,22 +480,29 @@ func (_c *BackupRepo_List_Call) Return(_a0 []*types.BackupFile, _a1 error) *Back
 	return _c
 }
 
-func (_c *BackupRepo_List_Call) RunAndReturn(run func(biz.BackupType) ([]*types.BackupFile, error)) *BackupRepo_List_Call {
+func (_c *BackupRepo_List_Call) RunAndReturn(run func(biz.BackupType, uint) ([]*types.BackupFile, error)) *BackupRepo_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Restore provides a mock function with given fields: typ, backup, target
-func (_m *BackupRepo) Restore(typ biz.BackupType, backup string, target string) error {
-	ret := _m.Called(typ, backup, target)
+// Restore provides a mock function with given fields: typ, storage, backup, target, point
+func (_m *BackupRepo) Restore(typ biz.BackupType, storage uint, backup string, target string, point ...biz.BackupPoint) error {
+	_va := make([]interface{}, len(point))
+	for _i := range point {
+		_va[_i] = point[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, typ, storage, backup, target)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Restore")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(biz.BackupType, string, string) error); ok {
-		r0 = rf(typ, backup, target)
+	if rf, ok := ret.Get(0).(func(biz.BackupType, uint, string, string, ...biz.BackupPoint) error); ok {
+		r0 = rf(typ, storage, backup, target, point...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -410,15 +517,24 @@ type BackupRepo_Restore_Call struct {
 
 // Restore is a helper method to define mock.On call
 //   - typ biz.BackupType
+//   - storage uint
 //   - backup string
 //   - target string
-func (_e *BackupRepo_Expecter) Restore(typ interface{}, backup interface{}, target interface{}) *BackupRepo_Restore_Call {
-	return &BackupRepo_Restore_Call{Call: _e.mock.On("Restore", typ, backup, target)}
+//   - point ...biz.BackupPoint
+func (_e *BackupRepo_Expecter) Restore(typ interface{}, storage interface{}, backup interface{}, target interface{}, point ...interface{}) *BackupRepo_Restore_Call {
+	return &BackupRepo_Restore_Call{Call: _e.mock.On("Restore",
+		append([]interface{}{typ, storage, backup, target}, point...)...)}
 }
 
-func (_c *BackupRepo_Restore_Call) Run(run func(typ biz.BackupType, backup string, target string)) *BackupRepo_Restore_Call {
+func (_c *BackupRepo_Restore_Call) Run(run func(typ biz.BackupType, storage uint, backup string, target string, point ...biz.BackupPoint)) *BackupRepo_Restore_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(biz.BackupType), args[1].(string), args[2].(string))
+		variadicArgs := make([]biz.BackupPoint, len(args)-4)
+		for i, a := range args[4:] {
+			if a != nil {
+				variadicArgs[i] = a.(biz.BackupPoint)
+			}
+		}
+		run(args[0].(biz.BackupType), args[1].(uint), args[2].(string), args[3].(string), variadicArgs...)
 	})
 	return _c
 }
@@ -428,7 +544,159 @@ func (_c *BackupRepo_Restore_Call) Return(_a0 error) *BackupRepo_Restore_Call {
 	return _c
 }
 
-func (_c *BackupRepo_Restore_Call) RunAndReturn(run func(biz.BackupType, string, string) error) *BackupRepo_Restore_Call {
+func (_c *BackupRepo_Restore_Call) RunAndReturn(run func(biz.BackupType, uint, string, string, ...biz.BackupPoint) error) *BackupRepo_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreSnapshotFile provides a mock function with given fields: id, file, target
+func (_m *BackupRepo) RestoreSnapshotFile(id string, file string, target string) error {
+	ret := _m.Called(id, file, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreSnapshotFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(id, file, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BackupRepo_RestoreSnapshotFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreSnapshotFile'
+type BackupRepo_RestoreSnapshotFile_Call struct {
+	*mock.Call
+}
+
+// RestoreSnapshotFile is a helper method to define mock.On call
+//   - id string
+//   - file string
+//   - target string
+func (_e *BackupRepo_Expecter) RestoreSnapshotFile(id interface{}, file interface{}, target interface{}) *BackupRepo_RestoreSnapshotFile_Call {
+	return &BackupRepo_RestoreSnapshotFile_Call{Call: _e.mock.On("RestoreSnapshotFile", id, file, target)}
+}
+
+func (_c *BackupRepo_RestoreSnapshotFile_Call) Run(run func(id string, file string, target string)) *BackupRepo_RestoreSnapshotFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *BackupRepo_RestoreSnapshotFile_Call) Return(_a0 error) *BackupRepo_RestoreSnapshotFile_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BackupRepo_RestoreSnapshotFile_Call) RunAndReturn(run func(string, string, string) error) *BackupRepo_RestoreSnapshotFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SnapshotFiles provides a mock function with given fields: id
+func (_m *BackupRepo) SnapshotFiles(id string) ([]snapshot.Node, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SnapshotFiles")
+	}
+
+	var r0 []snapshot.Node
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]snapshot.Node, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) []snapshot.Node); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]snapshot.Node)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BackupRepo_SnapshotFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SnapshotFiles'
+type BackupRepo_SnapshotFiles_Call struct {
+	*mock.Call
+}
+
+// SnapshotFiles is a helper method to define mock.On call
+//   - id string
+func (_e *BackupRepo_Expecter) SnapshotFiles(id interface{}) *BackupRepo_SnapshotFiles_Call {
+	return &BackupRepo_SnapshotFiles_Call{Call: _e.mock.On("SnapshotFiles", id)}
+}
+
+func (_c *BackupRepo_SnapshotFiles_Call) Run(run func(id string)) *BackupRepo_SnapshotFiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *BackupRepo_SnapshotFiles_Call) Return(_a0 []snapshot.Node, _a1 error) *BackupRepo_SnapshotFiles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BackupRepo_SnapshotFiles_Call) RunAndReturn(run func(string) ([]snapshot.Node, error)) *BackupRepo_SnapshotFiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SwapMySQLPoint provides a mock function with given fields: target
+func (_m *BackupRepo) SwapMySQLPoint(target string) error {
+	ret := _m.Called(target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SwapMySQLPoint")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(target)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BackupRepo_SwapMySQLPoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SwapMySQLPoint'
+type BackupRepo_SwapMySQLPoint_Call struct {
+	*mock.Call
+}
+
+// SwapMySQLPoint is a helper method to define mock.On call
+//   - target string
+func (_e *BackupRepo_Expecter) SwapMySQLPoint(target interface{}) *BackupRepo_SwapMySQLPoint_Call {
+	return &BackupRepo_SwapMySQLPoint_Call{Call: _e.mock.On("SwapMySQLPoint", target)}
+}
+
+func (_c *BackupRepo_SwapMySQLPoint_Call) Run(run func(target string)) *BackupRepo_SwapMySQLPoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *BackupRepo_SwapMySQLPoint_Call) Return(_a0 error) *BackupRepo_SwapMySQLPoint_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BackupRepo_SwapMySQLPoint_Call) RunAndReturn(run func(string) error) *BackupRepo_SwapMySQLPoint_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -481,6 +749,66 @@ func (_c *BackupRepo_UpdatePanel_Call) RunAndReturn(run func(string, string, str
 	return _c
 }
 
+// Verify provides a mock function with given fields: typ, storage, name
+func (_m *BackupRepo) Verify(typ biz.BackupType, storage uint, name string) (*biz.BackupCheck, error) {
+	ret := _m.Called(typ, storage, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 *biz.BackupCheck
+	var r1 error
+	if rf, ok := ret.Get(0).(func(biz.BackupType, uint, string) (*biz.BackupCheck, error)); ok {
+		return rf(typ, storage, name)
+	}
+	if rf, ok := ret.Get(0).(func(biz.BackupType, uint, string) *biz.BackupCheck); ok {
+		r0 = rf(typ, storage, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*biz.BackupCheck)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(biz.BackupType, uint, string) error); ok {
+		r1 = rf(typ, storage, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BackupRepo_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type BackupRepo_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - typ biz.BackupType
+//   - storage uint
+//   - name string
+func (_e *BackupRepo_Expecter) Verify(typ interface{}, storage interface{}, name interface{}) *BackupRepo_Verify_Call {
+	return &BackupRepo_Verify_Call{Call: _e.mock.On("Verify", typ, storage, name)}
+}
+
+func (_c *BackupRepo_Verify_Call) Run(run func(typ biz.BackupType, storage uint, name string)) *BackupRepo_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(biz.BackupType), args[1].(uint), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *BackupRepo_Verify_Call) Return(_a0 *biz.BackupCheck, _a1 error) *BackupRepo_Verify_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BackupRepo_Verify_Call) RunAndReturn(run func(biz.BackupType, uint, string) (*biz.BackupCheck, error)) *BackupRepo_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewBackupRepo creates a new instance of BackupRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewBackupRepo(t interface {
@@ -0,0 +1,359 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package biz
+
+import (
+	biz "github.com/acepanel/panel/internal/biz"
+	mock "github.com/stretchr/testify/mock"
+
+	request "github.com/acepanel/panel/internal/http/request"
+
+	storage "github.com/acepanel/panel/pkg/storage"
+)
+
+// BackupStorageRepo is an autogenerated mock type for the BackupStorageRepo type
+type BackupStorageRepo struct {
+	mock.Mock
+}
+
+type BackupStorageRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BackupStorageRepo) EXPECT() *BackupStorageRepo_Expecter {
+	return &BackupStorageRepo_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: req
+func (_m *BackupStorageRepo) Create(req *request.BackupStorageCreate) error {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*request.BackupStorageCreate) error); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BackupStorageRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type BackupStorageRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - req *request.BackupStorageCreate
+func (_e *BackupStorageRepo_Expecter) Create(req interface{}) *BackupStorageRepo_Create_Call {
+	return &BackupStorageRepo_Create_Call{Call: _e.mock.On("Create", req)}
+}
+
+func (_c *BackupStorageRepo_Create_Call) Run(run func(req *request.BackupStorageCreate)) *BackupStorageRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*request.BackupStorageCreate))
+	})
+	return _c
+}
+
+func (_c *BackupStorageRepo_Create_Call) Return(_a0 error) *BackupStorageRepo_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BackupStorageRepo_Create_Call) RunAndReturn(run func(*request.BackupStorageCreate) error) *BackupStorageRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *BackupStorageRepo) Delete(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BackupStorageRepo_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type BackupStorageRepo_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - id uint
+func (_e *BackupStorageRepo_Expecter) Delete(id interface{}) *BackupStorageRepo_Delete_Call {
+	return &BackupStorageRepo_Delete_Call{Call: _e.mock.On("Delete", id)}
+}
+
+func (_c *BackupStorageRepo_Delete_Call) Run(run func(id uint)) *BackupStorageRepo_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *BackupStorageRepo_Delete_Call) Return(_a0 error) *BackupStorageRepo_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BackupStorageRepo_Delete_Call) RunAndReturn(run func(uint) error) *BackupStorageRepo_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: id
+func (_m *BackupStorageRepo) Get(id uint) (*biz.BackupStorage, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *biz.BackupStorage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*biz.BackupStorage, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *biz.BackupStorage); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*biz.BackupStorage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BackupStorageRepo_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type BackupStorageRepo_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - id uint
+func (_e *BackupStorageRepo_Expecter) Get(id interface{}) *BackupStorageRepo_Get_Call {
+	return &BackupStorageRepo_Get_Call{Call: _e.mock.On("Get", id)}
+}
+
+func (_c *BackupStorageRepo_Get_Call) Run(run func(id uint)) *BackupStorageRepo_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *BackupStorageRepo_Get_Call) Return(_a0 *biz.BackupStorage, _a1 error) *BackupStorageRepo_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BackupStorageRepo_Get_Call) RunAndReturn(run func(uint) (*biz.BackupStorage, error)) *BackupStorageRepo_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: page, limit
+func (_m *BackupStorageRepo) List(page uint, limit uint) ([]*biz.BackupStorage, int64, error) {
+	ret := _m.Called(page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*biz.BackupStorage
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uint, uint) ([]*biz.BackupStorage, int64, error)); ok {
+		return rf(page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint) []*biz.BackupStorage); ok {
+		r0 = rf(page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.BackupStorage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint) int64); ok {
+		r1 = rf(page, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uint, uint) error); ok {
+		r2 = rf(page, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// BackupStorageRepo_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type BackupStorageRepo_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - page uint
+//   - limit uint
+func (_e *BackupStorageRepo_Expecter) List(page interface{}, limit interface{}) *BackupStorageRepo_List_Call {
+	return &BackupStorageRepo_List_Call{Call: _e.mock.On("List", page, limit)}
+}
+
+func (_c *BackupStorageRepo_List_Call) Run(run func(page uint, limit uint)) *BackupStorageRepo_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *BackupStorageRepo_List_Call) Return(_a0 []*biz.BackupStorage, _a1 int64, _a2 error) *BackupStorageRepo_List_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *BackupStorageRepo_List_Call) RunAndReturn(run func(uint, uint) ([]*biz.BackupStorage, int64, error)) *BackupStorageRepo_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Open provides a mock function with given fields: id
+func (_m *BackupStorageRepo) Open(id uint) (storage.Storage, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 storage.Storage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (storage.Storage, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) storage.Storage); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(storage.Storage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BackupStorageRepo_Open_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Open'
+type BackupStorageRepo_Open_Call struct {
+	*mock.Call
+}
+
+// Open is a helper method to define mock.On call
+//   - id uint
+func (_e *BackupStorageRepo_Expecter) Open(id interface{}) *BackupStorageRepo_Open_Call {
+	return &BackupStorageRepo_Open_Call{Call: _e.mock.On("Open", id)}
+}
+
+func (_c *BackupStorageRepo_Open_Call) Run(run func(id uint)) *BackupStorageRepo_Open_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *BackupStorageRepo_Open_Call) Return(_a0 storage.Storage, _a1 error) *BackupStorageRepo_Open_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BackupStorageRepo_Open_Call) RunAndReturn(run func(uint) (storage.Storage, error)) *BackupStorageRepo_Open_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: req
+func (_m *BackupStorageRepo) Update(req *request.BackupStorageUpdate) error {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*request.BackupStorageUpdate) error); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BackupStorageRepo_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type BackupStorageRepo_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - req *request.BackupStorageUpdate
+func (_e *BackupStorageRepo_Expecter) Update(req interface{}) *BackupStorageRepo_Update_Call {
+	return &BackupStorageRepo_Update_Call{Call: _e.mock.On("Update", req)}
+}
+
+func (_c *BackupStorageRepo_Update_Call) Run(run func(req *request.BackupStorageUpdate)) *BackupStorageRepo_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*request.BackupStorageUpdate))
+	})
+	return _c
+}
+
+func (_c *BackupStorageRepo_Update_Call) Return(_a0 error) *BackupStorageRepo_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BackupStorageRepo_Update_Call) RunAndReturn(run func(*request.BackupStorageUpdate) error) *BackupStorageRepo_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBackupStorageRepo creates a new instance of BackupStorageRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBackupStorageRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BackupStorageRepo {
+	mock := &BackupStorageRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
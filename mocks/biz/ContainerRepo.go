@@ -662,6 +662,63 @@ func (_c *ContainerRepo_Unpause_Call) RunAndReturn(run func(string) error) *Cont
 	return _c
 }
 
+// Usage provides a mock function with no fields
+func (_m *ContainerRepo) Usage() ([]types.ContainerUsage, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Usage")
+	}
+
+	var r0 []types.ContainerUsage
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]types.ContainerUsage, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []types.ContainerUsage); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.ContainerUsage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContainerRepo_Usage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Usage'
+type ContainerRepo_Usage_Call struct {
+	*mock.Call
+}
+
+// Usage is a helper method to define mock.On call
+func (_e *ContainerRepo_Expecter) Usage() *ContainerRepo_Usage_Call {
+	return &ContainerRepo_Usage_Call{Call: _e.mock.On("Usage")}
+}
+
+func (_c *ContainerRepo_Usage_Call) Run(run func()) *ContainerRepo_Usage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *ContainerRepo_Usage_Call) Return(_a0 []types.ContainerUsage, _a1 error) *ContainerRepo_Usage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContainerRepo_Usage_Call) RunAndReturn(run func() ([]types.ContainerUsage, error)) *ContainerRepo_Usage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewContainerRepo creates a new instance of ContainerRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewContainerRepo(t interface {
@@ -161,9 +161,9 @@ func (_c *DatabaseRepo_Delete_Call) RunAndReturn(run func(uint, string) error) *
 	return _c
 }
 
-// List provides a mock function with given fields: page, limit
-func (_m *DatabaseRepo) List(page uint, limit uint) ([]*biz.Database, int64, error) {
-	ret := _m.Called(page, limit)
+// List provides a mock function with given fields: owner, page, limit
+func (_m *DatabaseRepo) List(owner uint, page uint, limit uint) ([]*biz.Database, int64, error) {
+	ret := _m.Called(owner, page, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
@@ -172,25 +172,25 @@ func (_m *DatabaseRepo) List(page uint, limit uint) ([]*biz.Database, int64, err
 	var r0 []*biz.Database
 	var r1 int64
 	var r2 error
-	if rf, ok := ret.Get(0).(func(uint, uint) ([]*biz.Database, int64, error)); ok {
-		return rf(page, limit)
+	if rf, ok := ret.Get(0).(func(uint, uint, uint) ([]*biz.Database, int64, error)); ok {
+		return rf(owner, page, limit)
 	}
-	if rf, ok := ret.Get(0).(func(uint, uint) []*biz.Database); ok {
-		r0 = rf(page, limit)
+	if rf, ok := ret.Get(0).(func(uint, uint, uint) []*biz.Database); ok {
+		r0 = rf(owner, page, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*biz.Database)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint, uint) int64); ok {
-		r1 = rf(page, limit)
+	if rf, ok := ret.Get(1).(func(uint, uint, uint) int64); ok {
+		r1 = rf(owner, page, limit)
 	} else {
 		r1 = ret.Get(1).(int64)
 	}
 
-	if rf, ok := ret.Get(2).(func(uint, uint) error); ok {
-		r2 = rf(page, limit)
+	if rf, ok := ret.Get(2).(func(uint, uint, uint) error); ok {
+		r2 = rf(owner, page, limit)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -204,15 +204,16 @@ type DatabaseRepo_List_Call struct {
 }
 
 // List is a helper method to define mock.On call
+//   - owner uint
 //   - page uint
 //   - limit uint
-func (_e *DatabaseRepo_Expecter) List(page interface{}, limit interface{}) *DatabaseRepo_List_Call {
-	return &DatabaseRepo_List_Call{Call: _e.mock.On("List", page, limit)}
+func (_e *DatabaseRepo_Expecter) List(owner interface{}, page interface{}, limit interface{}) *DatabaseRepo_List_Call {
+	return &DatabaseRepo_List_Call{Call: _e.mock.On("List", owner, page, limit)}
 }
 
-func (_c *DatabaseRepo_List_Call) Run(run func(page uint, limit uint)) *DatabaseRepo_List_Call {
+func (_c *DatabaseRepo_List_Call) Run(run func(owner uint, page uint, limit uint)) *DatabaseRepo_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint), args[1].(uint))
+		run(args[0].(uint), args[1].(uint), args[2].(uint))
 	})
 	return _c
 }
@@ -222,7 +223,65 @@ func (_c *DatabaseRepo_List_Call) Return(_a0 []*biz.Database, _a1 int64, _a2 err
 	return _c
 }
 
-func (_c *DatabaseRepo_List_Call) RunAndReturn(run func(uint, uint) ([]*biz.Database, int64, error)) *DatabaseRepo_List_Call {
+func (_c *DatabaseRepo_List_Call) RunAndReturn(run func(uint, uint, uint) ([]*biz.Database, int64, error)) *DatabaseRepo_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Owns provides a mock function with given fields: owner, serverID, name
+func (_m *DatabaseRepo) Owns(owner uint, serverID uint, name string) (bool, error) {
+	ret := _m.Called(owner, serverID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Owns")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, uint, string) (bool, error)); ok {
+		return rf(owner, serverID, name)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint, string) bool); ok {
+		r0 = rf(owner, serverID, name)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint, string) error); ok {
+		r1 = rf(owner, serverID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DatabaseRepo_Owns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Owns'
+type DatabaseRepo_Owns_Call struct {
+	*mock.Call
+}
+
+// Owns is a helper method to define mock.On call
+//   - owner uint
+//   - serverID uint
+//   - name string
+func (_e *DatabaseRepo_Expecter) Owns(owner interface{}, serverID interface{}, name interface{}) *DatabaseRepo_Owns_Call {
+	return &DatabaseRepo_Owns_Call{Call: _e.mock.On("Owns", owner, serverID, name)}
+}
+
+func (_c *DatabaseRepo_Owns_Call) Run(run func(owner uint, serverID uint, name string)) *DatabaseRepo_Owns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *DatabaseRepo_Owns_Call) Return(_a0 bool, _a1 error) *DatabaseRepo_Owns_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DatabaseRepo_Owns_Call) RunAndReturn(run func(uint, uint, string) (bool, error)) *DatabaseRepo_Owns_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,333 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package biz
+
+import (
+	biz "github.com/acepanel/panel/internal/biz"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LoginLockRepo is an autogenerated mock type for the LoginLockRepo type
+type LoginLockRepo struct {
+	mock.Mock
+}
+
+type LoginLockRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LoginLockRepo) EXPECT() *LoginLockRepo_Expecter {
+	return &LoginLockRepo_Expecter{mock: &_m.Mock}
+}
+
+// Check provides a mock function with given fields: username, ip
+func (_m *LoginLockRepo) Check(username string, ip string) error {
+	ret := _m.Called(username, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(username, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LoginLockRepo_Check_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Check'
+type LoginLockRepo_Check_Call struct {
+	*mock.Call
+}
+
+// Check is a helper method to define mock.On call
+//   - username string
+//   - ip string
+func (_e *LoginLockRepo_Expecter) Check(username interface{}, ip interface{}) *LoginLockRepo_Check_Call {
+	return &LoginLockRepo_Check_Call{Call: _e.mock.On("Check", username, ip)}
+}
+
+func (_c *LoginLockRepo_Check_Call) Run(run func(username string, ip string)) *LoginLockRepo_Check_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *LoginLockRepo_Check_Call) Return(_a0 error) *LoginLockRepo_Check_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LoginLockRepo_Check_Call) RunAndReturn(run func(string, string) error) *LoginLockRepo_Check_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Clear provides a mock function with no fields
+func (_m *LoginLockRepo) Clear() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Clear")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LoginLockRepo_Clear_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Clear'
+type LoginLockRepo_Clear_Call struct {
+	*mock.Call
+}
+
+// Clear is a helper method to define mock.On call
+func (_e *LoginLockRepo_Expecter) Clear() *LoginLockRepo_Clear_Call {
+	return &LoginLockRepo_Clear_Call{Call: _e.mock.On("Clear")}
+}
+
+func (_c *LoginLockRepo_Clear_Call) Run(run func()) *LoginLockRepo_Clear_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *LoginLockRepo_Clear_Call) Return(_a0 error) *LoginLockRepo_Clear_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LoginLockRepo_Clear_Call) RunAndReturn(run func() error) *LoginLockRepo_Clear_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *LoginLockRepo) Delete(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LoginLockRepo_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type LoginLockRepo_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - id uint
+func (_e *LoginLockRepo_Expecter) Delete(id interface{}) *LoginLockRepo_Delete_Call {
+	return &LoginLockRepo_Delete_Call{Call: _e.mock.On("Delete", id)}
+}
+
+func (_c *LoginLockRepo_Delete_Call) Run(run func(id uint)) *LoginLockRepo_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *LoginLockRepo_Delete_Call) Return(_a0 error) *LoginLockRepo_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LoginLockRepo_Delete_Call) RunAndReturn(run func(uint) error) *LoginLockRepo_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Fail provides a mock function with given fields: username, ip
+func (_m *LoginLockRepo) Fail(username string, ip string) error {
+	ret := _m.Called(username, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Fail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(username, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LoginLockRepo_Fail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Fail'
+type LoginLockRepo_Fail_Call struct {
+	*mock.Call
+}
+
+// Fail is a helper method to define mock.On call
+//   - username string
+//   - ip string
+func (_e *LoginLockRepo_Expecter) Fail(username interface{}, ip interface{}) *LoginLockRepo_Fail_Call {
+	return &LoginLockRepo_Fail_Call{Call: _e.mock.On("Fail", username, ip)}
+}
+
+func (_c *LoginLockRepo_Fail_Call) Run(run func(username string, ip string)) *LoginLockRepo_Fail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *LoginLockRepo_Fail_Call) Return(_a0 error) *LoginLockRepo_Fail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LoginLockRepo_Fail_Call) RunAndReturn(run func(string, string) error) *LoginLockRepo_Fail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: page, limit
+func (_m *LoginLockRepo) List(page uint, limit uint) ([]*biz.LoginLock, int64, error) {
+	ret := _m.Called(page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*biz.LoginLock
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uint, uint) ([]*biz.LoginLock, int64, error)); ok {
+		return rf(page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint) []*biz.LoginLock); ok {
+		r0 = rf(page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.LoginLock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint) int64); ok {
+		r1 = rf(page, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uint, uint) error); ok {
+		r2 = rf(page, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// LoginLockRepo_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type LoginLockRepo_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - page uint
+//   - limit uint
+func (_e *LoginLockRepo_Expecter) List(page interface{}, limit interface{}) *LoginLockRepo_List_Call {
+	return &LoginLockRepo_List_Call{Call: _e.mock.On("List", page, limit)}
+}
+
+func (_c *LoginLockRepo_List_Call) Run(run func(page uint, limit uint)) *LoginLockRepo_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *LoginLockRepo_List_Call) Return(_a0 []*biz.LoginLock, _a1 int64, _a2 error) *LoginLockRepo_List_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *LoginLockRepo_List_Call) RunAndReturn(run func(uint, uint) ([]*biz.LoginLock, int64, error)) *LoginLockRepo_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Succeed provides a mock function with given fields: username, ip
+func (_m *LoginLockRepo) Succeed(username string, ip string) error {
+	ret := _m.Called(username, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Succeed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(username, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LoginLockRepo_Succeed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Succeed'
+type LoginLockRepo_Succeed_Call struct {
+	*mock.Call
+}
+
+// Succeed is a helper method to define mock.On call
+//   - username string
+//   - ip string
+func (_e *LoginLockRepo_Expecter) Succeed(username interface{}, ip interface{}) *LoginLockRepo_Succeed_Call {
+	return &LoginLockRepo_Succeed_Call{Call: _e.mock.On("Succeed", username, ip)}
+}
+
+func (_c *LoginLockRepo_Succeed_Call) Run(run func(username string, ip string)) *LoginLockRepo_Succeed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *LoginLockRepo_Succeed_Call) Return(_a0 error) *LoginLockRepo_Succeed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LoginLockRepo_Succeed_Call) RunAndReturn(run func(string, string) error) *LoginLockRepo_Succeed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLoginLockRepo creates a new instance of LoginLockRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLoginLockRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LoginLockRepo {
+	mock := &LoginLockRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -127,23 +127,23 @@ func (_c *MonitorRepo_GetSetting_Call) RunAndReturn(run func() (*request.Monitor
 }
 
 // List provides a mock function with given fields: start, end
-func (_m *MonitorRepo) List(start time.Time, end time.Time) ([]*biz.Monitor, error) {
+func (_m *MonitorRepo) List(start time.Time, end time.Time) ([]*biz.MonitorAggregate, error) {
 	ret := _m.Called(start, end)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
 	}
 
-	var r0 []*biz.Monitor
+	var r0 []*biz.MonitorAggregate
 	var r1 error
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time) ([]*biz.Monitor, error)); ok {
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) ([]*biz.MonitorAggregate, error)); ok {
 		return rf(start, end)
 	}
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time) []*biz.Monitor); ok {
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) []*biz.MonitorAggregate); ok {
 		r0 = rf(start, end)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*biz.Monitor)
+			r0 = ret.Get(0).([]*biz.MonitorAggregate)
 		}
 	}
 
@@ -175,12 +175,118 @@ func (_c *MonitorRepo_List_Call) Run(run func(start time.Time, end time.Time)) *
 	return _c
 }
 
-func (_c *MonitorRepo_List_Call) Return(_a0 []*biz.Monitor, _a1 error) *MonitorRepo_List_Call {
+func (_c *MonitorRepo_List_Call) Return(_a0 []*biz.MonitorAggregate, _a1 error) *MonitorRepo_List_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MonitorRepo_List_Call) RunAndReturn(run func(time.Time, time.Time) ([]*biz.Monitor, error)) *MonitorRepo_List_Call {
+func (_c *MonitorRepo_List_Call) RunAndReturn(run func(time.Time, time.Time) ([]*biz.MonitorAggregate, error)) *MonitorRepo_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Processes provides a mock function with given fields: at, typ, sort
+func (_m *MonitorRepo) Processes(at time.Time, typ biz.MonitorProcessType, sort string) ([]*biz.MonitorProcess, error) {
+	ret := _m.Called(at, typ, sort)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Processes")
+	}
+
+	var r0 []*biz.MonitorProcess
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time, biz.MonitorProcessType, string) ([]*biz.MonitorProcess, error)); ok {
+		return rf(at, typ, sort)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time, biz.MonitorProcessType, string) []*biz.MonitorProcess); ok {
+		r0 = rf(at, typ, sort)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.MonitorProcess)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time, biz.MonitorProcessType, string) error); ok {
+		r1 = rf(at, typ, sort)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MonitorRepo_Processes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Processes'
+type MonitorRepo_Processes_Call struct {
+	*mock.Call
+}
+
+// Processes is a helper method to define mock.On call
+//   - at time.Time
+//   - typ biz.MonitorProcessType
+//   - sort string
+func (_e *MonitorRepo_Expecter) Processes(at interface{}, typ interface{}, sort interface{}) *MonitorRepo_Processes_Call {
+	return &MonitorRepo_Processes_Call{Call: _e.mock.On("Processes", at, typ, sort)}
+}
+
+func (_c *MonitorRepo_Processes_Call) Run(run func(at time.Time, typ biz.MonitorProcessType, sort string)) *MonitorRepo_Processes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time), args[1].(biz.MonitorProcessType), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MonitorRepo_Processes_Call) Return(_a0 []*biz.MonitorProcess, _a1 error) *MonitorRepo_Processes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MonitorRepo_Processes_Call) RunAndReturn(run func(time.Time, biz.MonitorProcessType, string) ([]*biz.MonitorProcess, error)) *MonitorRepo_Processes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rollup provides a mock function with given fields: now
+func (_m *MonitorRepo) Rollup(now time.Time) error {
+	ret := _m.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rollup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Time) error); ok {
+		r0 = rf(now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MonitorRepo_Rollup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rollup'
+type MonitorRepo_Rollup_Call struct {
+	*mock.Call
+}
+
+// Rollup is a helper method to define mock.On call
+//   - now time.Time
+func (_e *MonitorRepo_Expecter) Rollup(now interface{}) *MonitorRepo_Rollup_Call {
+	return &MonitorRepo_Rollup_Call{Call: _e.mock.On("Rollup", now)}
+}
+
+func (_c *MonitorRepo_Rollup_Call) Run(run func(now time.Time)) *MonitorRepo_Rollup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MonitorRepo_Rollup_Call) Return(_a0 error) *MonitorRepo_Rollup_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MonitorRepo_Rollup_Call) RunAndReturn(run func(time.Time) error) *MonitorRepo_Rollup_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,393 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package biz
+
+import (
+	biz "github.com/acepanel/panel/internal/biz"
+	mock "github.com/stretchr/testify/mock"
+
+	request "github.com/acepanel/panel/internal/http/request"
+)
+
+// NotifyRepo is an autogenerated mock type for the NotifyRepo type
+type NotifyRepo struct {
+	mock.Mock
+}
+
+type NotifyRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *NotifyRepo) EXPECT() *NotifyRepo_Expecter {
+	return &NotifyRepo_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: req
+func (_m *NotifyRepo) Create(req *request.NotifyChannelCreate) error {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*request.NotifyChannelCreate) error); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifyRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type NotifyRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - req *request.NotifyChannelCreate
+func (_e *NotifyRepo_Expecter) Create(req interface{}) *NotifyRepo_Create_Call {
+	return &NotifyRepo_Create_Call{Call: _e.mock.On("Create", req)}
+}
+
+func (_c *NotifyRepo_Create_Call) Run(run func(req *request.NotifyChannelCreate)) *NotifyRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*request.NotifyChannelCreate))
+	})
+	return _c
+}
+
+func (_c *NotifyRepo_Create_Call) Return(_a0 error) *NotifyRepo_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotifyRepo_Create_Call) RunAndReturn(run func(*request.NotifyChannelCreate) error) *NotifyRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *NotifyRepo) Delete(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifyRepo_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type NotifyRepo_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - id uint
+func (_e *NotifyRepo_Expecter) Delete(id interface{}) *NotifyRepo_Delete_Call {
+	return &NotifyRepo_Delete_Call{Call: _e.mock.On("Delete", id)}
+}
+
+func (_c *NotifyRepo_Delete_Call) Run(run func(id uint)) *NotifyRepo_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *NotifyRepo_Delete_Call) Return(_a0 error) *NotifyRepo_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotifyRepo_Delete_Call) RunAndReturn(run func(uint) error) *NotifyRepo_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: id
+func (_m *NotifyRepo) Get(id uint) (*biz.NotifyChannel, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *biz.NotifyChannel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*biz.NotifyChannel, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *biz.NotifyChannel); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*biz.NotifyChannel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyRepo_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type NotifyRepo_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - id uint
+func (_e *NotifyRepo_Expecter) Get(id interface{}) *NotifyRepo_Get_Call {
+	return &NotifyRepo_Get_Call{Call: _e.mock.On("Get", id)}
+}
+
+func (_c *NotifyRepo_Get_Call) Run(run func(id uint)) *NotifyRepo_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *NotifyRepo_Get_Call) Return(_a0 *biz.NotifyChannel, _a1 error) *NotifyRepo_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyRepo_Get_Call) RunAndReturn(run func(uint) (*biz.NotifyChannel, error)) *NotifyRepo_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: page, limit
+func (_m *NotifyRepo) List(page uint, limit uint) ([]*biz.NotifyChannel, int64, error) {
+	ret := _m.Called(page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*biz.NotifyChannel
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uint, uint) ([]*biz.NotifyChannel, int64, error)); ok {
+		return rf(page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint) []*biz.NotifyChannel); ok {
+		r0 = rf(page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.NotifyChannel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint) int64); ok {
+		r1 = rf(page, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uint, uint) error); ok {
+		r2 = rf(page, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NotifyRepo_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type NotifyRepo_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - page uint
+//   - limit uint
+func (_e *NotifyRepo_Expecter) List(page interface{}, limit interface{}) *NotifyRepo_List_Call {
+	return &NotifyRepo_List_Call{Call: _e.mock.On("List", page, limit)}
+}
+
+func (_c *NotifyRepo_List_Call) Run(run func(page uint, limit uint)) *NotifyRepo_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *NotifyRepo_List_Call) Return(_a0 []*biz.NotifyChannel, _a1 int64, _a2 error) *NotifyRepo_List_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *NotifyRepo_List_Call) RunAndReturn(run func(uint, uint) ([]*biz.NotifyChannel, int64, error)) *NotifyRepo_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Notify provides a mock function with given fields: event, title, content
+func (_m *NotifyRepo) Notify(event biz.NotifyEvent, title string, content string) error {
+	ret := _m.Called(event, title, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Notify")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(biz.NotifyEvent, string, string) error); ok {
+		r0 = rf(event, title, content)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifyRepo_Notify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Notify'
+type NotifyRepo_Notify_Call struct {
+	*mock.Call
+}
+
+// Notify is a helper method to define mock.On call
+//   - event biz.NotifyEvent
+//   - title string
+//   - content string
+func (_e *NotifyRepo_Expecter) Notify(event interface{}, title interface{}, content interface{}) *NotifyRepo_Notify_Call {
+	return &NotifyRepo_Notify_Call{Call: _e.mock.On("Notify", event, title, content)}
+}
+
+func (_c *NotifyRepo_Notify_Call) Run(run func(event biz.NotifyEvent, title string, content string)) *NotifyRepo_Notify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(biz.NotifyEvent), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *NotifyRepo_Notify_Call) Return(_a0 error) *NotifyRepo_Notify_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotifyRepo_Notify_Call) RunAndReturn(run func(biz.NotifyEvent, string, string) error) *NotifyRepo_Notify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Test provides a mock function with given fields: id
+func (_m *NotifyRepo) Test(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Test")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifyRepo_Test_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Test'
+type NotifyRepo_Test_Call struct {
+	*mock.Call
+}
+
+// Test is a helper method to define mock.On call
+//   - id uint
+func (_e *NotifyRepo_Expecter) Test(id interface{}) *NotifyRepo_Test_Call {
+	return &NotifyRepo_Test_Call{Call: _e.mock.On("Test", id)}
+}
+
+func (_c *NotifyRepo_Test_Call) Run(run func(id uint)) *NotifyRepo_Test_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *NotifyRepo_Test_Call) Return(_a0 error) *NotifyRepo_Test_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotifyRepo_Test_Call) RunAndReturn(run func(uint) error) *NotifyRepo_Test_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: req
+func (_m *NotifyRepo) Update(req *request.NotifyChannelUpdate) error {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*request.NotifyChannelUpdate) error); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifyRepo_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type NotifyRepo_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - req *request.NotifyChannelUpdate
+func (_e *NotifyRepo_Expecter) Update(req interface{}) *NotifyRepo_Update_Call {
+	return &NotifyRepo_Update_Call{Call: _e.mock.On("Update", req)}
+}
+
+func (_c *NotifyRepo_Update_Call) Run(run func(req *request.NotifyChannelUpdate)) *NotifyRepo_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*request.NotifyChannelUpdate))
+	})
+	return _c
+}
+
+func (_c *NotifyRepo_Update_Call) Return(_a0 error) *NotifyRepo_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotifyRepo_Update_Call) RunAndReturn(run func(*request.NotifyChannelUpdate) error) *NotifyRepo_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewNotifyRepo creates a new instance of NotifyRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotifyRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotifyRepo {
+	mock := &NotifyRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
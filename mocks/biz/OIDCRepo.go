@@ -0,0 +1,266 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package biz
+
+import (
+	context "context"
+
+	biz "github.com/acepanel/panel/internal/biz"
+
+	mock "github.com/stretchr/testify/mock"
+
+	oidc "github.com/acepanel/panel/pkg/oidc"
+
+	request "github.com/acepanel/panel/internal/http/request"
+)
+
+// OIDCRepo is an autogenerated mock type for the OIDCRepo type
+type OIDCRepo struct {
+	mock.Mock
+}
+
+type OIDCRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OIDCRepo) EXPECT() *OIDCRepo_Expecter {
+	return &OIDCRepo_Expecter{mock: &_m.Mock}
+}
+
+// AuthCodeURL provides a mock function with given fields: ctx, redirectURL
+func (_m *OIDCRepo) AuthCodeURL(ctx context.Context, redirectURL string) (*oidc.Auth, error) {
+	ret := _m.Called(ctx, redirectURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthCodeURL")
+	}
+
+	var r0 *oidc.Auth
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*oidc.Auth, error)); ok {
+		return rf(ctx, redirectURL)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *oidc.Auth); ok {
+		r0 = rf(ctx, redirectURL)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oidc.Auth)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, redirectURL)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCRepo_AuthCodeURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthCodeURL'
+type OIDCRepo_AuthCodeURL_Call struct {
+	*mock.Call
+}
+
+// AuthCodeURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - redirectURL string
+func (_e *OIDCRepo_Expecter) AuthCodeURL(ctx interface{}, redirectURL interface{}) *OIDCRepo_AuthCodeURL_Call {
+	return &OIDCRepo_AuthCodeURL_Call{Call: _e.mock.On("AuthCodeURL", ctx, redirectURL)}
+}
+
+func (_c *OIDCRepo_AuthCodeURL_Call) Run(run func(ctx context.Context, redirectURL string)) *OIDCRepo_AuthCodeURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCRepo_AuthCodeURL_Call) Return(_a0 *oidc.Auth, _a1 error) *OIDCRepo_AuthCodeURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCRepo_AuthCodeURL_Call) RunAndReturn(run func(context.Context, string) (*oidc.Auth, error)) *OIDCRepo_AuthCodeURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetConfig provides a mock function with no fields
+func (_m *OIDCRepo) GetConfig() (*request.SettingOIDC, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfig")
+	}
+
+	var r0 *request.SettingOIDC
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*request.SettingOIDC, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *request.SettingOIDC); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.SettingOIDC)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCRepo_GetConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetConfig'
+type OIDCRepo_GetConfig_Call struct {
+	*mock.Call
+}
+
+// GetConfig is a helper method to define mock.On call
+func (_e *OIDCRepo_Expecter) GetConfig() *OIDCRepo_GetConfig_Call {
+	return &OIDCRepo_GetConfig_Call{Call: _e.mock.On("GetConfig")}
+}
+
+func (_c *OIDCRepo_GetConfig_Call) Run(run func()) *OIDCRepo_GetConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *OIDCRepo_GetConfig_Call) Return(_a0 *request.SettingOIDC, _a1 error) *OIDCRepo_GetConfig_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCRepo_GetConfig_Call) RunAndReturn(run func() (*request.SettingOIDC, error)) *OIDCRepo_GetConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Login provides a mock function with given fields: ctx, redirectURL, code, verifier, nonce
+func (_m *OIDCRepo) Login(ctx context.Context, redirectURL string, code string, verifier string, nonce string) (*biz.User, error) {
+	ret := _m.Called(ctx, redirectURL, code, verifier, nonce)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Login")
+	}
+
+	var r0 *biz.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*biz.User, error)); ok {
+		return rf(ctx, redirectURL, code, verifier, nonce)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) *biz.User); ok {
+		r0 = rf(ctx, redirectURL, code, verifier, nonce)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*biz.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, redirectURL, code, verifier, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCRepo_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
+type OIDCRepo_Login_Call struct {
+	*mock.Call
+}
+
+// Login is a helper method to define mock.On call
+//   - ctx context.Context
+//   - redirectURL string
+//   - code string
+//   - verifier string
+//   - nonce string
+func (_e *OIDCRepo_Expecter) Login(ctx interface{}, redirectURL interface{}, code interface{}, verifier interface{}, nonce interface{}) *OIDCRepo_Login_Call {
+	return &OIDCRepo_Login_Call{Call: _e.mock.On("Login", ctx, redirectURL, code, verifier, nonce)}
+}
+
+func (_c *OIDCRepo_Login_Call) Run(run func(ctx context.Context, redirectURL string, code string, verifier string, nonce string)) *OIDCRepo_Login_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *OIDCRepo_Login_Call) Return(_a0 *biz.User, _a1 error) *OIDCRepo_Login_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OIDCRepo_Login_Call) RunAndReturn(run func(context.Context, string, string, string, string) (*biz.User, error)) *OIDCRepo_Login_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateConfig provides a mock function with given fields: req
+func (_m *OIDCRepo) UpdateConfig(req *request.SettingOIDC) error {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateConfig")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*request.SettingOIDC) error); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OIDCRepo_UpdateConfig_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateConfig'
+type OIDCRepo_UpdateConfig_Call struct {
+	*mock.Call
+}
+
+// UpdateConfig is a helper method to define mock.On call
+//   - req *request.SettingOIDC
+func (_e *OIDCRepo_Expecter) UpdateConfig(req interface{}) *OIDCRepo_UpdateConfig_Call {
+	return &OIDCRepo_UpdateConfig_Call{Call: _e.mock.On("UpdateConfig", req)}
+}
+
+func (_c *OIDCRepo_UpdateConfig_Call) Run(run func(req *request.SettingOIDC)) *OIDCRepo_UpdateConfig_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*request.SettingOIDC))
+	})
+	return _c
+}
+
+func (_c *OIDCRepo_UpdateConfig_Call) Return(_a0 error) *OIDCRepo_UpdateConfig_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OIDCRepo_UpdateConfig_Call) RunAndReturn(run func(*request.SettingOIDC) error) *OIDCRepo_UpdateConfig_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOIDCRepo creates a new instance of OIDCRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOIDCRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OIDCRepo {
+	mock := &OIDCRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,370 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package biz
+
+import (
+	biz "github.com/acepanel/panel/internal/biz"
+	mock "github.com/stretchr/testify/mock"
+
+	request "github.com/acepanel/panel/internal/http/request"
+)
+
+// RoleRepo is an autogenerated mock type for the RoleRepo type
+type RoleRepo struct {
+	mock.Mock
+}
+
+type RoleRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RoleRepo) EXPECT() *RoleRepo_Expecter {
+	return &RoleRepo_Expecter{mock: &_m.Mock}
+}
+
+// Allowed provides a mock function with given fields: userID, tokenID, group, write
+func (_m *RoleRepo) Allowed(userID uint, tokenID uint, group string, write bool) (bool, error) {
+	ret := _m.Called(userID, tokenID, group, write)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Allowed")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, uint, string, bool) (bool, error)); ok {
+		return rf(userID, tokenID, group, write)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint, string, bool) bool); ok {
+		r0 = rf(userID, tokenID, group, write)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint, string, bool) error); ok {
+		r1 = rf(userID, tokenID, group, write)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RoleRepo_Allowed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Allowed'
+type RoleRepo_Allowed_Call struct {
+	*mock.Call
+}
+
+// Allowed is a helper method to define mock.On call
+//   - userID uint
+//   - tokenID uint
+//   - group string
+//   - write bool
+func (_e *RoleRepo_Expecter) Allowed(userID interface{}, tokenID interface{}, group interface{}, write interface{}) *RoleRepo_Allowed_Call {
+	return &RoleRepo_Allowed_Call{Call: _e.mock.On("Allowed", userID, tokenID, group, write)}
+}
+
+func (_c *RoleRepo_Allowed_Call) Run(run func(userID uint, tokenID uint, group string, write bool)) *RoleRepo_Allowed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint), args[2].(string), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *RoleRepo_Allowed_Call) Return(_a0 bool, _a1 error) *RoleRepo_Allowed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RoleRepo_Allowed_Call) RunAndReturn(run func(uint, uint, string, bool) (bool, error)) *RoleRepo_Allowed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: req
+func (_m *RoleRepo) Create(req *request.RoleCreate) (*biz.Role, error) {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *biz.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*request.RoleCreate) (*biz.Role, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(*request.RoleCreate) *biz.Role); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*biz.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*request.RoleCreate) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RoleRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type RoleRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - req *request.RoleCreate
+func (_e *RoleRepo_Expecter) Create(req interface{}) *RoleRepo_Create_Call {
+	return &RoleRepo_Create_Call{Call: _e.mock.On("Create", req)}
+}
+
+func (_c *RoleRepo_Create_Call) Run(run func(req *request.RoleCreate)) *RoleRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*request.RoleCreate))
+	})
+	return _c
+}
+
+func (_c *RoleRepo_Create_Call) Return(_a0 *biz.Role, _a1 error) *RoleRepo_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RoleRepo_Create_Call) RunAndReturn(run func(*request.RoleCreate) (*biz.Role, error)) *RoleRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *RoleRepo) Delete(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RoleRepo_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type RoleRepo_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - id uint
+func (_e *RoleRepo_Expecter) Delete(id interface{}) *RoleRepo_Delete_Call {
+	return &RoleRepo_Delete_Call{Call: _e.mock.On("Delete", id)}
+}
+
+func (_c *RoleRepo_Delete_Call) Run(run func(id uint)) *RoleRepo_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *RoleRepo_Delete_Call) Return(_a0 error) *RoleRepo_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RoleRepo_Delete_Call) RunAndReturn(run func(uint) error) *RoleRepo_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: id
+func (_m *RoleRepo) Get(id uint) (*biz.Role, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *biz.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*biz.Role, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *biz.Role); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*biz.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RoleRepo_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type RoleRepo_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - id uint
+func (_e *RoleRepo_Expecter) Get(id interface{}) *RoleRepo_Get_Call {
+	return &RoleRepo_Get_Call{Call: _e.mock.On("Get", id)}
+}
+
+func (_c *RoleRepo_Get_Call) Run(run func(id uint)) *RoleRepo_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *RoleRepo_Get_Call) Return(_a0 *biz.Role, _a1 error) *RoleRepo_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RoleRepo_Get_Call) RunAndReturn(run func(uint) (*biz.Role, error)) *RoleRepo_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: page, limit
+func (_m *RoleRepo) List(page uint, limit uint) ([]*biz.Role, int64, error) {
+	ret := _m.Called(page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*biz.Role
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uint, uint) ([]*biz.Role, int64, error)); ok {
+		return rf(page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint) []*biz.Role); ok {
+		r0 = rf(page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint) int64); ok {
+		r1 = rf(page, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uint, uint) error); ok {
+		r2 = rf(page, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RoleRepo_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type RoleRepo_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - page uint
+//   - limit uint
+func (_e *RoleRepo_Expecter) List(page interface{}, limit interface{}) *RoleRepo_List_Call {
+	return &RoleRepo_List_Call{Call: _e.mock.On("List", page, limit)}
+}
+
+func (_c *RoleRepo_List_Call) Run(run func(page uint, limit uint)) *RoleRepo_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *RoleRepo_List_Call) Return(_a0 []*biz.Role, _a1 int64, _a2 error) *RoleRepo_List_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *RoleRepo_List_Call) RunAndReturn(run func(uint, uint) ([]*biz.Role, int64, error)) *RoleRepo_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: req
+func (_m *RoleRepo) Update(req *request.RoleUpdate) error {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*request.RoleUpdate) error); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RoleRepo_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type RoleRepo_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - req *request.RoleUpdate
+func (_e *RoleRepo_Expecter) Update(req interface{}) *RoleRepo_Update_Call {
+	return &RoleRepo_Update_Call{Call: _e.mock.On("Update", req)}
+}
+
+func (_c *RoleRepo_Update_Call) Run(run func(req *request.RoleUpdate)) *RoleRepo_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*request.RoleUpdate))
+	})
+	return _c
+}
+
+func (_c *RoleRepo_Update_Call) Return(_a0 error) *RoleRepo_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RoleRepo_Update_Call) RunAndReturn(run func(*request.RoleUpdate) error) *RoleRepo_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRoleRepo creates a new instance of RoleRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRoleRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RoleRepo {
+	mock := &RoleRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
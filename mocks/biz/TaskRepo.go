@@ -326,6 +326,63 @@ func (_c *TaskRepo_Push_Call) RunAndReturn(run func(*biz.Task) error) *TaskRepo_
 	return _c
 }
 
+// StatusCount provides a mock function with no fields
+func (_m *TaskRepo) StatusCount() (map[biz.TaskStatus]int64, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for StatusCount")
+	}
+
+	var r0 map[biz.TaskStatus]int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (map[biz.TaskStatus]int64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() map[biz.TaskStatus]int64); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[biz.TaskStatus]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TaskRepo_StatusCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StatusCount'
+type TaskRepo_StatusCount_Call struct {
+	*mock.Call
+}
+
+// StatusCount is a helper method to define mock.On call
+func (_e *TaskRepo_Expecter) StatusCount() *TaskRepo_StatusCount_Call {
+	return &TaskRepo_StatusCount_Call{Call: _e.mock.On("StatusCount")}
+}
+
+func (_c *TaskRepo_StatusCount_Call) Run(run func()) *TaskRepo_StatusCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *TaskRepo_StatusCount_Call) Return(_a0 map[biz.TaskStatus]int64, _a1 error) *TaskRepo_StatusCount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TaskRepo_StatusCount_Call) RunAndReturn(run func() (map[biz.TaskStatus]int64, error)) *TaskRepo_StatusCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateStatus provides a mock function with given fields: id, status
 func (_m *TaskRepo) UpdateStatus(id uint, status biz.TaskStatus) error {
 	ret := _m.Called(id, status)
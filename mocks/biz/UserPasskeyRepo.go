@@ -0,0 +1,505 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package biz
+
+import (
+	biz "github.com/acepanel/panel/internal/biz"
+	mock "github.com/stretchr/testify/mock"
+
+	protocol "github.com/go-webauthn/webauthn/protocol"
+
+	webauthn "github.com/go-webauthn/webauthn/webauthn"
+)
+
+// UserPasskeyRepo is an autogenerated mock type for the UserPasskeyRepo type
+type UserPasskeyRepo struct {
+	mock.Mock
+}
+
+type UserPasskeyRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UserPasskeyRepo) EXPECT() *UserPasskeyRepo_Expecter {
+	return &UserPasskeyRepo_Expecter{mock: &_m.Mock}
+}
+
+// BeginLogin provides a mock function with given fields: rp, username
+func (_m *UserPasskeyRepo) BeginLogin(rp *webauthn.WebAuthn, username string) (*protocol.CredentialAssertion, *webauthn.SessionData, error) {
+	ret := _m.Called(rp, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BeginLogin")
+	}
+
+	var r0 *protocol.CredentialAssertion
+	var r1 *webauthn.SessionData
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*webauthn.WebAuthn, string) (*protocol.CredentialAssertion, *webauthn.SessionData, error)); ok {
+		return rf(rp, username)
+	}
+	if rf, ok := ret.Get(0).(func(*webauthn.WebAuthn, string) *protocol.CredentialAssertion); ok {
+		r0 = rf(rp, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*protocol.CredentialAssertion)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*webauthn.WebAuthn, string) *webauthn.SessionData); ok {
+		r1 = rf(rp, username)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*webauthn.SessionData)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(*webauthn.WebAuthn, string) error); ok {
+		r2 = rf(rp, username)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UserPasskeyRepo_BeginLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BeginLogin'
+type UserPasskeyRepo_BeginLogin_Call struct {
+	*mock.Call
+}
+
+// BeginLogin is a helper method to define mock.On call
+//   - rp *webauthn.WebAuthn
+//   - username string
+func (_e *UserPasskeyRepo_Expecter) BeginLogin(rp interface{}, username interface{}) *UserPasskeyRepo_BeginLogin_Call {
+	return &UserPasskeyRepo_BeginLogin_Call{Call: _e.mock.On("BeginLogin", rp, username)}
+}
+
+func (_c *UserPasskeyRepo_BeginLogin_Call) Run(run func(rp *webauthn.WebAuthn, username string)) *UserPasskeyRepo_BeginLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*webauthn.WebAuthn), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserPasskeyRepo_BeginLogin_Call) Return(_a0 *protocol.CredentialAssertion, _a1 *webauthn.SessionData, _a2 error) *UserPasskeyRepo_BeginLogin_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *UserPasskeyRepo_BeginLogin_Call) RunAndReturn(run func(*webauthn.WebAuthn, string) (*protocol.CredentialAssertion, *webauthn.SessionData, error)) *UserPasskeyRepo_BeginLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BeginRegistration provides a mock function with given fields: rp, userID
+func (_m *UserPasskeyRepo) BeginRegistration(rp *webauthn.WebAuthn, userID uint) (*protocol.CredentialCreation, *webauthn.SessionData, error) {
+	ret := _m.Called(rp, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BeginRegistration")
+	}
+
+	var r0 *protocol.CredentialCreation
+	var r1 *webauthn.SessionData
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*webauthn.WebAuthn, uint) (*protocol.CredentialCreation, *webauthn.SessionData, error)); ok {
+		return rf(rp, userID)
+	}
+	if rf, ok := ret.Get(0).(func(*webauthn.WebAuthn, uint) *protocol.CredentialCreation); ok {
+		r0 = rf(rp, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*protocol.CredentialCreation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*webauthn.WebAuthn, uint) *webauthn.SessionData); ok {
+		r1 = rf(rp, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*webauthn.SessionData)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(*webauthn.WebAuthn, uint) error); ok {
+		r2 = rf(rp, userID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UserPasskeyRepo_BeginRegistration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BeginRegistration'
+type UserPasskeyRepo_BeginRegistration_Call struct {
+	*mock.Call
+}
+
+// BeginRegistration is a helper method to define mock.On call
+//   - rp *webauthn.WebAuthn
+//   - userID uint
+func (_e *UserPasskeyRepo_Expecter) BeginRegistration(rp interface{}, userID interface{}) *UserPasskeyRepo_BeginRegistration_Call {
+	return &UserPasskeyRepo_BeginRegistration_Call{Call: _e.mock.On("BeginRegistration", rp, userID)}
+}
+
+func (_c *UserPasskeyRepo_BeginRegistration_Call) Run(run func(rp *webauthn.WebAuthn, userID uint)) *UserPasskeyRepo_BeginRegistration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*webauthn.WebAuthn), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *UserPasskeyRepo_BeginRegistration_Call) Return(_a0 *protocol.CredentialCreation, _a1 *webauthn.SessionData, _a2 error) *UserPasskeyRepo_BeginRegistration_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *UserPasskeyRepo_BeginRegistration_Call) RunAndReturn(run func(*webauthn.WebAuthn, uint) (*protocol.CredentialCreation, *webauthn.SessionData, error)) *UserPasskeyRepo_BeginRegistration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Clear provides a mock function with given fields: userID
+func (_m *UserPasskeyRepo) Clear(userID uint) error {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Clear")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserPasskeyRepo_Clear_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Clear'
+type UserPasskeyRepo_Clear_Call struct {
+	*mock.Call
+}
+
+// Clear is a helper method to define mock.On call
+//   - userID uint
+func (_e *UserPasskeyRepo_Expecter) Clear(userID interface{}) *UserPasskeyRepo_Clear_Call {
+	return &UserPasskeyRepo_Clear_Call{Call: _e.mock.On("Clear", userID)}
+}
+
+func (_c *UserPasskeyRepo_Clear_Call) Run(run func(userID uint)) *UserPasskeyRepo_Clear_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *UserPasskeyRepo_Clear_Call) Return(_a0 error) *UserPasskeyRepo_Clear_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserPasskeyRepo_Clear_Call) RunAndReturn(run func(uint) error) *UserPasskeyRepo_Clear_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Count provides a mock function with given fields: userID
+func (_m *UserPasskeyRepo) Count(userID uint) (int64, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (int64, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) int64); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserPasskeyRepo_Count_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Count'
+type UserPasskeyRepo_Count_Call struct {
+	*mock.Call
+}
+
+// Count is a helper method to define mock.On call
+//   - userID uint
+func (_e *UserPasskeyRepo_Expecter) Count(userID interface{}) *UserPasskeyRepo_Count_Call {
+	return &UserPasskeyRepo_Count_Call{Call: _e.mock.On("Count", userID)}
+}
+
+func (_c *UserPasskeyRepo_Count_Call) Run(run func(userID uint)) *UserPasskeyRepo_Count_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *UserPasskeyRepo_Count_Call) Return(_a0 int64, _a1 error) *UserPasskeyRepo_Count_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserPasskeyRepo_Count_Call) RunAndReturn(run func(uint) (int64, error)) *UserPasskeyRepo_Count_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: userID, id
+func (_m *UserPasskeyRepo) Delete(userID uint, id uint) error {
+	ret := _m.Called(userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserPasskeyRepo_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type UserPasskeyRepo_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - userID uint
+//   - id uint
+func (_e *UserPasskeyRepo_Expecter) Delete(userID interface{}, id interface{}) *UserPasskeyRepo_Delete_Call {
+	return &UserPasskeyRepo_Delete_Call{Call: _e.mock.On("Delete", userID, id)}
+}
+
+func (_c *UserPasskeyRepo_Delete_Call) Run(run func(userID uint, id uint)) *UserPasskeyRepo_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *UserPasskeyRepo_Delete_Call) Return(_a0 error) *UserPasskeyRepo_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserPasskeyRepo_Delete_Call) RunAndReturn(run func(uint, uint) error) *UserPasskeyRepo_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FinishLogin provides a mock function with given fields: rp, userID, session, response
+func (_m *UserPasskeyRepo) FinishLogin(rp *webauthn.WebAuthn, userID uint, session webauthn.SessionData, response *protocol.ParsedCredentialAssertionData) (*biz.User, error) {
+	ret := _m.Called(rp, userID, session, response)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishLogin")
+	}
+
+	var r0 *biz.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*webauthn.WebAuthn, uint, webauthn.SessionData, *protocol.ParsedCredentialAssertionData) (*biz.User, error)); ok {
+		return rf(rp, userID, session, response)
+	}
+	if rf, ok := ret.Get(0).(func(*webauthn.WebAuthn, uint, webauthn.SessionData, *protocol.ParsedCredentialAssertionData) *biz.User); ok {
+		r0 = rf(rp, userID, session, response)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*biz.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*webauthn.WebAuthn, uint, webauthn.SessionData, *protocol.ParsedCredentialAssertionData) error); ok {
+		r1 = rf(rp, userID, session, response)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserPasskeyRepo_FinishLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FinishLogin'
+type UserPasskeyRepo_FinishLogin_Call struct {
+	*mock.Call
+}
+
+// FinishLogin is a helper method to define mock.On call
+//   - rp *webauthn.WebAuthn
+//   - userID uint
+//   - session webauthn.SessionData
+//   - response *protocol.ParsedCredentialAssertionData
+func (_e *UserPasskeyRepo_Expecter) FinishLogin(rp interface{}, userID interface{}, session interface{}, response interface{}) *UserPasskeyRepo_FinishLogin_Call {
+	return &UserPasskeyRepo_FinishLogin_Call{Call: _e.mock.On("FinishLogin", rp, userID, session, response)}
+}
+
+func (_c *UserPasskeyRepo_FinishLogin_Call) Run(run func(rp *webauthn.WebAuthn, userID uint, session webauthn.SessionData, response *protocol.ParsedCredentialAssertionData)) *UserPasskeyRepo_FinishLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*webauthn.WebAuthn), args[1].(uint), args[2].(webauthn.SessionData), args[3].(*protocol.ParsedCredentialAssertionData))
+	})
+	return _c
+}
+
+func (_c *UserPasskeyRepo_FinishLogin_Call) Return(_a0 *biz.User, _a1 error) *UserPasskeyRepo_FinishLogin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserPasskeyRepo_FinishLogin_Call) RunAndReturn(run func(*webauthn.WebAuthn, uint, webauthn.SessionData, *protocol.ParsedCredentialAssertionData) (*biz.User, error)) *UserPasskeyRepo_FinishLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FinishRegistration provides a mock function with given fields: rp, userID, name, session, response
+func (_m *UserPasskeyRepo) FinishRegistration(rp *webauthn.WebAuthn, userID uint, name string, session webauthn.SessionData, response *protocol.ParsedCredentialCreationData) (*biz.UserPasskey, error) {
+	ret := _m.Called(rp, userID, name, session, response)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishRegistration")
+	}
+
+	var r0 *biz.UserPasskey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*webauthn.WebAuthn, uint, string, webauthn.SessionData, *protocol.ParsedCredentialCreationData) (*biz.UserPasskey, error)); ok {
+		return rf(rp, userID, name, session, response)
+	}
+	if rf, ok := ret.Get(0).(func(*webauthn.WebAuthn, uint, string, webauthn.SessionData, *protocol.ParsedCredentialCreationData) *biz.UserPasskey); ok {
+		r0 = rf(rp, userID, name, session, response)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*biz.UserPasskey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*webauthn.WebAuthn, uint, string, webauthn.SessionData, *protocol.ParsedCredentialCreationData) error); ok {
+		r1 = rf(rp, userID, name, session, response)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserPasskeyRepo_FinishRegistration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FinishRegistration'
+type UserPasskeyRepo_FinishRegistration_Call struct {
+	*mock.Call
+}
+
+// FinishRegistration is a helper method to define mock.On call
+//   - rp *webauthn.WebAuthn
+//   - userID uint
+//   - name string
+//   - session webauthn.SessionData
+//   - response *protocol.ParsedCredentialCreationData
+func (_e *UserPasskeyRepo_Expecter) FinishRegistration(rp interface{}, userID interface{}, name interface{}, session interface{}, response interface{}) *UserPasskeyRepo_FinishRegistration_Call {
+	return &UserPasskeyRepo_FinishRegistration_Call{Call: _e.mock.On("FinishRegistration", rp, userID, name, session, response)}
+}
+
+func (_c *UserPasskeyRepo_FinishRegistration_Call) Run(run func(rp *webauthn.WebAuthn, userID uint, name string, session webauthn.SessionData, response *protocol.ParsedCredentialCreationData)) *UserPasskeyRepo_FinishRegistration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*webauthn.WebAuthn), args[1].(uint), args[2].(string), args[3].(webauthn.SessionData), args[4].(*protocol.ParsedCredentialCreationData))
+	})
+	return _c
+}
+
+func (_c *UserPasskeyRepo_FinishRegistration_Call) Return(_a0 *biz.UserPasskey, _a1 error) *UserPasskeyRepo_FinishRegistration_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserPasskeyRepo_FinishRegistration_Call) RunAndReturn(run func(*webauthn.WebAuthn, uint, string, webauthn.SessionData, *protocol.ParsedCredentialCreationData) (*biz.UserPasskey, error)) *UserPasskeyRepo_FinishRegistration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: userID
+func (_m *UserPasskeyRepo) List(userID uint) ([]*biz.UserPasskey, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*biz.UserPasskey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]*biz.UserPasskey, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []*biz.UserPasskey); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.UserPasskey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserPasskeyRepo_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type UserPasskeyRepo_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - userID uint
+func (_e *UserPasskeyRepo_Expecter) List(userID interface{}) *UserPasskeyRepo_List_Call {
+	return &UserPasskeyRepo_List_Call{Call: _e.mock.On("List", userID)}
+}
+
+func (_c *UserPasskeyRepo_List_Call) Run(run func(userID uint)) *UserPasskeyRepo_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *UserPasskeyRepo_List_Call) Return(_a0 []*biz.UserPasskey, _a1 error) *UserPasskeyRepo_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserPasskeyRepo_List_Call) RunAndReturn(run func(uint) ([]*biz.UserPasskey, error)) *UserPasskeyRepo_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUserPasskeyRepo creates a new instance of UserPasskeyRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserPasskeyRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserPasskeyRepo {
+	mock := &UserPasskeyRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
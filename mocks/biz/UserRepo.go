@@ -440,6 +440,64 @@ func (_c *UserRepo_List_Call) RunAndReturn(run func(uint, uint) ([]*biz.User, in
 	return _c
 }
 
+// Owns provides a mock function with given fields: id, table, resource
+func (_m *UserRepo) Owns(id uint, table string, resource uint) (bool, error) {
+	ret := _m.Called(id, table, resource)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Owns")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, string, uint) (bool, error)); ok {
+		return rf(id, table, resource)
+	}
+	if rf, ok := ret.Get(0).(func(uint, string, uint) bool); ok {
+		r0 = rf(id, table, resource)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, string, uint) error); ok {
+		r1 = rf(id, table, resource)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepo_Owns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Owns'
+type UserRepo_Owns_Call struct {
+	*mock.Call
+}
+
+// Owns is a helper method to define mock.On call
+//   - id uint
+//   - table string
+//   - resource uint
+func (_e *UserRepo_Expecter) Owns(id interface{}, table interface{}, resource interface{}) *UserRepo_Owns_Call {
+	return &UserRepo_Owns_Call{Call: _e.mock.On("Owns", id, table, resource)}
+}
+
+func (_c *UserRepo_Owns_Call) Run(run func(id uint, table string, resource uint)) *UserRepo_Owns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(string), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *UserRepo_Owns_Call) Return(_a0 bool, _a1 error) *UserRepo_Owns_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepo_Owns_Call) RunAndReturn(run func(uint, string, uint) (bool, error)) *UserRepo_Owns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateEmail provides a mock function with given fields: id, email
 func (_m *UserRepo) UpdateEmail(id uint, email string) error {
 	ret := _m.Called(id, email)
@@ -534,6 +592,101 @@ func (_c *UserRepo_UpdatePassword_Call) RunAndReturn(run func(uint, string) erro
 	return _c
 }
 
+// UpdateResources provides a mock function with given fields: id, typ, ids
+func (_m *UserRepo) UpdateResources(id uint, typ string, ids []uint) error {
+	ret := _m.Called(id, typ, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateResources")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, string, []uint) error); ok {
+		r0 = rf(id, typ, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_UpdateResources_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateResources'
+type UserRepo_UpdateResources_Call struct {
+	*mock.Call
+}
+
+// UpdateResources is a helper method to define mock.On call
+//   - id uint
+//   - typ string
+//   - ids []uint
+func (_e *UserRepo_Expecter) UpdateResources(id interface{}, typ interface{}, ids interface{}) *UserRepo_UpdateResources_Call {
+	return &UserRepo_UpdateResources_Call{Call: _e.mock.On("UpdateResources", id, typ, ids)}
+}
+
+func (_c *UserRepo_UpdateResources_Call) Run(run func(id uint, typ string, ids []uint)) *UserRepo_UpdateResources_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(string), args[2].([]uint))
+	})
+	return _c
+}
+
+func (_c *UserRepo_UpdateResources_Call) Return(_a0 error) *UserRepo_UpdateResources_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_UpdateResources_Call) RunAndReturn(run func(uint, string, []uint) error) *UserRepo_UpdateResources_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRole provides a mock function with given fields: id, roleID
+func (_m *UserRepo) UpdateRole(id uint, roleID uint) error {
+	ret := _m.Called(id, roleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(id, roleID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_UpdateRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRole'
+type UserRepo_UpdateRole_Call struct {
+	*mock.Call
+}
+
+// UpdateRole is a helper method to define mock.On call
+//   - id uint
+//   - roleID uint
+func (_e *UserRepo_Expecter) UpdateRole(id interface{}, roleID interface{}) *UserRepo_UpdateRole_Call {
+	return &UserRepo_UpdateRole_Call{Call: _e.mock.On("UpdateRole", id, roleID)}
+}
+
+func (_c *UserRepo_UpdateRole_Call) Run(run func(id uint, roleID uint)) *UserRepo_UpdateRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *UserRepo_UpdateRole_Call) Return(_a0 error) *UserRepo_UpdateRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_UpdateRole_Call) RunAndReturn(run func(uint, uint) error) *UserRepo_UpdateRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateTwoFA provides a mock function with given fields: id, code, secret
 func (_m *UserRepo) UpdateTwoFA(id uint, code string, secret string) error {
 	ret := _m.Called(id, code, secret)
@@ -0,0 +1,340 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package biz
+
+import (
+	biz "github.com/acepanel/panel/internal/biz"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserSessionRepo is an autogenerated mock type for the UserSessionRepo type
+type UserSessionRepo struct {
+	mock.Mock
+}
+
+type UserSessionRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UserSessionRepo) EXPECT() *UserSessionRepo_Expecter {
+	return &UserSessionRepo_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: userID, sessionID, ip, userAgent
+func (_m *UserSessionRepo) Create(userID uint, sessionID string, ip string, userAgent string) error {
+	ret := _m.Called(userID, sessionID, ip, userAgent)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, string, string, string) error); ok {
+		r0 = rf(userID, sessionID, ip, userAgent)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserSessionRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type UserSessionRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - userID uint
+//   - sessionID string
+//   - ip string
+//   - userAgent string
+func (_e *UserSessionRepo_Expecter) Create(userID interface{}, sessionID interface{}, ip interface{}, userAgent interface{}) *UserSessionRepo_Create_Call {
+	return &UserSessionRepo_Create_Call{Call: _e.mock.On("Create", userID, sessionID, ip, userAgent)}
+}
+
+func (_c *UserSessionRepo_Create_Call) Run(run func(userID uint, sessionID string, ip string, userAgent string)) *UserSessionRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *UserSessionRepo_Create_Call) Return(_a0 error) *UserSessionRepo_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserSessionRepo_Create_Call) RunAndReturn(run func(uint, string, string, string) error) *UserSessionRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: userID, id
+func (_m *UserSessionRepo) Delete(userID uint, id uint) error {
+	ret := _m.Called(userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserSessionRepo_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type UserSessionRepo_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - userID uint
+//   - id uint
+func (_e *UserSessionRepo_Expecter) Delete(userID interface{}, id interface{}) *UserSessionRepo_Delete_Call {
+	return &UserSessionRepo_Delete_Call{Call: _e.mock.On("Delete", userID, id)}
+}
+
+func (_c *UserSessionRepo_Delete_Call) Run(run func(userID uint, id uint)) *UserSessionRepo_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *UserSessionRepo_Delete_Call) Return(_a0 error) *UserSessionRepo_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserSessionRepo_Delete_Call) RunAndReturn(run func(uint, uint) error) *UserSessionRepo_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteBySessionID provides a mock function with given fields: sessionID
+func (_m *UserSessionRepo) DeleteBySessionID(sessionID string) error {
+	ret := _m.Called(sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBySessionID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserSessionRepo_DeleteBySessionID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteBySessionID'
+type UserSessionRepo_DeleteBySessionID_Call struct {
+	*mock.Call
+}
+
+// DeleteBySessionID is a helper method to define mock.On call
+//   - sessionID string
+func (_e *UserSessionRepo_Expecter) DeleteBySessionID(sessionID interface{}) *UserSessionRepo_DeleteBySessionID_Call {
+	return &UserSessionRepo_DeleteBySessionID_Call{Call: _e.mock.On("DeleteBySessionID", sessionID)}
+}
+
+func (_c *UserSessionRepo_DeleteBySessionID_Call) Run(run func(sessionID string)) *UserSessionRepo_DeleteBySessionID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *UserSessionRepo_DeleteBySessionID_Call) Return(_a0 error) *UserSessionRepo_DeleteBySessionID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserSessionRepo_DeleteBySessionID_Call) RunAndReturn(run func(string) error) *UserSessionRepo_DeleteBySessionID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: userID
+func (_m *UserSessionRepo) List(userID uint) ([]*biz.UserSession, error) {
+	ret := _m.Called(userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*biz.UserSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]*biz.UserSession, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []*biz.UserSession); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.UserSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserSessionRepo_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type UserSessionRepo_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - userID uint
+func (_e *UserSessionRepo_Expecter) List(userID interface{}) *UserSessionRepo_List_Call {
+	return &UserSessionRepo_List_Call{Call: _e.mock.On("List", userID)}
+}
+
+func (_c *UserSessionRepo_List_Call) Run(run func(userID uint)) *UserSessionRepo_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *UserSessionRepo_List_Call) Return(_a0 []*biz.UserSession, _a1 error) *UserSessionRepo_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserSessionRepo_List_Call) RunAndReturn(run func(uint) ([]*biz.UserSession, error)) *UserSessionRepo_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function with given fields: userID, except
+func (_m *UserSessionRepo) Revoke(userID uint, except string) error {
+	ret := _m.Called(userID, except)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, string) error); ok {
+		r0 = rf(userID, except)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserSessionRepo_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type UserSessionRepo_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - userID uint
+//   - except string
+func (_e *UserSessionRepo_Expecter) Revoke(userID interface{}, except interface{}) *UserSessionRepo_Revoke_Call {
+	return &UserSessionRepo_Revoke_Call{Call: _e.mock.On("Revoke", userID, except)}
+}
+
+func (_c *UserSessionRepo_Revoke_Call) Run(run func(userID uint, except string)) *UserSessionRepo_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *UserSessionRepo_Revoke_Call) Return(_a0 error) *UserSessionRepo_Revoke_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserSessionRepo_Revoke_Call) RunAndReturn(run func(uint, string) error) *UserSessionRepo_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Validate provides a mock function with given fields: userID, sessionID, ip
+func (_m *UserSessionRepo) Validate(userID uint, sessionID string, ip string) (bool, error) {
+	ret := _m.Called(userID, sessionID, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Validate")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, string, string) (bool, error)); ok {
+		return rf(userID, sessionID, ip)
+	}
+	if rf, ok := ret.Get(0).(func(uint, string, string) bool); ok {
+		r0 = rf(userID, sessionID, ip)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, string, string) error); ok {
+		r1 = rf(userID, sessionID, ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserSessionRepo_Validate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Validate'
+type UserSessionRepo_Validate_Call struct {
+	*mock.Call
+}
+
+// Validate is a helper method to define mock.On call
+//   - userID uint
+//   - sessionID string
+//   - ip string
+func (_e *UserSessionRepo_Expecter) Validate(userID interface{}, sessionID interface{}, ip interface{}) *UserSessionRepo_Validate_Call {
+	return &UserSessionRepo_Validate_Call{Call: _e.mock.On("Validate", userID, sessionID, ip)}
+}
+
+func (_c *UserSessionRepo_Validate_Call) Run(run func(userID uint, sessionID string, ip string)) *UserSessionRepo_Validate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *UserSessionRepo_Validate_Call) Return(_a0 bool, _a1 error) *UserSessionRepo_Validate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserSessionRepo_Validate_Call) RunAndReturn(run func(uint, string, string) (bool, error)) *UserSessionRepo_Validate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewUserSessionRepo creates a new instance of UserSessionRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUserSessionRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UserSessionRepo {
+	mock := &UserSessionRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
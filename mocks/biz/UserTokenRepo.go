@@ -25,9 +25,9 @@ func (_m *UserTokenRepo) EXPECT() *UserTokenRepo_Expecter {
 	return &UserTokenRepo_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function with given fields: userID, ips, expired
-func (_m *UserTokenRepo) Create(userID uint, ips []string, expired time.Time) (*biz.UserToken, error) {
-	ret := _m.Called(userID, ips, expired)
+// Create provides a mock function with given fields: userID, ips, roleID, scopes, websiteIDs, expired
+func (_m *UserTokenRepo) Create(userID uint, ips []string, roleID uint, scopes []string, websiteIDs []uint, expired time.Time) (*biz.UserToken, error) {
+	ret := _m.Called(userID, ips, roleID, scopes, websiteIDs, expired)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -35,19 +35,19 @@ func (_m *UserTokenRepo) Create(userID uint, ips []string, expired time.Time) (*
 
 	var r0 *biz.UserToken
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint, []string, time.Time) (*biz.UserToken, error)); ok {
-		return rf(userID, ips, expired)
+	if rf, ok := ret.Get(0).(func(uint, []string, uint, []string, []uint, time.Time) (*biz.UserToken, error)); ok {
+		return rf(userID, ips, roleID, scopes, websiteIDs, expired)
 	}
-	if rf, ok := ret.Get(0).(func(uint, []string, time.Time) *biz.UserToken); ok {
-		r0 = rf(userID, ips, expired)
+	if rf, ok := ret.Get(0).(func(uint, []string, uint, []string, []uint, time.Time) *biz.UserToken); ok {
+		r0 = rf(userID, ips, roleID, scopes, websiteIDs, expired)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*biz.UserToken)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint, []string, time.Time) error); ok {
-		r1 = rf(userID, ips, expired)
+	if rf, ok := ret.Get(1).(func(uint, []string, uint, []string, []uint, time.Time) error); ok {
+		r1 = rf(userID, ips, roleID, scopes, websiteIDs, expired)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -63,14 +63,17 @@ type UserTokenRepo_Create_Call struct {
 // Create is a helper method to define mock.On call
 //   - userID uint
 //   - ips []string
+//   - roleID uint
+//   - scopes []string
+//   - websiteIDs []uint
 //   - expired time.Time
-func (_e *UserTokenRepo_Expecter) Create(userID interface{}, ips interface{}, expired interface{}) *UserTokenRepo_Create_Call {
-	return &UserTokenRepo_Create_Call{Call: _e.mock.On("Create", userID, ips, expired)}
+func (_e *UserTokenRepo_Expecter) Create(userID interface{}, ips interface{}, roleID interface{}, scopes interface{}, websiteIDs interface{}, expired interface{}) *UserTokenRepo_Create_Call {
+	return &UserTokenRepo_Create_Call{Call: _e.mock.On("Create", userID, ips, roleID, scopes, websiteIDs, expired)}
 }
 
-func (_c *UserTokenRepo_Create_Call) Run(run func(userID uint, ips []string, expired time.Time)) *UserTokenRepo_Create_Call {
+func (_c *UserTokenRepo_Create_Call) Run(run func(userID uint, ips []string, roleID uint, scopes []string, websiteIDs []uint, expired time.Time)) *UserTokenRepo_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint), args[1].([]string), args[2].(time.Time))
+		run(args[0].(uint), args[1].([]string), args[2].(uint), args[3].([]string), args[4].([]uint), args[5].(time.Time))
 	})
 	return _c
 }
@@ -80,7 +83,7 @@ func (_c *UserTokenRepo_Create_Call) Return(_a0 *biz.UserToken, _a1 error) *User
 	return _c
 }
 
-func (_c *UserTokenRepo_Create_Call) RunAndReturn(run func(uint, []string, time.Time) (*biz.UserToken, error)) *UserTokenRepo_Create_Call {
+func (_c *UserTokenRepo_Create_Call) RunAndReturn(run func(uint, []string, uint, []string, []uint, time.Time) (*biz.UserToken, error)) *UserTokenRepo_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -256,9 +259,9 @@ func (_c *UserTokenRepo_List_Call) RunAndReturn(run func(uint, uint, uint) ([]*b
 	return _c
 }
 
-// Update provides a mock function with given fields: id, ips, expired
-func (_m *UserTokenRepo) Update(id uint, ips []string, expired time.Time) (*biz.UserToken, error) {
-	ret := _m.Called(id, ips, expired)
+// Update provides a mock function with given fields: id, ips, roleID, scopes, websiteIDs, expired
+func (_m *UserTokenRepo) Update(id uint, ips []string, roleID uint, scopes []string, websiteIDs []uint, expired time.Time) (*biz.UserToken, error) {
+	ret := _m.Called(id, ips, roleID, scopes, websiteIDs, expired)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
@@ -266,19 +269,19 @@ func (_m *UserTokenRepo) Update(id uint, ips []string, expired time.Time) (*biz.
 
 	var r0 *biz.UserToken
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint, []string, time.Time) (*biz.UserToken, error)); ok {
-		return rf(id, ips, expired)
+	if rf, ok := ret.Get(0).(func(uint, []string, uint, []string, []uint, time.Time) (*biz.UserToken, error)); ok {
+		return rf(id, ips, roleID, scopes, websiteIDs, expired)
 	}
-	if rf, ok := ret.Get(0).(func(uint, []string, time.Time) *biz.UserToken); ok {
-		r0 = rf(id, ips, expired)
+	if rf, ok := ret.Get(0).(func(uint, []string, uint, []string, []uint, time.Time) *biz.UserToken); ok {
+		r0 = rf(id, ips, roleID, scopes, websiteIDs, expired)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*biz.UserToken)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint, []string, time.Time) error); ok {
-		r1 = rf(id, ips, expired)
+	if rf, ok := ret.Get(1).(func(uint, []string, uint, []string, []uint, time.Time) error); ok {
+		r1 = rf(id, ips, roleID, scopes, websiteIDs, expired)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -294,14 +297,17 @@ type UserTokenRepo_Update_Call struct {
 // Update is a helper method to define mock.On call
 //   - id uint
 //   - ips []string
+//   - roleID uint
+//   - scopes []string
+//   - websiteIDs []uint
 //   - expired time.Time
-func (_e *UserTokenRepo_Expecter) Update(id interface{}, ips interface{}, expired interface{}) *UserTokenRepo_Update_Call {
-	return &UserTokenRepo_Update_Call{Call: _e.mock.On("Update", id, ips, expired)}
+func (_e *UserTokenRepo_Expecter) Update(id interface{}, ips interface{}, roleID interface{}, scopes interface{}, websiteIDs interface{}, expired interface{}) *UserTokenRepo_Update_Call {
+	return &UserTokenRepo_Update_Call{Call: _e.mock.On("Update", id, ips, roleID, scopes, websiteIDs, expired)}
 }
 
-func (_c *UserTokenRepo_Update_Call) Run(run func(id uint, ips []string, expired time.Time)) *UserTokenRepo_Update_Call {
+func (_c *UserTokenRepo_Update_Call) Run(run func(id uint, ips []string, roleID uint, scopes []string, websiteIDs []uint, expired time.Time)) *UserTokenRepo_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint), args[1].([]string), args[2].(time.Time))
+		run(args[0].(uint), args[1].([]string), args[2].(uint), args[3].([]string), args[4].([]uint), args[5].(time.Time))
 	})
 	return _c
 }
@@ -311,28 +317,30 @@ func (_c *UserTokenRepo_Update_Call) Return(_a0 *biz.UserToken, _a1 error) *User
 	return _c
 }
 
-func (_c *UserTokenRepo_Update_Call) RunAndReturn(run func(uint, []string, time.Time) (*biz.UserToken, error)) *UserTokenRepo_Update_Call {
+func (_c *UserTokenRepo_Update_Call) RunAndReturn(run func(uint, []string, uint, []string, []uint, time.Time) (*biz.UserToken, error)) *UserTokenRepo_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // ValidateReq provides a mock function with given fields: req
-func (_m *UserTokenRepo) ValidateReq(req *http.Request) (uint, error) {
+func (_m *UserTokenRepo) ValidateReq(req *http.Request) (*biz.UserToken, error) {
 	ret := _m.Called(req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ValidateReq")
 	}
 
-	var r0 uint
+	var r0 *biz.UserToken
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*http.Request) (uint, error)); ok {
+	if rf, ok := ret.Get(0).(func(*http.Request) (*biz.UserToken, error)); ok {
 		return rf(req)
 	}
-	if rf, ok := ret.Get(0).(func(*http.Request) uint); ok {
+	if rf, ok := ret.Get(0).(func(*http.Request) *biz.UserToken); ok {
 		r0 = rf(req)
 	} else {
-		r0 = ret.Get(0).(uint)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*biz.UserToken)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
@@ -362,12 +370,12 @@ func (_c *UserTokenRepo_ValidateReq_Call) Run(run func(req *http.Request)) *User
 	return _c
 }
 
-func (_c *UserTokenRepo_ValidateReq_Call) Return(_a0 uint, _a1 error) *UserTokenRepo_ValidateReq_Call {
+func (_c *UserTokenRepo_ValidateReq_Call) Return(_a0 *biz.UserToken, _a1 error) *UserTokenRepo_ValidateReq_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *UserTokenRepo_ValidateReq_Call) RunAndReturn(run func(*http.Request) (uint, error)) *UserTokenRepo_ValidateReq_Call {
+func (_c *UserTokenRepo_ValidateReq_Call) RunAndReturn(run func(*http.Request) (*biz.UserToken, error)) *UserTokenRepo_ValidateReq_Call {
 	_c.Call.Return(run)
 	return _c
 }
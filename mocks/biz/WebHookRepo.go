@@ -298,9 +298,9 @@ func (_c *WebHookRepo_GetByKey_Call) RunAndReturn(run func(string) (*biz.WebHook
 	return _c
 }
 
-// List provides a mock function with given fields: page, limit
-func (_m *WebHookRepo) List(page uint, limit uint) ([]*biz.WebHook, int64, error) {
-	ret := _m.Called(page, limit)
+// List provides a mock function with given fields: owner, page, limit
+func (_m *WebHookRepo) List(owner uint, page uint, limit uint) ([]*biz.WebHook, int64, error) {
+	ret := _m.Called(owner, page, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
@@ -309,25 +309,25 @@ func (_m *WebHookRepo) List(page uint, limit uint) ([]*biz.WebHook, int64, error
 	var r0 []*biz.WebHook
 	var r1 int64
 	var r2 error
-	if rf, ok := ret.Get(0).(func(uint, uint) ([]*biz.WebHook, int64, error)); ok {
-		return rf(page, limit)
+	if rf, ok := ret.Get(0).(func(uint, uint, uint) ([]*biz.WebHook, int64, error)); ok {
+		return rf(owner, page, limit)
 	}
-	if rf, ok := ret.Get(0).(func(uint, uint) []*biz.WebHook); ok {
-		r0 = rf(page, limit)
+	if rf, ok := ret.Get(0).(func(uint, uint, uint) []*biz.WebHook); ok {
+		r0 = rf(owner, page, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*biz.WebHook)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint, uint) int64); ok {
-		r1 = rf(page, limit)
+	if rf, ok := ret.Get(1).(func(uint, uint, uint) int64); ok {
+		r1 = rf(owner, page, limit)
 	} else {
 		r1 = ret.Get(1).(int64)
 	}
 
-	if rf, ok := ret.Get(2).(func(uint, uint) error); ok {
-		r2 = rf(page, limit)
+	if rf, ok := ret.Get(2).(func(uint, uint, uint) error); ok {
+		r2 = rf(owner, page, limit)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -341,15 +341,16 @@ type WebHookRepo_List_Call struct {
 }
 
 // List is a helper method to define mock.On call
+//   - owner uint
 //   - page uint
 //   - limit uint
-func (_e *WebHookRepo_Expecter) List(page interface{}, limit interface{}) *WebHookRepo_List_Call {
-	return &WebHookRepo_List_Call{Call: _e.mock.On("List", page, limit)}
+func (_e *WebHookRepo_Expecter) List(owner interface{}, page interface{}, limit interface{}) *WebHookRepo_List_Call {
+	return &WebHookRepo_List_Call{Call: _e.mock.On("List", owner, page, limit)}
 }
 
-func (_c *WebHookRepo_List_Call) Run(run func(page uint, limit uint)) *WebHookRepo_List_Call {
+func (_c *WebHookRepo_List_Call) Run(run func(owner uint, page uint, limit uint)) *WebHookRepo_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint), args[1].(uint))
+		run(args[0].(uint), args[1].(uint), args[2].(uint))
 	})
 	return _c
 }
@@ -359,7 +360,7 @@ func (_c *WebHookRepo_List_Call) Return(_a0 []*biz.WebHook, _a1 int64, _a2 error
 	return _c
 }
 
-func (_c *WebHookRepo_List_Call) RunAndReturn(run func(uint, uint) ([]*biz.WebHook, int64, error)) *WebHookRepo_List_Call {
+func (_c *WebHookRepo_List_Call) RunAndReturn(run func(uint, uint, uint) ([]*biz.WebHook, int64, error)) *WebHookRepo_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -27,6 +27,108 @@ func (_m *WebsiteRepo) EXPECT() *WebsiteRepo_Expecter {
 	return &WebsiteRepo_Expecter{mock: &_m.Mock}
 }
 
+// AccessLogs provides a mock function with no fields
+func (_m *WebsiteRepo) AccessLogs() (map[string]string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AccessLogs")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (map[string]string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() map[string]string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebsiteRepo_AccessLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccessLogs'
+type WebsiteRepo_AccessLogs_Call struct {
+	*mock.Call
+}
+
+// AccessLogs is a helper method to define mock.On call
+func (_e *WebsiteRepo_Expecter) AccessLogs() *WebsiteRepo_AccessLogs_Call {
+	return &WebsiteRepo_AccessLogs_Call{Call: _e.mock.On("AccessLogs")}
+}
+
+func (_c *WebsiteRepo_AccessLogs_Call) Run(run func()) *WebsiteRepo_AccessLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *WebsiteRepo_AccessLogs_Call) Return(_a0 map[string]string, _a1 error) *WebsiteRepo_AccessLogs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebsiteRepo_AccessLogs_Call) RunAndReturn(run func() (map[string]string, error)) *WebsiteRepo_AccessLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckUpstreams provides a mock function with no fields
+func (_m *WebsiteRepo) CheckUpstreams() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckUpstreams")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebsiteRepo_CheckUpstreams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckUpstreams'
+type WebsiteRepo_CheckUpstreams_Call struct {
+	*mock.Call
+}
+
+// CheckUpstreams is a helper method to define mock.On call
+func (_e *WebsiteRepo_Expecter) CheckUpstreams() *WebsiteRepo_CheckUpstreams_Call {
+	return &WebsiteRepo_CheckUpstreams_Call{Call: _e.mock.On("CheckUpstreams")}
+}
+
+func (_c *WebsiteRepo_CheckUpstreams_Call) Run(run func()) *WebsiteRepo_CheckUpstreams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *WebsiteRepo_CheckUpstreams_Call) Return(_a0 error) *WebsiteRepo_CheckUpstreams_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebsiteRepo_CheckUpstreams_Call) RunAndReturn(run func() error) *WebsiteRepo_CheckUpstreams_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ClearLog provides a mock function with given fields: id
 func (_m *WebsiteRepo) ClearLog(id uint) error {
 	ret := _m.Called(id)
@@ -128,9 +230,9 @@ func (_c *WebsiteRepo_Count_Call) RunAndReturn(run func() (int64, error)) *Websi
 	return _c
 }
 
-// Create provides a mock function with given fields: req
-func (_m *WebsiteRepo) Create(req *request.WebsiteCreate) (*biz.Website, error) {
-	ret := _m.Called(req)
+// Create provides a mock function with given fields: ctx, req
+func (_m *WebsiteRepo) Create(ctx context.Context, req *request.WebsiteCreate) (*biz.Website, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -138,19 +240,19 @@ func (_m *WebsiteRepo) Create(req *request.WebsiteCreate) (*biz.Website, error)
 
 	var r0 *biz.Website
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*request.WebsiteCreate) (*biz.Website, error)); ok {
-		return rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, *request.WebsiteCreate) (*biz.Website, error)); ok {
+		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(*request.WebsiteCreate) *biz.Website); ok {
-		r0 = rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, *request.WebsiteCreate) *biz.Website); ok {
+		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*biz.Website)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*request.WebsiteCreate) error); ok {
-		r1 = rf(req)
+	if rf, ok := ret.Get(1).(func(context.Context, *request.WebsiteCreate) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -164,14 +266,15 @@ type WebsiteRepo_Create_Call struct {
 }
 
 // Create is a helper method to define mock.On call
+//   - ctx context.Context
 //   - req *request.WebsiteCreate
-func (_e *WebsiteRepo_Expecter) Create(req interface{}) *WebsiteRepo_Create_Call {
-	return &WebsiteRepo_Create_Call{Call: _e.mock.On("Create", req)}
+func (_e *WebsiteRepo_Expecter) Create(ctx interface{}, req interface{}) *WebsiteRepo_Create_Call {
+	return &WebsiteRepo_Create_Call{Call: _e.mock.On("Create", ctx, req)}
 }
 
-func (_c *WebsiteRepo_Create_Call) Run(run func(req *request.WebsiteCreate)) *WebsiteRepo_Create_Call {
+func (_c *WebsiteRepo_Create_Call) Run(run func(ctx context.Context, req *request.WebsiteCreate)) *WebsiteRepo_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*request.WebsiteCreate))
+		run(args[0].(context.Context), args[1].(*request.WebsiteCreate))
 	})
 	return _c
 }
@@ -181,7 +284,7 @@ func (_c *WebsiteRepo_Create_Call) Return(_a0 *biz.Website, _a1 error) *WebsiteR
 	return _c
 }
 
-func (_c *WebsiteRepo_Create_Call) RunAndReturn(run func(*request.WebsiteCreate) (*biz.Website, error)) *WebsiteRepo_Create_Call {
+func (_c *WebsiteRepo_Create_Call) RunAndReturn(run func(context.Context, *request.WebsiteCreate) (*biz.Website, error)) *WebsiteRepo_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -232,6 +335,66 @@ func (_c *WebsiteRepo_Delete_Call) RunAndReturn(run func(*request.WebsiteDelete)
 	return _c
 }
 
+// DiffRevisions provides a mock function with given fields: id, from, to
+func (_m *WebsiteRepo) DiffRevisions(id uint, from uint, to uint) ([]*biz.WebsiteRevisionDiff, error) {
+	ret := _m.Called(id, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiffRevisions")
+	}
+
+	var r0 []*biz.WebsiteRevisionDiff
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, uint, uint) ([]*biz.WebsiteRevisionDiff, error)); ok {
+		return rf(id, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint, uint) []*biz.WebsiteRevisionDiff); ok {
+		r0 = rf(id, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.WebsiteRevisionDiff)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint, uint) error); ok {
+		r1 = rf(id, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebsiteRepo_DiffRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiffRevisions'
+type WebsiteRepo_DiffRevisions_Call struct {
+	*mock.Call
+}
+
+// DiffRevisions is a helper method to define mock.On call
+//   - id uint
+//   - from uint
+//   - to uint
+func (_e *WebsiteRepo_Expecter) DiffRevisions(id interface{}, from interface{}, to interface{}) *WebsiteRepo_DiffRevisions_Call {
+	return &WebsiteRepo_DiffRevisions_Call{Call: _e.mock.On("DiffRevisions", id, from, to)}
+}
+
+func (_c *WebsiteRepo_DiffRevisions_Call) Run(run func(id uint, from uint, to uint)) *WebsiteRepo_DiffRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *WebsiteRepo_DiffRevisions_Call) Return(_a0 []*biz.WebsiteRevisionDiff, _a1 error) *WebsiteRepo_DiffRevisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebsiteRepo_DiffRevisions_Call) RunAndReturn(run func(uint, uint, uint) ([]*biz.WebsiteRevisionDiff, error)) *WebsiteRepo_DiffRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Get provides a mock function with given fields: id
 func (_m *WebsiteRepo) Get(id uint) (*types.WebsiteSetting, error) {
 	ret := _m.Called(id)
@@ -405,9 +568,9 @@ func (_c *WebsiteRepo_GetRewrites_Call) RunAndReturn(run func() (map[string]stri
 	return _c
 }
 
-// List provides a mock function with given fields: typ, page, limit
-func (_m *WebsiteRepo) List(typ string, page uint, limit uint) ([]*biz.Website, int64, error) {
-	ret := _m.Called(typ, page, limit)
+// List provides a mock function with given fields: owner, typ, page, limit
+func (_m *WebsiteRepo) List(owner uint, typ string, page uint, limit uint) ([]*biz.Website, int64, error) {
+	ret := _m.Called(owner, typ, page, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
@@ -416,25 +579,25 @@ func (_m *WebsiteRepo) List(typ string, page uint, limit uint) ([]*biz.Website,
 	var r0 []*biz.Website
 	var r1 int64
 	var r2 error
-	if rf, ok := ret.Get(0).(func(string, uint, uint) ([]*biz.Website, int64, error)); ok {
-		return rf(typ, page, limit)
+	if rf, ok := ret.Get(0).(func(uint, string, uint, uint) ([]*biz.Website, int64, error)); ok {
+		return rf(owner, typ, page, limit)
 	}
-	if rf, ok := ret.Get(0).(func(string, uint, uint) []*biz.Website); ok {
-		r0 = rf(typ, page, limit)
+	if rf, ok := ret.Get(0).(func(uint, string, uint, uint) []*biz.Website); ok {
+		r0 = rf(owner, typ, page, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*biz.Website)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, uint, uint) int64); ok {
-		r1 = rf(typ, page, limit)
+	if rf, ok := ret.Get(1).(func(uint, string, uint, uint) int64); ok {
+		r1 = rf(owner, typ, page, limit)
 	} else {
 		r1 = ret.Get(1).(int64)
 	}
 
-	if rf, ok := ret.Get(2).(func(string, uint, uint) error); ok {
-		r2 = rf(typ, page, limit)
+	if rf, ok := ret.Get(2).(func(uint, string, uint, uint) error); ok {
+		r2 = rf(owner, typ, page, limit)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -448,16 +611,17 @@ type WebsiteRepo_List_Call struct {
 }
 
 // List is a helper method to define mock.On call
+//   - owner uint
 //   - typ string
 //   - page uint
 //   - limit uint
-func (_e *WebsiteRepo_Expecter) List(typ interface{}, page interface{}, limit interface{}) *WebsiteRepo_List_Call {
-	return &WebsiteRepo_List_Call{Call: _e.mock.On("List", typ, page, limit)}
+func (_e *WebsiteRepo_Expecter) List(owner interface{}, typ interface{}, page interface{}, limit interface{}) *WebsiteRepo_List_Call {
+	return &WebsiteRepo_List_Call{Call: _e.mock.On("List", owner, typ, page, limit)}
 }
 
-func (_c *WebsiteRepo_List_Call) Run(run func(typ string, page uint, limit uint)) *WebsiteRepo_List_Call {
+func (_c *WebsiteRepo_List_Call) Run(run func(owner uint, typ string, page uint, limit uint)) *WebsiteRepo_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(uint), args[2].(uint))
+		run(args[0].(uint), args[1].(string), args[2].(uint), args[3].(uint))
 	})
 	return _c
 }
@@ -467,7 +631,7 @@ func (_c *WebsiteRepo_List_Call) Return(_a0 []*biz.Website, _a1 int64, _a2 error
 	return _c
 }
 
-func (_c *WebsiteRepo_List_Call) RunAndReturn(run func(string, uint, uint) ([]*biz.Website, int64, error)) *WebsiteRepo_List_Call {
+func (_c *WebsiteRepo_List_Call) RunAndReturn(run func(uint, string, uint, uint) ([]*biz.Website, int64, error)) *WebsiteRepo_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -519,17 +683,75 @@ func (_c *WebsiteRepo_ObtainCert_Call) RunAndReturn(run func(context.Context, ui
 	return _c
 }
 
-// ResetConfig provides a mock function with given fields: id
-func (_m *WebsiteRepo) ResetConfig(id uint) error {
-	ret := _m.Called(id)
+// Paths provides a mock function with given fields: owner
+func (_m *WebsiteRepo) Paths(owner uint) ([]string, error) {
+	ret := _m.Called(owner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Paths")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]string, error)); ok {
+		return rf(owner)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []string); ok {
+		r0 = rf(owner)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(owner)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebsiteRepo_Paths_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Paths'
+type WebsiteRepo_Paths_Call struct {
+	*mock.Call
+}
+
+// Paths is a helper method to define mock.On call
+//   - owner uint
+func (_e *WebsiteRepo_Expecter) Paths(owner interface{}) *WebsiteRepo_Paths_Call {
+	return &WebsiteRepo_Paths_Call{Call: _e.mock.On("Paths", owner)}
+}
+
+func (_c *WebsiteRepo_Paths_Call) Run(run func(owner uint)) *WebsiteRepo_Paths_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *WebsiteRepo_Paths_Call) Return(_a0 []string, _a1 error) *WebsiteRepo_Paths_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebsiteRepo_Paths_Call) RunAndReturn(run func(uint) ([]string, error)) *WebsiteRepo_Paths_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetConfig provides a mock function with given fields: ctx, id
+func (_m *WebsiteRepo) ResetConfig(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ResetConfig")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -543,14 +765,15 @@ type WebsiteRepo_ResetConfig_Call struct {
 }
 
 // ResetConfig is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id uint
-func (_e *WebsiteRepo_Expecter) ResetConfig(id interface{}) *WebsiteRepo_ResetConfig_Call {
-	return &WebsiteRepo_ResetConfig_Call{Call: _e.mock.On("ResetConfig", id)}
+func (_e *WebsiteRepo_Expecter) ResetConfig(ctx interface{}, id interface{}) *WebsiteRepo_ResetConfig_Call {
+	return &WebsiteRepo_ResetConfig_Call{Call: _e.mock.On("ResetConfig", ctx, id)}
 }
 
-func (_c *WebsiteRepo_ResetConfig_Call) Run(run func(id uint)) *WebsiteRepo_ResetConfig_Call {
+func (_c *WebsiteRepo_ResetConfig_Call) Run(run func(ctx context.Context, id uint)) *WebsiteRepo_ResetConfig_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint))
+		run(args[0].(context.Context), args[1].(uint))
 	})
 	return _c
 }
@@ -560,22 +783,137 @@ func (_c *WebsiteRepo_ResetConfig_Call) Return(_a0 error) *WebsiteRepo_ResetConf
 	return _c
 }
 
-func (_c *WebsiteRepo_ResetConfig_Call) RunAndReturn(run func(uint) error) *WebsiteRepo_ResetConfig_Call {
+func (_c *WebsiteRepo_ResetConfig_Call) RunAndReturn(run func(context.Context, uint) error) *WebsiteRepo_ResetConfig_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Update provides a mock function with given fields: req
-func (_m *WebsiteRepo) Update(req *request.WebsiteUpdate) error {
-	ret := _m.Called(req)
+// RestoreRevision provides a mock function with given fields: ctx, id, revision
+func (_m *WebsiteRepo) RestoreRevision(ctx context.Context, id uint, revision uint) error {
+	ret := _m.Called(ctx, id, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreRevision")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, id, revision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebsiteRepo_RestoreRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreRevision'
+type WebsiteRepo_RestoreRevision_Call struct {
+	*mock.Call
+}
+
+// RestoreRevision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint
+//   - revision uint
+func (_e *WebsiteRepo_Expecter) RestoreRevision(ctx interface{}, id interface{}, revision interface{}) *WebsiteRepo_RestoreRevision_Call {
+	return &WebsiteRepo_RestoreRevision_Call{Call: _e.mock.On("RestoreRevision", ctx, id, revision)}
+}
+
+func (_c *WebsiteRepo_RestoreRevision_Call) Run(run func(ctx context.Context, id uint, revision uint)) *WebsiteRepo_RestoreRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *WebsiteRepo_RestoreRevision_Call) Return(_a0 error) *WebsiteRepo_RestoreRevision_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebsiteRepo_RestoreRevision_Call) RunAndReturn(run func(context.Context, uint, uint) error) *WebsiteRepo_RestoreRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revisions provides a mock function with given fields: id, page, limit
+func (_m *WebsiteRepo) Revisions(id uint, page uint, limit uint) ([]*biz.WebsiteRevision, int64, error) {
+	ret := _m.Called(id, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revisions")
+	}
+
+	var r0 []*biz.WebsiteRevision
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uint, uint, uint) ([]*biz.WebsiteRevision, int64, error)); ok {
+		return rf(id, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint, uint) []*biz.WebsiteRevision); ok {
+		r0 = rf(id, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.WebsiteRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint, uint) int64); ok {
+		r1 = rf(id, page, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uint, uint, uint) error); ok {
+		r2 = rf(id, page, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// WebsiteRepo_Revisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revisions'
+type WebsiteRepo_Revisions_Call struct {
+	*mock.Call
+}
+
+// Revisions is a helper method to define mock.On call
+//   - id uint
+//   - page uint
+//   - limit uint
+func (_e *WebsiteRepo_Expecter) Revisions(id interface{}, page interface{}, limit interface{}) *WebsiteRepo_Revisions_Call {
+	return &WebsiteRepo_Revisions_Call{Call: _e.mock.On("Revisions", id, page, limit)}
+}
+
+func (_c *WebsiteRepo_Revisions_Call) Run(run func(id uint, page uint, limit uint)) *WebsiteRepo_Revisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint), args[1].(uint), args[2].(uint))
+	})
+	return _c
+}
+
+func (_c *WebsiteRepo_Revisions_Call) Return(_a0 []*biz.WebsiteRevision, _a1 int64, _a2 error) *WebsiteRepo_Revisions_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *WebsiteRepo_Revisions_Call) RunAndReturn(run func(uint, uint, uint) ([]*biz.WebsiteRevision, int64, error)) *WebsiteRepo_Revisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, req
+func (_m *WebsiteRepo) Update(ctx context.Context, req *request.WebsiteUpdate) error {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*request.WebsiteUpdate) error); ok {
-		r0 = rf(req)
+	if rf, ok := ret.Get(0).(func(context.Context, *request.WebsiteUpdate) error); ok {
+		r0 = rf(ctx, req)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -589,14 +927,15 @@ type WebsiteRepo_Update_Call struct {
 }
 
 // Update is a helper method to define mock.On call
+//   - ctx context.Context
 //   - req *request.WebsiteUpdate
-func (_e *WebsiteRepo_Expecter) Update(req interface{}) *WebsiteRepo_Update_Call {
-	return &WebsiteRepo_Update_Call{Call: _e.mock.On("Update", req)}
+func (_e *WebsiteRepo_Expecter) Update(ctx interface{}, req interface{}) *WebsiteRepo_Update_Call {
+	return &WebsiteRepo_Update_Call{Call: _e.mock.On("Update", ctx, req)}
 }
 
-func (_c *WebsiteRepo_Update_Call) Run(run func(req *request.WebsiteUpdate)) *WebsiteRepo_Update_Call {
+func (_c *WebsiteRepo_Update_Call) Run(run func(ctx context.Context, req *request.WebsiteUpdate)) *WebsiteRepo_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*request.WebsiteUpdate))
+		run(args[0].(context.Context), args[1].(*request.WebsiteUpdate))
 	})
 	return _c
 }
@@ -606,7 +945,7 @@ func (_c *WebsiteRepo_Update_Call) Return(_a0 error) *WebsiteRepo_Update_Call {
 	return _c
 }
 
-func (_c *WebsiteRepo_Update_Call) RunAndReturn(run func(*request.WebsiteUpdate) error) *WebsiteRepo_Update_Call {
+func (_c *WebsiteRepo_Update_Call) RunAndReturn(run func(context.Context, *request.WebsiteUpdate) error) *WebsiteRepo_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -750,17 +1089,17 @@ func (_c *WebsiteRepo_UpdateRemark_Call) RunAndReturn(run func(uint, string) err
 	return _c
 }
 
-// UpdateStatus provides a mock function with given fields: id, status
-func (_m *WebsiteRepo) UpdateStatus(id uint, status bool) error {
-	ret := _m.Called(id, status)
+// UpdateStatus provides a mock function with given fields: ctx, id, status
+func (_m *WebsiteRepo) UpdateStatus(ctx context.Context, id uint, status bool) error {
+	ret := _m.Called(ctx, id, status)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateStatus")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, bool) error); ok {
-		r0 = rf(id, status)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, bool) error); ok {
+		r0 = rf(ctx, id, status)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -774,15 +1113,16 @@ type WebsiteRepo_UpdateStatus_Call struct {
 }
 
 // UpdateStatus is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id uint
 //   - status bool
-func (_e *WebsiteRepo_Expecter) UpdateStatus(id interface{}, status interface{}) *WebsiteRepo_UpdateStatus_Call {
-	return &WebsiteRepo_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", id, status)}
+func (_e *WebsiteRepo_Expecter) UpdateStatus(ctx interface{}, id interface{}, status interface{}) *WebsiteRepo_UpdateStatus_Call {
+	return &WebsiteRepo_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", ctx, id, status)}
 }
 
-func (_c *WebsiteRepo_UpdateStatus_Call) Run(run func(id uint, status bool)) *WebsiteRepo_UpdateStatus_Call {
+func (_c *WebsiteRepo_UpdateStatus_Call) Run(run func(ctx context.Context, id uint, status bool)) *WebsiteRepo_UpdateStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint), args[1].(bool))
+		run(args[0].(context.Context), args[1].(uint), args[2].(bool))
 	})
 	return _c
 }
@@ -792,7 +1132,65 @@ func (_c *WebsiteRepo_UpdateStatus_Call) Return(_a0 error) *WebsiteRepo_UpdateSt
 	return _c
 }
 
-func (_c *WebsiteRepo_UpdateStatus_Call) RunAndReturn(run func(uint, bool) error) *WebsiteRepo_UpdateStatus_Call {
+func (_c *WebsiteRepo_UpdateStatus_Call) RunAndReturn(run func(context.Context, uint, bool) error) *WebsiteRepo_UpdateStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpstreamStatuses provides a mock function with given fields: id
+func (_m *WebsiteRepo) UpstreamStatuses(id uint) ([]*biz.WebsiteUpstreamStatus, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpstreamStatuses")
+	}
+
+	var r0 []*biz.WebsiteUpstreamStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]*biz.WebsiteUpstreamStatus, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []*biz.WebsiteUpstreamStatus); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*biz.WebsiteUpstreamStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebsiteRepo_UpstreamStatuses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpstreamStatuses'
+type WebsiteRepo_UpstreamStatuses_Call struct {
+	*mock.Call
+}
+
+// UpstreamStatuses is a helper method to define mock.On call
+//   - id uint
+func (_e *WebsiteRepo_Expecter) UpstreamStatuses(id interface{}) *WebsiteRepo_UpstreamStatuses_Call {
+	return &WebsiteRepo_UpstreamStatuses_Call{Call: _e.mock.On("UpstreamStatuses", id)}
+}
+
+func (_c *WebsiteRepo_UpstreamStatuses_Call) Run(run func(id uint)) *WebsiteRepo_UpstreamStatuses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *WebsiteRepo_UpstreamStatuses_Call) Return(_a0 []*biz.WebsiteUpstreamStatus, _a1 error) *WebsiteRepo_UpstreamStatuses_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebsiteRepo_UpstreamStatuses_Call) RunAndReturn(run func(uint) ([]*biz.WebsiteUpstreamStatus, error)) *WebsiteRepo_UpstreamStatuses_Call {
 	_c.Call.Return(run)
 	return _c
 }
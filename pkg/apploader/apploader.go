@@ -1,6 +1,7 @@
 package apploader
 
 import (
+	"net/http"
 	"reflect"
 	"slices"
 	"strings"
@@ -22,10 +23,16 @@ func (r *Loader) Add(app ...types.App) {
 	}
 }
 
-func (r *Loader) Register(mux chi.Router) {
+// Register 注册应用路由，middlewares 按应用 slug 生成中间件
+func (r *Loader) Register(mux chi.Router, middlewares ...func(slug string) func(http.Handler) http.Handler) {
 	apps.Range(func(key, value any) bool {
-		app := value.(types.App)
-		mux.Route("/"+key.(string), app.Route)
+		app, slug := value.(types.App), key.(string)
+		mux.Route("/"+slug, func(r chi.Router) {
+			for _, middleware := range middlewares {
+				r.Use(middleware(slug))
+			}
+			app.Route(r)
+		})
 		return true
 	})
 }
@@ -0,0 +1,179 @@
+// Package backupcrypto 备份文件加密，基于 age 格式，支持口令和 X25519 公钥两种方式
+package backupcrypto
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+)
+
+// Ext 加密备份文件扩展名
+const Ext = ".age"
+
+type Mode string
+
+const (
+	ModeNone       Mode = ""
+	ModePassphrase Mode = "passphrase"
+	ModeRecipient  Mode = "age"
+)
+
+// Config 加密配置
+type Config struct {
+	Mode       Mode   `json:"mode"`
+	Passphrase string `json:"passphrase"` // 口令模式的口令
+	Recipient  string `json:"recipient"`  // age 公钥，age1...
+	Identity   string `json:"identity"`   // age 私钥，AGE-SECRET-KEY-1...，仅用于解密
+}
+
+// Enabled 是否启用加密
+func (c Config) Enabled() bool {
+	return c.Mode == ModePassphrase || c.Mode == ModeRecipient
+}
+
+// Check 检查配置是否有效
+func (c Config) Check() error {
+	switch c.Mode {
+	case ModeNone:
+		return nil
+	case ModePassphrase:
+		if c.Passphrase == "" {
+			return errors.New("passphrase is required")
+		}
+	case ModeRecipient:
+		if _, err := age.ParseX25519Recipient(c.Recipient); err != nil {
+			return err
+		}
+	default:
+		return errors.New("unknown encryption mode")
+	}
+	if c.Identity != "" {
+		if _, err := age.ParseX25519Identity(c.Identity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsEncrypted 根据文件名判断是否为加密备份
+func IsEncrypted(name string) bool {
+	return strings.HasSuffix(name, Ext)
+}
+
+// EncryptFile 加密文件，写入 src + Ext 并返回其路径，成功后删除原文件
+func EncryptFile(src string, c Config) (string, error) {
+	var recipient age.Recipient
+	var err error
+	switch c.Mode {
+	case ModePassphrase:
+		recipient, err = age.NewScryptRecipient(c.Passphrase)
+	case ModeRecipient:
+		recipient, err = age.ParseX25519Recipient(c.Recipient)
+	default:
+		return "", errors.New("encryption is not enabled")
+	}
+	if err != nil {
+		return "", err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer func(in *os.File) { _ = in.Close() }(in)
+
+	dst := src + Ext
+	if err = write(dst, func(out io.Writer) error {
+		w, err := age.Encrypt(out, recipient)
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(w, in); err != nil {
+			return err
+		}
+		return w.Close()
+	}); err != nil {
+		return "", err
+	}
+
+	_ = in.Close()
+	return dst, os.Remove(src)
+}
+
+// DecryptFile 解密文件到 dir 目录，返回解密后的文件路径
+// 口令和私钥均会尝试，以便切换加密方式后仍能恢复旧备份
+func DecryptFile(src, dir string, c Config) (string, error) {
+	var identities []age.Identity
+	if c.Passphrase != "" {
+		identity, err := age.NewScryptIdentity(c.Passphrase)
+		if err != nil {
+			return "", err
+		}
+		identities = append(identities, identity)
+	}
+	if c.Identity != "" {
+		identity, err := age.ParseX25519Identity(c.Identity)
+		if err != nil {
+			return "", err
+		}
+		identities = append(identities, identity)
+	}
+	if len(identities) == 0 {
+		return "", errors.New("no passphrase or identity configured for decryption")
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer func(in *os.File) { _ = in.Close() }(in)
+
+	r, err := age.Decrypt(in, identities...)
+	if err != nil {
+		return "", err
+	}
+
+	dst := filepath.Join(dir, strings.TrimSuffix(filepath.Base(src), Ext))
+	if err = write(dst, func(out io.Writer) error {
+		_, err := io.Copy(out, r)
+		return err
+	}); err != nil {
+		return "", err
+	}
+
+	return dst, nil
+}
+
+// GenerateIdentity 生成 X25519 密钥对
+func GenerateIdentity() (identity, recipient string, err error) {
+	key, err := age.GenerateX25519Identity()
+	if err != nil {
+		return "", "", err
+	}
+
+	return key.String(), key.Recipient().String(), nil
+}
+
+// write 写入文件，失败时删除不完整的文件
+func write(dst string, fn func(out io.Writer) error) error {
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if err = fn(out); err != nil {
+		_ = out.Close()
+		_ = os.Remove(dst)
+		return err
+	}
+	if err = out.Close(); err != nil {
+		_ = os.Remove(dst)
+		return err
+	}
+
+	return nil
+}
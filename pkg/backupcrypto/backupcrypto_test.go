@@ -0,0 +1,74 @@
+package backupcrypto
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BackupCryptoTestSuite struct {
+	suite.Suite
+}
+
+func TestBackupCryptoTestSuite(t *testing.T) {
+	suite.Run(t, &BackupCryptoTestSuite{})
+}
+
+func (s *BackupCryptoTestSuite) TestPassphrase() {
+	src := s.file("db_20260101000000.sql.zip", "AcePanel")
+
+	encrypted, err := EncryptFile(src, Config{Mode: ModePassphrase, Passphrase: "secret"})
+	s.NoError(err)
+	s.Equal(src+Ext, encrypted)
+	s.True(IsEncrypted(encrypted))
+	s.NoFileExists(src)
+
+	_, err = DecryptFile(encrypted, s.T().TempDir(), Config{Passphrase: "wrong"})
+	s.Error(err)
+
+	decrypted, err := DecryptFile(encrypted, s.T().TempDir(), Config{Passphrase: "secret"})
+	s.NoError(err)
+	s.Equal("db_20260101000000.sql.zip", filepath.Base(decrypted))
+	s.content(decrypted, "AcePanel")
+}
+
+func (s *BackupCryptoTestSuite) TestRecipient() {
+	identity, recipient, err := GenerateIdentity()
+	s.NoError(err)
+	conf := Config{Mode: ModeRecipient, Recipient: recipient}
+	s.NoError(conf.Check())
+
+	encrypted, err := EncryptFile(s.file("site.zip", "AcePanel"), conf)
+	s.NoError(err)
+
+	// 仅有公钥无法解密
+	_, err = DecryptFile(encrypted, s.T().TempDir(), conf)
+	s.Error(err)
+
+	conf.Identity = identity
+	decrypted, err := DecryptFile(encrypted, s.T().TempDir(), conf)
+	s.NoError(err)
+	s.content(decrypted, "AcePanel")
+}
+
+func (s *BackupCryptoTestSuite) TestCheck() {
+	s.NoError(Config{}.Check())
+	s.Error(Config{Mode: ModePassphrase}.Check())
+	s.Error(Config{Mode: ModeRecipient, Recipient: "age1invalid"}.Check())
+	s.Error(Config{Mode: "aes"}.Check())
+	s.False(Config{}.Enabled())
+}
+
+func (s *BackupCryptoTestSuite) file(name, content string) string {
+	path := filepath.Join(s.T().TempDir(), name)
+	s.Require().NoError(os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func (s *BackupCryptoTestSuite) content(path, expected string) {
+	actual, err := os.ReadFile(path)
+	s.NoError(err)
+	s.Equal(expected, string(actual))
+}
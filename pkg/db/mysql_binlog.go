@@ -0,0 +1,65 @@
+package db
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+var (
+	mysqlDumpDatabaseRegex = regexp.MustCompile(`^-- Host: .*\s+Database: (\S+)`)
+	mysqlDumpPositionRegex = regexp.MustCompile(`(?:MASTER|SOURCE)_LOG_FILE='([^']+)',\s*(?:MASTER|SOURCE)_LOG_POS=(\d+)`)
+	mysqlGTIDRegex         = regexp.MustCompile(`^([0-9a-fA-F-]{36}):(\d+)$`)
+	mysqlGTIDSetRegex      = regexp.MustCompile(`^[0-9a-fA-F-]{36}(:\d+(-\d+)?)+(,[0-9a-fA-F-]{36}(:\d+(-\d+)?)+)*$`)
+	mariadbGTIDRegex       = regexp.MustCompile(`^\d+-\d+-\d+$`)
+)
+
+// MySQLDumpInfo mysqldump 导出文件头部记录的信息
+type MySQLDumpInfo struct {
+	Database string // 导出的数据库
+	LogFile  string // 导出时的 binlog 文件，未使用 --source-data 导出时为空
+	LogPos   int64  // 导出时的 binlog 位置
+}
+
+// ParseMySQLDump 解析 mysqldump 导出文件头部，数据部分开始后停止读取
+func ParseMySQLDump(r io.Reader) (*MySQLDumpInfo, error) {
+	info := new(MySQLDumpInfo)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if matches := mysqlDumpDatabaseRegex.FindStringSubmatch(line); len(matches) > 1 {
+			info.Database = matches[1]
+		}
+		if matches := mysqlDumpPositionRegex.FindStringSubmatch(line); len(matches) > 2 {
+			info.LogFile = matches[1]
+			info.LogPos = cast.ToInt64(matches[2])
+		}
+		if strings.HasPrefix(line, "DROP TABLE") || strings.HasPrefix(line, "CREATE TABLE") || strings.HasPrefix(line, "INSERT INTO") {
+			break
+		}
+	}
+
+	return info, scanner.Err()
+}
+
+// MySQLBinlogStopGTID 将要恢复到的 GTID 转换为 mysqlbinlog 参数
+// MySQL 格式 uuid:N 转换为 --include-gtids=uuid:1-N，也可直接传入 GTID 集合
+// MariaDB 格式 domain-server-seq 转换为 --stop-position
+func MySQLBinlogStopGTID(gtid string) (string, bool) {
+	gtid = strings.TrimSpace(gtid)
+	if matches := mysqlGTIDRegex.FindStringSubmatch(gtid); len(matches) > 2 {
+		return "--include-gtids=" + matches[1] + ":1-" + matches[2], true
+	}
+	if mariadbGTIDRegex.MatchString(gtid) {
+		return "--stop-position=" + gtid, true
+	}
+	if mysqlGTIDSetRegex.MatchString(gtid) {
+		return "--include-gtids=" + gtid, true
+	}
+
+	return "", false
+}
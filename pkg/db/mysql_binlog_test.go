@@ -0,0 +1,69 @@
+package db
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MySQLBinlogTestSuite struct {
+	suite.Suite
+}
+
+func TestMySQLBinlogTestSuite(t *testing.T) {
+	suite.Run(t, &MySQLBinlogTestSuite{})
+}
+
+func (s *MySQLBinlogTestSuite) TestParseMySQLDump() {
+	dump := `-- MySQL dump 10.13  Distrib 8.4.3, for Linux (x86_64)
+--
+-- Host: localhost    Database: shop
+-- ------------------------------------------------------
+-- Server version	8.4.3
+
+--
+-- Position to start replication or point-in-time recovery from
+--
+
+-- CHANGE REPLICATION SOURCE TO SOURCE_LOG_FILE='binlog.000012', SOURCE_LOG_POS=1573;
+
+DROP TABLE IF EXISTS ` + "`orders`" + `;
+-- CHANGE MASTER TO MASTER_LOG_FILE='fake.000001', MASTER_LOG_POS=1;
+`
+	info, err := ParseMySQLDump(strings.NewReader(dump))
+	s.NoError(err)
+	s.Equal("shop", info.Database)
+	s.Equal("binlog.000012", info.LogFile)
+	s.Equal(int64(1573), info.LogPos)
+
+	mariadb := `-- Host: localhost    Database: blog
+-- CHANGE MASTER TO MASTER_LOG_FILE='mysql-bin.000003', MASTER_LOG_POS=328;
+`
+	info, err = ParseMySQLDump(strings.NewReader(mariadb))
+	s.NoError(err)
+	s.Equal("blog", info.Database)
+	s.Equal("mysql-bin.000003", info.LogFile)
+	s.Equal(int64(328), info.LogPos)
+
+	info, err = ParseMySQLDump(strings.NewReader("-- Host: localhost    Database: blog\nCREATE TABLE `a` (id int);\n"))
+	s.NoError(err)
+	s.Empty(info.LogFile)
+}
+
+func (s *MySQLBinlogTestSuite) TestMySQLBinlogStopGTID() {
+	arg, ok := MySQLBinlogStopGTID("3E11FA47-71CA-11E1-9E33-C80AA9429562:23")
+	s.True(ok)
+	s.Equal("--include-gtids=3E11FA47-71CA-11E1-9E33-C80AA9429562:1-23", arg)
+
+	arg, ok = MySQLBinlogStopGTID("3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5:7-9")
+	s.True(ok)
+	s.Equal("--include-gtids=3E11FA47-71CA-11E1-9E33-C80AA9429562:1-5:7-9", arg)
+
+	arg, ok = MySQLBinlogStopGTID("0-1-100")
+	s.True(ok)
+	s.Equal("--stop-position=0-1-100", arg)
+
+	_, ok = MySQLBinlogStopGTID("'; rm -rf /")
+	s.False(ok)
+}
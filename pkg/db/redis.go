@@ -3,6 +3,7 @@ package db
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gomodule/redigo/redis"
@@ -174,3 +175,102 @@ func (r *Redis) Clear() error {
 	_, err := r.conn.Do("FLUSHDB")
 	return err
 }
+
+// Config 获取配置项
+func (r *Redis) Config(key string) (string, error) {
+	values, err := redis.Strings(r.conn.Do("CONFIG", "GET", key))
+	if err != nil {
+		return "", err
+	}
+	if len(values) < 2 {
+		return "", fmt.Errorf("config %s not found", key)
+	}
+
+	return values[1], nil
+}
+
+// BGSave 触发后台 RDB 快照并等待完成
+// 通过 LASTSAVE 的变化判断本次快照已完成，避免把之前的快照当作本次的结果
+func (r *Redis) BGSave(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	// 已有快照在进行时先等待其完成，否则 BGSAVE 会报错
+	if err := r.waitBGSave(deadline); err != nil {
+		return err
+	}
+	last, err := r.lastSave()
+	if err != nil {
+		return err
+	}
+	// LASTSAVE 精度为秒，上次快照在当前这一秒完成时等到下一秒，否则无法区分
+	if now := time.Now(); now.Unix() <= last {
+		time.Sleep(time.Unix(last+1, 0).Sub(now))
+	}
+	if _, err = r.conn.Do("BGSAVE"); err != nil {
+		return fmt.Errorf("failed to BGSAVE: %v", err)
+	}
+
+	for {
+		current, err := r.lastSave()
+		if err != nil {
+			return err
+		}
+		if current != last {
+			return nil
+		}
+		persistence, err := r.persistence()
+		if err != nil {
+			return err
+		}
+		if persistence["rdb_bgsave_in_progress"] != "1" && persistence["rdb_last_bgsave_status"] != "ok" {
+			return fmt.Errorf("background save failed: %s", persistence["rdb_last_bgsave_status"])
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout waiting for background save")
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+// lastSave 获取最近一次成功保存快照的时间戳
+func (r *Redis) lastSave() (int64, error) {
+	last, err := redis.Int64(r.conn.Do("LASTSAVE"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get LASTSAVE: %v", err)
+	}
+
+	return last, nil
+}
+
+// waitBGSave 等待后台快照结束
+func (r *Redis) waitBGSave(deadline time.Time) error {
+	for {
+		persistence, err := r.persistence()
+		if err != nil {
+			return err
+		}
+		if persistence["rdb_bgsave_in_progress"] != "1" {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout waiting for background save")
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+// persistence 获取持久化状态
+func (r *Redis) persistence() (map[string]string, error) {
+	info, err := redis.String(r.conn.Do("INFO", "persistence"))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		if key, value, ok := strings.Cut(strings.TrimSpace(line), ":"); ok {
+			result[key] = value
+		}
+	}
+
+	return result, nil
+}
@@ -1,6 +1,9 @@
 package io
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -110,3 +113,19 @@ func GetSymlink(path string) string {
 	}
 	return linkPath
 }
+
+// Sha256 计算文件的 sha256
+func Sha256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func(file *os.File) { _ = file.Close() }(file)
+
+	hash := sha256.New()
+	if _, err = io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
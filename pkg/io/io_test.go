@@ -52,6 +52,18 @@ func (s *IOTestSuite) TestWriteAppendAppendsToFile() {
 	s.Equal("Hello, World!", content)
 }
 
+func (s *IOTestSuite) TestSha256() {
+	path := "testdata/sha256_test.txt"
+	s.NoError(Write(path, "Hello, World!", 0644))
+
+	sum, err := Sha256(path)
+	s.NoError(err)
+	s.Equal("dffd6021bb2bd5b0af676290809ec3a53191dd81c7f70a4b28688a362182986f", sum)
+
+	_, err = Sha256("testdata/not_exists.txt")
+	s.Error(err)
+}
+
 func (s *IOTestSuite) TestCompress() {
 	abs, err := filepath.Abs("testdata")
 	s.NoError(err)
@@ -309,3 +321,19 @@ func (s *IOTestSuite) TestIsDirReturnsFalseForFile() {
 	s.NoError(Write(path, "test", 0644))
 	s.False(IsDir(path))
 }
+
+func (s *IOTestSuite) TestWithin() {
+	root, err := filepath.Abs("testdata/site")
+	s.NoError(err)
+	s.NoError(os.MkdirAll(filepath.Join(root, "public"), 0755))
+	s.NoError(os.Symlink("/etc", filepath.Join(root, "public", "etc")))
+
+	s.True(Within(root, root))
+	s.True(Within(filepath.Join(root, "public", "index.html"), root))
+	s.True(Within(filepath.Join(root, "public", "new", "dir"), root))
+	s.False(Within(filepath.Join(root, "..", "other"), root))
+	s.False(Within(root+"-other", root))
+	s.False(Within(filepath.Join(root, "public", "etc", "passwd"), root))
+	s.True(Within("/etc/passwd", "/"))
+	s.False(Within("/etc/passwd"))
+}
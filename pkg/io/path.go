@@ -104,3 +104,33 @@ func CountX(path string) (int64, error) {
 	count := len(out)
 	return int64(count), nil
 }
+
+// Within 判断路径是否位于任一根目录内，会解析符号链接防止越界
+func Within(path string, roots ...string) bool {
+	path = realPath(path)
+	for _, root := range roots {
+		rel, err := filepath.Rel(realPath(root), path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, "../") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// realPath 解析路径中已存在部分的符号链接
+func realPath(path string) string {
+	path, _ = filepath.Abs(path)
+	rest := ""
+	for {
+		if real, err := filepath.EvalSymlinks(path); err == nil {
+			return filepath.Join(real, rest)
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return filepath.Join(path, rest)
+		}
+		rest = filepath.Join(filepath.Base(path), rest)
+		path = parent
+	}
+}
@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 匹配 combined 日志格式中请求行之后的状态码，如 "GET / HTTP/1.1" 200
+var accessLogStatus = regexp.MustCompile(`" ([1-5])\d{2} `)
+
+var websiteRequestsDesc = prometheus.NewDesc("ace_website_requests_total", "Website requests counted from access logs since panel start.", []string{"website", "code"}, nil)
+
+type accessLogFile struct {
+	offset int64
+	counts map[string]float64 // 状态码类别 -> 请求数
+}
+
+// AccessLog 通过增量读取访问日志统计各网站的请求数
+type AccessLog struct {
+	sources func() (map[string]string, error) // 网站名 -> 访问日志路径
+
+	mu    sync.Mutex
+	files map[string]*accessLogFile
+}
+
+func NewAccessLog(sources func() (map[string]string, error)) *AccessLog {
+	return &AccessLog{
+		sources: sources,
+		files:   make(map[string]*accessLogFile),
+	}
+}
+
+func (a *AccessLog) Describe(ch chan<- *prometheus.Desc) {
+	ch <- websiteRequestsDesc
+}
+
+func (a *AccessLog) Collect(ch chan<- prometheus.Metric) {
+	sources, err := a.sources()
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(websiteRequestsDesc, err)
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for website, path := range sources {
+		file, ok := a.files[website]
+		if !ok {
+			// 首次发现时从日志末尾开始统计，避免读取历史日志
+			file = &accessLogFile{counts: make(map[string]float64)}
+			if stat, err := os.Stat(path); err == nil {
+				file.offset = stat.Size()
+			}
+			a.files[website] = file
+		} else {
+			_ = a.read(path, file)
+		}
+
+		for code, count := range file.counts {
+			ch <- prometheus.MustNewConstMetric(websiteRequestsDesc, prometheus.CounterValue, count, website, code)
+		}
+	}
+
+	// 删除的网站不再输出
+	for website := range a.files {
+		if _, ok := sources[website]; !ok {
+			delete(a.files, website)
+		}
+	}
+}
+
+// read 读取上次位置之后的完整行，日志被截断或轮转时从头开始读取
+func (a *AccessLog) read(path string, file *accessLogFile) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func(f *os.File) { _ = f.Close() }(f)
+
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if stat.Size() < file.offset {
+		file.offset = 0
+	}
+	if _, err = f.Seek(file.offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// 未写完的行留到下次读取
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		file.offset += int64(len(line))
+		file.counts[statusClass(line)]++
+	}
+}
+
+// statusClass 解析日志行的状态码类别，支持 combined 格式（Nginx、Apache）和 JSON 格式（Caddy）
+func statusClass(line []byte) string {
+	if line = bytes.TrimSpace(line); len(line) > 0 && line[0] == '{' {
+		var entry struct {
+			Status int `json:"status"`
+		}
+		if err := json.Unmarshal(line, &entry); err == nil && entry.Status >= 100 && entry.Status < 600 {
+			return strconv.Itoa(entry.Status/100) + "xx"
+		}
+		return "unknown"
+	}
+	if match := accessLogStatus.FindSubmatch(line); match != nil {
+		return string(match[1]) + "xx"
+	}
+
+	return "unknown"
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/acepanel/panel/pkg/tools"
+	"github.com/acepanel/panel/pkg/types"
+)
+
+var (
+	cpuUsageDesc      = prometheus.NewDesc("ace_cpu_usage_percent", "Total CPU usage.", nil, nil)
+	cpuCoreUsageDesc  = prometheus.NewDesc("ace_cpu_core_usage_percent", "CPU usage per core.", []string{"core"}, nil)
+	loadDesc          = prometheus.NewDesc("ace_load_average", "System load average.", []string{"period"}, nil)
+	bootTimeDesc      = prometheus.NewDesc("ace_boot_time_seconds", "System boot time as unix timestamp.", nil, nil)
+	memTotalDesc      = prometheus.NewDesc("ace_memory_total_bytes", "Total memory.", nil, nil)
+	memUsedDesc       = prometheus.NewDesc("ace_memory_used_bytes", "Used memory.", nil, nil)
+	memAvailableDesc  = prometheus.NewDesc("ace_memory_available_bytes", "Available memory.", nil, nil)
+	swapTotalDesc     = prometheus.NewDesc("ace_swap_total_bytes", "Total swap.", nil, nil)
+	swapUsedDesc      = prometheus.NewDesc("ace_swap_used_bytes", "Used swap.", nil, nil)
+	netRecvDesc       = prometheus.NewDesc("ace_network_receive_bytes_total", "Network bytes received.", []string{"device"}, nil)
+	netSentDesc       = prometheus.NewDesc("ace_network_transmit_bytes_total", "Network bytes sent.", []string{"device"}, nil)
+	diskTotalDesc     = prometheus.NewDesc("ace_disk_total_bytes", "Filesystem size.", []string{"mountpoint", "fstype"}, nil)
+	diskUsedDesc      = prometheus.NewDesc("ace_disk_used_bytes", "Filesystem used space.", []string{"mountpoint", "fstype"}, nil)
+	diskFreeDesc      = prometheus.NewDesc("ace_disk_free_bytes", "Filesystem free space.", []string{"mountpoint", "fstype"}, nil)
+	diskReadDesc      = prometheus.NewDesc("ace_disk_read_bytes_total", "Disk bytes read.", []string{"device"}, nil)
+	diskWrittenDesc   = prometheus.NewDesc("ace_disk_written_bytes_total", "Disk bytes written.", []string{"device"}, nil)
+	diskReadOpsDesc   = prometheus.NewDesc("ace_disk_reads_completed_total", "Disk reads completed.", []string{"device"}, nil)
+	diskWriteOpsDesc  = prometheus.NewDesc("ace_disk_writes_completed_total", "Disk writes completed.", []string{"device"}, nil)
+	hostCollectorDesc = []*prometheus.Desc{
+		cpuUsageDesc, cpuCoreUsageDesc, loadDesc, bootTimeDesc,
+		memTotalDesc, memUsedDesc, memAvailableDesc, swapTotalDesc, swapUsedDesc,
+		netRecvDesc, netSentDesc,
+		diskTotalDesc, diskUsedDesc, diskFreeDesc, diskReadDesc, diskWrittenDesc, diskReadOpsDesc, diskWriteOpsDesc,
+	}
+)
+
+// Host 采集主机指标，数据与面板监控相同
+type Host struct {
+	info func() types.CurrentInfo
+}
+
+func NewHost() *Host {
+	return &Host{
+		info: func() types.CurrentInfo {
+			return tools.CurrentInfo(nil, nil)
+		},
+	}
+}
+
+func (h *Host) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range hostCollectorDesc {
+		ch <- desc
+	}
+}
+
+func (h *Host) Collect(ch chan<- prometheus.Metric) {
+	info := h.info()
+
+	ch <- prometheus.MustNewConstMetric(cpuUsageDesc, prometheus.GaugeValue, info.Percent)
+	for i, percent := range info.Percents {
+		ch <- prometheus.MustNewConstMetric(cpuCoreUsageDesc, prometheus.GaugeValue, percent, strconv.Itoa(i))
+	}
+	if info.Load != nil {
+		ch <- prometheus.MustNewConstMetric(loadDesc, prometheus.GaugeValue, info.Load.Load1, "1m")
+		ch <- prometheus.MustNewConstMetric(loadDesc, prometheus.GaugeValue, info.Load.Load5, "5m")
+		ch <- prometheus.MustNewConstMetric(loadDesc, prometheus.GaugeValue, info.Load.Load15, "15m")
+	}
+	if info.Host != nil {
+		ch <- prometheus.MustNewConstMetric(bootTimeDesc, prometheus.GaugeValue, float64(info.Host.BootTime))
+	}
+	if info.Mem != nil {
+		ch <- prometheus.MustNewConstMetric(memTotalDesc, prometheus.GaugeValue, float64(info.Mem.Total))
+		ch <- prometheus.MustNewConstMetric(memUsedDesc, prometheus.GaugeValue, float64(info.Mem.Used))
+		ch <- prometheus.MustNewConstMetric(memAvailableDesc, prometheus.GaugeValue, float64(info.Mem.Available))
+	}
+	if info.Swap != nil {
+		ch <- prometheus.MustNewConstMetric(swapTotalDesc, prometheus.GaugeValue, float64(info.Swap.Total))
+		ch <- prometheus.MustNewConstMetric(swapUsedDesc, prometheus.GaugeValue, float64(info.Swap.Used))
+	}
+	for _, stat := range info.Net {
+		ch <- prometheus.MustNewConstMetric(netRecvDesc, prometheus.CounterValue, float64(stat.BytesRecv), stat.Name)
+		ch <- prometheus.MustNewConstMetric(netSentDesc, prometheus.CounterValue, float64(stat.BytesSent), stat.Name)
+	}
+	// 同一挂载点可能出现多次，重复的标签会导致抓取失败
+	seen := make(map[string]bool)
+	for _, usage := range info.DiskUsage {
+		if seen[usage.Path] {
+			continue
+		}
+		seen[usage.Path] = true
+		ch <- prometheus.MustNewConstMetric(diskTotalDesc, prometheus.GaugeValue, float64(usage.Total), usage.Path, usage.Fstype)
+		ch <- prometheus.MustNewConstMetric(diskUsedDesc, prometheus.GaugeValue, float64(usage.Used), usage.Path, usage.Fstype)
+		ch <- prometheus.MustNewConstMetric(diskFreeDesc, prometheus.GaugeValue, float64(usage.Free), usage.Path, usage.Fstype)
+	}
+	for _, stat := range info.DiskIO {
+		ch <- prometheus.MustNewConstMetric(diskReadDesc, prometheus.CounterValue, float64(stat.ReadBytes), stat.Name)
+		ch <- prometheus.MustNewConstMetric(diskWrittenDesc, prometheus.CounterValue, float64(stat.WriteBytes), stat.Name)
+		ch <- prometheus.MustNewConstMetric(diskReadOpsDesc, prometheus.CounterValue, float64(stat.ReadCount), stat.Name)
+		ch <- prometheus.MustNewConstMetric(diskWriteOpsDesc, prometheus.CounterValue, float64(stat.WriteCount), stat.Name)
+	}
+}
@@ -0,0 +1,59 @@
+// Package metrics 提供面板 Prometheus 指标的采集器
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// HTTP 统计面板 HTTP 请求耗时
+type HTTP struct {
+	duration *prometheus.HistogramVec
+}
+
+func NewHTTP() *HTTP {
+	return &HTTP{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "ace_http_request_duration_seconds",
+			Help:    "Panel HTTP request latency by route.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "code"}),
+	}
+}
+
+func (h *HTTP) Describe(ch chan<- *prometheus.Desc) {
+	h.duration.Describe(ch)
+}
+
+func (h *HTTP) Collect(ch chan<- prometheus.Metric) {
+	h.duration.Collect(ch)
+}
+
+// Middleware 记录请求耗时，按路由模板分组以限制标签数量
+func (h *HTTP) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		route := ""
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			route = rctx.RoutePattern()
+		}
+		// 前端页面等未匹配的请求统一归类
+		if route == "" || route == "/*" {
+			route = "other"
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		h.duration.WithLabelValues(r.Method, route, strconv.Itoa(status)).Observe(time.Since(start).Seconds())
+	})
+}
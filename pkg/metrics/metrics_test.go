@@ -0,0 +1,141 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/expfmt"
+	"github.com/shirou/gopsutil/disk"
+	"github.com/shirou/gopsutil/load"
+	"github.com/shirou/gopsutil/mem"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/acepanel/panel/pkg/types"
+)
+
+type MetricsTestSuite struct {
+	suite.Suite
+}
+
+func TestMetricsTestSuite(t *testing.T) {
+	suite.Run(t, &MetricsTestSuite{})
+}
+
+func (s *MetricsTestSuite) TestHTTP() {
+	h := NewHTTP()
+	r := chi.NewRouter()
+	r.Use(h.Middleware)
+	r.Get("/api/website/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	for _, path := range []string{"/api/website/1", "/api/website/2", "/index.html"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	s.Equal(2, testutil.CollectAndCount(h, "ace_http_request_duration_seconds"))
+	out, err := testutil.CollectAndFormat(h, expfmt.TypeTextPlain, "ace_http_request_duration_seconds")
+	s.Require().NoError(err)
+	s.Contains(string(out), `ace_http_request_duration_seconds_count{code="404",method="GET",route="/api/website/{id}"} 2`)
+	s.Contains(string(out), `ace_http_request_duration_seconds_count{code="404",method="GET",route="other"} 1`)
+}
+
+func (s *MetricsTestSuite) TestHost() {
+	h := &Host{info: func() types.CurrentInfo {
+		return types.CurrentInfo{
+			Percent:  12.5,
+			Percents: []float64{10, 15},
+			Load:     &load.AvgStat{Load1: 1, Load5: 2, Load15: 3},
+			Mem:      &mem.VirtualMemoryStat{Total: 1024, Used: 512, Available: 256},
+			DiskUsage: []disk.UsageStat{
+				{Path: "/", Fstype: "ext4", Total: 100, Used: 40, Free: 60},
+				{Path: "/", Fstype: "ext4", Total: 100, Used: 40, Free: 60},
+			},
+		}
+	}}
+
+	expected := `
+# HELP ace_cpu_usage_percent Total CPU usage.
+# TYPE ace_cpu_usage_percent gauge
+ace_cpu_usage_percent 12.5
+# HELP ace_disk_used_bytes Filesystem used space.
+# TYPE ace_disk_used_bytes gauge
+ace_disk_used_bytes{fstype="ext4",mountpoint="/"} 40
+# HELP ace_load_average System load average.
+# TYPE ace_load_average gauge
+ace_load_average{period="15m"} 3
+ace_load_average{period="1m"} 1
+ace_load_average{period="5m"} 2
+`
+	s.NoError(testutil.CollectAndCompare(h, strings.NewReader(expected), "ace_cpu_usage_percent", "ace_disk_used_bytes", "ace_load_average"))
+	s.Equal(2, testutil.CollectAndCount(h, "ace_cpu_core_usage_percent"))
+}
+
+func (s *MetricsTestSuite) TestAccessLog() {
+	path := filepath.Join(s.T().TempDir(), "access.log")
+	s.Require().NoError(os.WriteFile(path, []byte(`1.1.1.1 - - [18/Oct/2026:10:00:00 +0800] "GET /old HTTP/1.1" 200 10 "-" "curl"`+"\n"), 0644))
+	a := NewAccessLog(func() (map[string]string, error) {
+		return map[string]string{"example": path}, nil
+	})
+
+	// 首次发现时不统计已有日志
+	s.Equal(0, testutil.CollectAndCount(a))
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	s.Require().NoError(err)
+	_, _ = f.WriteString(`1.1.1.1 - - [18/Oct/2026:10:00:01 +0800] "GET / HTTP/1.1" 200 10 "-" "curl"` + "\n")
+	_, _ = f.WriteString(`1.1.1.1 - - [18/Oct/2026:10:00:02 +0800] "GET /a HTTP/1.1" 404 10 "-" "curl"` + "\n")
+	_, _ = f.WriteString(`1.1.1.1 - - [18/Oct/2026:10:00:03 +0800] "GET /b HTTP/1.1" 502 10`)
+	s.Require().NoError(f.Close())
+
+	expected := `
+# HELP ace_website_requests_total Website requests counted from access logs since panel start.
+# TYPE ace_website_requests_total counter
+ace_website_requests_total{code="2xx",website="example"} 1
+ace_website_requests_total{code="4xx",website="example"} 1
+`
+	s.NoError(testutil.CollectAndCompare(a, strings.NewReader(expected)))
+
+	// 未写完的行不统计，日志轮转后从头读取
+	s.Require().NoError(os.WriteFile(path, []byte(`1.1.1.1 - - [18/Oct/2026:10:00:04 +0800] "GET / HTTP/1.1" 500 10 "-" "curl"`+"\n"+"garbage\n"), 0644))
+	expected = `
+# HELP ace_website_requests_total Website requests counted from access logs since panel start.
+# TYPE ace_website_requests_total counter
+ace_website_requests_total{code="2xx",website="example"} 1
+ace_website_requests_total{code="4xx",website="example"} 1
+ace_website_requests_total{code="5xx",website="example"} 1
+ace_website_requests_total{code="unknown",website="example"} 1
+`
+	s.NoError(testutil.CollectAndCompare(a, strings.NewReader(expected)))
+}
+
+func (s *MetricsTestSuite) TestAccessLogJSON() {
+	path := filepath.Join(s.T().TempDir(), "access.log")
+	s.Require().NoError(os.WriteFile(path, nil, 0644))
+	a := NewAccessLog(func() (map[string]string, error) {
+		return map[string]string{"example": path}, nil
+	})
+	s.Equal(0, testutil.CollectAndCount(a))
+
+	// Caddy 默认使用 JSON 格式的访问日志
+	lines := `{"level":"info","ts":1792288800.1,"logger":"http.log.access","msg":"handled request","request":{"method":"GET","uri":"/"},"status":200}
+{"level":"error","ts":1792288801.2,"logger":"http.log.access","msg":"handled request","request":{"method":"GET","uri":"/a"},"status":502}
+{"level":"info","msg":"no status"}
+`
+	s.Require().NoError(os.WriteFile(path, []byte(lines), 0644))
+
+	expected := `
+# HELP ace_website_requests_total Website requests counted from access logs since panel start.
+# TYPE ace_website_requests_total counter
+ace_website_requests_total{code="2xx",website="example"} 1
+ace_website_requests_total{code="5xx",website="example"} 1
+ace_website_requests_total{code="unknown",website="example"} 1
+`
+	s.NoError(testutil.CollectAndCompare(a, strings.NewReader(expected)))
+}
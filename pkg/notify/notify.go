@@ -0,0 +1,69 @@
+// Package notify 实现通知渠道的发送，支持 SMTP 邮件、Telegram 机器人和通用 Webhook
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type Type string
+
+const (
+	TypeSMTP     Type = "smtp"
+	TypeTelegram Type = "telegram"
+	TypeWebhook  Type = "webhook"
+)
+
+// Message 通知内容
+type Message struct {
+	Event   string    `json:"event"`
+	Title   string    `json:"title"`
+	Content string    `json:"content"`
+	Time    time.Time `json:"time"`
+}
+
+// Sender 通知渠道
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// New 根据渠道类型和配置创建发送器，配置字段见各渠道的 Config
+func New(typ Type, config map[string]any) (Sender, error) {
+	raw, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	switch typ {
+	case TypeSMTP:
+		conf := new(SMTPConfig)
+		if err = json.Unmarshal(raw, conf); err != nil {
+			return nil, err
+		}
+		return NewSMTP(*conf)
+	case TypeTelegram:
+		conf := new(TelegramConfig)
+		if err = json.Unmarshal(raw, conf); err != nil {
+			return nil, err
+		}
+		return NewTelegram(*conf)
+	case TypeWebhook:
+		conf := new(WebhookConfig)
+		if err = json.Unmarshal(raw, conf); err != nil {
+			return nil, err
+		}
+		return NewWebhook(*conf)
+	default:
+		return nil, fmt.Errorf("unsupported notify type: %s", typ)
+	}
+}
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// text 纯文本格式的通知内容
+func (m *Message) text() string {
+	return m.Title + "\n\n" + m.Content
+}
@@ -0,0 +1,196 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type NotifyTestSuite struct {
+	suite.Suite
+	msg *Message
+}
+
+func TestNotifyTestSuite(t *testing.T) {
+	suite.Run(t, &NotifyTestSuite{})
+}
+
+func (s *NotifyTestSuite) SetupTest() {
+	s.msg = &Message{
+		Event:   "test",
+		Title:   "证书续签失败",
+		Content: "example.com\nrenew failed",
+		Time:    time.Now(),
+	}
+}
+
+// smtpSink 启动只接收邮件的本地 SMTP 服务，返回端口和收到的数据
+func (s *NotifyTestSuite) smtpSink() (int, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	received := make(chan string, 1)
+
+	go func() {
+		defer func() { _ = listener.Close() }()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+		reply("220 localhost ESMTP")
+		var data strings.Builder
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(command, "MAIL"), strings.HasPrefix(command, "RCPT"):
+				data.WriteString(strings.TrimSpace(line) + "\n")
+				reply("250 OK")
+			case command == "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				for {
+					line, err = reader.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				reply("250 OK")
+			case command == "QUIT":
+				reply("221 Bye")
+				received <- data.String()
+				return
+			default:
+				reply("502 Command not implemented")
+			}
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port, received
+}
+
+func (s *NotifyTestSuite) TestSMTP() {
+	port, received := s.smtpSink()
+	sender, err := New(TypeSMTP, map[string]any{
+		"host":       "127.0.0.1",
+		"port":       port,
+		"from":       "panel@example.com",
+		"to":         []string{"ops@example.com"},
+		"encryption": EncryptionNone,
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(sender.Send(context.Background(), s.msg))
+	data := <-received
+	s.Contains(data, "MAIL FROM:<panel@example.com>")
+	s.Contains(data, "RCPT TO:<ops@example.com>")
+	s.Contains(data, "Subject: =?UTF-8?q?")
+	s.Contains(data, "renew failed")
+}
+
+func (s *NotifyTestSuite) TestSMTPRequireSTARTTLS() {
+	port, _ := s.smtpSink()
+	sender, err := New(TypeSMTP, map[string]any{
+		"host": "127.0.0.1",
+		"port": port,
+		"from": "panel@example.com",
+		"to":   []string{"ops@example.com"},
+	})
+	s.Require().NoError(err)
+
+	s.Error(sender.Send(context.Background(), s.msg))
+}
+
+func (s *NotifyTestSuite) TestTelegram() {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/botTOKEN/sendMessage" {
+			_, _ = w.Write([]byte(`{"ok":false,"description":"Not Found"}`))
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	sender, err := New(TypeTelegram, map[string]any{"token": "TOKEN", "chat_id": "42", "api_url": server.URL + "/"})
+	s.Require().NoError(err)
+	s.Require().NoError(sender.Send(context.Background(), s.msg))
+	s.Equal("42", body["chat_id"])
+	s.Equal(s.msg.Title+"\n\n"+s.msg.Content, body["text"])
+
+	sender, err = New(TypeTelegram, map[string]any{"token": "WRONG", "chat_id": "42", "api_url": server.URL})
+	s.Require().NoError(err)
+	s.ErrorContains(sender.Send(context.Background(), s.msg), "Not Found")
+}
+
+func (s *NotifyTestSuite) TestWebhook() {
+	var payload []byte
+	var signature, custom string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get("X-Signature-256")
+		custom = r.Header.Get("X-Custom")
+	}))
+	defer server.Close()
+
+	sender, err := New(TypeWebhook, map[string]any{
+		"url":     server.URL,
+		"headers": map[string]string{"X-Custom": "1"},
+		"secret":  "secret",
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(sender.Send(context.Background(), s.msg))
+
+	msg := new(Message)
+	s.Require().NoError(json.Unmarshal(payload, msg))
+	s.Equal(s.msg.Event, msg.Event)
+	s.Equal(s.msg.Title, msg.Title)
+	s.Equal("1", custom)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(payload)
+	s.Equal("sha256="+hex.EncodeToString(mac.Sum(nil)), signature)
+}
+
+func (s *NotifyTestSuite) TestWebhookError() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	sender, err := New(TypeWebhook, map[string]any{"url": server.URL})
+	s.Require().NoError(err)
+	s.ErrorContains(sender.Send(context.Background(), s.msg), strconv.Itoa(http.StatusBadGateway))
+}
+
+func (s *NotifyTestSuite) TestInvalidConfig() {
+	_, err := New(TypeSMTP, map[string]any{"host": "127.0.0.1"})
+	s.Error(err)
+	_, err = New(TypeTelegram, map[string]any{"token": "TOKEN"})
+	s.Error(err)
+	_, err = New(TypeWebhook, map[string]any{})
+	s.Error(err)
+	_, err = New("sms", map[string]any{})
+	s.Error(err)
+}
@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type TelegramConfig struct {
+	Token  string `json:"token"`
+	ChatID string `json:"chat_id"`
+	APIURL string `json:"api_url"` // 为空时使用 https://api.telegram.org，可配置为反向代理地址
+}
+
+type Telegram struct {
+	conf TelegramConfig
+}
+
+func NewTelegram(conf TelegramConfig) (*Telegram, error) {
+	if conf.Token == "" || conf.ChatID == "" {
+		return nil, errors.New("telegram token and chat id are required")
+	}
+	if conf.APIURL == "" {
+		conf.APIURL = "https://api.telegram.org"
+	}
+	conf.APIURL = strings.TrimSuffix(conf.APIURL, "/")
+
+	return &Telegram{conf: conf}, nil
+}
+
+func (t *Telegram) Send(ctx context.Context, msg *Message) error {
+	payload, err := json.Marshal(map[string]any{
+		"chat_id": t.conf.ChatID,
+		"text":    msg.text(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.conf.APIURL+"/bot"+t.conf.Token+"/sendMessage", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		// 错误信息中的地址包含令牌，不直接返回
+		return errors.New("failed to request telegram api")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var result struct {
+		OK          bool   `json:"ok"`
+		Description string `json:"description"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("invalid telegram response: %s", resp.Status)
+	}
+	if !result.OK {
+		return fmt.Errorf("telegram api error: %s", result.Description)
+	}
+
+	return nil
+}
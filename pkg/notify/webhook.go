@@ -0,0 +1,68 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type WebhookConfig struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Secret  string            `json:"secret"` // 不为空时在 X-Signature-256 头中携带请求体的 HMAC-SHA256 签名
+}
+
+type Webhook struct {
+	conf WebhookConfig
+}
+
+func NewWebhook(conf WebhookConfig) (*Webhook, error) {
+	if conf.URL == "" {
+		return nil, errors.New("webhook url is required")
+	}
+
+	return &Webhook{conf: conf}, nil
+}
+
+// Send 以 JSON 格式 POST 通知内容
+func (h *Webhook) Send(ctx context.Context, msg *Message) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.conf.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "AcePanel")
+	for key, value := range h.conf.Headers {
+		req.Header.Set(key, value)
+	}
+	if h.conf.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(h.conf.Secret))
+		mac.Write(payload)
+		req.Header.Set("X-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook responded with %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+
+	return nil
+}
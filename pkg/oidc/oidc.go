@@ -0,0 +1,205 @@
+// Package oidc 实现 OpenID Connect 授权码登录，支持自动发现、PKCE 及用户组和邮箱域名限制
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// Config 身份提供方配置
+type Config struct {
+	Issuer         string
+	ClientID       string
+	ClientSecret   string
+	RedirectURL    string
+	Scopes         []string // 为空时使用 openid profile email
+	UsernameClaim  string   // 为空时使用 preferred_username
+	GroupsClaim    string   // 为空时使用 groups
+	AllowedGroups  []string // 为空时不限制
+	AllowedDomains []string // 为空时不限制
+}
+
+// Identity 从 ID Token 中解析的用户身份
+type Identity struct {
+	Issuer        string
+	Subject       string
+	Username      string
+	Email         string
+	EmailVerified bool
+	Groups        []string
+}
+
+// Auth 跳转授权所需的参数，State、Nonce、Verifier 需保存在会话中供回调校验
+type Auth struct {
+	URL      string
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+type Client struct {
+	conf     Config
+	oauth    *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+// New 通过 Issuer 自动发现端点并创建客户端
+func New(ctx context.Context, conf Config) (*Client, error) {
+	if conf.Issuer == "" || conf.ClientID == "" || conf.RedirectURL == "" {
+		return nil, errors.New("issuer, client id and redirect url are required")
+	}
+	if len(conf.Scopes) == 0 {
+		conf.Scopes = []string{"profile", "email"}
+	}
+	if !slices.Contains(conf.Scopes, oidc.ScopeOpenID) {
+		conf.Scopes = append([]string{oidc.ScopeOpenID}, conf.Scopes...)
+	}
+	if conf.UsernameClaim == "" {
+		conf.UsernameClaim = "preferred_username"
+	}
+	if conf.GroupsClaim == "" {
+		conf.GroupsClaim = "groups"
+	}
+
+	provider, err := oidc.NewProvider(ctx, conf.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover provider: %w", err)
+	}
+
+	return &Client{
+		conf: conf,
+		oauth: &oauth2.Config{
+			ClientID:     conf.ClientID,
+			ClientSecret: conf.ClientSecret,
+			RedirectURL:  conf.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       conf.Scopes,
+		},
+		verifier: provider.Verifier(&oidc.Config{ClientID: conf.ClientID}),
+	}, nil
+}
+
+// AuthCodeURL 生成授权地址
+func (c *Client) AuthCodeURL() (*Auth, error) {
+	state, err := random()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := random()
+	if err != nil {
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	return &Auth{
+		URL:      c.oauth.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)),
+		State:    state,
+		Nonce:    nonce,
+		Verifier: verifier,
+	}, nil
+}
+
+// Exchange 使用授权码换取并校验 ID Token，返回通过限制检查的身份
+func (c *Client) Exchange(ctx context.Context, code, verifier, nonce string) (*Identity, error) {
+	token, err := c.oauth.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange token: %w", err)
+	}
+	raw, ok := token.Extra("id_token").(string)
+	if !ok || raw == "" {
+		return nil, errors.New("id_token not found in token response")
+	}
+
+	idToken, err := c.verifier.Verify(ctx, raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify id_token: %w", err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, errors.New("invalid nonce")
+	}
+
+	claims := make(map[string]any)
+	if err = idToken.Claims(&claims); err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{
+		Issuer:   idToken.Issuer,
+		Subject:  idToken.Subject,
+		Username: claimString(claims, c.conf.UsernameClaim),
+		Email:    claimString(claims, "email"),
+		Groups:   claimStrings(claims, c.conf.GroupsClaim),
+	}
+	// 部分身份提供方以字符串返回 email_verified
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = v
+	case string:
+		identity.EmailVerified = v == "true"
+	}
+
+	if err = c.Allowed(identity); err != nil {
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+// Allowed 检查身份是否满足用户组和邮箱域名限制
+func (c *Client) Allowed(identity *Identity) error {
+	if len(c.conf.AllowedGroups) > 0 && !slices.ContainsFunc(identity.Groups, func(group string) bool {
+		return slices.Contains(c.conf.AllowedGroups, group)
+	}) {
+		return errors.New("user is not in allowed groups")
+	}
+	if len(c.conf.AllowedDomains) > 0 {
+		_, domain, found := strings.Cut(identity.Email, "@")
+		if !found || !identity.EmailVerified || !slices.ContainsFunc(c.conf.AllowedDomains, func(allowed string) bool {
+			return strings.EqualFold(allowed, domain)
+		}) {
+			return errors.New("email domain is not allowed or not verified")
+		}
+	}
+
+	return nil
+}
+
+func claimString(claims map[string]any, name string) string {
+	if v, ok := claims[name].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// claimStrings 读取字符串数组声明，兼容单个字符串
+func claimStrings(claims map[string]any, name string) []string {
+	switch v := claims[name].(type) {
+	case string:
+		return []string{v}
+	case []any:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+func random() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,188 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type OIDCTestSuite struct {
+	suite.Suite
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	claims map[string]any
+	auth   url.Values // 最近一次授权请求的参数
+}
+
+func TestOIDCTestSuite(t *testing.T) {
+	suite.Run(t, &OIDCTestSuite{})
+}
+
+// SetupTest 启动模拟的身份提供方
+func (s *OIDCTestSuite) SetupTest() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.key = key
+	s.claims = map[string]any{
+		"preferred_username": "alice",
+		"email":              "alice@example.com",
+		"email_verified":     true,
+		"groups":             []string{"ops", "dev"},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                s.server.URL,
+			"authorization_endpoint":                s.server.URL + "/authorize",
+			"token_endpoint":                        s.server.URL + "/token",
+			"jwks_uri":                              s.server.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &s.key.PublicKey, KeyID: "test", Algorithm: "RS256", Use: "sig"},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if r.PostForm.Get("code") != "code" || base64.RawURLEncoding.EncodeToString(sum[:]) != s.auth.Get("code_challenge") {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+
+		claims := map[string]any{
+			"iss":   s.server.URL,
+			"sub":   "user-1",
+			"aud":   "panel",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"iat":   time.Now().Unix(),
+			"nonce": s.auth.Get("nonce"),
+		}
+		for k, v := range s.claims {
+			claims[k] = v
+		}
+		payload, _ := json.Marshal(claims)
+		signer, _ := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: s.key}, (&jose.SignerOptions{}).WithHeader("kid", "test"))
+		signed, _ := signer.Sign(payload)
+		idToken, _ := signed.CompactSerialize()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     idToken,
+		})
+	})
+	s.server = httptest.NewServer(mux)
+}
+
+func (s *OIDCTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *OIDCTestSuite) client(conf Config) *Client {
+	conf.Issuer = s.server.URL
+	conf.ClientID = "panel"
+	conf.ClientSecret = "secret"
+	conf.RedirectURL = "https://panel.example.com/api/user/oidc/callback"
+	client, err := New(context.Background(), conf)
+	s.Require().NoError(err)
+	return client
+}
+
+// authorize 模拟浏览器跳转授权
+func (s *OIDCTestSuite) authorize(client *Client) *Auth {
+	auth, err := client.AuthCodeURL()
+	s.Require().NoError(err)
+	u, err := url.Parse(auth.URL)
+	s.Require().NoError(err)
+	s.auth = u.Query()
+	return auth
+}
+
+func (s *OIDCTestSuite) TestAuthCodeURL() {
+	auth := s.authorize(s.client(Config{}))
+
+	s.Equal(auth.State, s.auth.Get("state"))
+	s.Equal(auth.Nonce, s.auth.Get("nonce"))
+	s.Equal("S256", s.auth.Get("code_challenge_method"))
+	s.NotEmpty(s.auth.Get("code_challenge"))
+	s.Equal("openid profile email", s.auth.Get("scope"))
+}
+
+func (s *OIDCTestSuite) TestExchange() {
+	client := s.client(Config{})
+	auth := s.authorize(client)
+
+	identity, err := client.Exchange(context.Background(), "code", auth.Verifier, auth.Nonce)
+	s.Require().NoError(err)
+	s.Equal(s.server.URL, identity.Issuer)
+	s.Equal("user-1", identity.Subject)
+	s.Equal("alice", identity.Username)
+	s.Equal("alice@example.com", identity.Email)
+	s.True(identity.EmailVerified)
+	s.Equal([]string{"ops", "dev"}, identity.Groups)
+}
+
+func (s *OIDCTestSuite) TestExchangeCustomClaims() {
+	s.claims["name"] = "Alice"
+	s.claims["roles"] = "admin"
+	client := s.client(Config{UsernameClaim: "name", GroupsClaim: "roles"})
+	auth := s.authorize(client)
+
+	identity, err := client.Exchange(context.Background(), "code", auth.Verifier, auth.Nonce)
+	s.Require().NoError(err)
+	s.Equal("Alice", identity.Username)
+	s.Equal([]string{"admin"}, identity.Groups)
+}
+
+func (s *OIDCTestSuite) TestExchangeInvalidVerifier() {
+	client := s.client(Config{})
+	auth := s.authorize(client)
+
+	_, err := client.Exchange(context.Background(), "code", "wrong", auth.Nonce)
+	s.Error(err)
+}
+
+func (s *OIDCTestSuite) TestExchangeInvalidNonce() {
+	client := s.client(Config{})
+	auth := s.authorize(client)
+
+	_, err := client.Exchange(context.Background(), "code", auth.Verifier, "wrong")
+	s.Error(err)
+}
+
+func (s *OIDCTestSuite) TestExchangeNotAllowed() {
+	client := s.client(Config{AllowedGroups: []string{"admin"}})
+	auth := s.authorize(client)
+
+	_, err := client.Exchange(context.Background(), "code", auth.Verifier, auth.Nonce)
+	s.Error(err)
+}
+
+func (s *OIDCTestSuite) TestAllowed() {
+	client := s.client(Config{AllowedGroups: []string{"ops"}, AllowedDomains: []string{"Example.com"}})
+
+	s.NoError(client.Allowed(&Identity{Email: "bob@example.com", EmailVerified: true, Groups: []string{"ops"}}))
+	s.Error(client.Allowed(&Identity{Email: "bob@example.com", EmailVerified: true, Groups: []string{"dev"}}))
+	s.Error(client.Allowed(&Identity{Email: "bob@example.com", EmailVerified: false, Groups: []string{"ops"}}))
+	s.Error(client.Allowed(&Identity{Email: "bob@other.com", EmailVerified: true, Groups: []string{"ops"}}))
+	s.Error(client.Allowed(&Identity{Email: "", EmailVerified: true, Groups: []string{"ops"}}))
+}
@@ -0,0 +1,136 @@
+// Package redact 对请求参数中的敏感字段进行脱敏，用于审计日志等场景
+package redact
+
+import (
+	"encoding/json"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Mask 脱敏后的占位符
+const Mask = "******"
+
+// keywords 字段名按非字母数字拆分后，任一部分命中即视为敏感
+var keywords = []string{
+	"password", "passwd", "pass", "passcode", "passphrase", "secret", "token", "key", "privatekey",
+	"identity", "credential", "credentials", "signature", "authorization", "cookie", "otp", "code", "dsn",
+}
+
+// Sensitive 判断字段名是否敏感，支持 snake_case、kebab-case 和 CamelCase
+func Sensitive(name string) bool {
+	return slices.ContainsFunc(words(name), func(word string) bool {
+		return slices.Contains(keywords, word)
+	})
+}
+
+// words 将字段名拆分为小写单词
+func words(name string) []string {
+	var result []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			result = append(result, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && i > 0 && unicode.IsLower(runes[i-1]):
+			flush()
+		}
+		word = append(word, r)
+	}
+	flush()
+
+	return result
+}
+
+// JSON 对 JSON 中的敏感字段脱敏，无法解析时返回 false
+func JSON(data []byte) ([]byte, bool) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, false
+	}
+	out, err := json.Marshal(value(v))
+	if err != nil {
+		return nil, false
+	}
+
+	return out, true
+}
+
+// Form 对表单中的敏感字段脱敏
+func Form(values url.Values) url.Values {
+	out := make(url.Values, len(values))
+	for k, v := range values {
+		if Sensitive(k) {
+			out[k] = []string{Mask}
+			continue
+		}
+		out[k] = v
+	}
+
+	return out
+}
+
+// Args 对命令行参数中的敏感选项脱敏，支持 --key=value 和 --key value 两种形式
+func Args(args []string) []string {
+	out := slices.Clone(args)
+	for i := 0; i < len(out); i++ {
+		if !strings.HasPrefix(out[i], "-") {
+			continue
+		}
+		name, _, hasValue := strings.Cut(strings.TrimLeft(out[i], "-"), "=")
+		if !Sensitive(name) {
+			continue
+		}
+		if hasValue {
+			out[i] = out[i][:strings.Index(out[i], "=")+1] + Mask
+		} else if i+1 < len(out) {
+			out[i+1] = Mask
+			i++
+		}
+	}
+
+	return out
+}
+
+// Truncate 按字节截断字符串，保证不破坏 UTF-8 字符
+func Truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	s = s[:limit]
+	for len(s) > 0 && !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+
+	return s + "..."
+}
+
+// value 递归脱敏，敏感字段的值无论是什么类型都整体替换
+func value(v any) any {
+	switch item := v.(type) {
+	case map[string]any:
+		for k, val := range item {
+			if Sensitive(k) {
+				item[k] = Mask
+				continue
+			}
+			item[k] = value(val)
+		}
+	case []any:
+		for i := range item {
+			item[i] = value(item[i])
+		}
+	}
+
+	return v
+}
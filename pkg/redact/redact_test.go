@@ -0,0 +1,59 @@
+package redact
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RedactTestSuite struct {
+	suite.Suite
+}
+
+func TestRedactTestSuite(t *testing.T) {
+	suite.Run(t, &RedactTestSuite{})
+}
+
+func (s *RedactTestSuite) TestSensitive() {
+	s.True(Sensitive("password"))
+	s.True(Sensitive("db_password"))
+	s.True(Sensitive("ssl_key"))
+	s.True(Sensitive("Info.S3.SecretKey"))
+	s.True(Sensitive("pass_code"))
+	s.False(Sensitive("name"))
+	s.False(Sensitive("bypass"))
+	s.False(Sensitive("keep_daily"))
+}
+
+func (s *RedactTestSuite) TestJSON() {
+	out, ok := JSON([]byte(`{"name":"site","db_password":"p@ss","code":123456,"info":{"sftp":{"password":"x","host":"h"}},"users":[{"token":"t"}]}`))
+	s.True(ok)
+	s.JSONEq(`{"name":"site","db_password":"******","code":"******","info":{"sftp":{"password":"******","host":"h"}},"users":[{"token":"******"}]}`, string(out))
+
+	// 敏感字段的值为数组或对象时整体脱敏
+	out, ok = JSON([]byte(`{"private_key":["a","b"],"credentials":{"user":"u","host":"h"},"keep":[1,2]}`))
+	s.True(ok)
+	s.JSONEq(`{"private_key":"******","credentials":"******","keep":[1,2]}`, string(out))
+
+	_, ok = JSON([]byte("not json"))
+	s.False(ok)
+}
+
+func (s *RedactTestSuite) TestForm() {
+	out := Form(url.Values{"username": {"admin"}, "password": {"secret"}})
+	s.Equal("admin", out.Get("username"))
+	s.Equal(Mask, out.Get("password"))
+}
+
+func (s *RedactTestSuite) TestArgs() {
+	s.Equal([]string{"backup", "--password", Mask, "-n", "site"}, Args([]string{"backup", "--password", "secret", "-n", "site"}))
+	s.Equal([]string{"--token=" + Mask, "--name=a"}, Args([]string{"--token=abc", "--name=a"}))
+	s.Equal([]string{"--key"}, Args([]string{"--key"}))
+}
+
+func (s *RedactTestSuite) TestTruncate() {
+	s.Equal("abc", Truncate("abc", 5))
+	s.Equal("ab...", Truncate("abcdef", 2))
+	s.Equal("中...", Truncate("中文", 4))
+}
@@ -0,0 +1,87 @@
+package snapshot
+
+import (
+	"io"
+)
+
+const (
+	minChunkSize = 512 << 10 // 512 KiB
+	maxChunkSize = 8 << 20   // 8 MiB
+	chunkMask    = 1<<20 - 1 // 平均 1 MiB
+)
+
+// gear 内容定义分块使用的随机表，固定种子保证不同版本间分块结果一致
+var gear = func() [256]uint64 {
+	var table [256]uint64
+	seed := uint64(0x41636550616e656c) // "AcePanel"
+	for i := range table {
+		// splitmix64
+		seed += 0x9e3779b97f4a7c15
+		z := seed
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		table[i] = z ^ (z >> 31)
+	}
+	return table
+}()
+
+// chunker 基于 Gear 哈希的内容定义分块器
+// 文件中间插入或删除数据只会影响附近的块，其余块仍可去重
+type chunker struct {
+	r     io.Reader
+	buf   []byte
+	start int
+	end   int
+	eof   bool
+}
+
+func newChunker(r io.Reader) *chunker {
+	return &chunker{
+		r:   r,
+		buf: make([]byte, maxChunkSize),
+	}
+}
+
+// Next 返回下一个块，返回的切片在下次调用前有效
+func (c *chunker) Next() ([]byte, error) {
+	if c.end-c.start < maxChunkSize && !c.eof {
+		copy(c.buf, c.buf[c.start:c.end])
+		c.end -= c.start
+		c.start = 0
+
+		n, err := io.ReadFull(c.r, c.buf[c.end:])
+		c.end += n
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			c.eof = true
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	data := c.buf[c.start:c.end]
+	if len(data) == 0 {
+		return nil, io.EOF
+	}
+
+	n := cut(data)
+	c.start += n
+	return data[:n], nil
+}
+
+// cut 计算切分点
+func cut(data []byte) int {
+	if len(data) <= minChunkSize {
+		return len(data)
+	}
+
+	n := min(len(data), maxChunkSize)
+	var hash uint64
+	for i := minChunkSize; i < n; i++ {
+		hash = (hash << 1) + gear[data[i]]
+		if hash&chunkMask == 0 {
+			return i + 1
+		}
+	}
+
+	return n
+}
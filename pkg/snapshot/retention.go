@@ -0,0 +1,58 @@
+package snapshot
+
+import (
+	"fmt"
+	"time"
+)
+
+// Policy 祖父-父-子（GFS）保留策略
+// 每个周期保留该周期内最新的一个快照，各周期分别计数，均为 0 时保留全部快照
+type Policy struct {
+	Daily   int `json:"daily"`   // 保留最近 N 天的每日快照
+	Weekly  int `json:"weekly"`  // 保留最近 N 周的每周快照
+	Monthly int `json:"monthly"` // 保留最近 N 月的每月快照
+}
+
+// Apply 根据策略计算需要保留和删除的快照，snapshots 需按时间从新到旧排序
+func (p Policy) Apply(snapshots []*Snapshot) (keep, remove []*Snapshot) {
+	if p.Daily <= 0 && p.Weekly <= 0 && p.Monthly <= 0 {
+		return snapshots, nil
+	}
+
+	buckets := []struct {
+		remain int
+		last   string
+		key    func(t time.Time) string
+	}{
+		{remain: p.Daily, key: func(t time.Time) string { return t.Format(time.DateOnly) }},
+		{remain: p.Weekly, key: func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-%02d", year, week)
+		}},
+		{remain: p.Monthly, key: func(t time.Time) string { return t.Format("2006-01") }},
+	}
+
+	for _, snap := range snapshots {
+		kept := false
+		t := snap.Time.Local()
+		for i := range buckets {
+			b := &buckets[i]
+			if b.remain <= 0 {
+				continue
+			}
+			if key := b.key(t); key != b.last {
+				b.last = key
+				b.remain--
+				kept = true
+			}
+		}
+
+		if kept {
+			keep = append(keep, snap)
+		} else {
+			remove = append(remove, snap)
+		}
+	}
+
+	return keep, remove
+}
@@ -0,0 +1,553 @@
+// Package snapshot 提供内容寻址、分块去重的增量快照仓库
+package snapshot
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// pruneGrace 最近修改过的块不会被清理，避免误删正在进行的备份写入的块
+const pruneGrace = 24 * time.Hour
+
+var idRegexp = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+type NodeType string
+
+const (
+	NodeFile    NodeType = "file"
+	NodeDir     NodeType = "dir"
+	NodeSymlink NodeType = "symlink"
+)
+
+// Node 快照中的文件
+type Node struct {
+	Path    string      `json:"path"` // 相对源目录的 / 分隔路径
+	Type    NodeType    `json:"type"`
+	Mode    fs.FileMode `json:"mode"`
+	ModTime time.Time   `json:"mod_time"`
+	Size    int64       `json:"size"`
+	Link    string      `json:"link,omitempty"`
+	Chunks  []string    `json:"chunks,omitempty"`
+}
+
+// Snapshot 快照
+type Snapshot struct {
+	ID     string    `json:"id"`
+	Target string    `json:"target"` // 备份目标名称
+	Source string    `json:"source"` // 源目录
+	Time   time.Time `json:"time"`
+	Files  int64     `json:"files"` // 文件数
+	Size   int64     `json:"size"`  // 原始数据大小
+	Added  int64     `json:"added"` // 本次新增写入仓库的数据大小（压缩后）
+}
+
+// Repository 快照仓库
+//
+// 目录结构：
+//
+//	chunks/ab/abcdef...  zstd 压缩的数据块，以原始内容的 sha256 命名
+//	trees/<id>           zstd 压缩的 JSON 文件列表
+//	snapshots/<id>.json  快照信息
+type Repository struct {
+	root string
+	enc  *zstd.Encoder
+	dec  *zstd.Decoder
+}
+
+// Open 打开快照仓库，不存在时自动初始化
+func Open(root string) (*Repository, error) {
+	for _, dir := range []string{"chunks", "trees", "snapshots", "tmp"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0700); err != nil {
+			return nil, err
+		}
+	}
+
+	enc, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+	if err != nil {
+		return nil, err
+	}
+	dec, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repository{
+		root: root,
+		enc:  enc,
+		dec:  dec,
+	}, nil
+}
+
+// Close 释放资源
+func (r *Repository) Close() {
+	_ = r.enc.Close()
+	r.dec.Close()
+}
+
+// Backup 为 src 目录创建快照
+// 与该目标上一个快照相比，大小和修改时间未变化的文件不会重新读取
+func (r *Repository) Backup(target, src string) (*Snapshot, error) {
+	src, err := filepath.Abs(src)
+	if err != nil {
+		return nil, err
+	}
+
+	parent := make(map[string]Node)
+	if list, err := r.List(target); err == nil && len(list) > 0 {
+		if nodes, err := r.Nodes(list[0].ID); err == nil {
+			for _, node := range nodes {
+				parent[node.Path] = node
+			}
+		}
+	}
+
+	snap := &Snapshot{
+		ID:     newID(),
+		Target: target,
+		Source: src,
+		Time:   time.Now(),
+	}
+	nodes := make([]Node, 0)
+	err = filepath.WalkDir(src, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if file == src {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, file)
+		if err != nil {
+			return err
+		}
+		node := Node{
+			Path:    filepath.ToSlash(rel),
+			Mode:    info.Mode().Perm(),
+			ModTime: info.ModTime(),
+		}
+
+		switch {
+		case info.IsDir():
+			node.Type = NodeDir
+		case info.Mode()&fs.ModeSymlink != 0:
+			node.Type = NodeSymlink
+			if node.Link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		case info.Mode().IsRegular():
+			node.Type = NodeFile
+			node.Size = info.Size()
+			old, ok := parent[node.Path]
+			if ok && old.Type == NodeFile && old.Size == node.Size && old.ModTime.Equal(node.ModTime) && r.touchChunks(old.Chunks) {
+				node.Chunks = old.Chunks
+			} else {
+				added, chunks, err := r.saveFile(file)
+				if err != nil {
+					return err
+				}
+				node.Chunks = chunks
+				snap.Added += added
+			}
+			snap.Files++
+			snap.Size += node.Size
+		default:
+			// 跳过 socket、设备等特殊文件
+			return nil
+		}
+
+		nodes = append(nodes, node)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 先写文件列表再写快照信息，保证快照可见时数据已完整
+	tree, err := json.Marshal(nodes)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.writeAtomic(filepath.Join(r.root, "trees", snap.ID), r.enc.EncodeAll(tree, nil)); err != nil {
+		return nil, err
+	}
+	meta, err := json.Marshal(snap)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.writeAtomic(filepath.Join(r.root, "snapshots", snap.ID+".json"), meta); err != nil {
+		return nil, err
+	}
+
+	return snap, nil
+}
+
+// List 列出快照，按时间从新到旧排序，target 为空时列出所有快照
+func (r *Repository) List(target string) ([]*Snapshot, error) {
+	entries, err := os.ReadDir(filepath.Join(r.root, "snapshots"))
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*Snapshot, 0)
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok {
+			continue
+		}
+		snap, err := r.Get(id)
+		if err != nil {
+			continue
+		}
+		if target == "" || snap.Target == target {
+			list = append(list, snap)
+		}
+	}
+
+	slices.SortFunc(list, func(a, b *Snapshot) int {
+		return b.Time.Compare(a.Time)
+	})
+
+	return list, nil
+}
+
+// Get 获取快照信息
+func (r *Repository) Get(id string) (*Snapshot, error) {
+	if !idRegexp.MatchString(id) {
+		return nil, fmt.Errorf("invalid snapshot id: %s", id)
+	}
+
+	data, err := os.ReadFile(filepath.Join(r.root, "snapshots", id+".json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("snapshot %s not exists", id)
+		}
+		return nil, err
+	}
+
+	snap := new(Snapshot)
+	if err = json.Unmarshal(data, snap); err != nil {
+		return nil, err
+	}
+
+	return snap, nil
+}
+
+// Nodes 获取快照中的文件列表
+func (r *Repository) Nodes(id string) ([]Node, error) {
+	if _, err := r.Get(id); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(r.root, "trees", id))
+	if err != nil {
+		return nil, err
+	}
+	if data, err = r.dec.DecodeAll(data, nil); err != nil {
+		return nil, err
+	}
+
+	var nodes []Node
+	if err = json.Unmarshal(data, &nodes); err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+// Restore 将快照完整恢复到 dst 目录
+func (r *Repository) Restore(id, dst string) error {
+	return r.restore(id, dst, "")
+}
+
+// RestoreFile 将快照中的单个文件（或目录）恢复到 dst 目录下的相同相对路径
+func (r *Repository) RestoreFile(id, file, dst string) error {
+	file = strings.Trim(path.Clean("/"+filepath.ToSlash(file)), "/")
+	if file == "" {
+		return errors.New("file path is required")
+	}
+
+	return r.restore(id, dst, file)
+}
+
+// Forget 删除快照信息，数据块需要调用 Prune 清理
+func (r *Repository) Forget(id string) error {
+	if _, err := r.Get(id); err != nil {
+		return err
+	}
+
+	if err := os.Remove(filepath.Join(r.root, "snapshots", id+".json")); err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(r.root, "trees", id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// Prune 清理不再被任何快照引用的数据块，返回清理的块数量和大小
+func (r *Repository) Prune() (int, int64, error) {
+	list, err := r.List("")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	used := make(map[string]struct{})
+	for _, snap := range list {
+		nodes, err := r.Nodes(snap.ID)
+		if err != nil {
+			// 文件列表损坏时无法确认引用关系，为安全起见停止清理
+			return 0, 0, fmt.Errorf("failed to read snapshot %s: %w", snap.ID, err)
+		}
+		for _, node := range nodes {
+			for _, chunk := range node.Chunks {
+				used[chunk] = struct{}{}
+			}
+		}
+	}
+
+	count, size := 0, int64(0)
+	err = filepath.WalkDir(filepath.Join(r.root, "chunks"), func(file string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if _, ok := used[d.Name()]; ok {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if time.Since(info.ModTime()) < pruneGrace {
+			return nil
+		}
+		if err = os.Remove(file); err != nil {
+			return err
+		}
+		count++
+		size += info.Size()
+		return nil
+	})
+
+	return count, size, err
+}
+
+// restore 恢复快照，prefix 不为空时只恢复该路径及其子路径
+func (r *Repository) restore(id, dst, prefix string) error {
+	nodes, err := r.Nodes(id)
+	if err != nil {
+		return err
+	}
+
+	if prefix != "" {
+		nodes = slices.DeleteFunc(nodes, func(node Node) bool {
+			return node.Path != prefix && !strings.HasPrefix(node.Path, prefix+"/")
+		})
+		if len(nodes) == 0 {
+			return fmt.Errorf("file %s not found in snapshot %s", prefix, id)
+		}
+	}
+
+	if err = os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+
+	var dirs []Node
+	for _, node := range nodes {
+		target := filepath.Join(dst, filepath.FromSlash(node.Path))
+		if err = os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+
+		switch node.Type {
+		case NodeDir:
+			if err = os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			dirs = append(dirs, node)
+			continue
+		case NodeSymlink:
+			_ = os.Remove(target)
+			if err = os.Symlink(node.Link, target); err != nil {
+				return err
+			}
+			continue
+		case NodeFile:
+			if err = r.restoreFile(node, target); err != nil {
+				return err
+			}
+		}
+
+		if err = os.Chmod(target, node.Mode); err != nil {
+			return err
+		}
+		if err = os.Chtimes(target, node.ModTime, node.ModTime); err != nil {
+			return err
+		}
+	}
+
+	// 目录的权限和时间最后设置，避免被写入文件修改
+	for _, node := range slices.Backward(dirs) {
+		target := filepath.Join(dst, filepath.FromSlash(node.Path))
+		if err = os.Chmod(target, node.Mode); err != nil {
+			return err
+		}
+		if err = os.Chtimes(target, node.ModTime, node.ModTime); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoreFile 从数据块还原单个文件
+func (r *Repository) restoreFile(node Node, target string) error {
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer func(out *os.File) { _ = out.Close() }(out)
+
+	for _, chunk := range node.Chunks {
+		data, err := r.loadChunk(chunk)
+		if err != nil {
+			return fmt.Errorf("failed to restore %s: %w", node.Path, err)
+		}
+		if _, err = out.Write(data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// saveFile 分块保存文件，返回新增数据大小和块列表
+func (r *Repository) saveFile(file string) (int64, []string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func(f *os.File) { _ = f.Close() }(f)
+
+	var added int64
+	chunks := make([]string, 0)
+	c := newChunker(f)
+	for {
+		data, err := c.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+
+		sum := sha256.Sum256(data)
+		hash := hex.EncodeToString(sum[:])
+		n, err := r.saveChunk(hash, data)
+		if err != nil {
+			return 0, nil, err
+		}
+		added += n
+		chunks = append(chunks, hash)
+	}
+
+	return added, chunks, nil
+}
+
+// saveChunk 保存数据块，已存在时只刷新修改时间
+func (r *Repository) saveChunk(hash string, data []byte) (int64, error) {
+	file := r.chunkPath(hash)
+	now := time.Now()
+	if err := os.Chtimes(file, now, now); err == nil {
+		return 0, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return 0, err
+	}
+	compressed := r.enc.EncodeAll(data, nil)
+	if err := r.writeAtomic(file, compressed); err != nil {
+		return 0, err
+	}
+
+	return int64(len(compressed)), nil
+}
+
+// loadChunk 读取并校验数据块
+func (r *Repository) loadChunk(hash string) ([]byte, error) {
+	compressed, err := os.ReadFile(r.chunkPath(hash))
+	if err != nil {
+		return nil, err
+	}
+	data, err := r.dec.DecodeAll(compressed, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != hash {
+		return nil, fmt.Errorf("chunk %s is corrupted", hash)
+	}
+
+	return data, nil
+}
+
+// touchChunks 刷新数据块修改时间，任意块不存在时返回 false
+func (r *Repository) touchChunks(chunks []string) bool {
+	now := time.Now()
+	for _, chunk := range chunks {
+		if err := os.Chtimes(r.chunkPath(chunk), now, now); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *Repository) chunkPath(hash string) string {
+	return filepath.Join(r.root, "chunks", hash[:2], hash)
+}
+
+// writeAtomic 先写入临时文件再重命名，避免中断时留下不完整的文件
+func (r *Repository) writeAtomic(file string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Join(r.root, "tmp"), "write-*")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), file)
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
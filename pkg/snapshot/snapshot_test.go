@@ -0,0 +1,153 @@
+package snapshot
+
+import (
+	"bytes"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SnapshotTestSuite struct {
+	suite.Suite
+	repo *Repository
+	src  string
+}
+
+func TestSnapshotTestSuite(t *testing.T) {
+	suite.Run(t, &SnapshotTestSuite{})
+}
+
+func (s *SnapshotTestSuite) SetupTest() {
+	repo, err := Open(filepath.Join(s.T().TempDir(), "repo"))
+	s.Require().NoError(err)
+	s.repo = repo
+
+	s.src = s.T().TempDir()
+	s.Require().NoError(os.MkdirAll(filepath.Join(s.src, "sub"), 0755))
+	s.Require().NoError(os.WriteFile(filepath.Join(s.src, "index.html"), []byte("AcePanel"), 0644))
+	s.Require().NoError(os.WriteFile(filepath.Join(s.src, "sub", "big.bin"), randomBytes(3<<20), 0600))
+	s.Require().NoError(os.Symlink("index.html", filepath.Join(s.src, "link.html")))
+}
+
+func (s *SnapshotTestSuite) TearDownTest() {
+	s.repo.Close()
+}
+
+func (s *SnapshotTestSuite) TestBackupAndRestore() {
+	snap, err := s.repo.Backup("site", s.src)
+	s.NoError(err)
+	s.Equal(int64(2), snap.Files)
+	s.Positive(snap.Added)
+
+	// 未变化的数据不会重复写入
+	second, err := s.repo.Backup("site", s.src)
+	s.NoError(err)
+	s.Zero(second.Added)
+
+	list, err := s.repo.List("site")
+	s.NoError(err)
+	s.Len(list, 2)
+	s.Equal(second.ID, list[0].ID)
+
+	dst := s.T().TempDir()
+	s.NoError(s.repo.Restore(snap.ID, dst))
+	s.sameFile(filepath.Join(s.src, "index.html"), filepath.Join(dst, "index.html"))
+	s.sameFile(filepath.Join(s.src, "sub", "big.bin"), filepath.Join(dst, "sub", "big.bin"))
+	link, err := os.Readlink(filepath.Join(dst, "link.html"))
+	s.NoError(err)
+	s.Equal("index.html", link)
+	info, err := os.Stat(filepath.Join(dst, "sub", "big.bin"))
+	s.NoError(err)
+	s.Equal(os.FileMode(0600), info.Mode().Perm())
+}
+
+func (s *SnapshotTestSuite) TestRestoreFile() {
+	snap, err := s.repo.Backup("site", s.src)
+	s.NoError(err)
+
+	dst := s.T().TempDir()
+	s.NoError(s.repo.RestoreFile(snap.ID, "/sub/big.bin", dst))
+	s.sameFile(filepath.Join(s.src, "sub", "big.bin"), filepath.Join(dst, "sub", "big.bin"))
+	s.NoFileExists(filepath.Join(dst, "index.html"))
+
+	s.Error(s.repo.RestoreFile(snap.ID, "not-exists", dst))
+	s.Error(s.repo.RestoreFile("../../etc", "index.html", dst))
+}
+
+func (s *SnapshotTestSuite) TestForgetAndPrune() {
+	snap, err := s.repo.Backup("site", s.src)
+	s.NoError(err)
+	s.NoError(s.repo.Forget(snap.ID))
+
+	// 宽限期内的块不会被清理
+	count, _, err := s.repo.Prune()
+	s.NoError(err)
+	s.Zero(count)
+
+	old := time.Now().Add(-2 * pruneGrace)
+	s.NoError(filepath.WalkDir(filepath.Join(s.repo.root, "chunks"), func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		return os.Chtimes(path, old, old)
+	}))
+	count, size, err := s.repo.Prune()
+	s.NoError(err)
+	s.Positive(count)
+	s.Positive(size)
+}
+
+func (s *SnapshotTestSuite) TestChunker() {
+	data := randomBytes(20 << 20)
+	c := newChunker(bytes.NewReader(data))
+
+	var joined []byte
+	for {
+		chunk, err := c.Next()
+		if err != nil {
+			break
+		}
+		s.LessOrEqual(len(chunk), maxChunkSize)
+		joined = append(joined, chunk...)
+	}
+	s.Equal(data, joined)
+}
+
+func (s *SnapshotTestSuite) TestPolicy() {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.Local)
+	var list []*Snapshot
+	for i := range 90 {
+		list = append(list, &Snapshot{ID: newID(), Time: now.AddDate(0, 0, -i)})
+	}
+
+	keep, remove := Policy{Daily: 7, Weekly: 4, Monthly: 3}.Apply(list)
+	var days []string
+	for _, snap := range keep {
+		days = append(days, snap.Time.Format("01-02"))
+	}
+	// 每日：03-31 ~ 03-25；每周：03-31、03-29、03-22、03-15；每月：03-31、02-28、01-31
+	s.Equal([]string{"03-31", "03-30", "03-29", "03-28", "03-27", "03-26", "03-25", "03-22", "03-15", "02-28", "01-31"}, days)
+	s.Len(remove, 90-len(keep))
+
+	keep, remove = Policy{}.Apply(list)
+	s.Len(keep, 90)
+	s.Empty(remove)
+}
+
+func (s *SnapshotTestSuite) sameFile(a, b string) {
+	expected, err := os.ReadFile(a)
+	s.NoError(err)
+	actual, err := os.ReadFile(b)
+	s.NoError(err)
+	s.Equal(expected, actual)
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return b
+}
@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+type Local struct {
+	root string
+}
+
+func NewLocal(root string) (*Local, error) {
+	if root == "" {
+		return nil, errors.New("local storage path is required")
+	}
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, err
+	}
+
+	return &Local{root: filepath.Clean(root)}, nil
+}
+
+func (s *Local) Put(name string, r io.Reader, size int64) error {
+	file, err := join(s.root, name)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer func(out *os.File) { _ = out.Close() }(out)
+
+	_, err = io.Copy(out, r)
+	return err
+}
+
+func (s *Local) Get(name string) (io.ReadCloser, error) {
+	file, err := join(s.root, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(file)
+}
+
+func (s *Local) Delete(name string) error {
+	file, err := join(s.root, name)
+	if err != nil {
+		return err
+	}
+
+	return os.Remove(file)
+}
+
+func (s *Local) Exists(name string) (bool, error) {
+	file, err := join(s.root, name)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err = os.Stat(file); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *Local) List(dir string) ([]File, error) {
+	entries, err := os.ReadDir(joinDir(s.root, dir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []File{}, nil
+		}
+		return nil, err
+	}
+
+	files := make([]File, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, File{
+			Name: entry.Name(),
+			Size: info.Size(),
+			Time: info.ModTime(),
+		})
+	}
+
+	return files, nil
+}
@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+type S3 struct {
+	client *minio.Client
+	bucket string
+	root   string
+}
+
+func NewS3(conf S3Config, root string) (*S3, error) {
+	if conf.Endpoint == "" || conf.Bucket == "" {
+		return nil, errors.New("s3 endpoint and bucket are required")
+	}
+
+	lookup := minio.BucketLookupAuto
+	if conf.PathStyle {
+		lookup = minio.BucketLookupPath
+	}
+	client, err := minio.New(conf.Endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(conf.AccessKey, conf.SecretKey, ""),
+		Secure:       !conf.Insecure,
+		Region:       conf.Region,
+		BucketLookup: lookup,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3{
+		client: client,
+		bucket: conf.Bucket,
+		root:   strings.Trim(root, "/"),
+	}, nil
+}
+
+func (s *S3) Put(name string, r io.Reader, size int64) error {
+	key, err := s.key(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.PutObject(context.Background(), s.bucket, key, r, size, minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	})
+	return err
+}
+
+func (s *S3) Get(name string) (io.ReadCloser, error) {
+	key, err := s.key(name)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := s.client.GetObject(context.Background(), s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// GetObject 是惰性的，先 Stat 一次以便尽早返回不存在等错误
+	if _, err = obj.Stat(); err != nil {
+		_ = obj.Close()
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (s *S3) Delete(name string) error {
+	key, err := s.key(name)
+	if err != nil {
+		return err
+	}
+
+	return s.client.RemoveObject(context.Background(), s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+func (s *S3) Exists(name string) (bool, error) {
+	key, err := s.key(name)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err = s.client.StatObject(context.Background(), s.bucket, key, minio.StatObjectOptions{}); err != nil {
+		if minio.ToErrorResponse(err).Code == minio.NoSuchKey {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *S3) List(dir string) ([]File, error) {
+	prefix := strings.TrimPrefix(joinDir(s.root, dir), "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	files := make([]File, 0)
+	for obj := range s.client.ListObjects(context.Background(), s.bucket, minio.ListObjectsOptions{Prefix: prefix}) {
+		if obj.Err != nil {
+			return nil, obj.Err
+		}
+		// 跳过子目录
+		if strings.HasSuffix(obj.Key, "/") {
+			continue
+		}
+		files = append(files, File{
+			Name: path.Base(obj.Key),
+			Size: obj.Size,
+			Time: obj.LastModified,
+		})
+	}
+
+	return files, nil
+}
+
+// key 计算对象键，S3 的键不以 / 开头
+func (s *S3) key(name string) (string, error) {
+	key, err := join("/"+s.root, name)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimPrefix(key, "/"), nil
+}
@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path"
+
+	"github.com/pkg/sftp"
+	cryptossh "golang.org/x/crypto/ssh"
+
+	"github.com/acepanel/panel/pkg/ssh"
+)
+
+type SFTP struct {
+	conn   *cryptossh.Client
+	client *sftp.Client
+	root   string
+}
+
+func NewSFTP(conf ssh.ClientConfig, root string) (*SFTP, error) {
+	if root == "" {
+		return nil, errors.New("sftp storage path is required")
+	}
+
+	conn, err := ssh.NewSSHClient(conf)
+	if err != nil {
+		return nil, err
+	}
+	client, err := sftp.NewClient(conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return &SFTP{
+		conn:   conn,
+		client: client,
+		root:   path.Clean(root),
+	}, nil
+}
+
+func (s *SFTP) Put(name string, r io.Reader, size int64) error {
+	file, err := join(s.root, name)
+	if err != nil {
+		return err
+	}
+	if err = s.client.MkdirAll(path.Dir(file)); err != nil {
+		return err
+	}
+
+	out, err := s.client.Create(file)
+	if err != nil {
+		return err
+	}
+	defer func(out *sftp.File) { _ = out.Close() }(out)
+
+	_, err = out.ReadFrom(r)
+	return err
+}
+
+func (s *SFTP) Get(name string) (io.ReadCloser, error) {
+	file, err := join(s.root, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Open(file)
+}
+
+func (s *SFTP) Delete(name string) error {
+	file, err := join(s.root, name)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Remove(file)
+}
+
+func (s *SFTP) Exists(name string) (bool, error) {
+	file, err := join(s.root, name)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err = s.client.Stat(file); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *SFTP) List(dir string) ([]File, error) {
+	entries, err := s.client.ReadDir(joinDir(s.root, dir))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []File{}, nil
+		}
+		return nil, err
+	}
+
+	files := make([]File, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		files = append(files, File{
+			Name: entry.Name(),
+			Size: entry.Size(),
+			Time: entry.ModTime(),
+		})
+	}
+
+	return files, nil
+}
+
+func (s *SFTP) Close() error {
+	_ = s.client.Close()
+	return s.conn.Close()
+}
@@ -0,0 +1,107 @@
+// Package storage 提供备份文件的存储后端
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/acepanel/panel/pkg/ssh"
+)
+
+type Type string
+
+const (
+	TypeLocal  Type = "local"
+	TypeS3     Type = "s3"
+	TypeSFTP   Type = "sftp"
+	TypeWebDav Type = "webdav"
+)
+
+// File 存储中的文件
+type File struct {
+	Name string    `json:"name"`
+	Size int64     `json:"size"`
+	Time time.Time `json:"time"`
+}
+
+// Storage 存储后端
+// 所有 name 和 dir 均为相对于存储根目录的 / 分隔路径
+type Storage interface {
+	// Put 写入文件
+	Put(name string, r io.Reader, size int64) error
+	// Get 读取文件
+	Get(name string) (io.ReadCloser, error)
+	// Delete 删除文件
+	Delete(name string) error
+	// Exists 文件是否存在
+	Exists(name string) (bool, error)
+	// List 列出目录下的文件（不含子目录）
+	List(dir string) ([]File, error)
+}
+
+type S3Config struct {
+	Endpoint  string `json:"endpoint"`   // 不带协议的地址，如 s3.amazonaws.com
+	Region    string `json:"region"`     // 区域
+	Bucket    string `json:"bucket"`     // 存储桶
+	AccessKey string `json:"access_key"` // 访问密钥
+	SecretKey string `json:"secret_key"` // 私有密钥
+	Insecure  bool   `json:"insecure"`   // 使用 HTTP 而非 HTTPS
+	PathStyle bool   `json:"path_style"` // 使用路径风格访问（MinIO 等需要）
+}
+
+type WebDavConfig struct {
+	URL      string `json:"url"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Config 存储配置，根据类型使用对应字段
+type Config struct {
+	Path   string           `json:"path"` // 根目录
+	S3     S3Config         `json:"s3"`
+	SFTP   ssh.ClientConfig `json:"sftp"`
+	WebDav WebDavConfig     `json:"webdav"`
+}
+
+// New 根据类型创建存储后端
+func New(typ Type, conf Config) (Storage, error) {
+	switch typ {
+	case TypeLocal:
+		return NewLocal(conf.Path)
+	case TypeS3:
+		return NewS3(conf.S3, conf.Path)
+	case TypeSFTP:
+		return NewSFTP(conf.SFTP, conf.Path)
+	case TypeWebDav:
+		return NewWebDav(conf.WebDav, conf.Path)
+	}
+
+	return nil, fmt.Errorf("unsupported storage type: %s", typ)
+}
+
+// Close 关闭存储后端持有的连接（如果有）
+func Close(s Storage) error {
+	if c, ok := s.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// join 拼接根目录和相对路径，并阻止路径穿越
+func join(root, name string) (string, error) {
+	name = path.Clean("/" + strings.ReplaceAll(name, "\\", "/"))
+	if name == "/" {
+		return "", errors.New("invalid file name")
+	}
+
+	return path.Join(root, name), nil
+}
+
+// joinDir 拼接根目录和相对目录，空目录表示根目录
+func joinDir(root, dir string) string {
+	return path.Join(root, path.Clean("/"+strings.ReplaceAll(dir, "\\", "/")))
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StorageTestSuite struct {
+	suite.Suite
+}
+
+func TestStorageTestSuite(t *testing.T) {
+	suite.Run(t, &StorageTestSuite{})
+}
+
+func (s *StorageTestSuite) TestJoin() {
+	name, err := join("/backup", "website/a.zip")
+	s.NoError(err)
+	s.Equal("/backup/website/a.zip", name)
+
+	name, err = join("/backup", "../../etc/passwd")
+	s.NoError(err)
+	s.Equal("/backup/etc/passwd", name)
+
+	_, err = join("/backup", "")
+	s.Error(err)
+
+	s.Equal("/backup", joinDir("/backup", ""))
+	s.Equal("/backup/mysql", joinDir("/backup", "../mysql"))
+}
+
+func (s *StorageTestSuite) TestLocal() {
+	local, err := New(TypeLocal, Config{Path: s.T().TempDir()})
+	s.NoError(err)
+
+	data := []byte("AcePanel")
+	s.NoError(local.Put("website/test.zip", bytes.NewReader(data), int64(len(data))))
+
+	exists, err := local.Exists("website/test.zip")
+	s.NoError(err)
+	s.True(exists)
+
+	files, err := local.List("website")
+	s.NoError(err)
+	s.Len(files, 1)
+	s.Equal("test.zip", files[0].Name)
+	s.Equal(int64(len(data)), files[0].Size)
+
+	reader, err := local.Get("website/test.zip")
+	s.NoError(err)
+	content, err := io.ReadAll(reader)
+	s.NoError(err)
+	s.NoError(reader.Close())
+	s.Equal(data, content)
+
+	s.NoError(local.Delete("website/test.zip"))
+	exists, err = local.Exists("website/test.zip")
+	s.NoError(err)
+	s.False(exists)
+
+	files, err = local.List("not-exists")
+	s.NoError(err)
+	s.Empty(files)
+}
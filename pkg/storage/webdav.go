@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"path"
+	"time"
+
+	"github.com/studio-b12/gowebdav"
+)
+
+type WebDav struct {
+	client *gowebdav.Client
+	root   string
+}
+
+func NewWebDav(conf WebDavConfig, root string) (*WebDav, error) {
+	if conf.URL == "" {
+		return nil, errors.New("webdav url is required")
+	}
+
+	client := gowebdav.NewClient(conf.URL, conf.Username, conf.Password)
+	client.SetTimeout(10 * time.Minute)
+	if err := client.Connect(); err != nil {
+		return nil, err
+	}
+
+	return &WebDav{
+		client: client,
+		root:   path.Clean("/" + root),
+	}, nil
+}
+
+func (s *WebDav) Put(name string, r io.Reader, size int64) error {
+	file, err := join(s.root, name)
+	if err != nil {
+		return err
+	}
+	if err = s.client.MkdirAll(path.Dir(file), 0700); err != nil {
+		return err
+	}
+
+	return s.client.WriteStreamWithLength(file, r, size, 0600)
+}
+
+func (s *WebDav) Get(name string) (io.ReadCloser, error) {
+	file, err := join(s.root, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.ReadStream(file)
+}
+
+func (s *WebDav) Delete(name string) error {
+	file, err := join(s.root, name)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Remove(file)
+}
+
+func (s *WebDav) Exists(name string) (bool, error) {
+	file, err := join(s.root, name)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err = s.client.Stat(file); err != nil {
+		if gowebdav.IsErrNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *WebDav) List(dir string) ([]File, error) {
+	entries, err := s.client.ReadDir(joinDir(s.root, dir))
+	if err != nil {
+		if gowebdav.IsErrNotFound(err) {
+			return []File{}, nil
+		}
+		return nil, err
+	}
+
+	files := make([]File, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		files = append(files, File{
+			Name: entry.Name(),
+			Size: entry.Size(),
+			Time: entry.ModTime(),
+		})
+	}
+
+	return files, nil
+}
@@ -1,6 +1,9 @@
 package systemctl
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/acepanel/panel/pkg/shell"
@@ -12,6 +15,29 @@ func Status(name string) (bool, error) {
 	return output == "active", nil
 }
 
+// Usage 获取运行中服务的累计 CPU 时间和内存占用，未开启资源统计的项为 0
+func Usage(name string) (time.Duration, uint64, error) {
+	output, err := shell.Execf("systemctl show '%s' -p ActiveState -p CPUUsageNSec -p MemoryCurrent", name)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	props := make(map[string]string)
+	for line := range strings.Lines(output) {
+		if key, value, ok := strings.Cut(strings.TrimSpace(line), "="); ok {
+			props[key] = value
+		}
+	}
+	if props["ActiveState"] != "active" {
+		return 0, 0, fmt.Errorf("service %s is not active", name)
+	}
+
+	// 未开启统计时值为 [not set]
+	cpu, _ := strconv.ParseUint(props["CPUUsageNSec"], 10, 64)
+	memory, _ := strconv.ParseUint(props["MemoryCurrent"], 10, 64)
+	return time.Duration(cpu), memory, nil
+}
+
 // IsEnabled 服务是否启用
 func IsEnabled(name string) (bool, error) {
 	out, _ := shell.Execf("systemctl is-enabled '%s'", name) // 不判断错误，因为 is-enabled 在服务禁用时会返回 1
@@ -3,8 +3,15 @@ package types
 import "time"
 
 type BackupFile struct {
-	Name string    `json:"name"`
-	Path string    `json:"path"`
-	Size string    `json:"size"`
-	Time time.Time `json:"time"`
+	Name      string    `json:"name"`
+	Path      string    `json:"path"`
+	Size      string    `json:"size"`
+	Time      time.Time `json:"time"`
+	Target    string    `json:"target,omitempty"` // 快照备份的目标名称
+	Encrypted bool      `json:"encrypted"`        // 是否为加密备份
+
+	Checksum      string     `json:"checksum,omitempty"`       // sha256 校验和
+	Verify        string     `json:"verify"`                   // 校验结果：passed 通过，failed 失败，为空表示未校验
+	VerifyMessage string     `json:"verify_message,omitempty"` // 校验失败原因
+	VerifiedAt    *time.Time `json:"verified_at,omitempty"`
 }
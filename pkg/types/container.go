@@ -39,3 +39,11 @@ type ContainerContainerNetwork struct {
 	IPRange string `form:"ip_range" json:"ip_range"`
 	Subnet  string `form:"subnet" json:"subnet"`
 }
+
+// ContainerUsage 容器资源占用
+type ContainerUsage struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	CPU    uint64 `json:"cpu"`    // 累计 CPU 时间，单位：纳秒
+	Memory uint64 `json:"memory"` // 不含页缓存的内存占用
+}
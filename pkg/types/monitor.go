@@ -1,19 +1,26 @@
 package types
 
+// 汇总数据中带 Min、Max 的字段为区间内的最小值和最大值，其余为平均值
+
 type Load struct {
-	Load1  []float64 `json:"load1"`
-	Load5  []float64 `json:"load5"`
-	Load15 []float64 `json:"load15"`
+	Load1    []float64 `json:"load1"`
+	Load5    []float64 `json:"load5"`
+	Load15   []float64 `json:"load15"`
+	Load1Max []float64 `json:"load1_max,omitempty"`
 }
 
 type CPU struct {
 	Percent []string `json:"percent"`
+	Min     []string `json:"min,omitempty"`
+	Max     []string `json:"max,omitempty"`
 }
 
 type Mem struct {
 	Total     string   `json:"total"`
 	Available []string `json:"available"`
 	Used      []string `json:"used"`
+	UsedMin   []string `json:"used_min,omitempty"`
+	UsedMax   []string `json:"used_max,omitempty"`
 }
 
 type SWAP struct {
@@ -23,17 +30,32 @@ type SWAP struct {
 }
 
 type Network struct {
-	Sent []string `json:"sent"`
-	Recv []string `json:"recv"`
-	Tx   []string `json:"tx"`
-	Rx   []string `json:"rx"`
+	Sent  []string `json:"sent"`
+	Recv  []string `json:"recv"`
+	Tx    []string `json:"tx"`
+	Rx    []string `json:"rx"`
+	TxMax []string `json:"tx_max,omitempty"`
+	RxMax []string `json:"rx_max,omitempty"`
+}
+
+// DiskIO 单个设备的读写速率，缺少数据的时间点为 -
+type DiskIO struct {
+	Read      []string `json:"read"`
+	Write     []string `json:"write"`
+	ReadIOPS  []string `json:"read_iops"`
+	WriteIOPS []string `json:"write_iops"`
+	ReadMax   []string `json:"read_max,omitempty"`
+	WriteMax  []string `json:"write_max,omitempty"`
 }
 
 type MonitorData struct {
-	Times []string `json:"times"`
-	Load  Load     `json:"load"`
-	CPU   CPU      `json:"cpu"`
-	Mem   Mem      `json:"mem"`
-	SWAP  SWAP     `json:"swap"`
-	Net   Network  `json:"net"`
+	Resolution uint                `json:"resolution"` // 数据精度，单位：秒
+	Times      []string            `json:"times"`
+	Load       Load                `json:"load"`
+	CPU        CPU                 `json:"cpu"`
+	Mem        Mem                 `json:"mem"`
+	SWAP       SWAP                `json:"swap"`
+	Net        Network             `json:"net"`
+	DiskIO     map[string]*DiskIO  `json:"disk_io"`    // 按设备
+	DiskUsage  map[string][]string `json:"disk_usage"` // 按挂载点，使用率百分比
 }
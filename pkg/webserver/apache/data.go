@@ -6,6 +6,25 @@ const DisablePagePath = "/opt/ace/server/apache/stop"
 // SitesPath 网站目录
 const SitesPath = "/opt/ace/sites"
 
+// ErrorPageConf 新建网站的 404 页面配置
+const ErrorPageConf = "ErrorDocument 404 /404.html\n"
+
+// StaticConf 新建 PHP 网站的静态资源缓存和敏感文件配置
+const StaticConf = `# browser cache
+<IfModule mod_expires.c>
+    <FilesMatch "\.(bmp|jpg|jpeg|png|gif|svg|ico|tiff|webp|avif|heif|heic|jxl)$">
+        ExpiresActive On
+        ExpiresDefault "access plus 30 days"
+    </FilesMatch>
+    <FilesMatch "\.(js|css|ttf|otf|woff|woff2|eot)$">
+        ExpiresActive On
+        ExpiresDefault "access plus 6 hours"
+    </FilesMatch>
+</IfModule>
+# deny sensitive files
+RedirectMatch 404 ^/(\.user.ini|\.htaccess|\.git|\.svn|\.env)
+`
+
 // 配置文件序号范围
 const (
 	RedirectStartNum = 100 // 重定向配置起始序号 (100-199)
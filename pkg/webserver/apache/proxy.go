@@ -2,9 +2,11 @@ package apache
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -18,6 +20,16 @@ var proxyFilePattern = regexp.MustCompile(`^(\d{3})-proxy\.conf$`)
 // balancerFilePattern 匹配负载均衡配置文件名
 var balancerFilePattern = regexp.MustCompile(`^(\d{3})-balancer-(.+)\.conf$`)
 
+// corsHeaders 跨域配置使用的响应头，解析时不作为自定义响应头
+var corsHeaders = []string{
+	"Access-Control-Allow-Origin",
+	"Access-Control-Allow-Methods",
+	"Access-Control-Allow-Headers",
+	"Access-Control-Expose-Headers",
+	"Access-Control-Allow-Credentials",
+	"Access-Control-Max-Age",
+}
+
 // parseProxyFiles 从 site 目录解析所有代理配置
 func parseProxyFiles(siteDir string) ([]types.Proxy, error) {
 	entries, err := os.ReadDir(siteDir)
@@ -70,16 +82,52 @@ func parseProxyFile(filePath string) (*types.Proxy, error) {
 	}
 
 	// 解析 ProxyPass 指令
-	// ProxyPass / http://backend/
-	proxyPassPattern := regexp.MustCompile(`ProxyPass\s+(\S+)\s+(\S+)`)
-	if matches := proxyPassPattern.FindStringSubmatch(contentStr); matches != nil {
-		proxy.Location = matches[1]
-		proxy.Pass = matches[2]
+	// ProxyPass /api http://backend/api connectiontimeout=5 timeout=60 upgrade=websocket
+	// ProxyPassMatch "^/api/v[0-9]+/" http://backend
+	proxyPassPattern := regexp.MustCompile(`ProxyPass(?:Match)?\s+("[^"]*"|\S+)\s+(\S+)(.*)`)
+	matches := proxyPassPattern.FindStringSubmatch(contentStr)
+	if matches == nil {
+		return nil, nil
+	}
+	target := matches[2]
+	params := strings.Fields(matches[3])
+	proxy.Location = strings.Trim(matches[1], `"`)
+	proxy.Pass = target
+
+	// 注释中保存了原始的匹配路径和代理地址
+	// # Reverse proxy: ^~ /api -> http://backend
+	if cm := regexp.MustCompile(`# Reverse proxy: (.+) -> (\S+)`).FindStringSubmatch(contentStr); cm != nil {
+		proxy.Location = cm[1]
+		proxy.Pass = cm[2]
+	}
+	if path, regex := proxyLocation(proxy.Location); !regex && path != "/" {
+		proxy.StripPrefix = target == proxyTarget(proxy.Pass, path, false, true)
+	}
+
+	for _, param := range params {
+		key, value, _ := strings.Cut(param, "=")
+		switch key {
+		case "connectiontimeout":
+			seconds, _ := strconv.Atoi(value)
+			proxy.ConnectTimeout = time.Duration(seconds) * time.Second
+		case "timeout":
+			seconds, _ := strconv.Atoi(value)
+			proxy.ReadTimeout = time.Duration(seconds) * time.Second
+			proxy.SendTimeout = proxy.ReadTimeout
+		case "upgrade":
+			proxy.WebSocket = value == "websocket"
+		}
 	}
 
 	// 解析 ProxyPreserveHost
 	_ = regexp.MustCompile(`ProxyPreserveHost\s+On`).MatchString(contentStr)
 
+	// 解析 Location 块中的自定义头和跨域配置
+	if lm := regexp.MustCompile(`(?s)<Location(?:Match)?\s+[^>]*>(.*?)</Location(?:Match)?>`).FindStringSubmatch(contentStr); lm != nil {
+		parseLocationHeaders(proxy, lm[1])
+		contentStr = strings.Replace(contentStr, lm[0], "", 1)
+	}
+
 	// 解析 RequestHeader set Host
 	hostPattern := regexp.MustCompile(`RequestHeader\s+set\s+Host\s+"([^"]+)"`)
 	if matches := hostPattern.FindStringSubmatch(contentStr); matches != nil {
@@ -122,8 +170,63 @@ func parseProxyFile(filePath string) (*types.Proxy, error) {
 	return proxy, nil
 }
 
+// parseLocationHeaders 解析 Location 块中的自定义请求头、响应头和跨域配置
+func parseLocationHeaders(proxy *types.Proxy, content string) {
+	headerPattern := regexp.MustCompile(`(?m)^[ \t]*(RequestHeader|Header)[ \t]+(always[ \t]+)?(set|unset)[ \t]+(\S+)(?:[ \t]+("(?:[^"\\]|\\.)*"|\S+))?(.*)$`)
+	cors := make(map[string]string)
+	for _, hm := range headerPattern.FindAllStringSubmatch(content, -1) {
+		typ, action, name, value := hm[1], hm[3], hm[4], unquote(hm[5])
+		headers := &proxy.RequestHeaders
+		if typ == "Header" {
+			if slices.Contains(corsHeaders, name) {
+				cors[name] = value
+				continue
+			}
+			if name == "Vary" {
+				continue
+			}
+			headers = &proxy.ResponseHeaders
+		}
+		if strings.EqualFold(name, "Host") {
+			continue
+		}
+		if action == "unset" {
+			headers.Remove = append(headers.Remove, name)
+			continue
+		}
+		if headers.Set == nil {
+			headers.Set = make(map[string]string)
+		}
+		headers.Set[name] = value
+	}
+
+	origin, ok := cors["Access-Control-Allow-Origin"]
+	if !ok {
+		return
+	}
+	proxy.CORS = new(types.ProxyCORS)
+	if origin == "*" {
+		proxy.CORS.Origins = []string{"*"}
+	} else if om := regexp.MustCompile(`SetEnvIf\s+Origin\s+"\^\((.*)\)\$"`).FindStringSubmatch(content); om != nil {
+		for _, item := range strings.Split(om[1], "|") {
+			proxy.CORS.Origins = append(proxy.CORS.Origins, regexp.MustCompile(`\\(.)`).ReplaceAllString(item, "$1"))
+		}
+	}
+	proxy.CORS.Methods = splitList(cors["Access-Control-Allow-Methods"])
+	proxy.CORS.Headers = splitList(cors["Access-Control-Allow-Headers"])
+	proxy.CORS.ExposeHeaders = splitList(cors["Access-Control-Expose-Headers"])
+	proxy.CORS.Credentials = cors["Access-Control-Allow-Credentials"] == "true"
+	proxy.CORS.MaxAge, _ = strconv.Atoi(cors["Access-Control-Max-Age"])
+}
+
 // writeProxyFiles 将代理配置写入文件
 func writeProxyFiles(siteDir string, proxies []types.Proxy) error {
+	for _, proxy := range proxies {
+		if err := proxy.Validate(); err != nil {
+			return err
+		}
+	}
+
 	// 删除现有的代理配置文件 (200-299)
 	if err := clearProxyFiles(siteDir); err != nil {
 		return err
@@ -194,9 +297,33 @@ func generateProxyConfig(proxy types.Proxy) string {
 	// 启用代理模块
 	sb.WriteString("<IfModule mod_proxy.c>\n")
 
+	// ProxyPass 参数
+	var params []string
+	if proxy.ConnectTimeout > 0 {
+		params = append(params, fmt.Sprintf("connectiontimeout=%d", int(proxy.ConnectTimeout.Seconds())))
+	}
+	// Apache 不区分读写超时，取两者中较大的值
+	if timeout := max(proxy.ReadTimeout, proxy.SendTimeout); timeout > 0 {
+		params = append(params, fmt.Sprintf("timeout=%d", int(timeout.Seconds())))
+	}
+	if proxy.WebSocket {
+		params = append(params, "upgrade=websocket")
+	}
+	suffix := ""
+	if len(params) > 0 {
+		suffix = " " + strings.Join(params, " ")
+	}
+
 	// ProxyPass 和 ProxyPassReverse
-	sb.WriteString(fmt.Sprintf("    ProxyPass %s %s\n", location, proxy.Pass))
-	sb.WriteString(fmt.Sprintf("    ProxyPassReverse %s %s\n", location, proxy.Pass))
+	path, regex := proxyLocation(location)
+	target := proxyTarget(proxy.Pass, path, regex, proxy.StripPrefix)
+	if regex {
+		sb.WriteString(fmt.Sprintf("    ProxyPassMatch \"%s\" %s%s\n", path, target, suffix))
+		sb.WriteString(fmt.Sprintf("    ProxyPassReverse / %s\n", target))
+	} else {
+		sb.WriteString(fmt.Sprintf("    ProxyPass %s %s%s\n", path, target, suffix))
+		sb.WriteString(fmt.Sprintf("    ProxyPassReverse %s %s\n", path, target))
+	}
 
 	// Host 配置
 	if proxy.Host != "" {
@@ -246,11 +373,142 @@ func generateProxyConfig(proxy types.Proxy) string {
 		sb.WriteString("    </IfModule>\n")
 	}
 
+	// 自定义头和跨域配置仅作用于匹配的路径
+	if len(proxy.RequestHeaders.Set) > 0 || len(proxy.RequestHeaders.Remove) > 0 ||
+		len(proxy.ResponseHeaders.Set) > 0 || len(proxy.ResponseHeaders.Remove) > 0 || proxy.CORS != nil {
+		if regex {
+			sb.WriteString(fmt.Sprintf("    <LocationMatch \"%s\">\n", path))
+		} else {
+			sb.WriteString(fmt.Sprintf("    <Location %s>\n", path))
+		}
+		for _, name := range slices.Sorted(maps.Keys(proxy.RequestHeaders.Set)) {
+			if !strings.EqualFold(name, "Host") {
+				sb.WriteString(fmt.Sprintf("        RequestHeader set %s %s\n", name, quote(proxy.RequestHeaders.Set[name])))
+			}
+		}
+		for _, name := range proxy.RequestHeaders.Remove {
+			if !strings.EqualFold(name, "Host") {
+				sb.WriteString(fmt.Sprintf("        RequestHeader unset %s\n", name))
+			}
+		}
+		for _, name := range proxy.ResponseHeaders.Remove {
+			sb.WriteString(fmt.Sprintf("        Header unset %s\n", name))
+		}
+		for _, name := range slices.Sorted(maps.Keys(proxy.ResponseHeaders.Set)) {
+			sb.WriteString(fmt.Sprintf("        Header always set %s %s\n", name, quote(proxy.ResponseHeaders.Set[name])))
+		}
+		if proxy.CORS != nil {
+			sb.WriteString(generateCORSConfig(proxy.CORS))
+		}
+		if regex {
+			sb.WriteString("    </LocationMatch>\n")
+		} else {
+			sb.WriteString("    </Location>\n")
+		}
+	}
+
 	sb.WriteString("</IfModule>\n")
 
 	return sb.String()
 }
 
+// generateCORSConfig 生成跨域配置，来源不匹配时不返回 Access-Control-Allow-Origin，预检请求直接返回 204
+func generateCORSConfig(cors *types.ProxyCORS) string {
+	var sb strings.Builder
+
+	if len(cors.Origins) == 0 || slices.Contains(cors.Origins, "*") {
+		sb.WriteString("        Header always set Access-Control-Allow-Origin \"*\"\n")
+	} else {
+		origins := make([]string, 0, len(cors.Origins))
+		for _, origin := range cors.Origins {
+			origins = append(origins, regexp.QuoteMeta(origin))
+		}
+		sb.WriteString(fmt.Sprintf("        SetEnvIf Origin \"^(%s)$\" CORS_ORIGIN=$0\n", strings.Join(origins, "|")))
+		sb.WriteString("        Header always set Access-Control-Allow-Origin \"%{CORS_ORIGIN}e\" env=CORS_ORIGIN\n")
+		sb.WriteString("        Header always merge Vary Origin\n")
+	}
+	if len(cors.Methods) > 0 {
+		sb.WriteString(fmt.Sprintf("        Header always set Access-Control-Allow-Methods \"%s\"\n", strings.Join(cors.Methods, ", ")))
+	}
+	if len(cors.Headers) > 0 {
+		sb.WriteString(fmt.Sprintf("        Header always set Access-Control-Allow-Headers \"%s\"\n", strings.Join(cors.Headers, ", ")))
+	}
+	if len(cors.ExposeHeaders) > 0 {
+		sb.WriteString(fmt.Sprintf("        Header always set Access-Control-Expose-Headers \"%s\"\n", strings.Join(cors.ExposeHeaders, ", ")))
+	}
+	if cors.Credentials {
+		sb.WriteString("        Header always set Access-Control-Allow-Credentials \"true\"\n")
+	}
+	if cors.MaxAge > 0 {
+		sb.WriteString(fmt.Sprintf("        Header always set Access-Control-Max-Age \"%d\"\n", cors.MaxAge))
+	}
+	sb.WriteString("        RewriteEngine On\n")
+	sb.WriteString("        RewriteCond %{REQUEST_METHOD} OPTIONS\n")
+	sb.WriteString("        RewriteRule ^ - [R=204,L]\n")
+
+	return sb.String()
+}
+
+// proxyLocation 将 Nginx 风格的 location 转换为 Apache 的路径或正则
+func proxyLocation(location string) (string, bool) {
+	modifier, path, found := strings.Cut(strings.TrimSpace(location), " ")
+	if !found {
+		return modifier, false
+	}
+	path = strings.TrimSpace(path)
+
+	switch modifier {
+	case "~":
+		return path, true
+	case "~*":
+		return "(?i)" + path, true
+	default:
+		// 前缀匹配和精确匹配，包括 ^~ 和 =
+		return path, false
+	}
+}
+
+// proxyTarget 生成 ProxyPass 的目标地址
+// Apache 会将匹配的路径替换为目标地址，不去除前缀时需要在目标地址后补上匹配的路径
+// ProxyPassMatch 的目标地址不含反向引用时会自动追加请求路径
+func proxyTarget(pass, path string, regex, stripPrefix bool) string {
+	base := strings.TrimSuffix(pass, "/")
+	switch {
+	case regex:
+		return base
+	case path == "/" || !stripPrefix:
+		return base + path
+	case strings.HasSuffix(path, "/"):
+		return base + "/"
+	default:
+		return base
+	}
+}
+
+// quote 为指令参数加上双引号
+func quote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// unquote 去掉指令参数的双引号
+func unquote(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return strings.NewReplacer(`\\`, `\`, `\"`, `"`).Replace(value[1 : len(value)-1])
+	}
+	return value
+}
+
+// splitList 拆分逗号分隔的列表
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 // parseBalancerFiles 从 shared 目录解析所有负载均衡配置（Apache 的 upstream 等价物）
 func parseBalancerFiles(sharedDir string) (map[string]types.Upstream, error) {
 	entries, err := os.ReadDir(sharedDir)
@@ -305,6 +563,12 @@ func parseBalancerFile(filePath string) (*types.Upstream, error) {
 	//     ProxySet lbmethod=byrequests
 	// </Proxy>
 
+	// 解析健康检查配置
+	// # Health check: type=http path=/health fails=3
+	if hm := regexp.MustCompile(`# Health check: (.+)`).FindStringSubmatch(contentStr); hm != nil {
+		upstream.HealthCheck = types.ParseUpstreamHealthCheck(hm[1])
+	}
+
 	// 解析 BalancerMember
 	memberPattern := regexp.MustCompile(`BalancerMember\s+(\S+)(?:\s+(.+))?`)
 	memberMatches := memberPattern.FindAllStringSubmatch(contentStr, -1)
@@ -314,7 +578,14 @@ func parseBalancerFile(filePath string) (*types.Upstream, error) {
 		if len(mm) > 2 {
 			options = strings.TrimSpace(mm[2])
 		}
-		upstream.Servers[addr] = options
+		var server types.UpstreamServer
+		upstream.Servers[addr], server = parseMemberOptions(options)
+		if server != (types.UpstreamServer{}) {
+			if upstream.ServerOptions == nil {
+				upstream.ServerOptions = make(map[string]types.UpstreamServer)
+			}
+			upstream.ServerOptions[addr] = server
+		}
 	}
 
 	// 解析负载均衡方法
@@ -341,6 +612,28 @@ func parseBalancerFile(filePath string) (*types.Upstream, error) {
 	return upstream, nil
 }
 
+// parseMemberOptions 从 BalancerMember 参数中取出失败判定和状态参数，返回其余参数
+// retry 对应 fail_timeout，status=+H 对应 backup，status=+D 对应 down
+func parseMemberOptions(options string) (string, types.UpstreamServer) {
+	var server types.UpstreamServer
+	var others []string
+	for _, option := range strings.Fields(options) {
+		key, value, _ := strings.Cut(option, "=")
+		switch {
+		case key == "retry":
+			seconds, _ := strconv.Atoi(value)
+			server.FailTimeout = time.Duration(seconds) * time.Second
+		case key == "status" && regexp.MustCompile(`^\+[HD]+$`).MatchString(value):
+			server.Backup = strings.Contains(value, "H")
+			server.Down = strings.Contains(value, "D")
+		default:
+			others = append(others, option)
+		}
+	}
+
+	return strings.Join(others, " "), server
+}
+
 // writeBalancerFiles 将负载均衡配置写入文件
 func writeBalancerFiles(sharedDir string, upstreams map[string]types.Upstream) error {
 	// 删除现有的负载均衡配置文件
@@ -390,16 +683,45 @@ func clearBalancerFiles(sharedDir string) error {
 	return nil
 }
 
+// formatMemberOptions 生成 BalancerMember 的失败判定和状态参数
+// Apache 没有失败次数的配置，MaxFails 不生成配置
+func formatMemberOptions(server types.UpstreamServer) string {
+	var options []string
+	if server.FailTimeout > 0 {
+		options = append(options, fmt.Sprintf("retry=%d", int(server.FailTimeout.Seconds())))
+	}
+	status := ""
+	if server.Backup {
+		status += "H"
+	}
+	if server.Down {
+		status += "D"
+	}
+	if status != "" {
+		options = append(options, "status=+"+status)
+	}
+
+	return strings.Join(options, " ")
+}
+
 // generateBalancerConfig 生成负载均衡配置内容
 func generateBalancerConfig(name string, upstream types.Upstream) string {
 	var sb strings.Builder
 
 	sb.WriteString(fmt.Sprintf("# Load balancer: %s\n", name))
+	if upstream.HealthCheck != nil {
+		sb.WriteString(fmt.Sprintf("# Health check: %s\n", upstream.HealthCheck))
+	}
 	sb.WriteString("<IfModule mod_proxy_balancer.c>\n")
 	sb.WriteString(fmt.Sprintf("    <Proxy balancer://%s>\n", name))
 
-	// 服务器列表
-	for addr, options := range upstream.Servers {
+	// 服务器列表，类型化的参数覆盖 Servers 中的同名参数
+	for _, addr := range slices.Sorted(maps.Keys(upstream.Servers)) {
+		options, server := parseMemberOptions(upstream.Servers[addr])
+		if typed, ok := upstream.ServerOptions[addr]; ok {
+			server = typed
+		}
+		options = strings.TrimSpace(options + " " + formatMemberOptions(server))
 		if options != "" {
 			sb.WriteString(fmt.Sprintf("        BalancerMember %s %s\n", addr, options))
 		} else {
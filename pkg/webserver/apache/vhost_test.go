@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
@@ -530,6 +531,61 @@ func (s *ProxyVhostTestSuite) TestProxyConfig() {
 	s.Contains(string(content), "example.com")
 }
 
+func (s *ProxyVhostTestSuite) TestProxyRules() {
+	proxies := []types.Proxy{
+		{
+			Location:       "^~ /api/",
+			Pass:           "http://backend:8080/v1",
+			StripPrefix:    true,
+			WebSocket:      true,
+			ConnectTimeout: 5 * time.Second,
+			ReadTimeout:    time.Minute,
+			SendTimeout:    time.Minute,
+			Replaces:       map[string]string{},
+			RequestHeaders: types.ProxyHeaders{
+				Set:    map[string]string{"X-Api-Key": `se"cret`},
+				Remove: []string{"Cookie"},
+			},
+			ResponseHeaders: types.ProxyHeaders{
+				Set:    map[string]string{"X-Frame-Options": "DENY"},
+				Remove: []string{"X-Powered-By"},
+			},
+			CORS: &types.ProxyCORS{
+				Origins:       []string{"https://example.com", "https://app.example.org"},
+				Methods:       []string{"GET", "POST"},
+				Headers:       []string{"Content-Type", "Authorization"},
+				ExposeHeaders: []string{"X-Request-Id"},
+				Credentials:   true,
+				MaxAge:        86400,
+			},
+		},
+		{
+			Location: "/static/",
+			Pass:     "http://backend:8080",
+			Replaces: map[string]string{},
+		},
+	}
+	s.NoError(s.vhost.SetProxies(proxies))
+
+	siteDir := filepath.Join(s.configDir, "site")
+	content, err := os.ReadFile(filepath.Join(siteDir, "200-proxy.conf"))
+	s.NoError(err)
+	s.Contains(string(content), "ProxyPass /api/ http://backend:8080/v1/ connectiontimeout=5 timeout=60 upgrade=websocket")
+	s.Contains(string(content), "<Location /api/>")
+	s.Contains(string(content), `RequestHeader set X-Api-Key "se\"cret"`)
+	s.Contains(string(content), "RequestHeader unset Cookie")
+	s.Contains(string(content), "Header unset X-Powered-By")
+	s.Contains(string(content), `Header always set X-Frame-Options "DENY"`)
+	s.Contains(string(content), `SetEnvIf Origin "^(https://example\.com|https://app\.example\.org)$" CORS_ORIGIN=$0`)
+	s.Contains(string(content), "RewriteRule ^ - [R=204,L]")
+
+	content, err = os.ReadFile(filepath.Join(siteDir, "201-proxy.conf"))
+	s.NoError(err)
+	s.Contains(string(content), "ProxyPass /static/ http://backend:8080/static/")
+
+	s.Equal(proxies, s.vhost.Proxies())
+}
+
 func (s *ProxyVhostTestSuite) TestClearProxies() {
 	proxies := []types.Proxy{
 		{Location: "/", Pass: "http://backend/"},
@@ -570,6 +626,38 @@ func (s *ProxyVhostTestSuite) TestUpstreams() {
 	s.Contains(got, "backend")
 }
 
+func (s *ProxyVhostTestSuite) TestUpstreamHealth() {
+	upstreams := map[string]types.Upstream{
+		"backend": {
+			Servers: map[string]string{
+				"http://127.0.0.1:8080": "loadfactor=5",
+				"http://127.0.0.1:8081": "",
+				"http://127.0.0.1:8082": "",
+			},
+			ServerOptions: map[string]types.UpstreamServer{
+				"http://127.0.0.1:8080": {FailTimeout: 30 * time.Second},
+				"http://127.0.0.1:8081": {Down: true},
+				"http://127.0.0.1:8082": {Backup: true},
+			},
+			HealthCheck: &types.UpstreamHealthCheck{
+				Type:    "tcp",
+				Timeout: 2 * time.Second,
+				Fails:   2,
+			},
+		},
+	}
+	s.NoError(s.vhost.SetUpstreams(upstreams))
+
+	content, err := os.ReadFile(filepath.Join(s.configDir, "shared", "100-balancer-backend.conf"))
+	s.NoError(err)
+	s.Contains(string(content), "# Health check: type=tcp timeout=2s fails=2")
+	s.Contains(string(content), "BalancerMember http://127.0.0.1:8080 loadfactor=5 retry=30")
+	s.Contains(string(content), "BalancerMember http://127.0.0.1:8081 status=+D")
+	s.Contains(string(content), "BalancerMember http://127.0.0.1:8082 status=+H")
+
+	s.Equal(upstreams, s.vhost.Upstreams())
+}
+
 func (s *ProxyVhostTestSuite) TestBalancerConfig() {
 	upstreams := map[string]types.Upstream{
 		"mybackend": {
@@ -0,0 +1,142 @@
+package caddy
+
+import (
+	"strings"
+)
+
+// Config Caddyfile 的 AST 根节点
+type Config struct {
+	Directives []*Directive `json:"directives"`
+	Comments   []string     `json:"comments"` // 文件末尾的注释
+}
+
+// Directive Caddyfile 指令，站点块、片段和全局选项块也按指令表示
+// Block 不为 nil 时表示带有 {} 块，空块为长度为 0 的切片
+type Directive struct {
+	Name     string       `json:"name"`
+	Args     []string     `json:"args"`
+	Line     int          `json:"line"`
+	Comments []string     `json:"comments"` // 指令前的注释，不含 #
+	Block    []*Directive `json:"block,omitempty"`
+}
+
+// Site 返回第一个站点块，跳过全局选项块和片段定义
+func (c *Config) Site() *Directive {
+	for _, dir := range c.Directives {
+		if dir.Block == nil || dir.Name == "" || strings.HasPrefix(dir.Name, "(") {
+			continue
+		}
+		return dir
+	}
+	return nil
+}
+
+// AddSite 添加站点块
+func (c *Config) AddSite(addresses ...string) *Directive {
+	site := &Directive{Block: []*Directive{}}
+	site.SetAddresses(addresses)
+	c.Directives = append(c.Directives, site)
+	return site
+}
+
+// Addresses 返回站点块的地址列表
+func (d *Directive) Addresses() []string {
+	var result []string
+	for _, token := range append([]string{d.Name}, d.Args...) {
+		for _, addr := range strings.Split(token, ",") {
+			if addr = strings.TrimSpace(addr); addr != "" {
+				result = append(result, addr)
+			}
+		}
+	}
+	return result
+}
+
+// SetAddresses 设置站点块的地址列表
+func (d *Directive) SetAddresses(addresses []string) {
+	tokens := make([]string, len(addresses))
+	for i, addr := range addresses {
+		tokens[i] = addr
+		if i < len(addresses)-1 {
+			tokens[i] += ","
+		}
+	}
+	if len(tokens) == 0 {
+		tokens = []string{":80"}
+	}
+	d.Name = tokens[0]
+	d.Args = tokens[1:]
+}
+
+// GetDirective 根据名称查找块内第一个匹配的指令
+func (d *Directive) GetDirective(name string) *Directive {
+	for _, dir := range d.Block {
+		if dir.Name == name {
+			return dir
+		}
+	}
+	return nil
+}
+
+// GetDirectives 根据名称查找块内所有匹配的指令
+func (d *Directive) GetDirectives(name string) []*Directive {
+	var result []*Directive
+	for _, dir := range d.Block {
+		if dir.Name == name {
+			result = append(result, dir)
+		}
+	}
+	return result
+}
+
+// GetDirectiveValue 获取块内指令的第一个参数
+func (d *Directive) GetDirectiveValue(name string) string {
+	dir := d.GetDirective(name)
+	if dir == nil || len(dir.Args) == 0 {
+		return ""
+	}
+	return dir.Args[0]
+}
+
+// HasDirective 检查块内是否存在指令
+func (d *Directive) HasDirective(name string) bool {
+	return d.GetDirective(name) != nil
+}
+
+// AddDirective 在块末尾添加指令
+func (d *Directive) AddDirective(name string, args ...string) *Directive {
+	dir := &Directive{Name: name, Args: args}
+	d.Block = append(d.Block, dir)
+	return dir
+}
+
+// AddBlock 在块末尾添加带块的指令
+func (d *Directive) AddBlock(name string, args ...string) *Directive {
+	dir := d.AddDirective(name, args...)
+	dir.Block = []*Directive{}
+	return dir
+}
+
+// SetDirective 设置指令，存在时替换第一个匹配指令的参数，不存在时添加
+func (d *Directive) SetDirective(name string, args ...string) *Directive {
+	if dir := d.GetDirective(name); dir != nil {
+		dir.Args = args
+		return dir
+	}
+	return d.AddDirective(name, args...)
+}
+
+// RemoveDirectives 删除块内所有匹配 filter 的指令，返回删除的数量
+func (d *Directive) RemoveDirectives(name string, filter ...func(*Directive) bool) int {
+	count := 0
+	result := make([]*Directive, 0, len(d.Block))
+	for _, dir := range d.Block {
+		if dir.Name == name && (len(filter) == 0 || filter[0](dir)) {
+			count++
+			continue
+		}
+		result = append(result, dir)
+	}
+	d.Block = result
+	return count
+}
@@ -0,0 +1,66 @@
+package caddy
+
+// DisablePagePath 禁用页面路径
+const DisablePagePath = "/opt/ace/server/caddy/stop"
+
+// SitesPath 网站目录
+const SitesPath = "/opt/ace/sites"
+
+// ConfPath 主配置文件路径
+const ConfPath = "/opt/ace/server/caddy/Caddyfile"
+
+// ErrorPageConf 新建网站的 404 页面配置
+const ErrorPageConf = `handle_errors 404 {
+	rewrite * /404.html
+	file_server
+}
+`
+
+// StaticConf 新建 PHP 网站的静态资源缓存和敏感文件配置
+const StaticConf = `# browser cache
+@cache_image path_regexp \.(bmp|jpg|jpeg|png|gif|svg|ico|tiff|webp|avif|heif|heic|jxl)$
+header @cache_image Cache-Control "max-age=2592000"
+@cache_asset path_regexp \.(js|css|ttf|otf|woff|woff2|eot)$
+header @cache_asset Cache-Control "max-age=21600"
+# deny sensitive files
+@sensitive path_regexp ^/(\.user.ini|\.htaccess|\.git|\.svn|\.env)
+respond @sensitive 404
+`
+
+// 配置文件序号范围
+const (
+	RedirectStartNum = 100 // 重定向配置起始序号 (100-199)
+	RedirectEndNum   = 199
+	ProxyStartNum    = 200 // 代理配置起始序号 (200-299)
+	ProxyEndNum      = 299
+	UpstreamStartNum = 100 // 上游服务器配置起始序号
+)
+
+// DefaultConf 默认配置模板
+// shared 目录存放上游等片段定义，需在站点块之前导入
+const DefaultConf = `import /opt/ace/sites/default/config/shared/*.conf
+
+http://localhost:80 {
+	root * /opt/ace/sites/default/public
+	file_server {
+		index index.php index.html
+	}
+	# custom configs
+	import /opt/ace/sites/default/config/site/*.conf
+}
+`
+
+// cipherSuites OpenSSL 加密套件名称与 Caddy 使用的 IANA 名称的对应关系
+// Caddy 仅支持 Go 标准库实现的 TLS 1.2 加密套件
+var cipherSuites = map[string]string{
+	"ECDHE-ECDSA-AES128-GCM-SHA256": "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	"ECDHE-RSA-AES128-GCM-SHA256":   "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	"ECDHE-ECDSA-AES256-GCM-SHA384": "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+	"ECDHE-RSA-AES256-GCM-SHA384":   "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	"ECDHE-ECDSA-CHACHA20-POLY1305": "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256",
+	"ECDHE-RSA-CHACHA20-POLY1305":   "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+	"ECDHE-ECDSA-AES128-SHA":        "TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA",
+	"ECDHE-RSA-AES128-SHA":          "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA",
+	"ECDHE-ECDSA-AES256-SHA":        "TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA",
+	"ECDHE-RSA-AES256-SHA":          "TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA",
+}
@@ -0,0 +1,73 @@
+package caddy
+
+import (
+	"strings"
+)
+
+// Export 导出为 Caddyfile 格式，使用 tab 缩进
+func (c *Config) Export() string {
+	var sb strings.Builder
+
+	for i, dir := range c.Directives {
+		// 顶层的块之间空一行
+		if i > 0 && (dir.Block != nil || c.Directives[i-1].Block != nil) {
+			sb.WriteString("\n")
+		}
+		dir.export(&sb, 0)
+	}
+	for _, comment := range c.Comments {
+		sb.WriteString("# " + comment + "\n")
+	}
+
+	return sb.String()
+}
+
+// Export 导出单条指令及其块
+func (d *Directive) Export() string {
+	var sb strings.Builder
+	d.export(&sb, 0)
+	return sb.String()
+}
+
+func (d *Directive) export(sb *strings.Builder, indent int) {
+	prefix := strings.Repeat("\t", indent)
+
+	for _, comment := range d.Comments {
+		sb.WriteString(prefix + "# " + comment + "\n")
+	}
+	// 仅包含注释的占位指令
+	if d.Name == "" && d.Block == nil {
+		return
+	}
+
+	var words []string
+	if d.Name != "" {
+		words = append(words, quote(d.Name))
+	}
+	for _, arg := range d.Args {
+		words = append(words, quote(arg))
+	}
+
+	sb.WriteString(prefix + strings.Join(words, " "))
+	if d.Block == nil {
+		sb.WriteString("\n")
+		return
+	}
+
+	if len(words) > 0 {
+		sb.WriteString(" ")
+	}
+	sb.WriteString("{\n")
+	for _, dir := range d.Block {
+		dir.export(sb, indent+1)
+	}
+	sb.WriteString(prefix + "}\n")
+}
+
+// quote 必要时为参数加上双引号
+func quote(s string) string {
+	if s != "" && s != "{" && s != "}" && !strings.HasPrefix(s, "#") && !strings.ContainsAny(s, " \t\r\n\"`") {
+		return s
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,183 @@
+package caddy
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// token Caddyfile 词法单元
+type token struct {
+	text    string
+	line    int
+	quoted  bool
+	comment bool
+}
+
+// lex 将 Caddyfile 拆分为词法单元
+// 支持双引号、反引号和注释，不支持 heredoc 和行尾反斜杠续行
+func lex(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	line := 1
+
+	for i := 0; i < len(runes); {
+		ch := runes[i]
+		switch {
+		case ch == '\n':
+			line++
+			i++
+		case ch == ' ' || ch == '\t' || ch == '\r':
+			i++
+		case ch == '#':
+			start := i + 1
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			tokens = append(tokens, token{text: strings.TrimSpace(string(runes[start:i])), line: line, comment: true})
+		case ch == '"' || ch == '`':
+			startLine := line
+			var sb strings.Builder
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == ch {
+					closed = true
+					i++
+					break
+				}
+				if ch == '"' && runes[i] == '\\' && i+1 < len(runes) && runes[i+1] == '"' {
+					sb.WriteRune('"')
+					i += 2
+					continue
+				}
+				if runes[i] == '\n' {
+					line++
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("line %d: unterminated quoted string", startLine)
+			}
+			tokens = append(tokens, token{text: sb.String(), line: startLine, quoted: true})
+		default:
+			start := i
+			for i < len(runes) && runes[i] != ' ' && runes[i] != '\t' && runes[i] != '\r' && runes[i] != '\n' {
+				i++
+			}
+			tokens = append(tokens, token{text: string(runes[start:i]), line: line})
+		}
+	}
+
+	return tokens, nil
+}
+
+// parser Caddyfile 语法分析器
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parseBlock 解析指令列表，nested 为 true 时遇到 } 结束
+func (p *parser) parseBlock(nested bool) ([]*Directive, []string, error) {
+	directives := make([]*Directive, 0)
+	var comments []string
+
+	for p.pos < len(p.tokens) {
+		tok := p.tokens[p.pos]
+		if tok.comment {
+			comments = append(comments, tok.text)
+			p.pos++
+			continue
+		}
+		if !tok.quoted && tok.text == "}" {
+			if !nested {
+				return nil, nil, fmt.Errorf("line %d: unexpected '}'", tok.line)
+			}
+			p.pos++
+			return directives, comments, nil
+		}
+
+		// 同一行的词法单元属于同一条指令
+		var words []token
+		line := tok.line
+		for p.pos < len(p.tokens) && p.tokens[p.pos].line == line {
+			if p.tokens[p.pos].comment {
+				break
+			}
+			words = append(words, p.tokens[p.pos])
+			p.pos++
+		}
+
+		dir := &Directive{Line: line, Comments: comments}
+		comments = nil
+
+		hasBlock := false
+		if last := words[len(words)-1]; !last.quoted && last.text == "{" {
+			hasBlock = true
+			words = words[:len(words)-1]
+		}
+		for i, word := range words {
+			if !word.quoted && (word.text == "{" || word.text == "}") {
+				return nil, nil, fmt.Errorf("line %d: unexpected '%s'", word.line, word.text)
+			}
+			if i == 0 {
+				dir.Name = word.text
+			} else {
+				dir.Args = append(dir.Args, word.text)
+			}
+		}
+
+		if hasBlock {
+			block, trailing, err := p.parseBlock(true)
+			if err != nil {
+				return nil, nil, err
+			}
+			// 块末尾的注释保留为空指令，以便导出时不丢失
+			if len(trailing) > 0 {
+				block = append(block, &Directive{Comments: trailing})
+			}
+			dir.Block = block
+		}
+
+		directives = append(directives, dir)
+	}
+
+	if nested {
+		return nil, nil, fmt.Errorf("unexpected end of file, expecting '}'")
+	}
+
+	return directives, comments, nil
+}
+
+// ParseString 解析 Caddyfile 字符串
+func ParseString(content string) (*Config, error) {
+	tokens, err := lex(content)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	directives, comments, err := p.parseBlock(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Config{Directives: directives, Comments: comments}, nil
+}
+
+// ParseFile 解析 Caddyfile 文件
+func ParseFile(filename string) (*Config, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	config, err := ParseString(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	return config, nil
+}
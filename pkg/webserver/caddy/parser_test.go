@@ -0,0 +1,93 @@
+package caddy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ParserTestSuite struct {
+	suite.Suite
+}
+
+func TestParserTestSuite(t *testing.T) {
+	suite.Run(t, &ParserTestSuite{})
+}
+
+func (s *ParserTestSuite) TestParseSite() {
+	input := `# global
+{
+	email admin@example.com
+}
+
+(common) {
+	encode gzip
+}
+
+http://example.com:80, https://example.com:443 {
+	root * /var/www/html
+	# static files
+	file_server
+	header Strict-Transport-Security "max-age=31536000"
+}
+`
+	config, err := ParseString(input)
+	s.NoError(err)
+	s.Len(config.Directives, 3)
+	s.Equal([]string{"global"}, config.Directives[0].Comments)
+	s.Equal("", config.Directives[0].Name)
+
+	site := config.Site()
+	s.Require().NotNil(site)
+	s.Equal([]string{"http://example.com:80", "https://example.com:443"}, site.Addresses())
+	s.Len(site.Block, 3)
+	s.Equal([]string{"*", "/var/www/html"}, site.GetDirective("root").Args)
+	s.Equal([]string{"static files"}, site.GetDirective("file_server").Comments)
+	s.Equal("max-age=31536000", site.GetDirective("header").Args[1])
+}
+
+func (s *ParserTestSuite) TestParseQuoted() {
+	config, err := ParseString(`header Alt-Svc "h3=\":443\"; ma=86400"` + "\nrespond `{\"ok\": true}` 200\n")
+	s.NoError(err)
+	s.Len(config.Directives, 2)
+	s.Equal(`h3=":443"; ma=86400`, config.Directives[0].Args[1])
+	s.Equal(`{"ok": true}`, config.Directives[1].Args[0])
+}
+
+func (s *ParserTestSuite) TestParsePlaceholder() {
+	config, err := ParseString("redir https://{host}{uri} 308\n")
+	s.NoError(err)
+	s.Len(config.Directives, 1)
+	s.Equal([]string{"https://{host}{uri}", "308"}, config.Directives[0].Args)
+	s.Nil(config.Directives[0].Block)
+}
+
+func (s *ParserTestSuite) TestParseError() {
+	_, err := ParseString("example.com {\n\troot * /var/www\n")
+	s.Error(err)
+
+	_, err = ParseString("}\n")
+	s.Error(err)
+
+	_, err = ParseString(`header X-Test "unterminated`)
+	s.Error(err)
+}
+
+func (s *ParserTestSuite) TestExportRoundTrip() {
+	config, err := ParseString(DefaultConf)
+	s.NoError(err)
+
+	exported := config.Export()
+	s.Equal(DefaultConf, exported)
+
+	again, err := ParseString(exported)
+	s.NoError(err)
+	s.Equal(exported, again.Export())
+}
+
+func (s *ParserTestSuite) TestExportQuote() {
+	dir := &Directive{Name: "basic_auth", Args: []string{"bcrypt", "Test Realm"}, Block: []*Directive{}}
+	dir.AddDirective("import", "/etc/caddy/users")
+
+	s.Equal("basic_auth bcrypt \"Test Realm\" {\n\timport /etc/caddy/users\n}\n", dir.Export())
+}
@@ -0,0 +1,504 @@
+package caddy
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/acepanel/panel/pkg/webserver/types"
+)
+
+// proxyFilePattern 匹配代理配置文件名 (200-299)
+var proxyFilePattern = regexp.MustCompile(`^(\d{3})-proxy\.conf$`)
+
+// corsHeaders 跨域配置使用的响应头
+var corsHeaders = []string{
+	"Access-Control-Allow-Origin",
+	"Access-Control-Allow-Methods",
+	"Access-Control-Allow-Headers",
+	"Access-Control-Expose-Headers",
+	"Access-Control-Allow-Credentials",
+	"Access-Control-Max-Age",
+}
+
+// parseProxyFiles 从 site 目录解析所有代理配置
+func parseProxyFiles(siteDir string) ([]types.Proxy, error) {
+	entries, err := os.ReadDir(siteDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var proxies []types.Proxy
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		matches := proxyFilePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		num, _ := strconv.Atoi(matches[1])
+		if num < ProxyStartNum || num > ProxyEndNum {
+			continue
+		}
+
+		filePath := filepath.Join(siteDir, entry.Name())
+		proxy, err := parseProxyFile(filePath)
+		if err != nil {
+			continue // 跳过解析失败的文件
+		}
+		if proxy != nil {
+			proxies = append(proxies, *proxy)
+		}
+	}
+
+	return proxies, nil
+}
+
+// parseProxyFile 解析单个代理配置文件
+func parseProxyFile(filePath string) (*types.Proxy, error) {
+	config, err := ParseFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// 命名匹配器: @proxy_200 path_regexp ^/api/v[0-9]+/
+	matchers := make(map[string][]string)
+	var handle *Directive
+	for _, dir := range config.Directives {
+		if strings.HasPrefix(dir.Name, "@") {
+			matchers[dir.Name] = dir.Args
+		}
+		if dir.Name == "handle" && handle == nil {
+			handle = dir
+		}
+	}
+	if handle == nil {
+		return nil, fmt.Errorf("handle block not found")
+	}
+	rp := handle.GetDirective("reverse_proxy")
+	if rp == nil {
+		return nil, fmt.Errorf("reverse_proxy directive not found")
+	}
+
+	proxy := &types.Proxy{
+		Location: "/",
+		Replaces: make(map[string]string),
+	}
+
+	// 匹配路径
+	if len(handle.Args) > 0 {
+		proxy.Location = matcherLocation(handle.Args[0], matchers[handle.Args[0]])
+	}
+
+	// 代理地址，引用上游时地址在片段中
+	if len(rp.Args) > 0 {
+		proxy.Pass = rp.Args[0]
+	}
+	for _, imp := range rp.GetDirectives("import") {
+		if len(imp.Args) > 0 {
+			if name, ok := strings.CutPrefix(imp.Args[0], "upstream_"); ok {
+				proxy.Pass = "http://" + name
+			}
+		}
+	}
+
+	// 去除前缀
+	if uri := handle.GetDirective("uri"); uri != nil && len(uri.Args) > 0 && uri.Args[0] == "strip_prefix" {
+		proxy.StripPrefix = true
+	}
+
+	// WebSocket
+	proxy.WebSocket = rp.HasDirective("stream_close_delay")
+
+	// 请求头和响应头，Host 头单独保存
+	for _, header := range rp.GetDirectives("header_up") {
+		if len(header.Args) >= 2 && strings.EqualFold(header.Args[0], "Host") {
+			proxy.Host = header.Args[1]
+			continue
+		}
+		parseProxyHeader(&proxy.RequestHeaders, header.Args)
+	}
+	for _, header := range rp.GetDirectives("header_down") {
+		parseProxyHeader(&proxy.ResponseHeaders, header.Args)
+	}
+
+	// 跨域配置
+	proxy.CORS = parseCORS(handle, matchers)
+
+	// 缓冲
+	proxy.Buffering = rp.HasDirective("response_buffers")
+
+	// 传输配置
+	if transport := rp.GetDirective("transport"); transport != nil {
+		proxy.SNI = transport.GetDirectiveValue("tls_server_name")
+		if resolvers := transport.GetDirective("resolvers"); resolvers != nil {
+			proxy.Resolver = resolvers.Args
+		}
+		if timeout := transport.GetDirectiveValue("dial_timeout"); timeout != "" {
+			proxy.ConnectTimeout, _ = time.ParseDuration(timeout)
+		}
+		if timeout := transport.GetDirectiveValue("read_timeout"); timeout != "" {
+			proxy.ReadTimeout, _ = time.ParseDuration(timeout)
+		}
+		if timeout := transport.GetDirectiveValue("write_timeout"); timeout != "" {
+			proxy.SendTimeout, _ = time.ParseDuration(timeout)
+		}
+	}
+
+	// 响应内容替换
+	if replace := handle.GetDirective("replace"); replace != nil {
+		for _, dir := range replace.Block {
+			if dir.Name != "" && len(dir.Args) > 0 {
+				proxy.Replaces[dir.Name] = dir.Args[0]
+			}
+		}
+	}
+
+	return proxy, nil
+}
+
+// parseProxyHeader 解析 header_up/header_down 指令，名称以 - 开头表示删除
+func parseProxyHeader(headers *types.ProxyHeaders, args []string) {
+	if len(args) == 0 {
+		return
+	}
+	if name, ok := strings.CutPrefix(args[0], "-"); ok {
+		headers.Remove = append(headers.Remove, name)
+		return
+	}
+	if headers.Set == nil {
+		headers.Set = make(map[string]string)
+	}
+	headers.Set[args[0]] = strings.Join(args[1:], " ")
+}
+
+// parseCORS 从 handle 块的 header 指令中解析跨域配置
+func parseCORS(handle *Directive, matchers map[string][]string) *types.ProxyCORS {
+	values := make(map[string]string)
+	var origins []string
+	for _, header := range handle.GetDirectives("header") {
+		args := header.Args
+		if len(args) > 0 && strings.HasPrefix(args[0], "@") {
+			// @cors_200 header Origin https://example.com
+			if named := matchers[args[0]]; len(named) > 2 && named[0] == "header" && named[1] == "Origin" {
+				origins = named[2:]
+			}
+			args = args[1:]
+		}
+		if len(args) >= 2 && slices.Contains(corsHeaders, args[0]) {
+			values[args[0]] = args[1]
+		}
+	}
+
+	origin, ok := values["Access-Control-Allow-Origin"]
+	if !ok {
+		return nil
+	}
+	cors := &types.ProxyCORS{
+		Origins:       origins,
+		Methods:       splitList(values["Access-Control-Allow-Methods"]),
+		Headers:       splitList(values["Access-Control-Allow-Headers"]),
+		ExposeHeaders: splitList(values["Access-Control-Expose-Headers"]),
+		Credentials:   values["Access-Control-Allow-Credentials"] == "true",
+	}
+	if origin == "*" {
+		cors.Origins = []string{"*"}
+	}
+	cors.MaxAge, _ = strconv.Atoi(values["Access-Control-Max-Age"])
+
+	return cors
+}
+
+// writeProxyFiles 将代理配置写入文件
+func writeProxyFiles(siteDir string, proxies []types.Proxy, upstreams []string) error {
+	for _, proxy := range proxies {
+		if err := proxy.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// 删除现有的代理配置文件 (200-299)
+	if err := clearProxyFiles(siteDir); err != nil {
+		return err
+	}
+
+	// 写入新的配置文件
+	for i, proxy := range proxies {
+		num := ProxyStartNum + i
+		if num > ProxyEndNum {
+			return fmt.Errorf("proxy rules exceed limit (%d)", ProxyEndNum-ProxyStartNum+1)
+		}
+
+		fileName := fmt.Sprintf("%03d-proxy.conf", num)
+		filePath := filepath.Join(siteDir, fileName)
+
+		content := generateProxyConfig(num, proxy, upstreams)
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write proxy config: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// clearProxyFiles 清除所有代理配置文件
+func clearProxyFiles(siteDir string) error {
+	entries, err := os.ReadDir(siteDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		matches := proxyFilePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		num, _ := strconv.Atoi(matches[1])
+		if num >= ProxyStartNum && num <= ProxyEndNum {
+			filePath := filepath.Join(siteDir, entry.Name())
+			if err = os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to delete proxy config: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// generateProxyConfig 生成代理配置内容
+// 代理地址的主机名为上游名称时导入对应的上游片段，此时传输配置由片段提供
+// 缓存和响应内容替换需要 Caddy 编译 cache-handler 和 replace-response 插件，当前仅支持替换
+// Caddy 没有单独的 DNS 解析超时，ResolverTimeout 不生成配置
+func generateProxyConfig(num int, proxy types.Proxy, upstreams []string) string {
+	location := proxy.Location
+	if location == "" {
+		location = "/"
+	}
+
+	config := &Config{}
+	matcher, named := locationMatcher(num, location)
+	if named != nil {
+		config.Directives = append(config.Directives, named)
+	}
+	handle := &Directive{
+		Name:     "handle",
+		Args:     []string{matcher},
+		Comments: []string{fmt.Sprintf("Reverse proxy: %s -> %s", location, proxy.Pass)},
+		Block:    []*Directive{},
+	}
+	config.Directives = append(config.Directives, handle)
+
+	// 去除前缀，根路径和正则匹配无需处理
+	if proxy.StripPrefix && !strings.HasPrefix(matcher, "@") {
+		if prefix := strings.TrimSuffix(strings.TrimSuffix(matcher, "*"), "/"); prefix != "" {
+			handle.AddDirective("uri", "strip_prefix", prefix)
+		}
+	}
+
+	// 跨域配置
+	if proxy.CORS != nil {
+		config.Directives = slices.Insert(config.Directives, len(config.Directives)-1, generateCORSConfig(num, proxy.CORS, handle)...)
+	}
+
+	// 响应内容替换
+	if len(proxy.Replaces) > 0 {
+		replace := handle.AddBlock("replace")
+		for _, from := range slices.Sorted(maps.Keys(proxy.Replaces)) {
+			replace.AddDirective(from, proxy.Replaces[from])
+		}
+	}
+
+	rp := handle.AddBlock("reverse_proxy")
+	upstream := ""
+	if u, err := url.Parse(proxy.Pass); err == nil && slices.Contains(upstreams, u.Hostname()) {
+		upstream = u.Hostname()
+		rp.AddDirective("import", "upstream_"+upstream)
+	} else {
+		rp.Args = []string{upstreamAddress(proxy.Pass)}
+	}
+
+	// Host 头，未设置时 Caddy 默认透传客户端的 Host
+	if proxy.Host != "" {
+		rp.AddDirective("header_up", "Host", proxy.Host)
+	}
+
+	// 自定义请求头和响应头
+	for _, name := range slices.Sorted(maps.Keys(proxy.RequestHeaders.Set)) {
+		if !strings.EqualFold(name, "Host") {
+			rp.AddDirective("header_up", name, proxy.RequestHeaders.Set[name])
+		}
+	}
+	for _, name := range proxy.RequestHeaders.Remove {
+		if !strings.EqualFold(name, "Host") {
+			rp.AddDirective("header_up", "-"+name)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(proxy.ResponseHeaders.Set)) {
+		rp.AddDirective("header_down", name, proxy.ResponseHeaders.Set[name])
+	}
+	for _, name := range proxy.ResponseHeaders.Remove {
+		rp.AddDirective("header_down", "-"+name)
+	}
+
+	// Caddy 原生支持 WebSocket，这里在重载配置时保持已有连接，避免长连接被中断
+	if proxy.WebSocket {
+		rp.AddDirective("stream_close_delay", "5m")
+	}
+
+	// Buffering 配置，Caddy 默认流式转发
+	if proxy.Buffering {
+		rp.AddDirective("request_buffers", "64KiB")
+		rp.AddDirective("response_buffers", "64KiB")
+	}
+
+	// 传输配置
+	if upstream == "" && (proxy.SNI != "" || len(proxy.Resolver) > 0 ||
+		proxy.ConnectTimeout > 0 || proxy.ReadTimeout > 0 || proxy.SendTimeout > 0) {
+		transport := rp.AddBlock("transport", "http")
+		if proxy.SNI != "" {
+			transport.AddDirective("tls_server_name", proxy.SNI)
+		}
+		var resolvers []string
+		for _, resolver := range proxy.Resolver {
+			if !strings.Contains(resolver, "=") { // 忽略 Nginx 的 ipv6=off 等参数
+				resolvers = append(resolvers, resolver)
+			}
+		}
+		if len(resolvers) > 0 {
+			transport.AddDirective("resolvers", resolvers...)
+		}
+		if proxy.ConnectTimeout > 0 {
+			transport.AddDirective("dial_timeout", proxy.ConnectTimeout.String())
+		}
+		if proxy.ReadTimeout > 0 {
+			transport.AddDirective("read_timeout", proxy.ReadTimeout.String())
+		}
+		if proxy.SendTimeout > 0 {
+			transport.AddDirective("write_timeout", proxy.SendTimeout.String())
+		}
+	}
+
+	if len(rp.Block) == 0 {
+		rp.Block = nil
+	}
+
+	return config.Export()
+}
+
+// generateCORSConfig 在 handle 块中生成跨域配置，返回需要定义在顶层的命名匹配器
+// 来源不匹配时不返回 Access-Control-Allow-Origin，预检请求直接返回 204
+func generateCORSConfig(num int, cors *types.ProxyCORS, handle *Directive) []*Directive {
+	var matchers []*Directive
+
+	if len(cors.Origins) == 0 || slices.Contains(cors.Origins, "*") {
+		handle.AddDirective("header", "Access-Control-Allow-Origin", "*")
+	} else {
+		name := fmt.Sprintf("@cors_%d", num)
+		matchers = append(matchers, &Directive{Name: name, Args: append([]string{"header", "Origin"}, cors.Origins...)})
+		handle.AddDirective("header", name, "Access-Control-Allow-Origin", "{http.request.header.Origin}")
+		handle.AddDirective("header", name, "Vary", "Origin")
+	}
+	if len(cors.Methods) > 0 {
+		handle.AddDirective("header", "Access-Control-Allow-Methods", strings.Join(cors.Methods, ", "))
+	}
+	if len(cors.Headers) > 0 {
+		handle.AddDirective("header", "Access-Control-Allow-Headers", strings.Join(cors.Headers, ", "))
+	}
+	if len(cors.ExposeHeaders) > 0 {
+		handle.AddDirective("header", "Access-Control-Expose-Headers", strings.Join(cors.ExposeHeaders, ", "))
+	}
+	if cors.Credentials {
+		handle.AddDirective("header", "Access-Control-Allow-Credentials", "true")
+	}
+	if cors.MaxAge > 0 {
+		handle.AddDirective("header", "Access-Control-Max-Age", strconv.Itoa(cors.MaxAge))
+	}
+
+	preflight := fmt.Sprintf("@preflight_%d", num)
+	matchers = append(matchers, &Directive{Name: preflight, Args: []string{"method", "OPTIONS"}})
+	handle.AddDirective("respond", preflight, "204")
+
+	return matchers
+}
+
+// locationMatcher 将 Nginx 风格的 location 转换为 handle 的匹配器
+// 正则匹配需要额外定义命名匹配器
+func locationMatcher(num int, location string) (string, *Directive) {
+	modifier, path, found := strings.Cut(strings.TrimSpace(location), " ")
+	if !found {
+		modifier, path = "", modifier
+	}
+	path = strings.TrimSpace(path)
+
+	switch modifier {
+	case "=":
+		return path, nil
+	case "~", "~*":
+		if modifier == "~*" {
+			path = "(?i)" + path
+		}
+		name := fmt.Sprintf("@proxy_%d", num)
+		return name, &Directive{Name: name, Args: []string{"path_regexp", path}}
+	default:
+		// 前缀匹配，包括 ^~
+		return path + "*", nil
+	}
+}
+
+// matcherLocation 将 handle 的匹配器还原为 location
+func matcherLocation(matcher string, named []string) string {
+	if strings.HasPrefix(matcher, "@") {
+		if len(named) >= 2 && named[0] == "path_regexp" {
+			if re, ok := strings.CutPrefix(named[1], "(?i)"); ok {
+				return "~* " + re
+			}
+			return "~ " + named[1]
+		}
+		return "/"
+	}
+	if path, ok := strings.CutSuffix(matcher, "*"); ok {
+		return path
+	}
+	return "= " + matcher
+}
+
+// splitList 拆分逗号分隔的列表
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// upstreamAddress 去掉代理地址中的路径，Caddy 的上游地址不能包含路径
+func upstreamAddress(pass string) string {
+	u, err := url.Parse(pass)
+	if err != nil || u.Host == "" {
+		return pass
+	}
+	return u.Scheme + "://" + u.Host
+}
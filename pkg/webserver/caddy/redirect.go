@@ -0,0 +1,210 @@
+package caddy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/acepanel/panel/pkg/webserver/types"
+)
+
+// redirectFilePattern 匹配重定向配置文件名 (100-199)
+var redirectFilePattern = regexp.MustCompile(`^(\d{3})-redirect\.conf$`)
+
+// parseRedirectFiles 从 site 目录解析所有重定向配置
+func parseRedirectFiles(siteDir string) ([]types.Redirect, error) {
+	entries, err := os.ReadDir(siteDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var redirects []types.Redirect
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		matches := redirectFilePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		num, _ := strconv.Atoi(matches[1])
+		if num < RedirectStartNum || num > RedirectEndNum {
+			continue
+		}
+
+		filePath := filepath.Join(siteDir, entry.Name())
+		redirect, err := parseRedirectFile(filePath)
+		if err != nil {
+			continue // 跳过解析失败的文件
+		}
+		if redirect != nil {
+			redirects = append(redirects, *redirect)
+		}
+	}
+
+	return redirects, nil
+}
+
+// parseRedirectFile 解析单个重定向配置文件
+func parseRedirectFile(filePath string) (*types.Redirect, error) {
+	config, err := ParseFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// 命名匹配器: @redirect_100 host old.example.com
+	hosts := make(map[string]string)
+	for _, dir := range config.Directives {
+		if strings.HasPrefix(dir.Name, "@") && len(dir.Args) >= 2 && dir.Args[0] == "host" {
+			hosts[dir.Name] = dir.Args[1]
+		}
+	}
+
+	for _, dir := range config.Directives {
+		switch dir.Name {
+		case "redir":
+			// redir [<matcher>] <to> [<code>]
+			args := dir.Args
+			redirect := &types.Redirect{Type: types.RedirectTypeURL}
+			if len(args) > 0 && strings.HasPrefix(args[0], "@") {
+				redirect.Type = types.RedirectTypeHost
+				redirect.From = hosts[args[0]]
+				args = args[1:]
+			} else if len(args) > 1 && strings.HasPrefix(args[0], "/") {
+				redirect.From = args[0]
+				args = args[1:]
+			}
+			if len(args) == 0 {
+				return nil, fmt.Errorf("invalid redir directive")
+			}
+			redirect.To, redirect.KeepURI = strings.CutSuffix(args[0], "{uri}")
+			redirect.StatusCode = 302
+			if len(args) > 1 {
+				redirect.StatusCode, _ = strconv.Atoi(args[1])
+			}
+			return redirect, nil
+
+		case "handle_errors":
+			// handle_errors 404 { redir <to> <code> }
+			if len(dir.Args) == 0 || dir.Args[0] != "404" {
+				continue
+			}
+			redir := dir.GetDirective("redir")
+			if redir == nil || len(redir.Args) == 0 {
+				continue
+			}
+			redirect := &types.Redirect{Type: types.RedirectType404, StatusCode: 302}
+			redirect.To, redirect.KeepURI = strings.CutSuffix(redir.Args[0], "{uri}")
+			if len(redir.Args) > 1 {
+				redirect.StatusCode, _ = strconv.Atoi(redir.Args[1])
+			}
+			return redirect, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// writeRedirectFiles 将重定向配置写入文件
+func writeRedirectFiles(siteDir string, redirects []types.Redirect) error {
+	// 删除现有的重定向配置文件 (100-199)
+	if err := clearRedirectFiles(siteDir); err != nil {
+		return err
+	}
+
+	// 写入新的配置文件
+	for i, redirect := range redirects {
+		num := RedirectStartNum + i
+		if num > RedirectEndNum {
+			return fmt.Errorf("redirect rules exceed limit (%d)", RedirectEndNum-RedirectStartNum+1)
+		}
+
+		fileName := fmt.Sprintf("%03d-redirect.conf", num)
+		filePath := filepath.Join(siteDir, fileName)
+
+		content := generateRedirectConfig(num, redirect)
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write redirect config: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// clearRedirectFiles 清除所有重定向配置文件
+func clearRedirectFiles(siteDir string) error {
+	entries, err := os.ReadDir(siteDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		matches := redirectFilePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		num, _ := strconv.Atoi(matches[1])
+		if num >= RedirectStartNum && num <= RedirectEndNum {
+			filePath := filepath.Join(siteDir, entry.Name())
+			if err = os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to delete redirect config: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// generateRedirectConfig 生成重定向配置内容
+// 同一站点内的命名匹配器不能重名，因此使用文件序号命名
+func generateRedirectConfig(num int, redirect types.Redirect) string {
+	statusCode := redirect.StatusCode
+	if statusCode == 0 {
+		statusCode = 308 // 默认使用 308 永久重定向
+	}
+
+	to := redirect.To
+	if redirect.KeepURI {
+		to += "{uri}"
+	}
+
+	var sb strings.Builder
+
+	switch redirect.Type {
+	case types.RedirectTypeURL:
+		// URL 重定向
+		sb.WriteString(fmt.Sprintf("# URL redirect: %s -> %s\n", redirect.From, redirect.To))
+		sb.WriteString(fmt.Sprintf("redir %s %s %d\n", quote(redirect.From), quote(to), statusCode))
+
+	case types.RedirectTypeHost:
+		// Host 重定向
+		sb.WriteString(fmt.Sprintf("# Host redirect: %s -> %s\n", redirect.From, redirect.To))
+		sb.WriteString(fmt.Sprintf("@redirect_%d host %s\n", num, quote(redirect.From)))
+		sb.WriteString(fmt.Sprintf("redir @redirect_%d %s %d\n", num, quote(to), statusCode))
+
+	case types.RedirectType404:
+		// 404 重定向
+		sb.WriteString(fmt.Sprintf("# 404 redirect -> %s\n", redirect.To))
+		sb.WriteString("handle_errors 404 {\n")
+		sb.WriteString(fmt.Sprintf("\tredir %s %d\n", quote(to), statusCode))
+		sb.WriteString("}\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,331 @@
+package caddy
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/acepanel/panel/pkg/webserver/types"
+)
+
+// upstreamFilePattern 匹配上游配置文件名 (100-XXX-name.conf)
+var upstreamFilePattern = regexp.MustCompile(`^(\d{3})-(.+)\.conf$`)
+
+// parseUpstreamFiles 从 shared 目录解析所有上游配置
+func parseUpstreamFiles(sharedDir string) (map[string]types.Upstream, error) {
+	entries, err := os.ReadDir(sharedDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	upstreams := make(map[string]types.Upstream)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		matches := upstreamFilePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		num, _ := strconv.Atoi(matches[1])
+		if num < UpstreamStartNum {
+			continue
+		}
+
+		name := matches[2]
+		filePath := filepath.Join(sharedDir, entry.Name())
+		upstream, err := parseUpstreamFile(filePath, name)
+		if err != nil {
+			continue // 跳过解析失败的文件
+		}
+		if upstream != nil {
+			upstreams[name] = *upstream
+		}
+	}
+
+	return upstreams, nil
+}
+
+// parseUpstreamFile 解析单个上游配置文件
+func parseUpstreamFile(filePath string, expectedName string) (*types.Upstream, error) {
+	config, err := ParseFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var snippet *Directive
+	for _, dir := range config.Directives {
+		if dir.Name == "(upstream_"+expectedName+")" && dir.Block != nil {
+			snippet = dir
+			break
+		}
+	}
+	if snippet == nil {
+		return nil, fmt.Errorf("upstream snippet %s not found", expectedName)
+	}
+
+	upstream := &types.Upstream{
+		Servers: make(map[string]string),
+	}
+
+	var servers []string
+	for _, to := range snippet.GetDirectives("to") {
+		servers = append(servers, to.Args...)
+	}
+
+	// 注释中的健康检查配置，以及不在 to 中的备用和不可用服务器
+	// # Health check: type=http path=/health
+	// # Backup: 127.0.0.1:8082 weight=2
+	// # Down: 127.0.0.1:8081
+	states := make(map[string]types.UpstreamServer)
+	for _, comment := range snippet.Comments {
+		key, value, _ := strings.Cut(comment, ": ")
+		switch key {
+		case "Health check":
+			upstream.HealthCheck = types.ParseUpstreamHealthCheck(value)
+		case "Backup", "Down":
+			fields := strings.Fields(value)
+			if len(fields) == 0 {
+				continue
+			}
+			if !slices.Contains(servers, fields[0]) {
+				servers = append(servers, fields[0])
+			}
+			upstream.Servers[fields[0]] = strings.Join(fields[1:], " ")
+			state := states[fields[0]]
+			state.Backup = state.Backup || key == "Backup"
+			state.Down = state.Down || key == "Down"
+			states[fields[0]] = state
+		}
+	}
+
+	// 负载均衡算法，加权轮询的权重按 to 的顺序排列
+	var weights []string
+	if policy := snippet.GetDirective("lb_policy"); policy != nil && len(policy.Args) > 0 {
+		switch policy.Args[0] {
+		case "round_robin":
+		case "weighted_round_robin":
+			weights = policy.Args[1:]
+		case "uri_hash":
+			upstream.Algo = "hash $request_uri"
+		default:
+			upstream.Algo = policy.Args[0]
+		}
+	}
+
+	// 被动健康检查，作用于所有服务器
+	var health types.UpstreamServer
+	health.MaxFails, _ = strconv.Atoi(snippet.GetDirectiveValue("max_fails"))
+	health.FailTimeout, _ = time.ParseDuration(snippet.GetDirectiveValue("fail_duration"))
+
+	for i, server := range servers {
+		if _, ok := states[server]; !ok {
+			options := ""
+			if i < len(weights) && weights[i] != "1" {
+				options = "weight=" + weights[i]
+			}
+			upstream.Servers[server] = options
+		}
+
+		state := states[server]
+		state.MaxFails, state.FailTimeout = health.MaxFails, health.FailTimeout
+		if state != (types.UpstreamServer{}) {
+			if upstream.ServerOptions == nil {
+				upstream.ServerOptions = make(map[string]types.UpstreamServer)
+			}
+			upstream.ServerOptions[server] = state
+		}
+	}
+
+	// 保持连接数
+	if transport := snippet.GetDirective("transport"); transport != nil {
+		if keepalive := transport.GetDirectiveValue("keepalive_idle_conns"); keepalive != "" {
+			upstream.Keepalive, _ = strconv.Atoi(keepalive)
+		}
+	}
+
+	return upstream, nil
+}
+
+// writeUpstreamFiles 将上游配置写入文件
+func writeUpstreamFiles(sharedDir string, upstreams map[string]types.Upstream) error {
+	// 删除现有的上游配置文件
+	if err := clearUpstreamFiles(sharedDir); err != nil {
+		return err
+	}
+
+	// 写入新的配置文件
+	num := UpstreamStartNum
+	for _, name := range slices.Sorted(maps.Keys(upstreams)) {
+		fileName := fmt.Sprintf("%03d-%s.conf", num, name)
+		filePath := filepath.Join(sharedDir, fileName)
+
+		content := generateUpstreamConfig(name, upstreams[name])
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write upstream config: %w", err)
+		}
+		num++
+	}
+
+	return nil
+}
+
+// clearUpstreamFiles 清除所有上游配置文件
+func clearUpstreamFiles(sharedDir string) error {
+	entries, err := os.ReadDir(sharedDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		matches := upstreamFilePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		num, _ := strconv.Atoi(matches[1])
+		if num >= UpstreamStartNum {
+			filePath := filepath.Join(sharedDir, entry.Name())
+			if err = os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to delete upstream config: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// generateUpstreamConfig 生成上游配置内容
+// Caddy 没有独立的 upstream 块，这里生成供 reverse_proxy 导入的片段
+// 服务器参数兼容 Nginx 写法，支持 weight、max_fails 和 fail_timeout，其中后两者作用于所有服务器
+// Caddy 不支持单独标记服务器，备用和不可用的服务器不写入 to，仅记录在注释中
+// 其它服务器均不可用时备用服务器写入 to，全部不可用时保留所有服务器，避免 reverse_proxy 没有上游
+func generateUpstreamConfig(name string, upstream types.Upstream) string {
+	snippet := &Directive{
+		Name:     fmt.Sprintf("(upstream_%s)", name),
+		Comments: []string{"Upstream: " + name},
+		Block:    []*Directive{},
+	}
+	if upstream.HealthCheck != nil {
+		snippet.Comments = append(snippet.Comments, "Health check: "+upstream.HealthCheck.String())
+	}
+
+	var primary, backup, down, servers []string
+	options := make(map[string]types.UpstreamServer)
+	for _, server := range slices.Sorted(maps.Keys(upstream.Servers)) {
+		opts := types.UpstreamServer{}
+		for _, option := range strings.Fields(upstream.Servers[server]) {
+			key, value, _ := strings.Cut(option, "=")
+			switch key {
+			case "max_fails":
+				opts.MaxFails, _ = strconv.Atoi(value)
+			case "fail_timeout":
+				if _, err := strconv.Atoi(value); err == nil {
+					value += "s"
+				}
+				opts.FailTimeout, _ = time.ParseDuration(value)
+			}
+		}
+		if typed, ok := upstream.ServerOptions[server]; ok {
+			opts = typed
+		}
+		options[server] = opts
+
+		switch {
+		case opts.Down:
+			down = append(down, server)
+			snippet.Comments = append(snippet.Comments, strings.TrimSpace("Down: "+server+" "+weightOption(upstream.Servers[server])))
+		case opts.Backup:
+			backup = append(backup, server)
+			snippet.Comments = append(snippet.Comments, strings.TrimSpace("Backup: "+server+" "+weightOption(upstream.Servers[server])))
+		default:
+			primary = append(primary, server)
+		}
+	}
+	switch {
+	case len(primary) > 0:
+		servers = primary
+	case len(backup) > 0:
+		servers = backup
+	default:
+		servers = down
+	}
+
+	weighted := false
+	weights := make([]string, len(servers))
+	maxFails, failTimeout := 0, time.Duration(0)
+	for i, server := range servers {
+		weights[i] = "1"
+		if weight := strings.TrimPrefix(weightOption(upstream.Servers[server]), "weight="); weight != "" {
+			weights[i] = weight
+			weighted = true
+		}
+	}
+	for _, server := range slices.Sorted(maps.Keys(options)) {
+		if opts := options[server]; opts.MaxFails > 0 || opts.FailTimeout > 0 {
+			maxFails, failTimeout = opts.MaxFails, opts.FailTimeout
+			break
+		}
+	}
+	if len(servers) > 0 {
+		snippet.AddDirective("to", servers...)
+	}
+
+	// 负载均衡算法
+	switch {
+	case upstream.Algo == "" && weighted:
+		snippet.AddDirective("lb_policy", append([]string{"weighted_round_robin"}, weights...)...)
+	case upstream.Algo == "hash $request_uri":
+		snippet.AddDirective("lb_policy", "uri_hash")
+	case upstream.Algo != "":
+		snippet.AddDirective("lb_policy", strings.Fields(upstream.Algo)...)
+	}
+
+	// 被动健康检查，只有设置了 fail_duration 才会生效
+	if maxFails > 0 || failTimeout > 0 {
+		if failTimeout == 0 {
+			failTimeout = 10 * time.Second
+		}
+		snippet.AddDirective("fail_duration", failTimeout.String())
+		if maxFails > 0 {
+			snippet.AddDirective("max_fails", strconv.Itoa(maxFails))
+		}
+	}
+
+	// 保持连接数
+	if upstream.Keepalive > 0 {
+		transport := snippet.AddBlock("transport", "http")
+		transport.AddDirective("keepalive_idle_conns", strconv.Itoa(upstream.Keepalive))
+	}
+
+	config := &Config{Directives: []*Directive{snippet}}
+	return config.Export()
+}
+
+// weightOption 取服务器参数中的 weight，如: weight=5
+func weightOption(options string) string {
+	for _, option := range strings.Fields(options) {
+		if strings.HasPrefix(option, "weight=") {
+			return option
+		}
+	}
+	return ""
+}
@@ -0,0 +1,824 @@
+package caddy
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/acepanel/panel/pkg/webserver/types"
+)
+
+// StaticVhost 纯静态虚拟主机
+type StaticVhost struct {
+	*baseVhost
+}
+
+// PHPVhost PHP 虚拟主机
+type PHPVhost struct {
+	*baseVhost
+}
+
+// ProxyVhost 反向代理虚拟主机
+type ProxyVhost struct {
+	*baseVhost
+}
+
+// baseVhost Caddy 虚拟主机基础实现
+// Caddy 的站点地址同时包含域名和端口，监听地址和域名会组合为站点块的地址列表，
+// 带 ssl 或 quic 参数的监听使用 https://，其余使用 http:// 以避免 Caddy 自动开启 HTTPS
+type baseVhost struct {
+	config    *Config
+	site      *Directive
+	configDir string // 配置目录
+}
+
+// newBaseVhost 创建基础虚拟主机实例
+func newBaseVhost(configDir string) (*baseVhost, error) {
+	if configDir == "" {
+		return nil, fmt.Errorf("config directory is required")
+	}
+
+	v := &baseVhost{
+		configDir: configDir,
+	}
+
+	// 加载配置
+	var config *Config
+	var err error
+
+	// 从配置目录加载主配置文件
+	configFile := filepath.Join(v.configDir, "caddy.conf")
+	if _, statErr := os.Stat(configFile); statErr == nil {
+		config, err = ParseFile(configFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse caddy config: %w", err)
+		}
+	}
+
+	// 如果没有配置文件，使用默认配置
+	if config == nil {
+		config, err = ParseString(DefaultConf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse default config: %w", err)
+		}
+	}
+
+	v.config = config
+
+	// 获取第一个站点块
+	if v.site = config.Site(); v.site == nil {
+		v.site = config.AddSite("http://:80")
+	}
+
+	return v, nil
+}
+
+// NewStaticVhost 创建纯静态虚拟主机实例
+func NewStaticVhost(configDir string) (*StaticVhost, error) {
+	base, err := newBaseVhost(configDir)
+	if err != nil {
+		return nil, err
+	}
+	return &StaticVhost{baseVhost: base}, nil
+}
+
+// NewPHPVhost 创建 PHP 虚拟主机实例
+func NewPHPVhost(configDir string) (*PHPVhost, error) {
+	base, err := newBaseVhost(configDir)
+	if err != nil {
+		return nil, err
+	}
+	return &PHPVhost{baseVhost: base}, nil
+}
+
+// NewProxyVhost 创建反向代理虚拟主机实例
+func NewProxyVhost(configDir string) (*ProxyVhost, error) {
+	base, err := newBaseVhost(configDir)
+	if err != nil {
+		return nil, err
+	}
+	return &ProxyVhost{baseVhost: base}, nil
+}
+
+func (v *baseVhost) Enable() bool {
+	return v.Root() != DisablePagePath
+}
+
+func (v *baseVhost) SetEnable(enable bool) error {
+	path := DisablePagePath
+
+	if enable {
+		// 尝试获取保存的根目录
+		if root, err := os.ReadFile(filepath.Join(v.configDir, "root.saved")); err != nil {
+			path = filepath.Join(SitesPath, filepath.Dir(v.configDir), "public") // 默认根目录
+		} else {
+			path = strings.TrimSpace(string(root))
+		}
+	} else {
+		// 禁用时，保存当前根目录
+		currentRoot := v.Root()
+		if currentRoot != "" && currentRoot != DisablePagePath {
+			if err := os.WriteFile(filepath.Join(v.configDir, "root.saved"), []byte(currentRoot), 0644); err != nil {
+				return fmt.Errorf("failed to save current root: %w", err)
+			}
+		}
+	}
+
+	// 设置根目录
+	if err := v.SetRoot(path); err != nil {
+		return err
+	}
+
+	// 清理保存的根目录文件
+	if enable {
+		_ = os.RemoveAll(filepath.Join(v.configDir, "root.saved"))
+	}
+
+	// 设置导入配置
+	v.site.RemoveDirectives("import")
+	if enable {
+		v.site.AddDirective("import", fmt.Sprintf("%s/site/*.conf", v.configDir))
+	}
+
+	return nil
+}
+
+func (v *baseVhost) Listen() []types.Listen {
+	var binds []string
+	if bind := v.site.GetDirective("bind"); bind != nil {
+		binds = bind.Args
+	}
+
+	var result []types.Listen
+	seen := make(map[string]bool)
+	for _, addr := range v.site.Addresses() {
+		scheme, _, port := splitSiteAddress(addr)
+		if seen[scheme+port] {
+			continue
+		}
+		seen[scheme+port] = true
+
+		args := []string{}
+		if scheme == "https" {
+			args = append(args, "ssl")
+		}
+		if len(binds) == 0 {
+			result = append(result, types.Listen{Address: port, Args: args})
+			continue
+		}
+		for _, bind := range binds {
+			result = append(result, types.Listen{Address: net.JoinHostPort(bind, port), Args: args})
+		}
+	}
+
+	return result
+}
+
+func (v *baseVhost) SetListen(listens []types.Listen) error {
+	v.setAddresses(listens, v.ServerName())
+	return nil
+}
+
+func (v *baseVhost) ServerName() []string {
+	var names []string
+	for _, addr := range v.site.Addresses() {
+		_, host, _ := splitSiteAddress(addr)
+		if host != "" && !slices.Contains(names, host) {
+			names = append(names, host)
+		}
+	}
+	return names
+}
+
+func (v *baseVhost) SetServerName(serverName []string) error {
+	if len(serverName) == 0 {
+		return nil
+	}
+	v.setAddresses(v.Listen(), serverName)
+	return nil
+}
+
+func (v *baseVhost) Index() []string {
+	fileServer := v.site.GetDirective("file_server")
+	if fileServer == nil {
+		return nil
+	}
+	if index := fileServer.GetDirective("index"); index != nil && len(index.Args) > 0 {
+		return index.Args
+	}
+	return nil
+}
+
+func (v *baseVhost) SetIndex(index []string) error {
+	fileServer := v.site.GetDirective("file_server")
+	if fileServer == nil {
+		fileServer = v.site.AddBlock("file_server")
+	}
+	if fileServer.Block == nil {
+		fileServer.Block = []*Directive{}
+	}
+
+	if len(index) == 0 {
+		fileServer.RemoveDirectives("index")
+		return nil
+	}
+	fileServer.SetDirective("index", index...)
+	return nil
+}
+
+func (v *baseVhost) Root() string {
+	root := v.site.GetDirective("root")
+	if root == nil || len(root.Args) == 0 {
+		return ""
+	}
+	// root [<matcher>] <path>
+	return root.Args[len(root.Args)-1]
+}
+
+func (v *baseVhost) SetRoot(root string) error {
+	v.site.SetDirective("root", "*", root)
+	if !v.site.HasDirective("file_server") {
+		v.site.AddDirective("file_server")
+	}
+	return nil
+}
+
+func (v *baseVhost) Includes() []types.IncludeFile {
+	var result []types.IncludeFile
+	for _, dir := range v.site.GetDirectives("import") {
+		if len(dir.Args) > 0 {
+			result = append(result, types.IncludeFile{
+				Path:    dir.Args[0],
+				Comment: dir.Comments,
+			})
+		}
+	}
+	return result
+}
+
+func (v *baseVhost) SetIncludes(includes []types.IncludeFile) error {
+	v.site.RemoveDirectives("import")
+
+	for _, inc := range includes {
+		dir := v.site.AddDirective("import", inc.Path)
+		dir.Comments = inc.Comment
+	}
+
+	return nil
+}
+
+func (v *baseVhost) AccessLog() string {
+	return v.logOutput("020-access-log.conf")
+}
+
+func (v *baseVhost) SetAccessLog(accessLog string) error {
+	if accessLog == "" {
+		return v.RemoveConfig("020-access-log.conf", "site")
+	}
+	return v.SetConfig("020-access-log.conf", "site", fmt.Sprintf("log {\n\toutput file %s\n}\n", quote(accessLog)))
+}
+
+func (v *baseVhost) ErrorLog() string {
+	return v.logOutput("020-error-log.conf")
+}
+
+// SetErrorLog Caddy 没有单独的站点错误日志，这里记录级别为 ERROR 的请求日志（5xx 响应）
+func (v *baseVhost) SetErrorLog(errorLog string) error {
+	if errorLog == "" {
+		return v.RemoveConfig("020-error-log.conf", "site")
+	}
+	return v.SetConfig("020-error-log.conf", "site", fmt.Sprintf("log {\n\toutput file %s\n\tlevel ERROR\n}\n", quote(errorLog)))
+}
+
+// logOutput 从日志配置文件中读取输出文件路径
+func (v *baseVhost) logOutput(name string) string {
+	config, err := ParseString(v.Config(name, "site"))
+	if err != nil {
+		return ""
+	}
+	for _, dir := range config.Directives {
+		if dir.Name != "log" {
+			continue
+		}
+		if output := dir.GetDirective("output"); output != nil && len(output.Args) >= 2 && output.Args[0] == "file" {
+			return output.Args[1]
+		}
+	}
+	return ""
+}
+
+func (v *baseVhost) Save() error {
+	configFile := filepath.Join(v.configDir, "caddy.conf")
+	content := v.config.Export()
+	if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to save config file: %w", err)
+	}
+
+	return nil
+}
+
+func (v *baseVhost) Reset() error {
+	// 重置配置为默认值
+	config, err := ParseString(DefaultConf)
+	if err != nil {
+		return fmt.Errorf("failed to reset config: %w", err)
+	}
+
+	v.config = config
+	v.site = config.Site()
+
+	return nil
+}
+
+func (v *baseVhost) Config(name string, typ string) string {
+	conf := filepath.Join(v.configDir, typ, name)
+	content, err := os.ReadFile(conf)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
+
+func (v *baseVhost) SetConfig(name string, typ string, content string) error {
+	conf := filepath.Join(v.configDir, typ, name)
+	if err := os.WriteFile(conf, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+func (v *baseVhost) RemoveConfig(name string, typ string) error {
+	conf := filepath.Join(v.configDir, typ, name)
+	if err := os.Remove(conf); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove config file: %w", err)
+	}
+	return nil
+}
+
+func (v *baseVhost) SSL() bool {
+	return v.site.HasDirective("tls")
+}
+
+func (v *baseVhost) SSLConfig() *types.SSLConfig {
+	tls := v.site.GetDirective("tls")
+	if tls == nil {
+		return nil
+	}
+
+	config := &types.SSLConfig{
+		OCSP: true, // Caddy 始终开启 OCSP Stapling
+	}
+
+	// 证书，未设置时使用自动 HTTPS
+	if len(tls.Args) >= 2 {
+		config.Cert = tls.Args[0]
+		config.Key = tls.Args[1]
+	}
+
+	// 协议，Caddy 使用最低和最高版本表示
+	if protocols := tls.GetDirective("protocols"); protocols != nil && len(protocols.Args) > 0 {
+		minVersion, maxVersion := protocols.Args[0], "tls1.3"
+		if len(protocols.Args) > 1 {
+			maxVersion = protocols.Args[1]
+		}
+		for _, version := range []string{"tls1.2", "tls1.3"} {
+			if version >= minVersion && version <= maxVersion {
+				config.Protocols = append(config.Protocols, "TLSv"+strings.TrimPrefix(version, "tls"))
+			}
+		}
+	}
+
+	// 加密套件
+	if ciphers := tls.GetDirective("ciphers"); ciphers != nil {
+		var names []string
+		for _, cipher := range ciphers.Args {
+			name := cipher
+			for openssl, iana := range cipherSuites {
+				if iana == cipher {
+					name = openssl
+					break
+				}
+			}
+			names = append(names, name)
+		}
+		config.Ciphers = strings.Join(names, ":")
+	}
+
+	// 响应头
+	for _, header := range v.site.GetDirectives("header") {
+		if len(header.Args) < 2 {
+			continue
+		}
+		switch header.Args[0] {
+		case "Strict-Transport-Security":
+			config.HSTS = true
+		case "Alt-Svc":
+			config.AltSvc = header.Args[1]
+		}
+	}
+
+	// HTTP 重定向
+	config.HTTPRedirect = v.site.HasDirective("@http_redirect")
+
+	return config
+}
+
+// SetSSLConfig 证书和私钥为空时使用 Caddy 的自动 HTTPS 申请证书
+// Caddy 开启 HTTP/3 时会自动发送 Alt-Svc 头，因此忽略 AltSvc 配置
+func (v *baseVhost) SetSSLConfig(cfg *types.SSLConfig) error {
+	if cfg == nil {
+		return fmt.Errorf("SSL config cannot be nil")
+	}
+
+	// 证书
+	v.site.RemoveDirectives("tls")
+	tls := v.site.AddBlock("tls")
+	if cfg.Cert != "" && cfg.Key != "" {
+		tls.Args = []string{cfg.Cert, cfg.Key}
+	}
+
+	// 协议
+	var versions []string
+	for _, protocol := range cfg.Protocols {
+		version := "tls" + strings.TrimPrefix(protocol, "TLSv")
+		if version == "tls1.2" || version == "tls1.3" {
+			versions = append(versions, version)
+		}
+	}
+	if len(versions) > 0 {
+		slices.Sort(versions)
+		tls.AddDirective("protocols", versions[0], versions[len(versions)-1])
+	}
+
+	// 加密套件，仅作用于 TLS 1.2
+	if cfg.Ciphers != "" && (len(versions) == 0 || versions[0] == "tls1.2") {
+		var ciphers []string
+		for _, cipher := range strings.Split(cfg.Ciphers, ":") {
+			if iana, ok := cipherSuites[cipher]; ok {
+				ciphers = append(ciphers, iana)
+			} else if strings.HasPrefix(cipher, "TLS_") {
+				ciphers = append(ciphers, cipher)
+			}
+		}
+		if len(ciphers) > 0 {
+			tls.AddDirective("ciphers", ciphers...)
+		}
+	}
+
+	// HSTS
+	v.removeHeader("Strict-Transport-Security")
+	if cfg.HSTS {
+		v.site.AddDirective("header", "Strict-Transport-Security", "max-age=31536000")
+	}
+
+	// HTTP 重定向
+	v.clearHTTPRedirect()
+	if cfg.HTTPRedirect {
+		v.site.AddDirective("@http_redirect", "protocol", "http")
+		v.site.AddDirective("redir", "@http_redirect", "https://{host}{uri}", "308")
+	}
+
+	// 确保有 HTTPS 监听
+	listens := v.Listen()
+	hasSSL := slices.ContainsFunc(listens, func(l types.Listen) bool {
+		return slices.Contains(l.Args, "ssl")
+	})
+	if !hasSSL {
+		listens = append(listens, types.Listen{Address: "443", Args: []string{"ssl"}})
+		v.setAddresses(listens, v.ServerName())
+	}
+
+	return nil
+}
+
+func (v *baseVhost) ClearSSL() error {
+	v.site.RemoveDirectives("tls")
+	v.removeHeader("Strict-Transport-Security")
+	v.clearHTTPRedirect()
+
+	// 移除 HTTPS 监听
+	var listens []types.Listen
+	for _, l := range v.Listen() {
+		if !slices.Contains(l.Args, "ssl") {
+			listens = append(listens, l)
+		}
+	}
+	if len(listens) == 0 {
+		listens = []types.Listen{{Address: "80"}}
+	}
+	v.setAddresses(listens, v.ServerName())
+
+	return nil
+}
+
+// RateLimit 使用 caddy-ratelimit 插件限制请求速率，Caddy 不支持带宽和并发连接数限制
+// Rate 和 Zone 的值为请求数，如 "10r/s"、"600r/m"，只写数字时按每秒计算
+func (v *baseVhost) RateLimit() *types.RateLimit {
+	rateLimit := v.site.GetDirective("rate_limit")
+	if rateLimit == nil {
+		return nil
+	}
+
+	result := &types.RateLimit{
+		Zone: make(map[string]string),
+	}
+	for _, zone := range rateLimit.GetDirectives("zone") {
+		if len(zone.Args) == 0 {
+			continue
+		}
+		rate := zone.GetDirectiveValue("events") + "r/s"
+		if zone.GetDirectiveValue("window") == "1m" {
+			rate = zone.GetDirectiveValue("events") + "r/m"
+		}
+		if zone.Args[0] == "rate" {
+			result.Rate = rate
+		} else {
+			result.Zone[zone.Args[0]] = rate
+		}
+	}
+
+	return result
+}
+
+func (v *baseVhost) SetRateLimit(limit *types.RateLimit) error {
+	rateLimit := &Directive{Name: "rate_limit", Block: []*Directive{}}
+
+	addZone := func(name, key, rate string) error {
+		events, window, err := parseRate(rate)
+		if err != nil {
+			return err
+		}
+		zone := rateLimit.AddBlock("zone", name)
+		zone.AddDirective("key", key)
+		zone.AddDirective("events", events)
+		zone.AddDirective("window", window)
+		return nil
+	}
+
+	if limit.Rate != "" {
+		if err := addZone("rate", "static", limit.Rate); err != nil {
+			return err
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(limit.Zone)) {
+		key := "{remote_host}"
+		if name == "perserver" {
+			key = "static"
+		}
+		if err := addZone(name, key, limit.Zone[name]); err != nil {
+			return err
+		}
+	}
+
+	v.site.RemoveDirectives("rate_limit")
+	if len(rateLimit.Block) > 0 {
+		v.site.Block = append(v.site.Block, rateLimit)
+	}
+
+	return nil
+}
+
+func (v *baseVhost) ClearRateLimit() error {
+	v.site.RemoveDirectives("rate_limit")
+	return nil
+}
+
+// BasicAuth Caddy 不支持 htpasswd 文件，user_file 为每行 "用户名 bcrypt 哈希" 格式的文件，通过 import 导入
+func (v *baseVhost) BasicAuth() map[string]string {
+	auth := v.site.GetDirective("basic_auth")
+	if auth == nil {
+		auth = v.site.GetDirective("basicauth") // Caddy 2.8 之前的名称
+	}
+	if auth == nil {
+		return nil
+	}
+
+	realm := ""
+	if len(auth.Args) >= 2 {
+		realm = auth.Args[1]
+	}
+
+	return map[string]string{
+		"realm":     realm,
+		"user_file": auth.GetDirectiveValue("import"),
+	}
+}
+
+func (v *baseVhost) SetBasicAuth(auth map[string]string) error {
+	realm := auth["realm"]
+	userFile := auth["user_file"]
+
+	if realm == "" {
+		realm = "Restricted"
+	}
+
+	_ = v.ClearBasicAuth()
+	basicAuth := v.site.AddBlock("basic_auth", "bcrypt", realm)
+	basicAuth.AddDirective("import", userFile)
+
+	return nil
+}
+
+func (v *baseVhost) ClearBasicAuth() error {
+	v.site.RemoveDirectives("basic_auth")
+	v.site.RemoveDirectives("basicauth")
+	return nil
+}
+
+func (v *baseVhost) Redirects() []types.Redirect {
+	siteDir := filepath.Join(v.configDir, "site")
+	redirects, _ := parseRedirectFiles(siteDir)
+	return redirects
+}
+
+func (v *baseVhost) SetRedirects(redirects []types.Redirect) error {
+	siteDir := filepath.Join(v.configDir, "site")
+	return writeRedirectFiles(siteDir, redirects)
+}
+
+// setAddresses 按监听地址和域名生成站点地址，监听地址中的 IP 写入 bind
+func (v *baseVhost) setAddresses(listens []types.Listen, names []string) {
+	var addresses, binds []string
+	for _, l := range listens {
+		host, port := splitListen(l.Address)
+		if host != "" && !slices.Contains(binds, host) {
+			binds = append(binds, host)
+		}
+
+		scheme := "http"
+		if slices.Contains(l.Args, "ssl") || slices.Contains(l.Args, "quic") {
+			scheme = "https"
+		}
+
+		hosts := names
+		if len(hosts) == 0 {
+			hosts = []string{""}
+		}
+		for _, name := range hosts {
+			addr := fmt.Sprintf("%s://%s:%s", scheme, name, port)
+			if !slices.Contains(addresses, addr) {
+				addresses = append(addresses, addr)
+			}
+		}
+	}
+
+	v.site.SetAddresses(addresses)
+	v.site.RemoveDirectives("bind")
+	if len(binds) > 0 {
+		// bind 放在最前面，便于阅读
+		v.site.Block = append([]*Directive{{Name: "bind", Args: binds}}, v.site.Block...)
+	}
+}
+
+// removeHeader 删除指定名称的响应头指令，保留其他响应头
+func (v *baseVhost) removeHeader(name string) {
+	v.site.RemoveDirectives("header", func(dir *Directive) bool {
+		return len(dir.Args) > 0 && strings.EqualFold(dir.Args[0], name)
+	})
+}
+
+// clearHTTPRedirect 删除 HTTP 跳转 HTTPS 的配置
+func (v *baseVhost) clearHTTPRedirect() {
+	v.site.RemoveDirectives("@http_redirect")
+	v.site.RemoveDirectives("redir", func(dir *Directive) bool {
+		return len(dir.Args) > 0 && dir.Args[0] == "@http_redirect"
+	})
+}
+
+// splitSiteAddress 拆分站点地址，如 https://example.com:443、:80、example.com
+func splitSiteAddress(addr string) (scheme, host, port string) {
+	scheme, rest, found := strings.Cut(addr, "://")
+	if !found {
+		scheme, rest = "", addr
+	}
+
+	if h, p, err := net.SplitHostPort(rest); err == nil {
+		host, port = h, p
+	} else {
+		host = rest
+	}
+
+	switch {
+	case scheme == "" && port == "80":
+		scheme = "http"
+	case scheme == "":
+		scheme = "https"
+	}
+	if port == "" {
+		port = "443"
+		if scheme == "http" {
+			port = "80"
+		}
+	}
+
+	return scheme, host, port
+}
+
+// splitListen 拆分监听地址，通配地址返回空主机
+func splitListen(address string) (host, port string) {
+	if _, err := strconv.Atoi(address); err == nil {
+		return "", address
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", address
+	}
+	if host == "*" || host == "0.0.0.0" || host == "::" {
+		host = ""
+	}
+	return host, port
+}
+
+// rateLimitPattern 匹配请求速率，如 10r/s、600r/m、10
+var rateLimitPattern = regexp.MustCompile(`^(\d+)(?:r/([sm]))?$`)
+
+// parseRate 将请求速率转换为 caddy-ratelimit 的事件数和时间窗口
+func parseRate(rate string) (string, string, error) {
+	matches := rateLimitPattern.FindStringSubmatch(strings.TrimSpace(rate))
+	if matches == nil {
+		return "", "", fmt.Errorf("unsupported rate limit: %s, caddy only supports request rate like 10r/s", rate)
+	}
+	if matches[2] == "m" {
+		return matches[1], "1m", nil
+	}
+	return matches[1], "1s", nil
+}
+
+// ========== PHPVhost ==========
+
+func (v *PHPVhost) PHP() uint {
+	content := v.Config("010-php.conf", "site")
+	if content == "" {
+		return 0
+	}
+
+	// 从配置内容中提取版本号
+	// 格式: php_fastcgi unix//tmp/php-cgi-84.sock
+	idx := strings.Index(content, "php-cgi-")
+	if idx == -1 {
+		return 0
+	}
+
+	var result uint
+	_, err := fmt.Sscanf(content[idx:], "php-cgi-%d.sock", &result)
+	if err != nil {
+		return 0
+	}
+	return result
+}
+
+func (v *PHPVhost) SetPHP(version uint) error {
+	if version == 0 {
+		return v.RemoveConfig("010-php.conf", "site")
+	}
+
+	// 生成 PHP-FPM 配置
+	content := fmt.Sprintf("php_fastcgi unix//tmp/php-cgi-%d.sock\n", version)
+
+	return v.SetConfig("010-php.conf", "site", content)
+}
+
+// ========== ProxyVhost ==========
+
+func (v *ProxyVhost) Proxies() []types.Proxy {
+	siteDir := filepath.Join(v.configDir, "site")
+	proxies, _ := parseProxyFiles(siteDir)
+	return proxies
+}
+
+func (v *ProxyVhost) SetProxies(proxies []types.Proxy) error {
+	var upstreams []string
+	for name := range v.Upstreams() {
+		upstreams = append(upstreams, name)
+	}
+
+	siteDir := filepath.Join(v.configDir, "site")
+	return writeProxyFiles(siteDir, proxies, upstreams)
+}
+
+func (v *ProxyVhost) ClearProxies() error {
+	siteDir := filepath.Join(v.configDir, "site")
+	return clearProxyFiles(siteDir)
+}
+
+func (v *ProxyVhost) Upstreams() map[string]types.Upstream {
+	sharedDir := filepath.Join(v.configDir, "shared")
+	upstreams, _ := parseUpstreamFiles(sharedDir)
+	return upstreams
+}
+
+func (v *ProxyVhost) SetUpstreams(upstreams map[string]types.Upstream) error {
+	sharedDir := filepath.Join(v.configDir, "shared")
+	return writeUpstreamFiles(sharedDir, upstreams)
+}
+
+func (v *ProxyVhost) ClearUpstreams() error {
+	sharedDir := filepath.Join(v.configDir, "shared")
+	return clearUpstreamFiles(sharedDir)
+}
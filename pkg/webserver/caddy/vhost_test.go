@@ -0,0 +1,785 @@
+package caddy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/acepanel/panel/pkg/webserver/types"
+)
+
+type VhostTestSuite struct {
+	suite.Suite
+	vhost     *PHPVhost
+	configDir string
+}
+
+func TestVhostTestSuite(t *testing.T) {
+	suite.Run(t, &VhostTestSuite{})
+}
+
+func (s *VhostTestSuite) SetupTest() {
+	// 创建临时配置目录
+	configDir, err := os.MkdirTemp("", "caddy-test-*")
+	s.Require().NoError(err)
+	s.configDir = configDir
+
+	// 创建 site 目录
+	err = os.MkdirAll(filepath.Join(configDir, "site"), 0755)
+	s.Require().NoError(err)
+
+	vhost, err := NewPHPVhost(configDir)
+	s.Require().NoError(err)
+	s.Require().NotNil(vhost)
+	s.vhost = vhost
+}
+
+func (s *VhostTestSuite) TearDownTest() {
+	// 清理临时目录
+	if s.configDir != "" {
+		s.NoError(os.RemoveAll(s.configDir))
+	}
+}
+
+func (s *VhostTestSuite) TestNewVhost() {
+	s.Equal(s.configDir, s.vhost.configDir)
+	s.NotNil(s.vhost.config)
+	s.NotNil(s.vhost.site)
+}
+
+func (s *VhostTestSuite) TestEnable() {
+	// 默认应该是启用状态
+	s.True(s.vhost.Enable())
+
+	// 禁用网站
+	s.NoError(s.vhost.SetEnable(false))
+	s.False(s.vhost.Enable())
+	s.Empty(s.vhost.Includes())
+
+	// 重新启用
+	s.NoError(s.vhost.SetEnable(true))
+	s.True(s.vhost.Enable())
+	s.Len(s.vhost.Includes(), 1)
+}
+
+func (s *VhostTestSuite) TestServerName() {
+	names := []string{"example.com", "www.example.com", "api.example.com"}
+	s.NoError(s.vhost.SetServerName(names))
+
+	got := s.vhost.ServerName()
+	s.Len(got, 3)
+	s.Equal("example.com", got[0])
+	s.Equal("www.example.com", got[1])
+	s.Equal("api.example.com", got[2])
+
+	// 域名与监听组合为站点地址
+	s.Equal([]string{"http://example.com:80", "http://www.example.com:80", "http://api.example.com:80"}, s.vhost.site.Addresses())
+}
+
+func (s *VhostTestSuite) TestServerNameEmpty() {
+	s.NoError(s.vhost.SetServerName([]string{}))
+}
+
+func (s *VhostTestSuite) TestRoot() {
+	root := "/var/www/html"
+	s.NoError(s.vhost.SetRoot(root))
+	s.Equal(root, s.vhost.Root())
+}
+
+func (s *VhostTestSuite) TestIndex() {
+	index := []string{"index.html", "index.php", "default.html"}
+	s.NoError(s.vhost.SetIndex(index))
+
+	got := s.vhost.Index()
+	s.Len(got, 3)
+	s.Equal(index, got)
+}
+
+func (s *VhostTestSuite) TestIndexEmpty() {
+	s.NoError(s.vhost.SetIndex([]string{}))
+	s.Nil(s.vhost.Index())
+}
+
+func (s *VhostTestSuite) TestListen() {
+	listens := []types.Listen{
+		{Address: "80"},
+		{Address: "443", Args: []string{"ssl"}},
+	}
+	s.NoError(s.vhost.SetListen(listens))
+
+	got := s.vhost.Listen()
+	s.Len(got, 2)
+	s.Equal("80", got[0].Address)
+	s.Empty(got[0].Args)
+	s.Equal("443", got[1].Address)
+	s.Equal([]string{"ssl"}, got[1].Args)
+	s.Equal([]string{"http://localhost:80", "https://localhost:443"}, s.vhost.site.Addresses())
+}
+
+func (s *VhostTestSuite) TestListenWithBind() {
+	listens := []types.Listen{
+		{Address: "127.0.0.1:8080"},
+		{Address: "[::]:8080"},
+	}
+	s.NoError(s.vhost.SetListen(listens))
+
+	// 通配地址不写入 bind
+	s.Equal([]string{"127.0.0.1"}, s.vhost.site.GetDirective("bind").Args)
+
+	got := s.vhost.Listen()
+	s.Len(got, 1)
+	s.Equal("127.0.0.1:8080", got[0].Address)
+}
+
+func (s *VhostTestSuite) TestSSL() {
+	s.False(s.vhost.SSL())
+	s.Nil(s.vhost.SSLConfig())
+}
+
+func (s *VhostTestSuite) TestSetSSLConfig() {
+	sslConfig := &types.SSLConfig{
+		Cert:      "/etc/ssl/cert.pem",
+		Key:       "/etc/ssl/key.pem",
+		Protocols: []string{"TLSv1.2", "TLSv1.3"},
+		Ciphers:   "ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256",
+		HSTS:      true,
+		OCSP:      true,
+	}
+	s.NoError(s.vhost.SetSSLConfig(sslConfig))
+
+	s.True(s.vhost.SSL())
+
+	got := s.vhost.SSLConfig()
+	s.NotNil(got)
+	s.Equal(sslConfig.Cert, got.Cert)
+	s.Equal(sslConfig.Key, got.Key)
+	s.Equal(sslConfig.Protocols, got.Protocols)
+	s.Equal(sslConfig.Ciphers, got.Ciphers)
+	s.True(got.HSTS)
+	s.True(got.OCSP)
+
+	// 自动添加 HTTPS 监听
+	s.Contains(s.vhost.site.Addresses(), "https://localhost:443")
+}
+
+func (s *VhostTestSuite) TestSetSSLConfigNil() {
+	s.Error(s.vhost.SetSSLConfig(nil))
+}
+
+func (s *VhostTestSuite) TestAutomaticHTTPS() {
+	s.NoError(s.vhost.SetServerName([]string{"example.com"}))
+	s.NoError(s.vhost.SetSSLConfig(&types.SSLConfig{}))
+
+	s.True(s.vhost.SSL())
+	got := s.vhost.SSLConfig()
+	s.Empty(got.Cert)
+	s.Empty(got.Key)
+
+	// 没有证书时不写入证书路径，由 Caddy 自动申请
+	content := s.vhost.config.Export()
+	s.Contains(content, "https://example.com:443")
+	s.Contains(content, "\ttls {\n")
+}
+
+func (s *VhostTestSuite) TestHTTPSRedirect() {
+	sslConfig := &types.SSLConfig{
+		Cert:         "/etc/ssl/cert.pem",
+		Key:          "/etc/ssl/key.pem",
+		HTTPRedirect: true,
+	}
+	s.NoError(s.vhost.SetSSLConfig(sslConfig))
+
+	got := s.vhost.SSLConfig()
+	s.NotNil(got)
+	s.True(got.HTTPRedirect)
+
+	content := s.vhost.config.Export()
+	s.Contains(content, "@http_redirect protocol http")
+	s.Contains(content, "redir @http_redirect https://{host}{uri} 308")
+}
+
+func (s *VhostTestSuite) TestClearSSL() {
+	sslConfig := &types.SSLConfig{
+		Cert:         "/etc/ssl/cert.pem",
+		Key:          "/etc/ssl/key.pem",
+		HSTS:         true,
+		HTTPRedirect: true,
+	}
+	s.NoError(s.vhost.SetSSLConfig(sslConfig))
+	s.True(s.vhost.SSL())
+
+	s.NoError(s.vhost.ClearSSL())
+	s.False(s.vhost.SSL())
+	s.Equal([]string{"http://localhost:80"}, s.vhost.site.Addresses())
+	s.NotContains(s.vhost.config.Export(), "redir")
+}
+
+func (s *VhostTestSuite) TestClearHTTPSPreservesOtherHeaders() {
+	// 添加一个非 HSTS 的 Header
+	s.vhost.site.AddDirective("header", "X-Custom-Header", "value")
+
+	sslConfig := &types.SSLConfig{
+		Cert: "/etc/ssl/cert.pem",
+		Key:  "/etc/ssl/key.pem",
+		HSTS: true,
+	}
+	s.NoError(s.vhost.SetSSLConfig(sslConfig))
+	s.NoError(s.vhost.ClearSSL())
+
+	headers := s.vhost.site.GetDirectives("header")
+	s.Len(headers, 1)
+	s.Equal("X-Custom-Header", headers[0].Args[0])
+}
+
+func (s *VhostTestSuite) TestPHP() {
+	s.Equal(uint(0), s.vhost.PHP())
+
+	s.NoError(s.vhost.SetPHP(84))
+	s.Equal(uint(84), s.vhost.PHP())
+	s.Contains(s.vhost.Config("010-php.conf", "site"), "php_fastcgi unix//tmp/php-cgi-84.sock")
+
+	s.NoError(s.vhost.SetPHP(0))
+	s.Equal(uint(0), s.vhost.PHP())
+}
+
+func (s *VhostTestSuite) TestAccessLog() {
+	accessLog := "/var/log/caddy/access.log"
+	s.NoError(s.vhost.SetAccessLog(accessLog))
+	s.Equal(accessLog, s.vhost.AccessLog())
+}
+
+func (s *VhostTestSuite) TestErrorLog() {
+	errorLog := "/var/log/caddy/error.log"
+	s.NoError(s.vhost.SetErrorLog(errorLog))
+	s.Equal(errorLog, s.vhost.ErrorLog())
+	s.Contains(s.vhost.Config("020-error-log.conf", "site"), "level ERROR")
+}
+
+func (s *VhostTestSuite) TestIncludes() {
+	includes := []types.IncludeFile{
+		{Path: "/etc/caddy/conf.d/ssl.conf"},
+		{Path: "/etc/caddy/conf.d/php.conf", Comment: []string{"php"}},
+	}
+	s.NoError(s.vhost.SetIncludes(includes))
+
+	got := s.vhost.Includes()
+	s.Len(got, 2)
+	s.Equal(includes[0].Path, got[0].Path)
+	s.Equal(includes[1].Path, got[1].Path)
+	s.Equal(includes[1].Comment, got[1].Comment)
+}
+
+func (s *VhostTestSuite) TestBasicAuth() {
+	s.Nil(s.vhost.BasicAuth())
+
+	auth := map[string]string{
+		"realm":     "Test Realm",
+		"user_file": "/etc/caddy/users",
+	}
+	s.NoError(s.vhost.SetBasicAuth(auth))
+
+	got := s.vhost.BasicAuth()
+	s.NotNil(got)
+	s.Equal(auth["realm"], got["realm"])
+	s.Equal(auth["user_file"], got["user_file"])
+	s.Contains(s.vhost.config.Export(), `basic_auth bcrypt "Test Realm" {`)
+
+	s.NoError(s.vhost.ClearBasicAuth())
+	s.Nil(s.vhost.BasicAuth())
+}
+
+func (s *VhostTestSuite) TestRateLimit() {
+	s.Nil(s.vhost.RateLimit())
+
+	limit := &types.RateLimit{
+		Rate: "10r/s",
+		Zone: map[string]string{
+			"perip": "600r/m",
+		},
+	}
+	s.NoError(s.vhost.SetRateLimit(limit))
+
+	got := s.vhost.RateLimit()
+	s.NotNil(got)
+	s.Equal("10r/s", got.Rate)
+	s.Equal("600r/m", got.Zone["perip"])
+
+	s.NoError(s.vhost.ClearRateLimit())
+	s.Nil(s.vhost.RateLimit())
+}
+
+func (s *VhostTestSuite) TestRateLimitBandwidth() {
+	// Caddy 不支持带宽限制
+	s.Error(s.vhost.SetRateLimit(&types.RateLimit{Rate: "512k"}))
+	s.Nil(s.vhost.RateLimit())
+}
+
+func (s *VhostTestSuite) TestReset() {
+	s.NoError(s.vhost.SetServerName([]string{"modified.com"}))
+	s.NoError(s.vhost.SetRoot("/modified/path"))
+
+	s.NoError(s.vhost.Reset())
+
+	names := s.vhost.ServerName()
+	s.NotContains(names, "modified.com")
+}
+
+func (s *VhostTestSuite) TestSave() {
+	s.NoError(s.vhost.SetServerName([]string{"save-test.com"}))
+	s.NoError(s.vhost.Save())
+
+	// 验证配置文件已保存
+	configFile := filepath.Join(s.configDir, "caddy.conf")
+	content, err := os.ReadFile(configFile)
+	s.NoError(err)
+	s.Contains(string(content), "save-test.com")
+
+	// 重新加载后配置不变
+	vhost, err := NewPHPVhost(s.configDir)
+	s.NoError(err)
+	s.Equal([]string{"save-test.com"}, vhost.ServerName())
+	s.Equal(s.vhost.Root(), vhost.Root())
+}
+
+func (s *VhostTestSuite) TestExport() {
+	s.NoError(s.vhost.SetServerName([]string{"export-test.com"}))
+	s.NoError(s.vhost.SetRoot("/var/www/export-test"))
+
+	content := s.vhost.config.Export()
+	s.NotEmpty(content)
+	s.Contains(content, "export-test.com")
+	s.Contains(content, "root * /var/www/export-test")
+	s.Contains(content, "file_server")
+}
+
+func (s *VhostTestSuite) TestExportWithSSL() {
+	sslConfig := &types.SSLConfig{
+		Cert:      "/etc/ssl/cert.pem",
+		Key:       "/etc/ssl/key.pem",
+		Protocols: []string{"TLSv1.2", "TLSv1.3"},
+	}
+	s.NoError(s.vhost.SetSSLConfig(sslConfig))
+
+	content := s.vhost.config.Export()
+	s.Contains(content, "tls /etc/ssl/cert.pem /etc/ssl/key.pem {")
+	s.Contains(content, "protocols tls1.2 tls1.3")
+}
+
+func (s *VhostTestSuite) TestDefaultConfIncludesServerD() {
+	// 验证默认配置包含 site 和 shared 的 import
+	s.Contains(DefaultConf, "site/*.conf")
+	s.Contains(DefaultConf, "shared/*.conf")
+	s.Contains(DefaultConf, "import")
+}
+
+func (s *VhostTestSuite) TestRedirects() {
+	// 初始应该没有重定向
+	s.Empty(s.vhost.Redirects())
+
+	// 设置重定向
+	redirects := []types.Redirect{
+		{
+			Type:       types.RedirectTypeURL,
+			From:       "/old",
+			To:         "/new",
+			StatusCode: 301,
+		},
+		{
+			Type:       types.RedirectTypeHost,
+			From:       "old.example.com",
+			To:         "https://new.example.com",
+			KeepURI:    true,
+			StatusCode: 308,
+		},
+	}
+	s.NoError(s.vhost.SetRedirects(redirects))
+
+	// 验证重定向文件已创建
+	siteDir := filepath.Join(s.configDir, "site")
+	entries, err := os.ReadDir(siteDir)
+	s.NoError(err)
+
+	redirectCount := 0
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), "1") && strings.HasSuffix(entry.Name(), "-redirect.conf") {
+			redirectCount++
+		}
+	}
+	s.Equal(2, redirectCount)
+
+	// 验证可以读取回来
+	got := s.vhost.Redirects()
+	s.Equal(redirects, got)
+}
+
+func (s *VhostTestSuite) TestRedirectURL() {
+	redirects := []types.Redirect{
+		{
+			Type:       types.RedirectTypeURL,
+			From:       "/old-page",
+			To:         "/new-page",
+			KeepURI:    true,
+			StatusCode: 301,
+		},
+	}
+	s.NoError(s.vhost.SetRedirects(redirects))
+
+	// 读取配置文件内容
+	siteDir := filepath.Join(s.configDir, "site")
+	content, err := os.ReadFile(filepath.Join(siteDir, "100-redirect.conf"))
+	s.NoError(err)
+
+	s.Contains(string(content), "redir /old-page /new-page{uri} 301")
+}
+
+func (s *VhostTestSuite) TestRedirectHost() {
+	redirects := []types.Redirect{
+		{
+			Type:       types.RedirectTypeHost,
+			From:       "old.example.com",
+			To:         "https://new.example.com",
+			KeepURI:    true,
+			StatusCode: 308,
+		},
+	}
+	s.NoError(s.vhost.SetRedirects(redirects))
+
+	// 读取配置文件内容
+	siteDir := filepath.Join(s.configDir, "site")
+	content, err := os.ReadFile(filepath.Join(siteDir, "100-redirect.conf"))
+	s.NoError(err)
+
+	s.Contains(string(content), "@redirect_100 host old.example.com")
+	s.Contains(string(content), "redir @redirect_100 https://new.example.com{uri} 308")
+}
+
+func (s *VhostTestSuite) TestRedirect404() {
+	redirects := []types.Redirect{
+		{
+			Type:       types.RedirectType404,
+			To:         "/custom-404.html",
+			StatusCode: 308,
+		},
+	}
+	s.NoError(s.vhost.SetRedirects(redirects))
+
+	// 读取配置文件内容
+	siteDir := filepath.Join(s.configDir, "site")
+	content, err := os.ReadFile(filepath.Join(siteDir, "100-redirect.conf"))
+	s.NoError(err)
+
+	s.Contains(string(content), "handle_errors 404")
+	s.Contains(string(content), "redir /custom-404.html 308")
+	s.Equal(redirects, s.vhost.Redirects())
+}
+
+// ProxyVhost 测试套件
+type ProxyVhostTestSuite struct {
+	suite.Suite
+	vhost     *ProxyVhost
+	configDir string
+}
+
+func TestProxyVhostTestSuite(t *testing.T) {
+	suite.Run(t, &ProxyVhostTestSuite{})
+}
+
+func (s *ProxyVhostTestSuite) SetupTest() {
+	configDir, err := os.MkdirTemp("", "caddy-proxy-test-*")
+	s.Require().NoError(err)
+	s.configDir = configDir
+
+	// 创建 site 和 shared 目录
+	s.NoError(os.MkdirAll(filepath.Join(configDir, "site"), 0755))
+	s.NoError(os.MkdirAll(filepath.Join(configDir, "shared"), 0755))
+
+	vhost, err := NewProxyVhost(configDir)
+	s.Require().NoError(err)
+	s.vhost = vhost
+}
+
+func (s *ProxyVhostTestSuite) TearDownTest() {
+	if s.configDir != "" {
+		s.NoError(os.RemoveAll(s.configDir))
+	}
+}
+
+func (s *ProxyVhostTestSuite) TestProxies() {
+	// 初始应该没有代理配置
+	s.Empty(s.vhost.Proxies())
+
+	// 设置代理配置
+	proxies := []types.Proxy{
+		{
+			Location: "/",
+			Pass:     "http://backend:8080",
+			Host:     "example.com",
+			Replaces: map[string]string{},
+		},
+		{
+			Location:       "~* ^/api/v[0-9]+/",
+			Pass:           "https://api-backend:8443",
+			SNI:            "api.example.com",
+			Buffering:      true,
+			Resolver:       []string{"8.8.8.8"},
+			ConnectTimeout: 5 * time.Second,
+			Replaces:       map[string]string{"http://": "https://"},
+		},
+	}
+	s.NoError(s.vhost.SetProxies(proxies))
+
+	// 验证代理文件已创建
+	siteDir := filepath.Join(s.configDir, "site")
+	entries, err := os.ReadDir(siteDir)
+	s.NoError(err)
+
+	proxyCount := 0
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), "2") && strings.HasSuffix(entry.Name(), "-proxy.conf") {
+			proxyCount++
+		}
+	}
+	s.Equal(2, proxyCount)
+
+	// 验证可以读取回来
+	got := s.vhost.Proxies()
+	s.Equal(proxies, got)
+}
+
+func (s *ProxyVhostTestSuite) TestProxyConfig() {
+	proxies := []types.Proxy{
+		{
+			Location:  "^~ /api",
+			Pass:      "https://backend/app",
+			Host:      "example.com",
+			SNI:       "example.com",
+			Buffering: true,
+		},
+	}
+	s.NoError(s.vhost.SetProxies(proxies))
+
+	// 读取配置文件内容
+	siteDir := filepath.Join(s.configDir, "site")
+	content, err := os.ReadFile(filepath.Join(siteDir, "200-proxy.conf"))
+	s.NoError(err)
+
+	s.Contains(string(content), "handle /api* {")
+	s.Contains(string(content), "reverse_proxy https://backend {")
+	s.Contains(string(content), "header_up Host example.com")
+	s.Contains(string(content), "tls_server_name example.com")
+	s.Contains(string(content), "response_buffers")
+}
+
+func (s *ProxyVhostTestSuite) TestProxyRules() {
+	proxies := []types.Proxy{
+		{
+			Location:       "/api/",
+			Pass:           "http://backend:8080",
+			StripPrefix:    true,
+			WebSocket:      true,
+			ConnectTimeout: 5 * time.Second,
+			ReadTimeout:    time.Minute,
+			SendTimeout:    30 * time.Second,
+			Replaces:       map[string]string{},
+			RequestHeaders: types.ProxyHeaders{
+				Set:    map[string]string{"X-Api-Key": `se"cret`, "X-Real-IP": "{remote_host}"},
+				Remove: []string{"Cookie"},
+			},
+			ResponseHeaders: types.ProxyHeaders{
+				Set:    map[string]string{"X-Frame-Options": "DENY"},
+				Remove: []string{"X-Powered-By"},
+			},
+			CORS: &types.ProxyCORS{
+				Origins:       []string{"https://example.com", "https://app.example.org"},
+				Methods:       []string{"GET", "POST"},
+				Headers:       []string{"Content-Type", "Authorization"},
+				ExposeHeaders: []string{"X-Request-Id"},
+				Credentials:   true,
+				MaxAge:        86400,
+			},
+		},
+	}
+	s.NoError(s.vhost.SetProxies(proxies))
+
+	content, err := os.ReadFile(filepath.Join(s.configDir, "site", "200-proxy.conf"))
+	s.NoError(err)
+	s.Contains(string(content), "uri strip_prefix /api")
+	s.Contains(string(content), `header_up X-Api-Key "se\"cret"`)
+	s.Contains(string(content), "header_up -Cookie")
+	s.Contains(string(content), "header_down -X-Powered-By")
+	s.Contains(string(content), "stream_close_delay 5m")
+	s.Contains(string(content), "read_timeout 1m0s")
+	s.Contains(string(content), "@cors_200 header Origin https://example.com https://app.example.org")
+	s.Contains(string(content), "header @cors_200 Access-Control-Allow-Origin {http.request.header.Origin}")
+	s.Contains(string(content), "respond @preflight_200 204")
+
+	s.Equal(proxies, s.vhost.Proxies())
+}
+
+func (s *ProxyVhostTestSuite) TestClearProxies() {
+	proxies := []types.Proxy{
+		{Location: "/", Pass: "http://backend"},
+	}
+	s.NoError(s.vhost.SetProxies(proxies))
+	s.Len(s.vhost.Proxies(), 1)
+
+	s.NoError(s.vhost.ClearProxies())
+	s.Empty(s.vhost.Proxies())
+}
+
+func (s *ProxyVhostTestSuite) TestUpstreams() {
+	// 初始应该没有上游服务器配置
+	s.Empty(s.vhost.Upstreams())
+
+	// 设置上游服务器
+	upstreams := map[string]types.Upstream{
+		"backend": {
+			Servers: map[string]string{
+				"127.0.0.1:8080": "weight=5",
+				"127.0.0.1:8081": "weight=3",
+			},
+			Keepalive: 32,
+		},
+		"api": {
+			Servers: map[string]string{
+				"127.0.0.1:3000": "",
+			},
+			ServerOptions: map[string]types.UpstreamServer{
+				"127.0.0.1:3000": {MaxFails: 3, FailTimeout: 30 * time.Second},
+			},
+			Algo: "least_conn",
+		},
+	}
+	s.NoError(s.vhost.SetUpstreams(upstreams))
+
+	// 验证上游文件已创建
+	sharedDir := filepath.Join(s.configDir, "shared")
+	entries, err := os.ReadDir(sharedDir)
+	s.NoError(err)
+	s.Len(entries, 2)
+
+	// 验证可以读取回来
+	got := s.vhost.Upstreams()
+	s.Equal(upstreams, got)
+}
+
+func (s *ProxyVhostTestSuite) TestUpstreamHealth() {
+	upstreams := map[string]types.Upstream{
+		"backend": {
+			Servers: map[string]string{
+				"127.0.0.1:8080": "weight=5",
+				"127.0.0.1:8081": "weight=3",
+				"127.0.0.1:8082": "",
+			},
+			ServerOptions: map[string]types.UpstreamServer{
+				"127.0.0.1:8081": {Down: true},
+				"127.0.0.1:8082": {Backup: true},
+			},
+			HealthCheck: &types.UpstreamHealthCheck{
+				Type:     "http",
+				Path:     "/health",
+				Fails:    3,
+				MarkDown: true,
+			},
+		},
+	}
+	s.NoError(s.vhost.SetUpstreams(upstreams))
+
+	sharedDir := filepath.Join(s.configDir, "shared")
+	entries, err := os.ReadDir(sharedDir)
+	s.NoError(err)
+	s.Require().Len(entries, 1)
+	content, err := os.ReadFile(filepath.Join(sharedDir, entries[0].Name()))
+	s.NoError(err)
+	s.Contains(string(content), "# Health check: type=http path=/health fails=3 mark_down")
+	s.Contains(string(content), "# Down: 127.0.0.1:8081 weight=3")
+	s.Contains(string(content), "# Backup: 127.0.0.1:8082")
+	s.Contains(string(content), "to 127.0.0.1:8080\n")
+	s.Equal(upstreams, s.vhost.Upstreams())
+
+	// 其它服务器均不可用时启用备用服务器
+	upstreams["backend"].ServerOptions["127.0.0.1:8080"] = types.UpstreamServer{Down: true}
+	s.NoError(s.vhost.SetUpstreams(upstreams))
+	content, err = os.ReadFile(filepath.Join(sharedDir, entries[0].Name()))
+	s.NoError(err)
+	s.Contains(string(content), "to 127.0.0.1:8082\n")
+	s.Equal(upstreams, s.vhost.Upstreams())
+}
+
+func (s *ProxyVhostTestSuite) TestUpstreamConfig() {
+	upstreams := map[string]types.Upstream{
+		"mybackend": {
+			Servers: map[string]string{
+				"127.0.0.1:8080": "weight=5",
+				"127.0.0.1:8081": "",
+			},
+			Keepalive: 16,
+		},
+	}
+	s.NoError(s.vhost.SetUpstreams(upstreams))
+
+	// 读取配置文件内容
+	sharedDir := filepath.Join(s.configDir, "shared")
+	entries, err := os.ReadDir(sharedDir)
+	s.NoError(err)
+	s.Require().NotEmpty(entries)
+
+	content, err := os.ReadFile(filepath.Join(sharedDir, entries[0].Name()))
+	s.NoError(err)
+
+	s.Contains(string(content), "(upstream_mybackend) {")
+	s.Contains(string(content), "to 127.0.0.1:8080 127.0.0.1:8081")
+	s.Contains(string(content), "lb_policy weighted_round_robin 5 1")
+	s.Contains(string(content), "keepalive_idle_conns 16")
+}
+
+func (s *ProxyVhostTestSuite) TestClearUpstreams() {
+	upstreams := map[string]types.Upstream{
+		"backend": {
+			Servers: map[string]string{"127.0.0.1:8080": ""},
+		},
+	}
+	s.NoError(s.vhost.SetUpstreams(upstreams))
+	s.Len(s.vhost.Upstreams(), 1)
+
+	s.NoError(s.vhost.ClearUpstreams())
+	s.Empty(s.vhost.Upstreams())
+}
+
+func (s *ProxyVhostTestSuite) TestProxyWithUpstream() {
+	// 先创建 upstream
+	upstreams := map[string]types.Upstream{
+		"api-servers": {
+			Servers: map[string]string{
+				"127.0.0.1:3000": "",
+				"127.0.0.1:3001": "",
+			},
+			Algo: "least_conn",
+		},
+	}
+	s.NoError(s.vhost.SetUpstreams(upstreams))
+
+	// 然后创建引用 upstream 的 proxy
+	proxies := []types.Proxy{
+		{
+			Location: "/api",
+			Pass:     "http://api-servers",
+		},
+	}
+	s.NoError(s.vhost.SetProxies(proxies))
+
+	// 验证两者都存在
+	s.Len(s.vhost.Upstreams(), 1)
+	s.Len(s.vhost.Proxies(), 1)
+	s.Equal("http://api-servers", s.vhost.Proxies()[0].Pass)
+
+	// 验证 proxy 配置中导入了 upstream 片段
+	siteDir := filepath.Join(s.configDir, "site")
+	content, err := os.ReadFile(filepath.Join(siteDir, "200-proxy.conf"))
+	s.NoError(err)
+	s.Contains(string(content), "import upstream_api-servers")
+}
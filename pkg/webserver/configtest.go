@@ -0,0 +1,73 @@
+package webserver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ConfigError 配置校验错误
+type ConfigError struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+func (e *ConfigError) Error() string {
+	if e.File == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Message)
+}
+
+var (
+	// nginx: [emerg] unknown directive "foo" in /opt/ace/sites/example/config/site/010-rewrite.conf:3
+	nginxErrorRe     = regexp.MustCompile(`\[(?:emerg|alert|crit|error)] (.+?) in (/\S+):(\d+)`)
+	nginxNoFileRe    = regexp.MustCompile(`\[(?:emerg|alert|crit|error)] (.+)`)
+	apacheErrorRe    = regexp.MustCompile(`Syntax error on line (\d+) of (/[^:\s]+):\s*(.*)`)
+	caddyFileFirstRe = regexp.MustCompile(`(/[^\s:,']+):(\d+)(?::| -) (.+)`)
+	caddyFileLastRe  = regexp.MustCompile(`(.+?),? at (/[^\s:,']+):(\d+)`)
+)
+
+// ParseConfigError 从 Web 服务器配置测试的输出中解析出错误位置
+// 无法解析时返回只包含原始信息的 ConfigError
+func ParseConfigError(serverType Type, output string) *ConfigError {
+	output = strings.TrimSpace(output)
+
+	switch serverType {
+	case TypeNginx:
+		if m := nginxErrorRe.FindStringSubmatch(output); m != nil {
+			line, _ := strconv.Atoi(m[3])
+			return &ConfigError{File: m[2], Line: line, Message: m[1]}
+		}
+		if m := nginxNoFileRe.FindStringSubmatch(output); m != nil {
+			return &ConfigError{Message: strings.TrimSpace(m[1])}
+		}
+	case TypeApache:
+		// 错误信息位于行号的下一行
+		if m := apacheErrorRe.FindStringSubmatch(strings.Join(strings.Fields(output), " ")); m != nil {
+			line, _ := strconv.Atoi(m[1])
+			return &ConfigError{File: m[2], Line: line, Message: m[3]}
+		}
+	case TypeCaddy:
+		for _, l := range strings.Split(output, "\n") {
+			_, msg, ok := strings.Cut(l, "Error: ")
+			if !ok {
+				continue
+			}
+			msg = strings.TrimPrefix(msg, "adapting config using caddyfile: ")
+			if m := caddyFileFirstRe.FindStringSubmatch(msg); m != nil && strings.HasPrefix(msg, m[1]) {
+				line, _ := strconv.Atoi(m[2])
+				return &ConfigError{File: m[1], Line: line, Message: m[3]}
+			}
+			if m := caddyFileLastRe.FindStringSubmatch(msg); m != nil {
+				line, _ := strconv.Atoi(m[3])
+				return &ConfigError{File: m[2], Line: line, Message: m[1]}
+			}
+			return &ConfigError{Message: msg}
+		}
+	}
+
+	return &ConfigError{Message: output}
+}
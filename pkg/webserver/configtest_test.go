@@ -0,0 +1,55 @@
+package webserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigTestTestSuite struct {
+	suite.Suite
+}
+
+func TestConfigTestTestSuite(t *testing.T) {
+	suite.Run(t, &ConfigTestTestSuite{})
+}
+
+func (s *ConfigTestTestSuite) TestNginx() {
+	err := ParseConfigError(TypeNginx, `run nginx -t failed, err: nginx: [emerg] unknown directive "foo" in /opt/ace/sites/example/config/site/010-rewrite.conf:3
+nginx: configuration file /opt/ace/server/nginx/conf/nginx.conf test failed`)
+	s.Equal("/opt/ace/sites/example/config/site/010-rewrite.conf", err.File)
+	s.Equal(3, err.Line)
+	s.Equal(`unknown directive "foo"`, err.Message)
+	s.Equal(`/opt/ace/sites/example/config/site/010-rewrite.conf:3: unknown directive "foo"`, err.Error())
+
+	err = ParseConfigError(TypeNginx, `nginx: [emerg] bind() to 0.0.0.0:80 failed (98: Address already in use)`)
+	s.Empty(err.File)
+	s.Equal("bind() to 0.0.0.0:80 failed (98: Address already in use)", err.Error())
+}
+
+func (s *ConfigTestTestSuite) TestApache() {
+	err := ParseConfigError(TypeApache, `run apachectl configtest failed, err: AH00526: Syntax error on line 12 of /opt/ace/sites/example/config/site/010-rewrite.conf:
+Invalid command 'Foo', perhaps misspelled or defined by a module not included in the server configuration`)
+	s.Equal("/opt/ace/sites/example/config/site/010-rewrite.conf", err.File)
+	s.Equal(12, err.Line)
+	s.Equal("Invalid command 'Foo', perhaps misspelled or defined by a module not included in the server configuration", err.Message)
+}
+
+func (s *ConfigTestTestSuite) TestCaddy() {
+	err := ParseConfigError(TypeCaddy, `run caddy validate failed, err: {"level":"info","msg":"using config from file"}
+Error: adapting config using caddyfile: /opt/ace/sites/example/config/site/010-rewrite.conf:3: unrecognized directive: foo`)
+	s.Equal("/opt/ace/sites/example/config/site/010-rewrite.conf", err.File)
+	s.Equal(3, err.Line)
+	s.Equal("unrecognized directive: foo", err.Message)
+
+	err = ParseConfigError(TypeCaddy, `Error: adapting config using caddyfile: parsing caddyfile tokens for 'tls': wrong argument count or unexpected line ending after 'tls', at /opt/ace/sites/example/config/caddy.conf:5 import chain ['/opt/ace/server/caddy/Caddyfile:2 (import /opt/ace/sites/*/config/caddy.conf)']`)
+	s.Equal("/opt/ace/sites/example/config/caddy.conf", err.File)
+	s.Equal(5, err.Line)
+	s.Equal("parsing caddyfile tokens for 'tls': wrong argument count or unexpected line ending after 'tls'", err.Message)
+}
+
+func (s *ConfigTestTestSuite) TestUnknown() {
+	err := ParseConfigError(TypeNginx, "  something went wrong\n")
+	s.Empty(err.File)
+	s.Equal("something went wrong", err.Error())
+}
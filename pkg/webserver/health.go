@@ -0,0 +1,123 @@
+package webserver
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/acepanel/panel/pkg/webserver/types"
+)
+
+// CheckUpstreamServer 按健康检查配置探测上游服务器，返回探测耗时
+func CheckUpstreamServer(server string, check types.UpstreamHealthCheck) (time.Duration, error) {
+	network, address, scheme, err := upstreamTarget(server)
+	if err != nil {
+		return 0, err
+	}
+
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	start := time.Now()
+	switch check.Type {
+	case "tcp":
+		conn, err := net.DialTimeout(network, address, timeout)
+		if err != nil {
+			return 0, err
+		}
+		_ = conn.Close()
+	case "http":
+		if err = checkHTTP(network, address, scheme, check, timeout); err != nil {
+			return 0, err
+		}
+	default:
+		return 0, fmt.Errorf("unsupported health check type: %s", check.Type)
+	}
+
+	return time.Since(start), nil
+}
+
+// checkHTTP 通过 HTTP 请求探测上游服务器
+func checkHTTP(network, address, scheme string, check types.UpstreamHealthCheck, timeout time.Duration) error {
+	client := &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, network, address)
+			},
+			// 上游通常使用自签名证书
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			DisableKeepAlives: true,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	host := address
+	if network == "unix" {
+		host = "localhost"
+	}
+	path := check.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	resp, err := client.Get(scheme + "://" + host + path)
+	if err != nil {
+		return err
+	}
+	defer func(body io.ReadCloser) { _ = body.Close() }(resp.Body)
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if check.Status > 0 && resp.StatusCode != check.Status || check.Status == 0 && resp.StatusCode >= 400 {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// upstreamTarget 将上游服务器地址解析为探测使用的网络、地址和协议
+// 支持 127.0.0.1:8080、unix:/run/app.sock (Nginx)、unix//run/app.sock (Caddy) 和 http://127.0.0.1:8080 (Apache)
+func upstreamTarget(server string) (network, address, scheme string, err error) {
+	if path, ok := strings.CutPrefix(server, "unix:"); ok {
+		return "unix", path, "http", nil
+	}
+	if path, ok := strings.CutPrefix(server, "unix/"); ok {
+		return "unix", path, "http", nil
+	}
+
+	scheme, host := "http", server
+	if strings.Contains(server, "://") {
+		u, err := url.Parse(server)
+		if err != nil {
+			return "", "", "", err
+		}
+		host = u.Host
+		switch u.Scheme {
+		case "https", "wss":
+			scheme = "https"
+		}
+	}
+	if host == "" {
+		return "", "", "", fmt.Errorf("invalid upstream server: %s", server)
+	}
+
+	if _, _, err = net.SplitHostPort(host); err != nil {
+		port := "80"
+		if scheme == "https" {
+			port = "443"
+		}
+		host = net.JoinHostPort(strings.Trim(host, "[]"), port)
+	}
+
+	return "tcp", host, scheme, nil
+}
@@ -0,0 +1,86 @@
+package webserver
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/acepanel/panel/pkg/webserver/types"
+)
+
+type HealthTestSuite struct {
+	suite.Suite
+	server *httptest.Server
+}
+
+func TestHealthTestSuite(t *testing.T) {
+	suite.Run(t, &HealthTestSuite{})
+}
+
+func (s *HealthTestSuite) SetupTest() {
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+}
+
+func (s *HealthTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *HealthTestSuite) TestHTTP() {
+	addr := strings.TrimPrefix(s.server.URL, "http://")
+
+	_, err := CheckUpstreamServer(addr, types.UpstreamHealthCheck{Type: "http", Path: "/health"})
+	s.NoError(err)
+	_, err = CheckUpstreamServer(s.server.URL, types.UpstreamHealthCheck{Type: "http", Path: "/health", Status: 204})
+	s.NoError(err)
+
+	_, err = CheckUpstreamServer(addr, types.UpstreamHealthCheck{Type: "http", Path: "/"})
+	s.ErrorContains(err, "unexpected status code: 503")
+	_, err = CheckUpstreamServer(addr, types.UpstreamHealthCheck{Type: "http", Path: "/health", Status: 200})
+	s.ErrorContains(err, "unexpected status code: 204")
+}
+
+func (s *HealthTestSuite) TestTCP() {
+	addr := strings.TrimPrefix(s.server.URL, "http://")
+	_, err := CheckUpstreamServer(addr, types.UpstreamHealthCheck{Type: "tcp"})
+	s.NoError(err)
+
+	// 关闭监听后端口不可达
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	closed := listener.Addr().String()
+	s.NoError(listener.Close())
+	_, err = CheckUpstreamServer(closed, types.UpstreamHealthCheck{Type: "tcp", Timeout: time.Second})
+	s.Error(err)
+}
+
+func (s *HealthTestSuite) TestUpstreamTarget() {
+	tests := []struct {
+		server, network, address, scheme string
+	}{
+		{"127.0.0.1:8080", "tcp", "127.0.0.1:8080", "http"},
+		{"backend.example.com", "tcp", "backend.example.com:80", "http"},
+		{"http://127.0.0.1:8080", "tcp", "127.0.0.1:8080", "http"},
+		{"https://backend", "tcp", "backend:443", "https"},
+		{"[::1]:8080", "tcp", "[::1]:8080", "http"},
+		{"unix:/run/app.sock", "unix", "/run/app.sock", "http"},
+		{"unix//run/app.sock", "unix", "/run/app.sock", "http"},
+	}
+	for _, tt := range tests {
+		network, address, scheme, err := upstreamTarget(tt.server)
+		s.NoError(err, tt.server)
+		s.Equal(tt.network, network, tt.server)
+		s.Equal(tt.address, address, tt.server)
+		s.Equal(tt.scheme, scheme, tt.server)
+	}
+}
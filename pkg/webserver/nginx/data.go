@@ -6,6 +6,27 @@ const DisablePagePath = "/opt/ace/server/nginx/stop"
 // SitesPath 网站目录
 const SitesPath = "/opt/ace/sites"
 
+// ErrorPageConf 新建网站的 404 页面配置
+const ErrorPageConf = "error_page 404 /404.html;\n"
+
+// StaticConf 新建 PHP 网站的静态资源缓存和敏感文件配置
+const StaticConf = `# browser cache
+location ~ .*\.(bmp|jpg|jpeg|png|gif|svg|ico|tiff|webp|avif|heif|heic|jxl)$ {
+    expires 30d;
+    access_log /dev/null;
+    error_log /dev/null;
+}
+location ~ .*\.(js|css|ttf|otf|woff|woff2|eot)$ {
+    expires 6h;
+    access_log /dev/null;
+    error_log /dev/null;
+}
+# deny sensitive files
+location ~ ^/(\.user.ini|\.htaccess|\.git|\.svn|\.env) {
+    return 404;
+}
+`
+
 // 配置文件序号范围
 const (
 	RedirectStartNum = 100 // 重定向配置起始序号 (100-199)
@@ -2,9 +2,12 @@ package nginx
 
 import (
 	"fmt"
+	"maps"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -15,6 +18,29 @@ import (
 // proxyFilePattern 匹配代理配置文件名 (200-299)
 var proxyFilePattern = regexp.MustCompile(`^(\d{3})-proxy\.conf$`)
 
+// proxyDefaultHeaders 默认传递给上游的请求头
+var proxyDefaultHeaders = [][2]string{
+	{"X-Real-IP", "$remote_addr"},
+	{"X-Forwarded-For", "$proxy_add_x_forwarded_for"},
+	{"X-Forwarded-Proto", "$scheme"},
+}
+
+// inheritedHeaderMarker 标记从 server 块重新声明的响应头，解析时忽略
+const inheritedHeaderMarker = "# inherited from server"
+
+// inheritedHeaderPattern 匹配从 server 块重新声明的响应头
+var inheritedHeaderPattern = regexp.MustCompile(`(?m)^[ \t]*add_header[^\n]*` + inheritedHeaderMarker + `[ \t]*\n?`)
+
+// corsHeaders 跨域配置使用的响应头，解析时不作为自定义响应头
+var corsHeaders = []string{
+	"Access-Control-Allow-Origin",
+	"Access-Control-Allow-Methods",
+	"Access-Control-Allow-Headers",
+	"Access-Control-Expose-Headers",
+	"Access-Control-Allow-Credentials",
+	"Access-Control-Max-Age",
+}
+
 // parseProxyFiles 从 site 目录解析所有代理配置
 func parseProxyFiles(siteDir string) ([]types.Proxy, error) {
 	entries, err := os.ReadDir(siteDir)
@@ -68,7 +94,7 @@ func parseProxyFile(filePath string) (*types.Proxy, error) {
 	//     proxy_pass http://backend;
 	//     ...
 	// }
-	locationPattern := regexp.MustCompile(`location\s+([^{]+)\{([^}]+(?:\{[^}]*}[^}]*)*)}`)
+	locationPattern := regexp.MustCompile(`location\s+([^{]+)\{((?:[^{}]|\{[^{}]*})*)}`)
 	matches := locationPattern.FindStringSubmatch(contentStr)
 	if matches == nil {
 		return nil, nil
@@ -79,7 +105,7 @@ func parseProxyFile(filePath string) (*types.Proxy, error) {
 		Replaces: make(map[string]string),
 	}
 
-	blockContent := matches[2]
+	blockContent := inheritedHeaderPattern.ReplaceAllString(matches[2], "")
 
 	// 解析 proxy_pass
 	passPattern := regexp.MustCompile(`proxy_pass\s+([^;]+);`)
@@ -123,33 +149,146 @@ func parseProxyFile(filePath string) (*types.Proxy, error) {
 		proxy.Resolver = parts
 	}
 
-	// 解析 resolver_timeout
-	resolverTimeoutPattern := regexp.MustCompile(`resolver_timeout\s+(\d+)([smh]?);`)
-	if rtm := resolverTimeoutPattern.FindStringSubmatch(blockContent); rtm != nil {
-		value, _ := strconv.Atoi(rtm[1])
-		unit := rtm[2]
-		switch unit {
-		case "m":
-			proxy.ResolverTimeout = time.Duration(value) * time.Minute
-		case "h":
-			proxy.ResolverTimeout = time.Duration(value) * time.Hour
+	// 解析 resolver_timeout 和代理超时
+	proxy.ResolverTimeout = parseTimeDirective(blockContent, "resolver_timeout")
+	proxy.ConnectTimeout = parseTimeDirective(blockContent, "proxy_connect_timeout")
+	proxy.ReadTimeout = parseTimeDirective(blockContent, "proxy_read_timeout")
+	proxy.SendTimeout = parseTimeDirective(blockContent, "proxy_send_timeout")
+
+	// 解析去除路径前缀
+	proxy.StripPrefix = regexp.MustCompile(`rewrite\s+\^\S*/\?\(\.\*\)\$\s+\S*\$1\s+break;`).MatchString(blockContent)
+
+	// 解析 WebSocket
+	proxy.WebSocket = regexp.MustCompile(`proxy_set_header\s+Upgrade\s+\$http_upgrade;`).MatchString(blockContent)
+
+	// 解析自定义请求头
+	headerPattern := regexp.MustCompile(`proxy_set_header\s+(\S+)\s+("(?:[^"\\]|\\.)*"|[^\s;]+);`)
+	for _, hm := range headerPattern.FindAllStringSubmatch(blockContent, -1) {
+		name, value := hm[1], unquote(hm[2])
+		switch {
+		case strings.EqualFold(name, "Host"):
+			continue
+		case proxy.WebSocket && (strings.EqualFold(name, "Upgrade") || strings.EqualFold(name, "Connection")):
+			continue
+		case slices.ContainsFunc(proxyDefaultHeaders, func(h [2]string) bool { return strings.EqualFold(h[0], name) && h[1] == value }):
+			continue
+		case value == "":
+			proxy.RequestHeaders.Remove = append(proxy.RequestHeaders.Remove, name)
 		default:
-			proxy.ResolverTimeout = time.Duration(value) * time.Second
+			if proxy.RequestHeaders.Set == nil {
+				proxy.RequestHeaders.Set = make(map[string]string)
+			}
+			proxy.RequestHeaders.Set[name] = value
 		}
 	}
 
+	// 解析自定义响应头
+	hidePattern := regexp.MustCompile(`proxy_hide_header\s+(\S+);`)
+	for _, hm := range hidePattern.FindAllStringSubmatch(blockContent, -1) {
+		proxy.ResponseHeaders.Remove = append(proxy.ResponseHeaders.Remove, hm[1])
+	}
+	addHeaderPattern := regexp.MustCompile(`add_header\s+(\S+)\s+("(?:[^"\\]|\\.)*"|[^\s;]+)(?:\s+always)?;`)
+	for _, hm := range addHeaderPattern.FindAllStringSubmatch(blockContent, -1) {
+		name, value := hm[1], unquote(hm[2])
+		if slices.Contains(corsHeaders, name) || (name == "Vary" && value == "Origin" && strings.Contains(blockContent, "$cors_origin")) {
+			continue
+		}
+		if proxy.ResponseHeaders.Set == nil {
+			proxy.ResponseHeaders.Set = make(map[string]string)
+		}
+		proxy.ResponseHeaders.Set[name] = value
+	}
+
+	// 解析跨域配置
+	proxy.CORS = parseCORS(blockContent)
+
 	// 解析 sub_filter (响应内容替换)
 	subFilterPattern := regexp.MustCompile(`sub_filter\s+"([^"]+)"\s+"([^"]*)";`)
 	subFilterMatches := subFilterPattern.FindAllStringSubmatch(blockContent, -1)
 	for _, sfm := range subFilterMatches {
 		proxy.Replaces[sfm[1]] = sfm[2]
 	}
+	// 响应内容替换需要上游返回未压缩的内容
+	if len(proxy.Replaces) > 0 {
+		proxy.RequestHeaders.Remove = slices.DeleteFunc(proxy.RequestHeaders.Remove, func(name string) bool {
+			return strings.EqualFold(name, "Accept-Encoding")
+		})
+		if len(proxy.RequestHeaders.Remove) == 0 {
+			proxy.RequestHeaders.Remove = nil
+		}
+	}
 
 	return proxy, nil
 }
 
+// parseCORS 解析跨域配置
+func parseCORS(blockContent string) *types.ProxyCORS {
+	headers := make(map[string]string)
+	addHeaderPattern := regexp.MustCompile(`add_header\s+(Access-Control-\S+)\s+("(?:[^"\\]|\\.)*"|[^\s;]+)(?:\s+always)?;`)
+	for _, hm := range addHeaderPattern.FindAllStringSubmatch(blockContent, -1) {
+		headers[hm[1]] = unquote(hm[2])
+	}
+	origin, ok := headers["Access-Control-Allow-Origin"]
+	if !ok {
+		return nil
+	}
+
+	cors := new(types.ProxyCORS)
+	if origin == "*" {
+		cors.Origins = []string{"*"}
+	} else if om := regexp.MustCompile(`if\s+\(\$http_origin\s+~\*\s+"\^\((.*)\)\$"\)`).FindStringSubmatch(blockContent); om != nil {
+		for _, item := range strings.Split(om[1], "|") {
+			cors.Origins = append(cors.Origins, unquoteMeta(item))
+		}
+	}
+	cors.Methods = splitList(headers["Access-Control-Allow-Methods"])
+	cors.Headers = splitList(headers["Access-Control-Allow-Headers"])
+	cors.ExposeHeaders = splitList(headers["Access-Control-Expose-Headers"])
+	cors.Credentials = headers["Access-Control-Allow-Credentials"] == "true"
+	cors.MaxAge, _ = strconv.Atoi(headers["Access-Control-Max-Age"])
+
+	return cors
+}
+
+// parseTimeDirective 解析时间类指令，如: proxy_read_timeout 60s;
+func parseTimeDirective(blockContent, name string) time.Duration {
+	tm := regexp.MustCompile(name + `\s+(\S+);`).FindStringSubmatch(blockContent)
+	if tm == nil {
+		return 0
+	}
+
+	return parseTime(tm[1])
+}
+
+// parseTime 解析时间值，如: 30s、1m、500ms，不带单位时为秒
+func parseTime(s string) time.Duration {
+	tm := regexp.MustCompile(`^(\d+)(ms|[smh]?)$`).FindStringSubmatch(s)
+	if tm == nil {
+		return 0
+	}
+
+	value, _ := strconv.Atoi(tm[1])
+	switch tm[2] {
+	case "ms":
+		return time.Duration(value) * time.Millisecond
+	case "m":
+		return time.Duration(value) * time.Minute
+	case "h":
+		return time.Duration(value) * time.Hour
+	default:
+		return time.Duration(value) * time.Second
+	}
+}
+
 // writeProxyFiles 将代理配置写入文件
-func writeProxyFiles(siteDir string, proxies []types.Proxy) error {
+// inherited 为 server 块中的响应头，如 ["Strict-Transport-Security max-age=31536000"]
+func writeProxyFiles(siteDir string, proxies []types.Proxy, inherited []string) error {
+	for _, proxy := range proxies {
+		if err := proxy.Validate(); err != nil {
+			return err
+		}
+	}
+
 	// 删除现有的代理配置文件 (200-299)
 	if err := clearProxyFiles(siteDir); err != nil {
 		return err
@@ -165,7 +304,7 @@ func writeProxyFiles(siteDir string, proxies []types.Proxy) error {
 		fileName := fmt.Sprintf("%03d-proxy.conf", num)
 		filePath := filepath.Join(siteDir, fileName)
 
-		content := generateProxyConfig(proxy)
+		content := generateProxyConfig(proxy, inherited)
 		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
 			return fmt.Errorf("failed to write proxy config: %w", err)
 		}
@@ -207,7 +346,8 @@ func clearProxyFiles(siteDir string) error {
 }
 
 // generateProxyConfig 生成代理配置内容
-func generateProxyConfig(proxy types.Proxy) string {
+// location 中存在 add_header 时不再继承 server 中的 add_header，因此需要重新声明 inherited 中的响应头
+func generateProxyConfig(proxy types.Proxy, inherited []string) string {
 	var sb strings.Builder
 
 	location := proxy.Location
@@ -225,6 +365,15 @@ func generateProxyConfig(proxy types.Proxy) string {
 		}
 	}
 
+	// 去除路径前缀，rewrite 后 proxy_pass 中的路径不再生效，需要写入改写后的地址
+	if prefix, ok := locationPrefix(location); ok && proxy.StripPrefix && prefix != "/" {
+		passPath := ""
+		if u, err := url.Parse(proxy.Pass); err == nil {
+			passPath = strings.TrimSuffix(u.Path, "/")
+		}
+		sb.WriteString(fmt.Sprintf("    rewrite ^%s/?(.*)$ %s/$1 break;\n", regexp.QuoteMeta(strings.TrimSuffix(prefix, "/")), passPath))
+	}
+
 	sb.WriteString(fmt.Sprintf("    proxy_pass %s;\n", proxy.Pass))
 
 	// Host 头
@@ -234,10 +383,42 @@ func generateProxyConfig(proxy types.Proxy) string {
 		sb.WriteString("    proxy_set_header Host $host;\n")
 	}
 
-	// 标准代理头
-	sb.WriteString("    proxy_set_header X-Real-IP $remote_addr;\n")
-	sb.WriteString("    proxy_set_header X-Forwarded-For $proxy_add_x_forwarded_for;\n")
-	sb.WriteString("    proxy_set_header X-Forwarded-Proto $scheme;\n")
+	// 标准代理头，自定义请求头中设置或移除的不再重复写入
+	for _, header := range proxyDefaultHeaders {
+		if !hasHeader(proxy.RequestHeaders, header[0]) {
+			sb.WriteString(fmt.Sprintf("    proxy_set_header %s %s;\n", header[0], header[1]))
+		}
+	}
+
+	// WebSocket 配置
+	if proxy.WebSocket {
+		sb.WriteString("    proxy_http_version 1.1;\n")
+		sb.WriteString("    proxy_set_header Upgrade $http_upgrade;\n")
+		sb.WriteString("    proxy_set_header Connection $http_connection;\n")
+	}
+
+	// 自定义请求头，Host 由 Host 字段控制
+	for _, name := range slices.Sorted(maps.Keys(proxy.RequestHeaders.Set)) {
+		if !strings.EqualFold(name, "Host") {
+			sb.WriteString(fmt.Sprintf("    proxy_set_header %s %s;\n", name, quote(proxy.RequestHeaders.Set[name])))
+		}
+	}
+	for _, name := range proxy.RequestHeaders.Remove {
+		if !strings.EqualFold(name, "Host") && (len(proxy.Replaces) == 0 || !strings.EqualFold(name, "Accept-Encoding")) {
+			sb.WriteString(fmt.Sprintf("    proxy_set_header %s \"\";\n", name))
+		}
+	}
+
+	// 超时配置
+	if proxy.ConnectTimeout > 0 {
+		sb.WriteString(fmt.Sprintf("    proxy_connect_timeout %ds;\n", int(proxy.ConnectTimeout.Seconds())))
+	}
+	if proxy.ReadTimeout > 0 {
+		sb.WriteString(fmt.Sprintf("    proxy_read_timeout %ds;\n", int(proxy.ReadTimeout.Seconds())))
+	}
+	if proxy.SendTimeout > 0 {
+		sb.WriteString(fmt.Sprintf("    proxy_send_timeout %ds;\n", int(proxy.SendTimeout.Seconds())))
+	}
 
 	// SNI 配置
 	if proxy.SNI != "" {
@@ -268,7 +449,120 @@ func generateProxyConfig(proxy types.Proxy) string {
 		}
 	}
 
+	// 自定义响应头
+	if len(proxy.ResponseHeaders.Set) > 0 || proxy.CORS != nil {
+		for _, header := range inherited {
+			name, _, _ := strings.Cut(header, " ")
+			if _, ok := proxy.ResponseHeaders.Set[name]; !ok {
+				sb.WriteString(fmt.Sprintf("    add_header %s; %s\n", header, inheritedHeaderMarker))
+			}
+		}
+	}
+	for _, name := range proxy.ResponseHeaders.Remove {
+		sb.WriteString(fmt.Sprintf("    proxy_hide_header %s;\n", name))
+	}
+	for _, name := range slices.Sorted(maps.Keys(proxy.ResponseHeaders.Set)) {
+		sb.WriteString(fmt.Sprintf("    add_header %s %s always;\n", name, quote(proxy.ResponseHeaders.Set[name])))
+	}
+
+	// 跨域配置
+	if proxy.CORS != nil {
+		sb.WriteString(generateCORSConfig(proxy.CORS))
+	}
+
 	sb.WriteString("}\n")
 
 	return sb.String()
 }
+
+// generateCORSConfig 生成跨域配置，来源不匹配时不返回 Access-Control-Allow-Origin，预检请求直接返回 204
+func generateCORSConfig(cors *types.ProxyCORS) string {
+	var sb strings.Builder
+
+	if len(cors.Origins) == 0 || slices.Contains(cors.Origins, "*") {
+		sb.WriteString("    add_header Access-Control-Allow-Origin * always;\n")
+	} else {
+		origins := make([]string, 0, len(cors.Origins))
+		for _, origin := range cors.Origins {
+			origins = append(origins, regexp.QuoteMeta(origin))
+		}
+		sb.WriteString("    set $cors_origin \"\";\n")
+		sb.WriteString(fmt.Sprintf("    if ($http_origin ~* \"^(%s)$\") {\n", strings.Join(origins, "|")))
+		sb.WriteString("        set $cors_origin $http_origin;\n")
+		sb.WriteString("    }\n")
+		sb.WriteString("    add_header Access-Control-Allow-Origin $cors_origin always;\n")
+		sb.WriteString("    add_header Vary Origin always;\n")
+	}
+	if len(cors.Methods) > 0 {
+		sb.WriteString(fmt.Sprintf("    add_header Access-Control-Allow-Methods \"%s\" always;\n", strings.Join(cors.Methods, ", ")))
+	}
+	if len(cors.Headers) > 0 {
+		sb.WriteString(fmt.Sprintf("    add_header Access-Control-Allow-Headers \"%s\" always;\n", strings.Join(cors.Headers, ", ")))
+	}
+	if len(cors.ExposeHeaders) > 0 {
+		sb.WriteString(fmt.Sprintf("    add_header Access-Control-Expose-Headers \"%s\" always;\n", strings.Join(cors.ExposeHeaders, ", ")))
+	}
+	if cors.Credentials {
+		sb.WriteString("    add_header Access-Control-Allow-Credentials true always;\n")
+	}
+	if cors.MaxAge > 0 {
+		sb.WriteString(fmt.Sprintf("    add_header Access-Control-Max-Age %d always;\n", cors.MaxAge))
+	}
+	sb.WriteString("    if ($request_method = OPTIONS) {\n")
+	sb.WriteString("        return 204;\n")
+	sb.WriteString("    }\n")
+
+	return sb.String()
+}
+
+// locationPrefix 取前缀匹配和精确匹配的路径，正则匹配返回 false
+func locationPrefix(location string) (string, bool) {
+	fields := strings.Fields(location)
+	switch {
+	case len(fields) == 1:
+		return fields[0], true
+	case len(fields) == 2 && (fields[0] == "^~" || fields[0] == "="):
+		return fields[1], true
+	default:
+		return "", false
+	}
+}
+
+// hasHeader 判断请求头是否在自定义配置中设置或移除
+func hasHeader(headers types.ProxyHeaders, name string) bool {
+	for key := range headers.Set {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return slices.ContainsFunc(headers.Remove, func(key string) bool { return strings.EqualFold(key, name) })
+}
+
+// quote 为指令参数加上双引号
+func quote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// unquote 去掉指令参数的双引号
+func unquote(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return strings.NewReplacer(`\\`, `\`, `\"`, `"`).Replace(value[1 : len(value)-1])
+	}
+	return value
+}
+
+// unquoteMeta 还原 regexp.QuoteMeta 转义的字符串
+func unquoteMeta(value string) string {
+	return regexp.MustCompile(`\\(.)`).ReplaceAllString(value, "$1")
+}
+
+// splitList 拆分逗号分隔的列表
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -2,9 +2,11 @@ package nginx
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -85,6 +87,12 @@ func parseUpstreamFile(filePath string, expectedName string) (*types.Upstream, e
 		Servers: make(map[string]string),
 	}
 
+	// 解析健康检查配置
+	// # Health check: type=http path=/health fails=3
+	if hm := regexp.MustCompile(`# Health check: (.+)`).FindStringSubmatch(contentStr); hm != nil {
+		upstream.HealthCheck = types.ParseUpstreamHealthCheck(hm[1])
+	}
+
 	// 解析负载均衡算法
 	algoPatterns := []string{"least_conn", "ip_hash", "hash", "random"}
 	for _, algo := range algoPatterns {
@@ -103,7 +111,14 @@ func parseUpstreamFile(filePath string, expectedName string) (*types.Upstream, e
 		if len(sm) > 2 {
 			options = strings.TrimSpace(sm[2])
 		}
-		upstream.Servers[addr] = options
+		var server types.UpstreamServer
+		upstream.Servers[addr], server = parseServerOptions(options)
+		if server != (types.UpstreamServer{}) {
+			if upstream.ServerOptions == nil {
+				upstream.ServerOptions = make(map[string]types.UpstreamServer)
+			}
+			upstream.ServerOptions[addr] = server
+		}
 	}
 
 	// 解析 keepalive 指令
@@ -115,6 +130,29 @@ func parseUpstreamFile(filePath string, expectedName string) (*types.Upstream, e
 	return upstream, nil
 }
 
+// parseServerOptions 从 server 参数中取出失败判定和状态参数，返回其余参数
+func parseServerOptions(options string) (string, types.UpstreamServer) {
+	var server types.UpstreamServer
+	var others []string
+	for _, option := range strings.Fields(options) {
+		key, value, _ := strings.Cut(option, "=")
+		switch key {
+		case "max_fails":
+			server.MaxFails, _ = strconv.Atoi(value)
+		case "fail_timeout":
+			server.FailTimeout = parseTime(value)
+		case "backup":
+			server.Backup = true
+		case "down":
+			server.Down = true
+		default:
+			others = append(others, option)
+		}
+	}
+
+	return strings.Join(others, " "), server
+}
+
 // writeUpstreamFiles 将 upstream 配置写入文件
 func writeUpstreamFiles(sharedDir string, upstreams map[string]types.Upstream) error {
 	// 删除现有的 upstream 配置文件
@@ -175,6 +213,9 @@ func generateUpstreamConfig(name string, upstream types.Upstream) string {
 	var sb strings.Builder
 
 	sb.WriteString(fmt.Sprintf("# Upstream: %s\n", name))
+	if upstream.HealthCheck != nil {
+		sb.WriteString(fmt.Sprintf("# Health check: %s\n", upstream.HealthCheck))
+	}
 	sb.WriteString(fmt.Sprintf("upstream %s {\n", name))
 
 	// 负载均衡算法
@@ -182,8 +223,13 @@ func generateUpstreamConfig(name string, upstream types.Upstream) string {
 		sb.WriteString(fmt.Sprintf("    %s;\n", upstream.Algo))
 	}
 
-	// 服务器列表
-	for addr, options := range upstream.Servers {
+	// 服务器列表，类型化的参数覆盖 Servers 中的同名参数
+	for _, addr := range slices.Sorted(maps.Keys(upstream.Servers)) {
+		options, server := parseServerOptions(upstream.Servers[addr])
+		if typed, ok := upstream.ServerOptions[addr]; ok {
+			server = typed
+		}
+		options = strings.TrimSpace(options + " " + formatServerOptions(server))
 		if options != "" {
 			sb.WriteString(fmt.Sprintf("    server %s %s;\n", addr, options))
 		} else {
@@ -200,3 +246,22 @@ func generateUpstreamConfig(name string, upstream types.Upstream) string {
 
 	return sb.String()
 }
+
+// formatServerOptions 生成 server 的失败判定和状态参数
+func formatServerOptions(server types.UpstreamServer) string {
+	var options []string
+	if server.MaxFails > 0 {
+		options = append(options, fmt.Sprintf("max_fails=%d", server.MaxFails))
+	}
+	if server.FailTimeout > 0 {
+		options = append(options, fmt.Sprintf("fail_timeout=%ds", int(server.FailTimeout.Seconds())))
+	}
+	if server.Backup {
+		options = append(options, "backup")
+	}
+	if server.Down {
+		options = append(options, "down")
+	}
+
+	return strings.Join(options, " ")
+}
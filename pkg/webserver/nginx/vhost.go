@@ -722,7 +722,20 @@ func (v *ProxyVhost) Proxies() []types.Proxy {
 
 func (v *ProxyVhost) SetProxies(proxies []types.Proxy) error {
 	siteDir := filepath.Join(v.configDir, "site")
-	return writeProxyFiles(siteDir, proxies)
+	return writeProxyFiles(siteDir, proxies, v.serverHeaders())
+}
+
+// serverHeaders 获取 server 块中 add_header 设置的响应头，如 HSTS 和 Alt-Svc
+func (v *ProxyVhost) serverHeaders() []string {
+	var headers []string
+	directives, _ := v.parser.Find("server.add_header")
+	for _, dir := range directives {
+		if params := v.parser.parameters2Slices(dir.GetParameters()); len(params) > 0 {
+			headers = append(headers, strings.Join(params, " "))
+		}
+	}
+
+	return headers
 }
 
 func (v *ProxyVhost) ClearProxies() error {
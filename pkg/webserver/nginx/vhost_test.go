@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
@@ -505,6 +506,86 @@ func (s *ProxyVhostTestSuite) TestProxyConfig() {
 	s.Contains(string(content), "proxy_buffering on")
 }
 
+func (s *ProxyVhostTestSuite) TestProxyRules() {
+	proxies := []types.Proxy{
+		{
+			Location:       "^~ /api/",
+			Pass:           "http://backend:8080/v1",
+			StripPrefix:    true,
+			WebSocket:      true,
+			ConnectTimeout: 5 * time.Second,
+			ReadTimeout:    time.Minute,
+			SendTimeout:    30 * time.Second,
+			Replaces:       map[string]string{},
+			RequestHeaders: types.ProxyHeaders{
+				Set:    map[string]string{"X-Api-Key": `se"cret`, "X-Real-IP": "$http_cf_connecting_ip"},
+				Remove: []string{"Cookie"},
+			},
+			ResponseHeaders: types.ProxyHeaders{
+				Set:    map[string]string{"X-Frame-Options": "DENY"},
+				Remove: []string{"X-Powered-By"},
+			},
+			CORS: &types.ProxyCORS{
+				Origins:       []string{"https://example.com", "https://app.example.org"},
+				Methods:       []string{"GET", "POST"},
+				Headers:       []string{"Content-Type", "Authorization"},
+				ExposeHeaders: []string{"X-Request-Id"},
+				Credentials:   true,
+				MaxAge:        86400,
+			},
+		},
+	}
+	s.NoError(s.vhost.SetProxies(proxies))
+
+	content, err := os.ReadFile(filepath.Join(s.configDir, "site", "200-proxy.conf"))
+	s.NoError(err)
+	s.Contains(string(content), "rewrite ^/api/?(.*)$ /v1/$1 break;")
+	s.Contains(string(content), "proxy_set_header Upgrade $http_upgrade;")
+	s.Contains(string(content), `proxy_set_header X-Api-Key "se\"cret";`)
+	s.Contains(string(content), `proxy_set_header Cookie "";`)
+	s.NotContains(string(content), "proxy_set_header X-Real-IP $remote_addr;")
+	s.Contains(string(content), "proxy_read_timeout 60s;")
+	s.Contains(string(content), "proxy_hide_header X-Powered-By;")
+	s.Contains(string(content), `add_header X-Frame-Options "DENY" always;`)
+	s.Contains(string(content), `if ($http_origin ~* "^(https://example\.com|https://app\.example\.org)$") {`)
+	s.Contains(string(content), "return 204;")
+
+	s.Equal(proxies, s.vhost.Proxies())
+}
+
+func (s *ProxyVhostTestSuite) TestProxyInheritedHeaders() {
+	s.NoError(s.vhost.SetSSLConfig(&types.SSLConfig{Cert: "/etc/ssl/cert.pem", Key: "/etc/ssl/key.pem", HSTS: true}))
+
+	proxies := []types.Proxy{
+		{
+			Location:        "/",
+			Pass:            "http://backend",
+			Replaces:        map[string]string{},
+			ResponseHeaders: types.ProxyHeaders{Set: map[string]string{"X-Frame-Options": "DENY"}},
+		},
+	}
+	s.NoError(s.vhost.SetProxies(proxies))
+
+	// location 中的 add_header 会覆盖 server 中的 HSTS，需要重新声明
+	content, err := os.ReadFile(filepath.Join(s.configDir, "site", "200-proxy.conf"))
+	s.NoError(err)
+	s.Contains(string(content), "add_header Strict-Transport-Security max-age=31536000; "+inheritedHeaderMarker)
+	s.Equal(proxies, s.vhost.Proxies())
+}
+
+func (s *ProxyVhostTestSuite) TestProxyInvalidHeaders() {
+	invalid := []types.Proxy{
+		{Location: "/", Pass: "http://backend", RequestHeaders: types.ProxyHeaders{Set: map[string]string{"X-Test;\n  alias /": "1"}}},
+		{Location: "/", Pass: "http://backend", ResponseHeaders: types.ProxyHeaders{Remove: []string{"X-Test {"}}},
+		{Location: "/", Pass: "http://backend", ResponseHeaders: types.ProxyHeaders{Set: map[string]string{"X-Test": "a\nb"}}},
+		{Location: "/", Pass: "http://backend", CORS: &types.ProxyCORS{Methods: []string{`GET" always; access_log /tmp/x`}}},
+		{Location: "/", Pass: "http://backend", CORS: &types.ProxyCORS{Origins: []string{`https://a.com"`}}},
+	}
+	for _, proxy := range invalid {
+		s.Error(s.vhost.SetProxies([]types.Proxy{proxy}))
+	}
+}
+
 func (s *ProxyVhostTestSuite) TestClearProxies() {
 	proxies := []types.Proxy{
 		{Location: "/", Pass: "http://backend"},
@@ -547,6 +628,45 @@ func (s *ProxyVhostTestSuite) TestUpstreams() {
 	s.Equal(32, got["backend"].Keepalive)
 }
 
+func (s *ProxyVhostTestSuite) TestUpstreamHealth() {
+	upstreams := map[string]types.Upstream{
+		"backend": {
+			Servers: map[string]string{
+				"127.0.0.1:8080": "weight=5",
+				"127.0.0.1:8081": "",
+				"127.0.0.1:8082": "",
+			},
+			ServerOptions: map[string]types.UpstreamServer{
+				"127.0.0.1:8080": {MaxFails: 3, FailTimeout: 30 * time.Second},
+				"127.0.0.1:8081": {Down: true},
+				"127.0.0.1:8082": {Backup: true},
+			},
+			HealthCheck: &types.UpstreamHealthCheck{
+				Type:     "http",
+				Path:     "/health",
+				Status:   200,
+				Timeout:  3 * time.Second,
+				Fails:    3,
+				Passes:   2,
+				MarkDown: true,
+			},
+		},
+	}
+	s.NoError(s.vhost.SetUpstreams(upstreams))
+
+	entries, err := os.ReadDir(filepath.Join(s.configDir, "shared"))
+	s.NoError(err)
+	s.Require().Len(entries, 1)
+	content, err := os.ReadFile(filepath.Join(s.configDir, "shared", entries[0].Name()))
+	s.NoError(err)
+	s.Contains(string(content), "# Health check: type=http path=/health status=200 timeout=3s fails=3 passes=2 mark_down")
+	s.Contains(string(content), "server 127.0.0.1:8080 weight=5 max_fails=3 fail_timeout=30s;")
+	s.Contains(string(content), "server 127.0.0.1:8081 down;")
+	s.Contains(string(content), "server 127.0.0.1:8082 backup;")
+
+	s.Equal(upstreams, s.vhost.Upstreams())
+}
+
 func (s *ProxyVhostTestSuite) TestUpstreamConfig() {
 	upstreams := map[string]types.Upstream{
 		"mybackend": {
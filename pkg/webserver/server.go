@@ -0,0 +1,69 @@
+package webserver
+
+import (
+	"fmt"
+
+	"github.com/acepanel/panel/pkg/shell"
+	"github.com/acepanel/panel/pkg/systemctl"
+	"github.com/acepanel/panel/pkg/webserver/apache"
+	"github.com/acepanel/panel/pkg/webserver/caddy"
+	"github.com/acepanel/panel/pkg/webserver/nginx"
+)
+
+// DefaultSiteConfig 新建网站的默认配置片段，返回 404 页面配置和 PHP 网站的静态资源配置
+func DefaultSiteConfig(serverType Type) (errorPage, static string, err error) {
+	switch serverType {
+	case TypeNginx:
+		return nginx.ErrorPageConf, nginx.StaticConf, nil
+	case TypeApache:
+		return apache.ErrorPageConf, apache.StaticConf, nil
+	case TypeCaddy:
+		return caddy.ErrorPageConf, caddy.StaticConf, nil
+	default:
+		return "", "", fmt.Errorf("unsupported server type: %s", serverType)
+	}
+}
+
+// Test 测试 Web 服务器配置，失败时返回解析出错误位置的 ConfigError
+func Test(serverType Type) error {
+	var err error
+	switch serverType {
+	case TypeNginx:
+		_, err = shell.Execf("nginx -t")
+	case TypeApache:
+		_, err = shell.Execf("apachectl configtest")
+	case TypeCaddy:
+		_, err = shell.Execf("caddy validate --adapter caddyfile --config '%s'", caddy.ConfPath)
+	default:
+		return fmt.Errorf("unsupported server type: %s", serverType)
+	}
+	if err != nil {
+		return ParseConfigError(serverType, err.Error())
+	}
+
+	return nil
+}
+
+// Reload 重载 Web 服务器，失败时优先返回配置测试的错误
+func Reload(serverType Type) error {
+	var service string
+	switch serverType {
+	case TypeNginx:
+		service = "nginx"
+	case TypeApache:
+		service = "httpd"
+	case TypeCaddy:
+		service = "caddy"
+	default:
+		return fmt.Errorf("unsupported server type: %s", serverType)
+	}
+
+	if err := systemctl.Reload(service); err != nil {
+		if testErr := Test(serverType); testErr != nil {
+			return testErr
+		}
+		return err
+	}
+
+	return nil
+}
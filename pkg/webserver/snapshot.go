@@ -0,0 +1,134 @@
+package webserver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Snapshot 网站配置目录快照
+// 快照目录与配置目录位于同一父目录下，回滚时通过重命名完成替换
+// 快照只能恢复或丢弃一次，之后的调用不做任何操作，因此可以直接 defer Restore
+type Snapshot struct {
+	dir    string // 配置目录
+	backup string // 快照目录，配置目录原本不存在时为空
+	done   bool
+}
+
+// NewSnapshot 为配置目录创建快照
+func NewSnapshot(dir string) (*Snapshot, error) {
+	dir = filepath.Clean(dir)
+	snapshot := &Snapshot{dir: dir}
+
+	if _, err := os.Stat(dir); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return snapshot, nil
+		}
+		return nil, err
+	}
+
+	backup, err := os.MkdirTemp(filepath.Dir(dir), fmt.Sprintf(".%s.snapshot-*", filepath.Base(dir)))
+	if err != nil {
+		return nil, err
+	}
+	if err = copyDir(dir, backup); err != nil {
+		_ = os.RemoveAll(backup)
+		return nil, err
+	}
+
+	snapshot.backup = backup
+	return snapshot, nil
+}
+
+// Restore 将配置目录恢复到快照时的状态
+func (s *Snapshot) Restore() error {
+	if s.done {
+		return nil
+	}
+	s.done = true
+
+	failed := fmt.Sprintf("%s.failed-%d", s.dir, time.Now().UnixNano())
+	if err := os.Rename(s.dir, failed); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	if s.backup != "" {
+		if err := os.Rename(s.backup, s.dir); err != nil {
+			// 尽量恢复为修改后的配置，避免配置目录丢失
+			_ = os.Rename(failed, s.dir)
+			return err
+		}
+	}
+
+	return os.RemoveAll(failed)
+}
+
+// Discard 丢弃快照
+func (s *Snapshot) Discard() error {
+	if s.done {
+		return nil
+	}
+	s.done = true
+
+	if s.backup == "" {
+		return nil
+	}
+	return os.RemoveAll(s.backup)
+}
+
+// copyDir 递归复制目录，保留文件权限和符号链接
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case d.IsDir():
+			if err = os.MkdirAll(target, info.Mode().Perm()); err != nil {
+				return err
+			}
+			return os.Chmod(target, info.Mode().Perm())
+		case info.Mode()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		default:
+			return copyFile(path, target, info.Mode().Perm())
+		}
+	})
+}
+
+func copyFile(src, dst string, perm fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func(in *os.File) { _ = in.Close() }(in)
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+
+	return out.Close()
+}
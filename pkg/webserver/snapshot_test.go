@@ -0,0 +1,74 @@
+package webserver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SnapshotTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func TestSnapshotTestSuite(t *testing.T) {
+	suite.Run(t, &SnapshotTestSuite{})
+}
+
+func (s *SnapshotTestSuite) SetupTest() {
+	s.dir = filepath.Join(s.T().TempDir(), "config")
+	s.NoError(os.MkdirAll(filepath.Join(s.dir, "site"), 0755))
+	s.NoError(os.WriteFile(filepath.Join(s.dir, "nginx.conf"), []byte("server {}\n"), 0644))
+	s.NoError(os.WriteFile(filepath.Join(s.dir, "site", "010-rewrite.conf"), []byte(""), 0600))
+}
+
+func (s *SnapshotTestSuite) TestRestore() {
+	snapshot, err := NewSnapshot(s.dir)
+	s.Require().NoError(err)
+
+	s.NoError(os.WriteFile(filepath.Join(s.dir, "nginx.conf"), []byte("broken"), 0644))
+	s.NoError(os.WriteFile(filepath.Join(s.dir, "site", "200-proxy.conf"), []byte("location / {}"), 0644))
+
+	s.NoError(snapshot.Restore())
+
+	content, err := os.ReadFile(filepath.Join(s.dir, "nginx.conf"))
+	s.NoError(err)
+	s.Equal("server {}\n", string(content))
+	s.NoFileExists(filepath.Join(s.dir, "site", "200-proxy.conf"))
+	info, err := os.Stat(filepath.Join(s.dir, "site", "010-rewrite.conf"))
+	s.NoError(err)
+	s.Equal(os.FileMode(0600), info.Mode().Perm())
+
+	entries, err := os.ReadDir(filepath.Dir(s.dir))
+	s.NoError(err)
+	s.Len(entries, 1)
+}
+
+func (s *SnapshotTestSuite) TestRestoreNotExist() {
+	dir := filepath.Join(filepath.Dir(s.dir), "new")
+	snapshot, err := NewSnapshot(dir)
+	s.Require().NoError(err)
+
+	s.NoError(os.MkdirAll(filepath.Join(dir, "site"), 0755))
+	s.NoError(snapshot.Restore())
+	s.NoDirExists(dir)
+}
+
+func (s *SnapshotTestSuite) TestDiscard() {
+	snapshot, err := NewSnapshot(s.dir)
+	s.Require().NoError(err)
+
+	s.NoError(os.WriteFile(filepath.Join(s.dir, "nginx.conf"), []byte("changed"), 0644))
+	s.NoError(snapshot.Discard())
+	s.NoError(snapshot.Restore())
+
+	content, err := os.ReadFile(filepath.Join(s.dir, "nginx.conf"))
+	s.NoError(err)
+	s.Equal("changed", string(content))
+
+	entries, err := os.ReadDir(filepath.Dir(s.dir))
+	s.NoError(err)
+	s.Len(entries, 1)
+}
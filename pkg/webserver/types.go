@@ -6,4 +6,5 @@ type Type string
 const (
 	TypeNginx  Type = "nginx"
 	TypeApache Type = "apache"
+	TypeCaddy  Type = "caddy"
 )
@@ -1,6 +1,11 @@
 package types
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Proxy 反向代理配置
 type Proxy struct {
@@ -13,11 +18,170 @@ type Proxy struct {
 	Resolver        []string          `form:"resolver" json:"resolver"`                     // 自定义 DNS 解析器配置，如: ["8.8.8.8", "ipv6=off"]
 	ResolverTimeout time.Duration     `form:"resolver_timeout" json:"resolver_timeout"`     // DNS 解析超时时间，如: 5 * time.Second
 	Replaces        map[string]string `form:"replaces" json:"replaces"`                     // 响应内容替换，如: map["/old"] = "/new"
+	StripPrefix     bool              `form:"strip_prefix" json:"strip_prefix"`             // 转发前去掉匹配的路径前缀，正则匹配时无效
+	WebSocket       bool              `form:"websocket" json:"websocket"`                   // 是否支持 WebSocket 升级
+	ConnectTimeout  time.Duration     `form:"connect_timeout" json:"connect_timeout"`       // 连接上游超时时间，如: 60 * time.Second
+	ReadTimeout     time.Duration     `form:"read_timeout" json:"read_timeout"`             // 读取上游响应超时时间
+	SendTimeout     time.Duration     `form:"send_timeout" json:"send_timeout"`             // 向上游发送请求超时时间
+	RequestHeaders  ProxyHeaders      `form:"request_headers" json:"request_headers"`       // 发往上游的请求头修改
+	ResponseHeaders ProxyHeaders      `form:"response_headers" json:"response_headers"`     // 返回客户端的响应头修改
+	CORS            *ProxyCORS        `form:"cors" json:"cors"`                             // 跨域配置，为 nil 表示不处理
+}
+
+// Validate 校验请求头、响应头和跨域配置，避免在配置中注入额外指令
+func (p Proxy) Validate() error {
+	for _, headers := range []ProxyHeaders{p.RequestHeaders, p.ResponseHeaders} {
+		for name, value := range headers.Set {
+			if !ValidHeaderName(name) {
+				return fmt.Errorf("invalid header name: %q", name)
+			}
+			if !validHeaderValue(value) {
+				return fmt.Errorf("invalid header value: %q", value)
+			}
+		}
+		for _, name := range headers.Remove {
+			if !ValidHeaderName(name) {
+				return fmt.Errorf("invalid header name: %q", name)
+			}
+		}
+	}
+
+	if p.CORS == nil {
+		return nil
+	}
+	for _, origin := range p.CORS.Origins {
+		if origin == "" || strings.ContainsFunc(origin, func(r rune) bool {
+			return r <= ' ' || r >= 0x7f || strings.ContainsRune("\"'\\;{}|()", r)
+		}) {
+			return fmt.Errorf("invalid cors origin: %q", origin)
+		}
+	}
+	for _, list := range [][]string{p.CORS.Methods, p.CORS.Headers, p.CORS.ExposeHeaders} {
+		for _, item := range list {
+			if item != "*" && !ValidHeaderName(item) {
+				return fmt.Errorf("invalid cors value: %q", item)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidHeaderName 判断是否为合法的头名称，即 RFC 7230 中的 token
+func ValidHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r > 0x7e || !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("!#$%&'*+-.^_`|~", r)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validHeaderValue 头的值不能包含控制字符
+func validHeaderValue(value string) bool {
+	return !strings.ContainsFunc(value, func(r rune) bool {
+		return r < ' ' && r != '\t' || r == 0x7f
+	})
+}
+
+// ProxyHeaders 请求头或响应头的设置和移除
+type ProxyHeaders struct {
+	Set    map[string]string `form:"set" json:"set"`       // 设置的头，如: map["X-Api-Key"] = "secret"
+	Remove []string          `form:"remove" json:"remove"` // 移除的头，如: ["X-Powered-By"]
+}
+
+// ProxyCORS 跨域配置
+type ProxyCORS struct {
+	Origins       []string `form:"origins" json:"origins"`               // 允许的来源，如: ["https://example.com"]，["*"] 表示任意来源
+	Methods       []string `form:"methods" json:"methods"`               // 允许的方法，如: ["GET", "POST"]
+	Headers       []string `form:"headers" json:"headers"`               // 允许的请求头，如: ["Content-Type", "Authorization"]
+	ExposeHeaders []string `form:"expose_headers" json:"expose_headers"` // 允许客户端读取的响应头
+	Credentials   bool     `form:"credentials" json:"credentials"`       // 是否允许携带凭据
+	MaxAge        int      `form:"max_age" json:"max_age"`               // 预检请求缓存时间（秒）
 }
 
 // Upstream 上游服务器配置
 type Upstream struct {
-	Servers   map[string]string `form:"servers" json:"servers" validate:"required"` // 上游服务器及配置，如: map["server1"] = "weight=5 resolve"
-	Algo      string            `form:"algo" json:"algo"`                           // 负载均衡算法，如: "least_conn", "ip_hash"
-	Keepalive int               `form:"keepalive" json:"keepalive"`                 // 保持连接数，如: 32
+	Servers       map[string]string         `form:"servers" json:"servers" validate:"required"` // 上游服务器及配置，如: map["server1"] = "weight=5 resolve"
+	ServerOptions map[string]UpstreamServer `form:"server_options" json:"server_options"`       // 上游服务器的失败判定和状态，键与 Servers 相同
+	Algo          string                    `form:"algo" json:"algo"`                           // 负载均衡算法，如: "least_conn", "ip_hash"
+	Keepalive     int                       `form:"keepalive" json:"keepalive"`                 // 保持连接数，如: 32
+	HealthCheck   *UpstreamHealthCheck      `form:"health_check" json:"health_check"`           // 主动健康检查，为 nil 表示不检查
+}
+
+// UpstreamServer 上游服务器的被动健康检查参数和状态
+type UpstreamServer struct {
+	MaxFails    int           `form:"max_fails" json:"max_fails"`       // 失败多少次后暂停转发，0 表示使用默认值
+	FailTimeout time.Duration `form:"fail_timeout" json:"fail_timeout"` // 统计失败次数的时间窗口，也是暂停转发的时间，如: 10 * time.Second
+	Backup      bool          `form:"backup" json:"backup"`             // 备用服务器，其它服务器均不可用时才转发
+	Down        bool          `form:"down" json:"down"`                 // 标记为不可用，不转发请求
+}
+
+// UpstreamHealthCheck 上游服务器主动健康检查配置，由面板定时探测
+type UpstreamHealthCheck struct {
+	Type     string        `form:"type" json:"type"`           // 探测方式，如: "http", "tcp"
+	Path     string        `form:"path" json:"path"`           // HTTP 探测的请求路径，如: "/health"
+	Status   int           `form:"status" json:"status"`       // HTTP 探测期望的状态码，0 表示 2xx 和 3xx 均视为健康
+	Timeout  time.Duration `form:"timeout" json:"timeout"`     // 探测超时时间，如: 5 * time.Second
+	Fails    int           `form:"fails" json:"fails"`         // 连续失败多少次后视为不可用，如: 3
+	Passes   int           `form:"passes" json:"passes"`       // 连续成功多少次后恢复可用，如: 2
+	MarkDown bool          `form:"mark_down" json:"mark_down"` // 不可用时在配置中将服务器标记为 down，恢复后自动取消
+}
+
+// String 将健康检查配置格式化为 key=value 形式，用于保存在配置文件的注释中
+func (h UpstreamHealthCheck) String() string {
+	parts := []string{"type=" + h.Type}
+	if h.Path != "" {
+		parts = append(parts, "path="+h.Path)
+	}
+	if h.Status > 0 {
+		parts = append(parts, "status="+strconv.Itoa(h.Status))
+	}
+	if h.Timeout > 0 {
+		parts = append(parts, "timeout="+h.Timeout.String())
+	}
+	if h.Fails > 0 {
+		parts = append(parts, "fails="+strconv.Itoa(h.Fails))
+	}
+	if h.Passes > 0 {
+		parts = append(parts, "passes="+strconv.Itoa(h.Passes))
+	}
+	if h.MarkDown {
+		parts = append(parts, "mark_down")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// ParseUpstreamHealthCheck 解析 String 格式化的健康检查配置
+func ParseUpstreamHealthCheck(s string) *UpstreamHealthCheck {
+	check := new(UpstreamHealthCheck)
+	for _, field := range strings.Fields(s) {
+		key, value, _ := strings.Cut(field, "=")
+		switch key {
+		case "type":
+			check.Type = value
+		case "path":
+			check.Path = value
+		case "status":
+			check.Status, _ = strconv.Atoi(value)
+		case "timeout":
+			check.Timeout, _ = time.ParseDuration(value)
+		case "fails":
+			check.Fails, _ = strconv.Atoi(value)
+		case "passes":
+			check.Passes, _ = strconv.Atoi(value)
+		case "mark_down":
+			check.MarkDown = true
+		}
+	}
+	if check.Type == "" {
+		return nil
+	}
+
+	return check
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/acepanel/panel/pkg/webserver/apache"
+	"github.com/acepanel/panel/pkg/webserver/caddy"
 	"github.com/acepanel/panel/pkg/webserver/nginx"
 	"github.com/acepanel/panel/pkg/webserver/types"
 )
@@ -14,6 +15,8 @@ func NewStaticVhost(serverType Type, configDir string) (types.StaticVhost, error
 		return nginx.NewStaticVhost(configDir)
 	case TypeApache:
 		return apache.NewStaticVhost(configDir)
+	case TypeCaddy:
+		return caddy.NewStaticVhost(configDir)
 	default:
 		return nil, fmt.Errorf("unsupported server type: %s", serverType)
 	}
@@ -25,6 +28,8 @@ func NewPHPVhost(serverType Type, configDir string) (types.PHPVhost, error) {
 		return nginx.NewPHPVhost(configDir)
 	case TypeApache:
 		return apache.NewPHPVhost(configDir)
+	case TypeCaddy:
+		return caddy.NewPHPVhost(configDir)
 	default:
 		return nil, fmt.Errorf("unsupported server type: %s", serverType)
 	}
@@ -36,6 +41,8 @@ func NewProxyVhost(serverType Type, configDir string) (types.ProxyVhost, error)
 		return nginx.NewProxyVhost(configDir)
 	case TypeApache:
 		return apache.NewProxyVhost(configDir)
+	case TypeCaddy:
+		return caddy.NewProxyVhost(configDir)
 	default:
 		return nil, fmt.Errorf("unsupported server type: %s", serverType)
 	}